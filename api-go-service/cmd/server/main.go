@@ -42,6 +42,15 @@ func main() {
 	// Initialize logger
 	appLogger := logger.New(cfg.Logger.Level, cfg.Logger.Format)
 
+	// Run a storage maintenance command instead of the server, if one is given
+	if flag.NArg() > 0 {
+		if err := runCommand(cfg, appLogger, flag.Args()); err != nil {
+			appLogger.LogError(err, "main", flag.Arg(0), nil)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Log startup information
 	appLogger.WithFields(map[string]interface{}{
 		"version":     version,
@@ -52,7 +61,14 @@ func main() {
 	}).Info("Starting zama-api-service")
 
 	// Create and start server
-	srv := server.New(cfg, appLogger)
+	srv, err := server.New(cfg, appLogger)
+	if err != nil {
+		appLogger.LogError(err, "main", "create_server", map[string]interface{}{
+			"version": version,
+			"commit":  commit,
+		})
+		os.Exit(1)
+	}
 
 	// Run server (this blocks until shutdown signal is received)
 	if err := srv.Run(); err != nil {
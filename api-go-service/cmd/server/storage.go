@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/storage"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// runCommand runs a maintenance command on the service storage
+// The server must not be running, since it holds the storage file open
+func runCommand(cfg *config.Config, log *logger.Logger, args []string) error {
+	if cfg.Storage.Backend != storage.BackendBolt {
+		return fmt.Errorf("%s needs persistent storage; set STORAGE_BACKEND=%s", args[0], storage.BackendBolt)
+	}
+
+	switch {
+	case args[0] == "migrate" && len(args) == 1:
+		return migrateStorage(cfg.Storage, log)
+	case args[0] == "snapshot" && len(args) == 2:
+		return snapshotStorage(cfg.Storage, log, args[1])
+	case args[0] == "restore" && len(args) == 2:
+		return restoreStorage(cfg.Storage, log, args[1])
+	default:
+		return errors.New("usage: server [migrate | snapshot FILE | restore FILE]")
+	}
+}
+
+// migrateStorage applies pending schema migrations
+func migrateStorage(cfg config.StorageConfig, log *logger.Logger) error {
+	db, err := storage.Open(cfg.Backend, cfg.Path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	from, to, err := storage.Migrate(db, storage.Migrations)
+	if err != nil {
+		return err
+	}
+	log.WithFields(map[string]interface{}{
+		"path": cfg.Path,
+		"from": from,
+		"to":   to,
+	}).Info("Storage schema up to date")
+	return nil
+}
+
+// snapshotStorage writes a snapshot of the storage to path, or to stdout for "-"
+// The file is written under a temporary name and renamed once complete
+func snapshotStorage(cfg config.StorageConfig, log *logger.Logger, path string) error {
+	db, err := storage.Open(cfg.Backend, cfg.Path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if path == "-" {
+		_, err := storage.Snapshot(db, os.Stdout)
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	header, err := storage.Snapshot(db, tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	log.WithFields(map[string]interface{}{
+		"path":           path,
+		"schema_version": header.SchemaVersion,
+	}).Info("Storage snapshot written")
+	return nil
+}
+
+// restoreStorage replaces the storage content with the snapshot at path, or read from stdin for "-"
+// Older snapshots are migrated to the current schema
+func restoreStorage(cfg config.StorageConfig, log *logger.Logger, path string) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	db, err := storage.Open(cfg.Backend, cfg.Path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	header, err := storage.Restore(db, r)
+	if err != nil {
+		return err
+	}
+	_, to, err := storage.Migrate(db, storage.Migrations)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(map[string]interface{}{
+		"path":           path,
+		"created_at":     header.CreatedAt,
+		"schema_version": to,
+	}).Info("Storage restored from snapshot")
+	return nil
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Entry is a cached response
+type Entry struct {
+	ETag        string
+	ContentType string
+	Body        []byte
+}
+
+// size approximates the memory held by an entry and its key
+func (e *Entry) size(key string) int {
+	return len(key) + len(e.ETag) + len(e.ContentType) + len(e.Body)
+}
+
+// item is a list element value
+type item struct {
+	key   string
+	entry *Entry
+}
+
+// LRU is a least-recently-used cache bounded by entry count and total size in bytes
+type LRU struct {
+	maxEntries int
+	maxBytes   int
+
+	mu    sync.Mutex
+	order *list.List // front is most recently used
+	items map[string]*list.Element
+	bytes int
+}
+
+// NewLRU creates a cache holding at most maxEntries entries and maxBytes bytes
+// A zero limit disables that bound
+func NewLRU(maxEntries, maxBytes int) *LRU {
+	return &LRU{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry stored under key and marks it as recently used
+func (c *LRU) Get(key string) (*Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*item).entry, true
+}
+
+// Add stores an entry, evicting the least recently used entries to stay within bounds
+// Entries larger than the byte bound are not stored
+func (c *LRU) Add(key string, entry *Entry) {
+	size := entry.size(key)
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+
+	c.items[key] = c.order.PushFront(&item{key: key, entry: entry})
+	c.bytes += size
+
+	for (c.maxEntries > 0 && c.order.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.remove(c.order.Back())
+	}
+}
+
+// Len returns the number of entries
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Bytes returns the approximate memory held by the entries
+func (c *LRU) Bytes() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bytes
+}
+
+// remove deletes a list element; callers hold c.mu
+func (c *LRU) remove(el *list.Element) {
+	it := el.Value.(*item)
+	c.order.Remove(el)
+	delete(c.items, it.key)
+	c.bytes -= it.entry.size(it.key)
+}
@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	t.Run("Evicts the least recently used entry", func(t *testing.T) {
+		c := NewLRU(2, 0)
+		c.Add("a", &Entry{Body: []byte("1")})
+		c.Add("b", &Entry{Body: []byte("2")})
+
+		_, ok := c.Get("a")
+		assert.True(t, ok)
+
+		c.Add("c", &Entry{Body: []byte("3")})
+		_, ok = c.Get("b")
+		assert.False(t, ok)
+		assert.Equal(t, 2, c.Len())
+	})
+
+	t.Run("Bounded by size", func(t *testing.T) {
+		c := NewLRU(0, 100)
+		c.Add("a", &Entry{Body: []byte(strings.Repeat("x", 60))})
+		c.Add("b", &Entry{Body: []byte(strings.Repeat("y", 30))})
+		assert.Equal(t, 2, c.Len())
+
+		c.Add("c", &Entry{Body: []byte(strings.Repeat("z", 30))})
+		_, ok := c.Get("a")
+		assert.False(t, ok)
+		assert.LessOrEqual(t, c.Bytes(), 100)
+
+		// Oversized entries are skipped
+		c.Add("huge", &Entry{Body: []byte(strings.Repeat("h", 200))})
+		_, ok = c.Get("huge")
+		assert.False(t, ok)
+	})
+
+	t.Run("Replacing an entry updates the size", func(t *testing.T) {
+		c := NewLRU(0, 0)
+		c.Add("a", &Entry{Body: []byte("12345")})
+		c.Add("a", &Entry{Body: []byte("1")})
+		assert.Equal(t, 1, c.Len())
+		assert.Equal(t, 2, c.Bytes())
+	})
+}
@@ -3,16 +3,38 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds all configuration for our application
 type Config struct {
-	Server   ServerConfig
-	Logger   LoggerConfig
-	Metrics  MetricsConfig
-	Health   HealthConfig
-	Security SecurityConfig
+	Server      ServerConfig
+	Logger      LoggerConfig
+	Metrics     MetricsConfig
+	Health      HealthConfig
+	Security    SecurityConfig
+	Linalg      LinalgConfig
+	Modular     ModularConfig
+	Paillier    PaillierConfig
+	Aggregation AggregationConfig
+	Privacy     PrivacyConfig
+	Jobs        JobsConfig
+	Webhook     WebhookConfig
+	Idempotency IdempotencyConfig
+	Cache       CacheConfig
+	Coalesce    CoalesceConfig
+	GRPC        GRPCConfig
+	RPC         RPCConfig
+	Stream      StreamConfig
+	Window      WindowConfig
+	GroupBy     GroupByConfig
+	Upload      UploadConfig
+	History     HistoryConfig
+	Storage     StorageConfig
+	Errors      ErrorsConfig
+	Validation  ValidationConfig
+	OpenAPI     OpenAPIConfig
 }
 
 // ServerConfig holds server-specific configuration
@@ -23,6 +45,7 @@ type ServerConfig struct {
 	WriteTimeout    time.Duration
 	ShutdownTimeout time.Duration
 	Environment     string
+	MaxBodyBytes    int64 // largest JSON request body accepted
 }
 
 // LoggerConfig holds logging configuration
@@ -48,6 +71,195 @@ type SecurityConfig struct {
 	TrustedProxies []string
 }
 
+// LinalgConfig holds limits for the linear algebra endpoints
+type LinalgConfig struct {
+	MaxElements int // total number of elements accepted across all operands of a request
+}
+
+// ModularConfig holds limits for the modular and polynomial ring endpoints
+type ModularConfig struct {
+	MaxModulusBits int
+	MaxDegree      int // largest polynomial ring degree n
+	MaxRingBits    int // largest degree times modulus bit length
+	MaxValues      int // total number of integers accepted per request
+}
+
+// PaillierConfig holds limits for the encrypted sum endpoints
+type PaillierConfig struct {
+	MinKeyBits       int
+	MaxKeyBits       int
+	MaxCiphertexts   int
+	MaxKeys          int           // registered public keys kept per consumer
+	KeyIdleTimeout   time.Duration // keys unused for this long are forgotten
+	DevKeyGeneration bool          // allow paillier_keygen jobs, which see the private key
+}
+
+// AggregationConfig holds configuration for secure aggregation sessions
+type AggregationConfig struct {
+	MaxParticipants int
+	MaxSessions     int
+	MaxPerConsumer  int // sessions held per consumer, closed ones included until they expire
+	MaxShares       int // shares accepted in a single submission
+	DefaultTimeout  time.Duration
+	MaxTimeout      time.Duration
+	Retention       time.Duration // how long closed sessions remain readable
+	SweepInterval   time.Duration
+}
+
+// PrivacyConfig holds configuration for the differentially private sum
+type PrivacyConfig struct {
+	Budget     float64 // cumulative epsilon granted to each consumer
+	MaxEpsilon float64 // largest epsilon a single request may spend
+}
+
+// JobsConfig holds configuration for asynchronous jobs
+type JobsConfig struct {
+	Workers        int
+	QueueSize      int
+	MaxPerConsumer int           // queued plus running jobs per consumer
+	Retention      time.Duration // how long finished jobs remain readable
+	SweepInterval  time.Duration
+	MaxNumbers     int // numbers accepted by a sum job
+	MaxCiphertexts int // ciphertexts accepted by an encrypted sum job
+}
+
+// WebhookConfig holds configuration for signed job completion callbacks
+// Callbacks are disabled while Secret or AllowedHosts is empty
+type WebhookConfig struct {
+	Secret         string
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Timeout        time.Duration // per delivery attempt
+	MaxDeadLetters int
+	AllowedHosts   []string // callback hosts accepted
+}
+
+// IdempotencyConfig holds configuration for Idempotency-Key handling
+type IdempotencyConfig struct {
+	Enabled      bool
+	TTL          time.Duration // how long a stored response is replayed
+	MaxKeys      int
+	MaxBytes     int   // total size of the stored responses
+	MaxBodyBytes int64 // largest request body accepted with an Idempotency-Key
+}
+
+// CacheConfig holds configuration for deterministic responses
+// ETags and Cache-Control are always emitted; the in-process cache is opt-in
+type CacheConfig struct {
+	Enabled    bool
+	MaxEntries int
+	MaxBytes   int
+	MaxAge     time.Duration // Cache-Control max-age for downstream caches
+}
+
+// CoalesceConfig holds configuration for sharing identical in-flight computations
+type CoalesceConfig struct {
+	Enabled bool
+}
+
+// GRPCConfig holds configuration for the gRPC server that runs next to the HTTP server
+type GRPCConfig struct {
+	Enabled    bool
+	Port       string
+	Reflection bool // register the server reflection service for tools such as grpcurl
+
+	// TrustedPeers are the IPs or CIDRs whose consumer metadata is trusted,
+	// the gRPC equivalent of Security.TrustedProxies; calls from any other
+	// peer are anonymous
+	TrustedPeers []string
+}
+
+// RPCConfig holds limits for the JSON-RPC endpoint
+type RPCConfig struct {
+	MaxBatch int // largest number of calls accepted in one batch
+}
+
+// StreamConfig holds limits for the running-sum WebSocket and SSE streams
+type StreamConfig struct {
+	MaxConnections  int           // open streams across all consumers
+	MaxPerConsumer  int           // open streams per consumer
+	MaxMessages     int           // messages accepted per stream
+	MaxNumbers      int           // numbers accepted per message
+	MaxMessageBytes int64         // largest WebSocket message accepted
+	IdleTimeout     time.Duration // streams without messages for this long are closed
+	PingInterval    time.Duration // keepalive interval; a WebSocket peer missing two pongs is dropped
+	SweepInterval   time.Duration
+	AllowedOrigins  []string // browser origins allowed to open WebSockets, empty for same-origin only
+}
+
+// WindowConfig holds limits for the time-windowed aggregation store
+type WindowConfig struct {
+	Retention     time.Duration // points older than this are evicted
+	Lateness      time.Duration // how far behind a stream's newest point a value may arrive
+	MaxSkew       time.Duration // how far ahead of the server clock a timestamp may be
+	MaxPoints     int           // points kept per stream; the oldest are evicted first
+	MaxStreams    int           // streams per consumer
+	MaxBatch      int           // points accepted per append
+	MaxWindows    int           // windows returned by one query
+	SweepInterval time.Duration
+}
+
+// GroupByConfig holds limits for the grouped aggregation endpoint
+type GroupByConfig struct {
+	MaxGroups    int   // distinct group keys per request
+	MaxRecords   int   // records per request
+	MaxFields    int   // numeric fields aggregated per request
+	MaxBodyBytes int64 // largest request body accepted
+}
+
+// UploadConfig holds limits for the file upload endpoint
+type UploadConfig struct {
+	MaxBytes     int64 // largest multipart request accepted
+	MaxColumns   int   // columns summed per file
+	MaxErrors    int   // row errors listed in a response; further ones are only counted
+	MaxLineBytes int   // longest line accepted, in CSV and JSON Lines files
+}
+
+// StorageConfig holds configuration for the persisted service state
+// (idempotency records, privacy budgets and finished jobs)
+type StorageConfig struct {
+	Backend string // memory or bolt
+	Path    string // database file of the bolt backend
+}
+
+// HistoryConfig holds configuration for the per-consumer computation history
+// Recording is opt-in; Consumers restricts it to the listed consumers when set
+type HistoryConfig struct {
+	Enabled       bool
+	Backend       string // memory or bolt
+	Path          string // database file of the bolt backend
+	Consumers     []string
+	RedactInputs  bool          // never store request bodies
+	Retention     time.Duration // age after which entries are removed
+	MaxBodyBytes  int           // request and response bytes stored per entry
+	MaxEntries    int           // entries kept per consumer
+	MaxPage       int           // entries returned per page
+	SweepInterval time.Duration
+}
+
+// ErrorsConfig holds configuration for error responses
+// DocsBaseURL is where each error code has a documentation page; empty uses the /errors catalog
+type ErrorsConfig struct {
+	DocsBaseURL string
+}
+
+// ValidationConfig holds the limits that the validate tags of request models refer to
+type ValidationConfig struct {
+	SumMinNumbers int
+	SumMaxNumbers int
+}
+
+// OpenAPIConfig holds configuration for the runtime checks against the OpenAPI contract
+// Requests are validated when Validate is set; outside production responses are
+// checked too, and drift fails the request when FailOnDrift is set
+type OpenAPIConfig struct {
+	Validate     bool
+	SpecPath     string // JSON or YAML document; empty uses the generated document
+	FailOnDrift  bool
+	MaxBodyBytes int // larger bodies are not validated
+}
+
 // Load loads configuration from environment variables with sensible defaults
 func Load() *Config {
 	return &Config{
@@ -58,6 +270,7 @@ func Load() *Config {
 			WriteTimeout:    getDurationEnv("WRITE_TIMEOUT", 30*time.Second),
 			ShutdownTimeout: getDurationEnv("SHUTDOWN_TIMEOUT", 15*time.Second),
 			Environment:     getEnv("ENVIRONMENT", "development"),
+			MaxBodyBytes:    int64(getIntEnv("MAX_BODY_BYTES", 8<<20)),
 		},
 		Logger: LoggerConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
@@ -74,6 +287,142 @@ func Load() *Config {
 			APIKeyHeader:   getEnv("API_KEY_HEADER", "X-API-Key"),
 			TrustedProxies: getSliceEnv("TRUSTED_PROXIES", []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}),
 		},
+		Linalg: LinalgConfig{
+			MaxElements: getIntEnv("LINALG_MAX_ELEMENTS", 10000),
+		},
+		Modular: ModularConfig{
+			MaxModulusBits: getIntEnv("MODULAR_MAX_MODULUS_BITS", 2048),
+			MaxDegree:      getIntEnv("MODULAR_MAX_DEGREE", 2048),
+			MaxRingBits:    getIntEnv("MODULAR_MAX_RING_BITS", 131072),
+			MaxValues:      getIntEnv("MODULAR_MAX_VALUES", 10000),
+		},
+		Paillier: PaillierConfig{
+			MinKeyBits:       getIntEnv("PAILLIER_MIN_KEY_BITS", 2048),
+			MaxKeyBits:       getIntEnv("PAILLIER_MAX_KEY_BITS", 4096),
+			MaxCiphertexts:   getIntEnv("PAILLIER_MAX_CIPHERTEXTS", 1000),
+			MaxKeys:          getIntEnv("PAILLIER_MAX_KEYS", 100),
+			KeyIdleTimeout:   getDurationEnv("PAILLIER_KEY_IDLE_TIMEOUT", 24*time.Hour),
+			DevKeyGeneration: getBoolEnv("PAILLIER_DEV_KEYGEN", false),
+		},
+		Aggregation: AggregationConfig{
+			MaxParticipants: getIntEnv("AGGREGATION_MAX_PARTICIPANTS", 1000),
+			MaxSessions:     getIntEnv("AGGREGATION_MAX_SESSIONS", 1000),
+			MaxPerConsumer:  getIntEnv("AGGREGATION_MAX_PER_CONSUMER", 50),
+			MaxShares:       getIntEnv("AGGREGATION_MAX_SHARES", 100),
+			DefaultTimeout:  getDurationEnv("AGGREGATION_DEFAULT_TIMEOUT", 5*time.Minute),
+			MaxTimeout:      getDurationEnv("AGGREGATION_MAX_TIMEOUT", time.Hour),
+			Retention:       getDurationEnv("AGGREGATION_RETENTION", 15*time.Minute),
+			SweepInterval:   getDurationEnv("AGGREGATION_SWEEP_INTERVAL", 10*time.Second),
+		},
+		Privacy: PrivacyConfig{
+			Budget:     getFloatEnv("PRIVACY_BUDGET", 10),
+			MaxEpsilon: getFloatEnv("PRIVACY_MAX_EPSILON", 1),
+		},
+		Jobs: JobsConfig{
+			Workers:        getIntEnv("JOBS_WORKERS", 4),
+			QueueSize:      getIntEnv("JOBS_QUEUE_SIZE", 100),
+			MaxPerConsumer: getIntEnv("JOBS_MAX_PER_CONSUMER", 4),
+			Retention:      getDurationEnv("JOBS_RETENTION", 15*time.Minute),
+			SweepInterval:  getDurationEnv("JOBS_SWEEP_INTERVAL", 30*time.Second),
+			MaxNumbers:     getIntEnv("JOBS_MAX_NUMBERS", 1000000),
+			MaxCiphertexts: getIntEnv("JOBS_MAX_CIPHERTEXTS", 100000),
+		},
+		Webhook: WebhookConfig{
+			Secret:         getEnv("WEBHOOK_SECRET", ""),
+			MaxAttempts:    getIntEnv("WEBHOOK_MAX_ATTEMPTS", 5),
+			InitialBackoff: getDurationEnv("WEBHOOK_INITIAL_BACKOFF", time.Second),
+			MaxBackoff:     getDurationEnv("WEBHOOK_MAX_BACKOFF", time.Minute),
+			Timeout:        getDurationEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxDeadLetters: getIntEnv("WEBHOOK_MAX_DEAD_LETTERS", 1000),
+			AllowedHosts:   getSliceEnv("WEBHOOK_ALLOWED_HOSTS", nil),
+		},
+		Idempotency: IdempotencyConfig{
+			Enabled:      getBoolEnv("IDEMPOTENCY_ENABLED", true),
+			TTL:          getDurationEnv("IDEMPOTENCY_TTL", 24*time.Hour),
+			MaxKeys:      getIntEnv("IDEMPOTENCY_MAX_KEYS", 10000),
+			MaxBytes:     getIntEnv("IDEMPOTENCY_MAX_BYTES", 64<<20),
+			MaxBodyBytes: int64(getIntEnv("IDEMPOTENCY_MAX_BODY_BYTES", 1<<20)),
+		},
+		Cache: CacheConfig{
+			Enabled:    getBoolEnv("RESPONSE_CACHE_ENABLED", false),
+			MaxEntries: getIntEnv("RESPONSE_CACHE_MAX_ENTRIES", 10000),
+			MaxBytes:   getIntEnv("RESPONSE_CACHE_MAX_BYTES", 64<<20),
+			MaxAge:     getDurationEnv("RESPONSE_CACHE_MAX_AGE", time.Hour),
+		},
+		Coalesce: CoalesceConfig{
+			Enabled: getBoolEnv("COALESCE_ENABLED", true),
+		},
+		GRPC: GRPCConfig{
+			Enabled:      getBoolEnv("GRPC_ENABLED", false),
+			Port:         getEnv("GRPC_PORT", "9090"),
+			Reflection:   getBoolEnv("GRPC_REFLECTION", false),
+			TrustedPeers: getSliceEnv("GRPC_TRUSTED_PEERS", nil),
+		},
+		RPC: RPCConfig{
+			MaxBatch: getIntEnv("RPC_MAX_BATCH", 100),
+		},
+		Stream: StreamConfig{
+			MaxConnections:  getIntEnv("STREAM_MAX_CONNECTIONS", 1000),
+			MaxPerConsumer:  getIntEnv("STREAM_MAX_PER_CONSUMER", 10),
+			MaxMessages:     getIntEnv("STREAM_MAX_MESSAGES", 100000),
+			MaxNumbers:      getIntEnv("STREAM_MAX_NUMBERS", 100),
+			MaxMessageBytes: int64(getIntEnv("STREAM_MAX_MESSAGE_BYTES", 65536)),
+			IdleTimeout:     getDurationEnv("STREAM_IDLE_TIMEOUT", 5*time.Minute),
+			PingInterval:    getDurationEnv("STREAM_PING_INTERVAL", 30*time.Second),
+			SweepInterval:   getDurationEnv("STREAM_SWEEP_INTERVAL", 30*time.Second),
+			AllowedOrigins:  getSliceEnv("STREAM_ALLOWED_ORIGINS", nil),
+		},
+		Window: WindowConfig{
+			Retention:     getDurationEnv("WINDOW_RETENTION", time.Hour),
+			Lateness:      getDurationEnv("WINDOW_LATENESS", time.Minute),
+			MaxSkew:       getDurationEnv("WINDOW_MAX_SKEW", time.Minute),
+			MaxPoints:     getIntEnv("WINDOW_MAX_POINTS", 100000),
+			MaxStreams:    getIntEnv("WINDOW_MAX_STREAMS", 100),
+			MaxBatch:      getIntEnv("WINDOW_MAX_BATCH", 1000),
+			MaxWindows:    getIntEnv("WINDOW_MAX_WINDOWS", 1000),
+			SweepInterval: getDurationEnv("WINDOW_SWEEP_INTERVAL", time.Minute),
+		},
+		GroupBy: GroupByConfig{
+			MaxGroups:    getIntEnv("GROUPBY_MAX_GROUPS", 10000),
+			MaxRecords:   getIntEnv("GROUPBY_MAX_RECORDS", 1000000),
+			MaxFields:    getIntEnv("GROUPBY_MAX_FIELDS", 16),
+			MaxBodyBytes: int64(getIntEnv("GROUPBY_MAX_BODY_BYTES", 64<<20)),
+		},
+		Upload: UploadConfig{
+			MaxBytes:     int64(getIntEnv("UPLOAD_MAX_BYTES", 100<<20)),
+			MaxColumns:   getIntEnv("UPLOAD_MAX_COLUMNS", 100),
+			MaxErrors:    getIntEnv("UPLOAD_MAX_ERRORS", 100),
+			MaxLineBytes: getIntEnv("UPLOAD_MAX_LINE_BYTES", 1<<20),
+		},
+		Storage: StorageConfig{
+			Backend: getEnv("STORAGE_BACKEND", "memory"),
+			Path:    getEnv("STORAGE_PATH", "data/service.db"),
+		},
+		History: HistoryConfig{
+			Enabled:       getBoolEnv("HISTORY_ENABLED", false),
+			Backend:       getEnv("HISTORY_BACKEND", "memory"),
+			Path:          getEnv("HISTORY_PATH", "data/history.db"),
+			Consumers:     getSliceEnv("HISTORY_CONSUMERS", nil),
+			RedactInputs:  getBoolEnv("HISTORY_REDACT_INPUTS", false),
+			Retention:     getDurationEnv("HISTORY_RETENTION", 7*24*time.Hour),
+			MaxBodyBytes:  getIntEnv("HISTORY_MAX_BODY_BYTES", 64<<10),
+			MaxEntries:    getIntEnv("HISTORY_MAX_ENTRIES", 10000),
+			MaxPage:       getIntEnv("HISTORY_MAX_PAGE", 100),
+			SweepInterval: getDurationEnv("HISTORY_SWEEP_INTERVAL", 10*time.Minute),
+		},
+		Errors: ErrorsConfig{
+			DocsBaseURL: getEnv("ERRORS_DOCS_BASE_URL", ""),
+		},
+		Validation: ValidationConfig{
+			SumMinNumbers: getIntEnv("SUM_MIN_NUMBERS", 2),
+			SumMaxNumbers: getIntEnv("SUM_MAX_NUMBERS", 100),
+		},
+		OpenAPI: OpenAPIConfig{
+			Validate:     getBoolEnv("OPENAPI_VALIDATE", false),
+			SpecPath:     getEnv("OPENAPI_SPEC_PATH", ""),
+			FailOnDrift:  getBoolEnv("OPENAPI_FAIL_ON_DRIFT", false),
+			MaxBodyBytes: getIntEnv("OPENAPI_MAX_BODY_BYTES", 1<<20),
+		},
 	}
 }
 
@@ -95,6 +444,26 @@ func getBoolEnv(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// getIntEnv gets an integer environment variable with a fallback default
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getFloatEnv gets a floating-point environment variable with a fallback default
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 // getDurationEnv gets a duration environment variable with a fallback default
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
@@ -108,9 +477,14 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 // getSliceEnv gets a slice environment variable with a fallback default
 func getSliceEnv(key string, defaultValue []string) []string {
 	if value := os.Getenv(key); value != "" {
-		// For simplicity, we'll assume comma-separated values
-		// In production, you might want more sophisticated parsing
-		return []string{value}
+		// Comma-separated values; empty entries are ignored
+		var values []string
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		return values
 	}
 	return defaultValue
 }
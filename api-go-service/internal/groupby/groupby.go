@@ -0,0 +1,168 @@
+package groupby
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Orderings of the groups
+const (
+	ByKey   = "key"
+	ByCount = "count"
+	BySum   = "sum"
+	ByMin   = "min"
+	ByMax   = "max"
+)
+
+// Limits a request may reach
+var (
+	ErrGroupLimit  = errors.New("maximum number of groups reached")
+	ErrRecordLimit = errors.New("maximum number of records reached")
+)
+
+// LimitError is returned when a record goes over one of the limits
+type LimitError struct {
+	Err error
+	Max int
+}
+
+// Error implements the error interface
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v (%d)", e.Err, e.Max)
+}
+
+// Unwrap returns the limit reached
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+// Stats holds the statistics of one numeric field within a group
+type Stats struct {
+	Sum float64
+	Min float64
+	Max float64
+}
+
+// Group holds the statistics of the records sharing a key
+// Stats are in the order of the aggregator's fields
+type Group struct {
+	Key   string
+	Count int
+	Stats []Stats
+}
+
+// Order selects how groups are sorted
+// Field is the index of the field that sum, min and max orderings compare
+type Order struct {
+	By    string
+	Field int
+	Desc  bool
+}
+
+// Aggregator accumulates per-group statistics of numeric fields
+type Aggregator struct {
+	fields    []string
+	maxGroups int
+	groups    map[string]*Group
+}
+
+// New creates an aggregator over the named fields
+// A maxGroups of 0 means no limit
+func New(fields []string, maxGroups int) *Aggregator {
+	return &Aggregator{
+		fields:    fields,
+		maxGroups: maxGroups,
+		groups:    make(map[string]*Group),
+	}
+}
+
+// Add adds the field values of one record to the group of key
+// A record that would overflow a sum of its group is refused and leaves the group unchanged
+func (a *Aggregator) Add(key string, values []float64) error {
+	if len(values) != len(a.fields) {
+		return fmt.Errorf("expected %d values, got %d", len(a.fields), len(values))
+	}
+
+	g, ok := a.groups[key]
+	if !ok {
+		if a.maxGroups > 0 && len(a.groups) >= a.maxGroups {
+			return &LimitError{Err: ErrGroupLimit, Max: a.maxGroups}
+		}
+		g = &Group{Key: key, Stats: make([]Stats, len(a.fields))}
+		for i := range g.Stats {
+			g.Stats[i] = Stats{Min: math.Inf(1), Max: math.Inf(-1)}
+		}
+		a.groups[key] = g
+	}
+
+	for i, v := range values {
+		if math.IsInf(g.Stats[i].Sum+v, 0) {
+			return &FieldError{Field: a.fields[i], Err: ErrSumOverflow}
+		}
+	}
+
+	g.Count++
+	for i, v := range values {
+		s := &g.Stats[i]
+		s.Sum += v
+		s.Min = math.Min(s.Min, v)
+		s.Max = math.Max(s.Max, v)
+	}
+	return nil
+}
+
+// Len returns the number of groups
+func (a *Aggregator) Len() int {
+	return len(a.groups)
+}
+
+// Groups returns the groups sorted by order, keeping the first limit if limit is positive
+// Ties are broken by key so that the result is deterministic
+func (a *Aggregator) Groups(order Order, limit int) ([]*Group, error) {
+	var value func(g *Group) float64
+	switch order.By {
+	case ByKey, "":
+	case ByCount:
+		value = func(g *Group) float64 { return float64(g.Count) }
+	case BySum, ByMin, ByMax:
+		if order.Field < 0 || order.Field >= len(a.fields) {
+			return nil, fmt.Errorf("no field %d to order by", order.Field)
+		}
+		value = func(g *Group) float64 {
+			s := g.Stats[order.Field]
+			switch order.By {
+			case BySum:
+				return s.Sum
+			case ByMin:
+				return s.Min
+			default:
+				return s.Max
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown ordering %q", order.By)
+	}
+
+	groups := make([]*Group, 0, len(a.groups))
+	for _, g := range a.groups {
+		groups = append(groups, g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if value != nil {
+			vi, vj := value(groups[i]), value(groups[j])
+			if vi != vj {
+				return (vi < vj) != order.Desc
+			}
+			return groups[i].Key < groups[j].Key
+		}
+		return (groups[i].Key < groups[j].Key) != order.Desc
+	})
+
+	if limit > 0 && len(groups) > limit {
+		groups = groups[:limit]
+	}
+	return groups, nil
+}
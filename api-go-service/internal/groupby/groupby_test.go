@@ -0,0 +1,158 @@
+package groupby
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func keys(groups []*Group) []string {
+	result := make([]string, len(groups))
+	for i, g := range groups {
+		result[i] = g.Key
+	}
+	return result
+}
+
+func TestAggregator(t *testing.T) {
+	a := New([]string{"latency", "bytes"}, 0)
+	require.NoError(t, a.Add("eu-west", []float64{3.2, 10}))
+	require.NoError(t, a.Add("us-east", []float64{1, 50}))
+	require.NoError(t, a.Add("eu-west", []float64{-1, 20}))
+	require.NoError(t, a.Add("ap-south", []float64{7, 5}))
+	assert.Error(t, a.Add("eu-west", []float64{1}))
+
+	groups, err := a.Groups(Order{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ap-south", "eu-west", "us-east"}, keys(groups))
+	assert.Equal(t, 2, groups[1].Count)
+	assert.InDelta(t, 2.2, groups[1].Stats[0].Sum, 1e-9)
+	assert.Equal(t, Stats{Sum: 30, Min: 10, Max: 20}, groups[1].Stats[1])
+
+	tests := []struct {
+		name  string
+		order Order
+		limit int
+		want  []string
+	}{
+		{"Key descending", Order{By: ByKey, Desc: true}, 0, []string{"us-east", "eu-west", "ap-south"}},
+		{"Count ties broken by key", Order{By: ByCount, Desc: true}, 0, []string{"eu-west", "ap-south", "us-east"}},
+		{"Top sum of the second field", Order{By: BySum, Field: 1, Desc: true}, 2, []string{"us-east", "eu-west"}},
+		{"Lowest minimum", Order{By: ByMin}, 1, []string{"eu-west"}},
+		{"Highest maximum", Order{By: ByMax, Desc: true}, 1, []string{"ap-south"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := a.Groups(tt.order, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, keys(groups))
+		})
+	}
+
+	_, err = a.Groups(Order{By: BySum, Field: 2}, 0)
+	assert.Error(t, err)
+	_, err = a.Groups(Order{By: "median"}, 0)
+	assert.Error(t, err)
+}
+
+func TestAggregator_GroupLimit(t *testing.T) {
+	a := New([]string{"value"}, 2)
+	require.NoError(t, a.Add("a", []float64{1}))
+	require.NoError(t, a.Add("b", []float64{1}))
+	assert.True(t, errors.Is(a.Add("c", []float64{1}), ErrGroupLimit))
+
+	// Existing groups still accept records
+	require.NoError(t, a.Add("a", []float64{1}))
+	assert.Equal(t, 2, a.Len())
+}
+
+func TestAggregator_Overflow(t *testing.T) {
+	a := New([]string{"v"}, 0)
+	require.NoError(t, a.Add("a", []float64{1e308}))
+	require.NoError(t, a.Add("b", []float64{1e308}))
+
+	err := a.Add("a", []float64{1e308})
+	var fieldErr *FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "v", fieldErr.Field)
+	assert.ErrorIs(t, err, ErrSumOverflow)
+
+	// The refused record left its group unchanged
+	groups, err := a.Groups(Order{By: ByKey}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, groups[0].Count)
+	assert.Equal(t, 1e308, groups[0].Stats[0].Sum)
+}
+
+func TestDecoder(t *testing.T) {
+	read := func(input string, ndjson bool) (int, error) {
+		d := NewDecoder(strings.NewReader(input), ndjson)
+		for {
+			if _, err := d.Next(); err != nil {
+				if err == io.EOF {
+					return d.Index(), nil
+				}
+				return d.Index(), err
+			}
+		}
+	}
+
+	n, err := read(`[{"key": "a", "value": 1}, {"key": "b", "value": 2}]`, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	n, err = read("{\"key\": \"a\", \"value\": 1}\n{\"key\": \"b\", \"value\": 2}\n\n", true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	n, err = read(`[]`, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	_, err = read(`{"key": "a"}`, false)
+	assert.Error(t, err)
+	_, err = read(`[{"key": "a"}] trailing`, false)
+	assert.Error(t, err)
+
+	// Malformed records are reported by index
+	var recordErr *RecordError
+	_, err = read(`[{"key": "a"}, {"key": "b"}, 3]`, false)
+	require.ErrorAs(t, err, &recordErr)
+	assert.Equal(t, 2, recordErr.Index)
+	assert.Contains(t, err.Error(), "records[2]")
+
+	_, err = read("{\"key\": \"a\"}\nnull\n", true)
+	require.ErrorAs(t, err, &recordErr)
+	assert.Equal(t, 1, recordErr.Index)
+}
+
+func TestExtract(t *testing.T) {
+	d := NewDecoder(strings.NewReader(`[
+		{"region": "eu-west", "value": 3.2, "latency": 12},
+		{"region": "eu-west", "value": "3.2"},
+		{"region": null, "value": 1},
+		{"region": "eu-west", "value": 1e400},
+		{"value": 1}
+	]`), false)
+
+	record, err := d.Next()
+	require.NoError(t, err)
+	key, values, err := Extract(record, "region", []string{"value", "latency"})
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west", key)
+	assert.Equal(t, []float64{3.2, 12}, values)
+	_, _, err = Extract(record, "region", []string{"missing"})
+	assert.ErrorContains(t, err, `field "missing" is missing`)
+
+	for _, want := range []string{"must be a number", "must be a string", "out of range", "is missing"} {
+		record, err := d.Next()
+		require.NoError(t, err)
+		_, _, err = Extract(record, "region", []string{"value"})
+		assert.ErrorContains(t, err, want)
+	}
+}
@@ -0,0 +1,160 @@
+package groupby
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Reasons a record is refused
+var (
+	ErrNotObject   = errors.New("record must be an object")
+	ErrMissing     = errors.New("is missing")
+	ErrNotString   = errors.New("must be a string")
+	ErrNotNumber   = errors.New("must be a number")
+	ErrOutOfRange  = errors.New("is out of range")
+	ErrSumOverflow = errors.New("overflows the sum of its group")
+)
+
+// FieldError is an error about one field of a record
+// Key is set when the field is the group key
+type FieldError struct {
+	Field string
+	Key   bool
+	Err   error
+}
+
+// Error implements the error interface
+func (e *FieldError) Error() string {
+	if e.Key {
+		return fmt.Sprintf("group key %q %v", e.Field, e.Err)
+	}
+	return fmt.Sprintf("field %q %v", e.Field, e.Err)
+}
+
+// Unwrap returns the reason the field is refused
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// RecordError is an error about one record of the input, identified by its index
+type RecordError struct {
+	Index int
+	Err   error
+}
+
+// Error implements the error interface
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("records[%d]: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// Decoder reads records one at a time from a JSON array or an NDJSON stream
+// Only the current record is held in memory
+type Decoder struct {
+	dec     *json.Decoder
+	ndjson  bool
+	started bool
+	index   int
+}
+
+// NewDecoder creates a record decoder
+// With ndjson set, r holds one JSON object per line; otherwise a single JSON array
+func NewDecoder(r io.Reader, ndjson bool) *Decoder {
+	return &Decoder{dec: json.NewDecoder(r), ndjson: ndjson}
+}
+
+// Index returns the index of the next record
+func (d *Decoder) Index() int {
+	return d.index
+}
+
+// Next decodes the next record, returning io.EOF after the last one
+func (d *Decoder) Next() (map[string]json.RawMessage, error) {
+	if !d.ndjson {
+		if !d.started {
+			d.started = true
+			if err := d.expect('['); err != nil {
+				return nil, err
+			}
+		}
+		if !d.dec.More() {
+			if err := d.expect(']'); err != nil {
+				return nil, err
+			}
+			if _, err := d.dec.Token(); err != io.EOF {
+				return nil, errors.New("unexpected data after the array of records")
+			}
+			return nil, io.EOF
+		}
+	}
+
+	var record map[string]json.RawMessage
+	if err := d.dec.Decode(&record); err != nil {
+		if err == io.EOF && d.ndjson {
+			return nil, io.EOF
+		}
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		// Read errors, such as a body over its size limit, are not about the record
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if err != io.ErrUnexpectedEOF && !errors.As(err, &syntaxErr) && !errors.As(err, &typeErr) {
+			return nil, err
+		}
+		return nil, &RecordError{Index: d.index, Err: err}
+	}
+	if record == nil {
+		return nil, &RecordError{Index: d.index, Err: ErrNotObject}
+	}
+	d.index++
+	return record, nil
+}
+
+// expect reads the next token and checks that it is delim
+func (d *Decoder) expect(delim json.Delim) error {
+	token, err := d.dec.Token()
+	if err != nil {
+		return fmt.Errorf("expected %q: %w", delim, err)
+	}
+	if token != delim {
+		return fmt.Errorf("expected %q, got %v", delim, token)
+	}
+	return nil
+}
+
+// Extract returns the group key and the field values of a record
+// The key must be a string and every field a number
+func Extract(record map[string]json.RawMessage, keyField string, fields []string) (string, []float64, error) {
+	raw, ok := record[keyField]
+	if !ok {
+		return "", nil, &FieldError{Field: keyField, Key: true, Err: ErrMissing}
+	}
+	var key string
+	if raw = bytes.TrimSpace(raw); len(raw) == 0 || raw[0] != '"' || json.Unmarshal(raw, &key) != nil {
+		return "", nil, &FieldError{Field: keyField, Key: true, Err: ErrNotString}
+	}
+
+	values := make([]float64, len(fields))
+	for i, field := range fields {
+		raw, ok := record[field]
+		if !ok {
+			return "", nil, &FieldError{Field: field, Err: ErrMissing}
+		}
+		// Quoted numbers and null are refused rather than converted
+		if raw = bytes.TrimSpace(raw); len(raw) == 0 || (raw[0] != '-' && (raw[0] < '0' || raw[0] > '9')) {
+			return "", nil, &FieldError{Field: field, Err: ErrNotNumber}
+		}
+		if err := json.Unmarshal(raw, &values[i]); err != nil {
+			return "", nil, &FieldError{Field: field, Err: ErrOutOfRange}
+		}
+	}
+	return key, values, nil
+}
@@ -0,0 +1,57 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// errorDomain identifies this service in google.rpc.ErrorInfo details
+const errorDomain = "zama-api-service"
+
+// statusError creates a gRPC status error carrying the API error code
+// The code and request ID are attached as a google.rpc.ErrorInfo, so clients can
+// branch on the same codes as the HTTP API. Invalid elements of the request are
+// listed in a google.rpc.BadRequest, with JSON pointers as field names
+func statusError(c codes.Code, err error, code, requestID string) error {
+	st := status.New(c, err.Error())
+	details := []protoiface.MessageV1{&errdetails.ErrorInfo{
+		Reason:   code,
+		Domain:   errorDomain,
+		Metadata: map[string]string{"request_id": requestID},
+	}}
+	if fieldErrs := models.FieldErrors(err); len(fieldErrs) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, fe := range fieldErrs {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fe.Pointer,
+				Description: fe.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	detailed, detailErr := st.WithDetails(details...)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// reject logs a failed call and returns its status error
+func reject(ctx context.Context, log *logger.Logger, component, operation string, c codes.Code, err error, code string) error {
+	log.WithError(err).WithFields(map[string]interface{}{
+		"component":  component,
+		"operation":  operation,
+		"request_id": RequestID(ctx),
+		"consumer":   Consumer(ctx),
+		"code":       code,
+	}).Error("gRPC request failed")
+
+	return statusError(c, err, code, RequestID(ctx))
+}
@@ -0,0 +1,99 @@
+package grpcapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// watchInterval is how often Watch re-runs the readiness checks
+const watchInterval = 5 * time.Second
+
+// HealthService implements grpc.health.v1.Health
+// Every service reports the readiness of the whole process, computed by the same
+// checks as GET /healthz/ready
+type HealthService struct {
+	healthpb.UnimplementedHealthServer
+
+	ready    func() (bool, map[string]string)
+	services map[string]bool
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+// NewHealthService creates the health service
+// The empty service name, which stands for the whole server, is always known
+func NewHealthService(ready func() (bool, map[string]string), services ...string) *HealthService {
+	known := map[string]bool{"": true}
+	for _, name := range services {
+		known[name] = true
+	}
+	return &HealthService{
+		ready:    ready,
+		services: known,
+		stopped:  make(chan struct{}),
+	}
+}
+
+// Shutdown reports every service as NOT_SERVING from now on
+// Watch streams receive the change and end, so they don't hold up a graceful stop
+func (h *HealthService) Shutdown() {
+	h.stopOnce.Do(func() { close(h.stopped) })
+}
+
+// Check reports the serving status of a service
+func (h *HealthService) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !h.services[req.GetService()] {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: h.status()}, nil
+}
+
+// Watch streams the serving status of a service whenever it changes
+// Unknown services are reported as SERVICE_UNKNOWN, as the protocol requires
+func (h *HealthService) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		current := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if h.services[req.GetService()] {
+			current = h.status()
+		}
+		if current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-h.stopped:
+			if last != healthpb.HealthCheckResponse_NOT_SERVING && current != healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+				return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING})
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// status runs the readiness checks
+func (h *HealthService) status() healthpb.HealthCheckResponse_ServingStatus {
+	select {
+	case <-h.stopped:
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	default:
+	}
+	if ready, _ := h.ready(); !ready {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
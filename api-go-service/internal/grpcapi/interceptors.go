@@ -0,0 +1,239 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"runtime"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys, the gRPC equivalents of the HTTP headers
+const (
+	metadataRequestID         = "x-request-id"
+	metadataConsumerUsername  = "x-consumer-username"
+	metadataConsumerID        = "x-consumer-id"
+	metadataAnonymousConsumer = "x-anonymous-consumer"
+)
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	consumerKey
+)
+
+var (
+	// gRPC request duration histogram
+	grpcDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_request_duration_seconds",
+			Help:    "Duration of gRPC requests in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "code"},
+	)
+
+	// gRPC request counter
+	grpcRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_requests_total",
+			Help: "Total number of gRPC requests",
+		},
+		[]string{"method", "code"},
+	)
+
+	// Active gRPC calls gauge
+	grpcActiveCalls = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "grpc_active_calls",
+			Help: "Number of gRPC calls in progress",
+		},
+	)
+)
+
+// RequestID returns the request ID of a call
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// Consumer returns the consumer identity of a call
+func Consumer(ctx context.Context) string {
+	if consumer, _ := ctx.Value(consumerKey).(string); consumer != "" {
+		return consumer
+	}
+	return middleware.AnonymousConsumer
+}
+
+// unaryInterceptors returns the interceptors of unary calls, outermost first
+// They mirror the HTTP middleware: request IDs and consumer, logging, metrics, recovery
+func unaryInterceptors(log *logger.Logger, trusted []*net.IPNet) []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(identify(ctx, trusted), req)
+		},
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+			done := track(ctx, log, info.FullMethod)
+			defer func() { done(err) }()
+			return handler(ctx, req)
+		},
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+			defer recoverPanic(ctx, log, info.FullMethod, &err)
+			return handler(ctx, req)
+		},
+	}
+}
+
+// streamInterceptors returns the interceptors of streaming calls, outermost first
+func streamInterceptors(log *logger.Logger, trusted []*net.IPNet) []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &serverStream{ServerStream: ss, ctx: identify(ss.Context(), trusted)})
+		},
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+			done := track(ss.Context(), log, info.FullMethod)
+			defer func() { done(err) }()
+			return handler(srv, ss)
+		},
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+			defer recoverPanic(ss.Context(), log, info.FullMethod, &err)
+			return handler(srv, ss)
+		},
+	}
+}
+
+// identify stores the request ID and consumer of a call in its context
+// The request ID is taken from x-request-id or generated, and returned in the response header
+// The consumer metadata is only read from trusted peers; other calls are anonymous
+func identify(ctx context.Context, trusted []*net.IPNet) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	requestID := first(metadataRequestID)
+	if requestID == "" {
+		requestID = middleware.GenerateRequestID()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, requestID))
+
+	consumer := middleware.AnonymousConsumer
+	if trustedPeer(ctx, trusted) {
+		consumer = middleware.ResolveConsumer(first(metadataConsumerUsername), first(metadataConsumerID), first(metadataAnonymousConsumer))
+	}
+
+	ctx = context.WithValue(ctx, requestIDKey, requestID)
+	return context.WithValue(ctx, consumerKey, consumer)
+}
+
+// trustedPeer reports whether the call comes from an address in the trusted networks
+func trustedPeer(ctx context.Context, trusted []*net.IPNet) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	addr, ok := p.Addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, network := range trusted {
+		if network.Contains(addr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedPeers parses IPs and CIDRs into networks, a bare IP matching only itself
+func parseTrustedPeers(peers []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(peers))
+	for _, entry := range peers {
+		if ip := net.ParseIP(entry); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted gRPC peer %q: %w", entry, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// track counts a call as active and returns a function that logs the finished
+// call and records its metrics
+func track(ctx context.Context, log *logger.Logger, method string) func(error) {
+	start := time.Now()
+	grpcActiveCalls.Inc()
+
+	return func(err error) {
+		grpcActiveCalls.Dec()
+
+		latency := time.Since(start)
+		code := status.Code(err).String()
+
+		grpcDuration.WithLabelValues(method, code).Observe(latency.Seconds())
+		grpcRequests.WithLabelValues(method, code).Inc()
+
+		client := ""
+		if p, ok := peer.FromContext(ctx); ok {
+			client = p.Addr.String()
+		}
+		log.LogGRPCRequest(method, client, code, latency, RequestID(ctx))
+	}
+}
+
+// recoverPanic turns a panic in a handler into an Internal error
+func recoverPanic(ctx context.Context, log *logger.Logger, method string, err *error) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	stack := make([]byte, 4096)
+	length := runtime.Stack(stack, false)
+
+	log.LogError(
+		fmt.Errorf("panic recovered: %v", recovered),
+		"grpc_recovery",
+		"panic_recovery",
+		map[string]interface{}{
+			"stack_trace": string(stack[:length]),
+			"request_id":  RequestID(ctx),
+			"method":      method,
+		},
+	)
+
+	*err = statusError(codes.Internal, fmt.Errorf("internal server error"), models.CodeInternalServer, RequestID(ctx))
+}
+
+// serverStream overrides the context of a stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying the request ID and consumer
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
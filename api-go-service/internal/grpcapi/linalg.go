@@ -0,0 +1,189 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/katvio/api-go-service/internal/linalg"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+	apiv1 "github.com/katvio/api-go-service/proto/zama/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// linalgRequest is implemented by all linear algebra request models
+type linalgRequest interface {
+	Validate(maxElements int) error
+}
+
+// LinalgService implements zama.api.v1.LinalgService
+type LinalgService struct {
+	apiv1.UnimplementedLinalgServiceServer
+
+	logger      *logger.Logger
+	maxElements int
+}
+
+// NewLinalgService creates the linear algebra service
+func NewLinalgService(logger *logger.Logger, maxElements int) *LinalgService {
+	return &LinalgService{
+		logger:      logger,
+		maxElements: maxElements,
+	}
+}
+
+// VectorAdd adds vectors element-wise
+func (s *LinalgService) VectorAdd(ctx context.Context, req *apiv1.VectorsRequest) (*apiv1.VectorResponse, error) {
+	request := models.VectorsRequest{Vectors: make([][]float64, len(req.GetVectors()))}
+	for i, v := range req.GetVectors() {
+		request.Vectors[i] = v.GetValues()
+	}
+	if err := s.validate(ctx, "vector_add", &request); err != nil {
+		return nil, err
+	}
+
+	vectors := make([]linalg.Vector, len(request.Vectors))
+	for i, v := range request.Vectors {
+		vectors[i] = v
+	}
+
+	return vectorResponseProto(models.NewVectorResponse("vector_add", linalg.AddVectors(vectors), RequestID(ctx))), nil
+}
+
+// VectorScale multiplies a vector by a scalar
+func (s *LinalgService) VectorScale(ctx context.Context, req *apiv1.ScaleRequest) (*apiv1.VectorResponse, error) {
+	if req.Scalar == nil {
+		return nil, reject(ctx, s.logger, "grpc_linalg", "vector_scale", codes.InvalidArgument, errors.New("scalar is required"), models.CodeInvalidRequestBody)
+	}
+
+	request := models.ScaleRequest{Scalar: req.Scalar, Vector: req.GetVector()}
+	if err := s.validate(ctx, "vector_scale", &request); err != nil {
+		return nil, err
+	}
+
+	return vectorResponseProto(models.NewVectorResponse("vector_scale", linalg.Scale(request.Vector, *request.Scalar), RequestID(ctx))), nil
+}
+
+// VectorDot computes the dot product of two vectors
+func (s *LinalgService) VectorDot(ctx context.Context, req *apiv1.DotRequest) (*apiv1.ScalarResponse, error) {
+	request := models.DotRequest{A: req.GetA(), B: req.GetB()}
+	if err := s.validate(ctx, "vector_dot", &request); err != nil {
+		return nil, err
+	}
+
+	response := models.NewScalarResponse("vector_dot", linalg.Dot(request.A, request.B), RequestID(ctx))
+	return &apiv1.ScalarResponse{
+		Operation: response.Operation,
+		Result:    response.Result,
+		Timestamp: timestamppb.New(response.Timestamp),
+		RequestId: response.RequestID,
+	}, nil
+}
+
+// MatrixAdd adds matrices element-wise
+func (s *LinalgService) MatrixAdd(ctx context.Context, req *apiv1.MatricesRequest) (*apiv1.MatrixResponse, error) {
+	request := models.MatricesRequest{Matrices: make([][][]float64, len(req.GetMatrices()))}
+	for i, m := range req.GetMatrices() {
+		request.Matrices[i] = matrixFromProto(m)
+	}
+	if err := s.validate(ctx, "matrix_add", &request); err != nil {
+		return nil, err
+	}
+
+	matrices := make([]linalg.Matrix, len(request.Matrices))
+	for i, m := range request.Matrices {
+		matrices[i] = m
+	}
+
+	return matrixResponseProto(models.NewMatrixResponse("matrix_add", linalg.AddMatrices(matrices), RequestID(ctx))), nil
+}
+
+// MatrixMultiply multiplies two matrices
+func (s *LinalgService) MatrixMultiply(ctx context.Context, req *apiv1.MatrixMultiplyRequest) (*apiv1.MatrixResponse, error) {
+	request := models.MatrixMultiplyRequest{A: matrixFromProto(req.GetA()), B: matrixFromProto(req.GetB())}
+	if err := s.validate(ctx, "matrix_multiply", &request); err != nil {
+		return nil, err
+	}
+
+	return matrixResponseProto(models.NewMatrixResponse("matrix_multiply", linalg.Multiply(request.A, request.B), RequestID(ctx))), nil
+}
+
+// MatrixTranspose transposes a matrix
+func (s *LinalgService) MatrixTranspose(ctx context.Context, req *apiv1.MatrixRequest) (*apiv1.MatrixResponse, error) {
+	request := models.MatrixRequest{Matrix: matrixFromProto(req.GetMatrix())}
+	if err := s.validate(ctx, "matrix_transpose", &request); err != nil {
+		return nil, err
+	}
+
+	return matrixResponseProto(models.NewMatrixResponse("matrix_transpose", linalg.Transpose(request.Matrix), RequestID(ctx))), nil
+}
+
+// RowSums sums each row of a matrix
+func (s *LinalgService) RowSums(ctx context.Context, req *apiv1.MatrixRequest) (*apiv1.VectorResponse, error) {
+	request := models.MatrixRequest{Matrix: matrixFromProto(req.GetMatrix())}
+	if err := s.validate(ctx, "matrix_row_sums", &request); err != nil {
+		return nil, err
+	}
+
+	return vectorResponseProto(models.NewVectorResponse("matrix_row_sums", linalg.RowSums(request.Matrix), RequestID(ctx))), nil
+}
+
+// ColumnSums sums each column of a matrix
+func (s *LinalgService) ColumnSums(ctx context.Context, req *apiv1.MatrixRequest) (*apiv1.VectorResponse, error) {
+	request := models.MatrixRequest{Matrix: matrixFromProto(req.GetMatrix())}
+	if err := s.validate(ctx, "matrix_column_sums", &request); err != nil {
+		return nil, err
+	}
+
+	return vectorResponseProto(models.NewVectorResponse("matrix_column_sums", linalg.ColumnSums(request.Matrix), RequestID(ctx))), nil
+}
+
+// validate checks a request against the configured element limit
+func (s *LinalgService) validate(ctx context.Context, operation string, request linalgRequest) error {
+	if err := request.Validate(s.maxElements); err != nil {
+		return reject(ctx, s.logger, "grpc_linalg", operation, codes.InvalidArgument, err, models.ErrorCode(err, models.CodeValidation))
+	}
+	return nil
+}
+
+// matrixFromProto converts a proto matrix to rows of values
+func matrixFromProto(m *apiv1.Matrix) [][]float64 {
+	rows := make([][]float64, len(m.GetRows()))
+	for i, row := range m.GetRows() {
+		rows[i] = row.GetValues()
+	}
+	return rows
+}
+
+// matrixToProto converts rows of values to a proto matrix
+func matrixToProto(rows [][]float64) *apiv1.Matrix {
+	m := &apiv1.Matrix{Rows: make([]*apiv1.Vector, len(rows))}
+	for i, row := range rows {
+		m.Rows[i] = &apiv1.Vector{Values: row}
+	}
+	return m
+}
+
+// vectorResponseProto converts a vector response to its proto message
+func vectorResponseProto(response *models.VectorResponse) *apiv1.VectorResponse {
+	return &apiv1.VectorResponse{
+		Operation: response.Operation,
+		Result:    response.Result,
+		Length:    int64(response.Length),
+		Timestamp: timestamppb.New(response.Timestamp),
+		RequestId: response.RequestID,
+	}
+}
+
+// matrixResponseProto converts a matrix response to its proto message
+func matrixResponseProto(response *models.MatrixResponse) *apiv1.MatrixResponse {
+	return &apiv1.MatrixResponse{
+		Operation: response.Operation,
+		Result:    matrixToProto(response.Result),
+		Rows:      int64(response.Rows),
+		Cols:      int64(response.Cols),
+		Timestamp: timestamppb.New(response.Timestamp),
+		RequestId: response.RequestID,
+	}
+}
@@ -0,0 +1,248 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/modring"
+	"github.com/katvio/api-go-service/pkg/logger"
+	apiv1 "github.com/katvio/api-go-service/proto/zama/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ModularService implements zama.api.v1.ModularService
+type ModularService struct {
+	apiv1.UnimplementedModularServiceServer
+
+	logger *logger.Logger
+	config config.ModularConfig
+}
+
+// NewModularService creates the modular arithmetic service
+func NewModularService(logger *logger.Logger, cfg config.ModularConfig) *ModularService {
+	return &ModularService{
+		logger: logger,
+		config: cfg,
+	}
+}
+
+// ModularSum adds values modulo q
+func (s *ModularService) ModularSum(ctx context.Context, req *apiv1.ModularRequest) (*apiv1.ModularResponse, error) {
+	request, modulus, err := s.bind(ctx, "modular_sum", req)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := modulus.Sum(models.BigInts(request.Values))
+	return modularResponseProto(models.NewModularResponse("modular_sum", modulus.Value(), sum, len(request.Values), RequestID(ctx))), nil
+}
+
+// ModularProduct multiplies values modulo q
+func (s *ModularService) ModularProduct(ctx context.Context, req *apiv1.ModularRequest) (*apiv1.ModularResponse, error) {
+	request, modulus, err := s.bind(ctx, "modular_product", req)
+	if err != nil {
+		return nil, err
+	}
+
+	product := modulus.Product(models.BigInts(request.Values))
+	return modularResponseProto(models.NewModularResponse("modular_product", modulus.Value(), product, len(request.Values), RequestID(ctx))), nil
+}
+
+// ModularReduce reduces each value modulo q
+func (s *ModularService) ModularReduce(ctx context.Context, req *apiv1.ModularRequest) (*apiv1.ModularResponse, error) {
+	request, modulus, err := s.bind(ctx, "modular_reduce", req)
+	if err != nil {
+		return nil, err
+	}
+
+	reduced := modulus.ReduceAll(models.BigInts(request.Values))
+	return modularResponseProto(models.NewModularVectorResponse("modular_reduce", modulus.Value(), reduced, RequestID(ctx))), nil
+}
+
+// RingAdd adds polynomials in Z_q[X]/(X^n+1)
+func (s *ModularService) RingAdd(ctx context.Context, req *apiv1.RingRequest) (*apiv1.RingResponse, error) {
+	request, err := s.ringRequest(ctx, "ring_add", req)
+	if err != nil {
+		return nil, err
+	}
+	ring, modulus, err := s.ring(ctx, "ring_add", request)
+	if err != nil {
+		return nil, err
+	}
+
+	polys := make([][]*big.Int, len(request.Polynomials))
+	for i, p := range request.Polynomials {
+		polys[i] = models.BigInts(p)
+	}
+
+	return ringResponseProto(models.NewRingResponse("ring_add", modulus.Value(), ring.Add(polys), "", RequestID(ctx))), nil
+}
+
+// RingMultiply multiplies polynomials in Z_q[X]/(X^n+1)
+func (s *ModularService) RingMultiply(ctx context.Context, req *apiv1.RingRequest) (*apiv1.RingResponse, error) {
+	request, err := s.ringRequest(ctx, "ring_multiply", req)
+	if err != nil {
+		return nil, err
+	}
+	ring, modulus, err := s.ring(ctx, "ring_multiply", request)
+	if err != nil {
+		return nil, err
+	}
+
+	product := models.BigInts(request.Polynomials[0])
+	for _, p := range request.Polynomials[1:] {
+		product = ring.Multiply(product, models.BigInts(p))
+	}
+
+	algorithm := "schoolbook"
+	if ring.NTTEnabled() {
+		algorithm = "ntt"
+	}
+
+	return ringResponseProto(models.NewRingResponse("ring_multiply", modulus.Value(), product, algorithm, RequestID(ctx))), nil
+}
+
+// RingReduce reduces a polynomial into Z_q[X]/(X^n+1)
+func (s *ModularService) RingReduce(ctx context.Context, req *apiv1.RingReduceRequest) (*apiv1.RingResponse, error) {
+	modulus, err := s.parse(ctx, "ring_reduce", "modulus", req.GetModulus())
+	if err != nil {
+		return nil, err
+	}
+	coefficients, err := s.parseAll(ctx, "ring_reduce", req.GetPolynomial().GetCoefficients())
+	if err != nil {
+		return nil, err
+	}
+
+	request := &models.RingReduceRequest{Modulus: modulus, Degree: int(req.GetDegree()), Polynomial: coefficients}
+	ring, q, err := s.ring(ctx, "ring_reduce", request)
+	if err != nil {
+		return nil, err
+	}
+
+	reduced := ring.Reduce(models.BigInts(request.Polynomial))
+	return ringResponseProto(models.NewRingResponse("ring_reduce", q.Value(), reduced, "", RequestID(ctx))), nil
+}
+
+// bind converts and validates a modular request and builds its modulus
+func (s *ModularService) bind(ctx context.Context, operation string, req *apiv1.ModularRequest) (*models.ModularRequest, *modring.Modulus, error) {
+	q, err := s.parse(ctx, operation, "modulus", req.GetModulus())
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := s.parseAll(ctx, operation, req.GetValues())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request := &models.ModularRequest{Modulus: q, Values: values}
+	if err := request.Validate(s.config.MaxValues); err != nil {
+		return nil, nil, reject(ctx, s.logger, "grpc_modular", operation, codes.InvalidArgument, err, models.ErrorCode(err, models.CodeValidation))
+	}
+
+	modulus, err := s.modulus(ctx, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return request, modulus, nil
+}
+
+// ringRequest converts a ring request
+func (s *ModularService) ringRequest(ctx context.Context, operation string, req *apiv1.RingRequest) (*models.RingRequest, error) {
+	q, err := s.parse(ctx, operation, "modulus", req.GetModulus())
+	if err != nil {
+		return nil, err
+	}
+
+	request := &models.RingRequest{Modulus: q, Degree: int(req.GetDegree()), Polynomials: make([][]models.BigInt, len(req.GetPolynomials()))}
+	for i, p := range req.GetPolynomials() {
+		if request.Polynomials[i], err = s.parseAll(ctx, operation, p.GetCoefficients()); err != nil {
+			return nil, err
+		}
+	}
+	return request, nil
+}
+
+// ring builds the ring a request operates in and validates the request's shapes
+// Shapes are validated only once the degree itself is known to be valid
+func (s *ModularService) ring(ctx context.Context, operation string, request interface {
+	Validate(maxValues int) error
+	RingParameters() (*models.BigInt, int)
+}) (*modring.Ring, *modring.Modulus, error) {
+	q, degree := request.RingParameters()
+	modulus, err := s.modulus(ctx, q)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ring, err := modring.NewRing(modulus, degree, s.config.MaxDegree, s.config.MaxRingBits)
+	if err != nil {
+		return nil, nil, reject(ctx, s.logger, "grpc_modular", operation, codes.InvalidArgument, models.ErrorAt(err, models.CodeInvalidDegree, "degree"), models.CodeInvalidDegree)
+	}
+
+	if err := request.Validate(s.config.MaxValues); err != nil {
+		return nil, nil, reject(ctx, s.logger, "grpc_modular", operation, codes.InvalidArgument, err, models.ErrorCode(err, models.CodeValidation))
+	}
+
+	return ring, modulus, nil
+}
+
+// modulus validates q against the configured bounds
+func (s *ModularService) modulus(ctx context.Context, q *models.BigInt) (*modring.Modulus, error) {
+	modulus, err := modring.NewModulus(q.Int(), s.config.MaxModulusBits)
+	if err != nil {
+		return nil, reject(ctx, s.logger, "grpc_modular", "validate_modulus", codes.InvalidArgument, models.ErrorAt(err, models.CodeInvalidModulus, "modulus"), models.CodeInvalidModulus)
+	}
+	return modulus, nil
+}
+
+// parse parses a base-10 integer field
+func (s *ModularService) parse(ctx context.Context, operation, field, text string) (*models.BigInt, error) {
+	value, err := models.ParseBigInt(text)
+	if err != nil {
+		return nil, reject(ctx, s.logger, "grpc_modular", operation, codes.InvalidArgument, fmt.Errorf("%s: %w", field, err), models.CodeInvalidRequestBody)
+	}
+	return value, nil
+}
+
+// parseAll parses a list of base-10 integers
+func (s *ModularService) parseAll(ctx context.Context, operation string, texts []string) ([]models.BigInt, error) {
+	values := make([]models.BigInt, len(texts))
+	for i, text := range texts {
+		value, err := s.parse(ctx, operation, fmt.Sprintf("value %d", i), text)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = *value
+	}
+	return values, nil
+}
+
+// modularResponseProto converts a modular response to its proto message
+func modularResponseProto(response *models.ModularResponse) *apiv1.ModularResponse {
+	return &apiv1.ModularResponse{
+		Operation: response.Operation,
+		Modulus:   response.Modulus,
+		Result:    response.Result,
+		Results:   response.Results,
+		Count:     int64(response.Count),
+		Timestamp: timestamppb.New(response.Timestamp),
+		RequestId: response.RequestID,
+	}
+}
+
+// ringResponseProto converts a ring response to its proto message
+func ringResponseProto(response *models.RingResponse) *apiv1.RingResponse {
+	return &apiv1.RingResponse{
+		Operation:    response.Operation,
+		Modulus:      response.Modulus,
+		Degree:       int64(response.Degree),
+		Coefficients: response.Coefficients,
+		Algorithm:    response.Algorithm,
+		Timestamp:    timestamppb.New(response.Timestamp),
+		RequestId:    response.RequestID,
+	}
+}
@@ -0,0 +1,107 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/privacy"
+	"github.com/katvio/api-go-service/pkg/logger"
+	apiv1 "github.com/katvio/api-go-service/proto/zama/api/v1"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server is the gRPC server running next to the HTTP router
+type Server struct {
+	grpcServer *grpc.Server
+	health     *HealthService
+	config     *config.Config
+	logger     *logger.Logger
+}
+
+// NewServer creates the gRPC server and registers all services
+// ready reports the readiness of the process for the health service
+func NewServer(cfg *config.Config, log *logger.Logger, accountant *privacy.Accountant, ready func() (bool, map[string]string)) (*Server, error) {
+	trusted, err := parseTrustedPeers(cfg.GRPC.TrustedPeers)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptors(log, trusted)...),
+		grpc.ChainStreamInterceptor(streamInterceptors(log, trusted)...),
+	)
+
+	apiv1.RegisterSumServiceServer(grpcServer, NewSumService(log, accountant))
+	apiv1.RegisterLinalgServiceServer(grpcServer, NewLinalgService(log, cfg.Linalg.MaxElements))
+	apiv1.RegisterModularServiceServer(grpcServer, NewModularService(log, cfg.Modular))
+
+	health := NewHealthService(ready,
+		apiv1.SumService_ServiceDesc.ServiceName,
+		apiv1.LinalgService_ServiceDesc.ServiceName,
+		apiv1.ModularService_ServiceDesc.ServiceName,
+	)
+	healthpb.RegisterHealthServer(grpcServer, health)
+
+	if cfg.GRPC.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	return &Server{
+		grpcServer: grpcServer,
+		health:     health,
+		config:     cfg,
+		logger:     log,
+	}, nil
+}
+
+// Start listens on the gRPC port and serves in the background
+// Listen errors are returned, so a port conflict fails startup
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%s", s.config.Server.Host, s.config.GRPC.Port))
+	if err != nil {
+		return err
+	}
+
+	go s.Serve(listener)
+
+	s.logger.WithFields(map[string]interface{}{
+		"port":       s.config.GRPC.Port,
+		"host":       s.config.Server.Host,
+		"reflection": s.config.GRPC.Reflection,
+	}).Info("gRPC server started successfully")
+
+	return nil
+}
+
+// Serve serves calls on a listener until the server is stopped
+func (s *Server) Serve(listener net.Listener) {
+	if err := s.grpcServer.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+		s.logger.LogError(err, "grpc_server", "serve", map[string]interface{}{
+			"port": s.config.GRPC.Port,
+			"host": s.config.Server.Host,
+		})
+	}
+}
+
+// Stop reports NOT_SERVING and waits for calls in flight to finish
+// Calls still running when ctx is done are cancelled
+func (s *Server) Stop(ctx context.Context) {
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		<-stopped
+	}
+}
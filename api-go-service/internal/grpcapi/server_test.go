@@ -0,0 +1,288 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/privacy"
+	"github.com/katvio/api-go-service/pkg/logger"
+	apiv1 "github.com/katvio/api-go-service/proto/zama/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func setupTestServer(t *testing.T, ready func() (bool, map[string]string)) (*Server, *grpc.ClientConn) {
+	return setupTestServerWithPeers(t, ready, []string{"127.0.0.1"})
+}
+
+// setupTestServerWithPeers serves on loopback, trusting the consumer metadata of the given peers
+func setupTestServerWithPeers(t *testing.T, ready func() (bool, map[string]string), trustedPeers []string) (*Server, *grpc.ClientConn) {
+	cfg := &config.Config{
+		GRPC:    config.GRPCConfig{TrustedPeers: trustedPeers},
+		Privacy: config.PrivacyConfig{Budget: 1, MaxEpsilon: 1},
+		Linalg:  config.LinalgConfig{MaxElements: 100},
+		Modular: config.ModularConfig{MaxModulusBits: 256, MaxDegree: 64, MaxValues: 100},
+	}
+	log := logger.New("error", "json") // Use error level to reduce test noise
+	server, err := NewServer(cfg, log, privacy.NewAccountant(cfg.Privacy.Budget), ready)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Stop(context.Background()) })
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return server, conn
+}
+
+// errorInfo returns the ErrorInfo detail of a status error
+func errorInfo(t *testing.T, err error) *errdetails.ErrorInfo {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatalf("no ErrorInfo in %v", err)
+	return nil
+}
+
+func alwaysReady() (bool, map[string]string) {
+	return true, map[string]string{"service": "ok"}
+}
+
+// TestSumService tests the gRPC sum service
+func TestSumService(t *testing.T) {
+	_, conn := setupTestServer(t, alwaysReady)
+	client := apiv1.NewSumServiceClient(conn)
+
+	t.Run("Sum adds the numbers and echoes the request ID", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-123")
+		var header metadata.MD
+
+		response, err := client.Sum(ctx, &apiv1.SumRequest{Numbers: []float64{1, 2, 3.5}}, grpc.Header(&header))
+		require.NoError(t, err)
+		assert.Equal(t, 6.5, response.GetSum())
+		assert.Equal(t, int64(3), response.GetCount())
+		assert.Equal(t, "req-123", response.GetRequestId())
+		assert.Equal(t, []string{"req-123"}, header.Get("x-request-id"))
+	})
+
+	t.Run("A request ID is generated when none is sent", func(t *testing.T) {
+		var header metadata.MD
+		response, err := client.Sum(context.Background(), &apiv1.SumRequest{Numbers: []float64{1, 2}}, grpc.Header(&header))
+		require.NoError(t, err)
+		assert.NotEmpty(t, response.GetRequestId())
+		assert.Equal(t, []string{response.GetRequestId()}, header.Get("x-request-id"))
+	})
+
+	t.Run("Invalid requests carry the API error code", func(t *testing.T) {
+		_, err := client.Sum(context.Background(), &apiv1.SumRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		info := errorInfo(t, err)
+		assert.Equal(t, "VALIDATION_ERROR", info.GetReason())
+		assert.Equal(t, errorDomain, info.GetDomain())
+		assert.NotEmpty(t, info.GetMetadata()["request_id"])
+
+		var violations []*errdetails.BadRequest_FieldViolation
+		for _, detail := range status.Convert(err).Details() {
+			if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+				violations = badRequest.GetFieldViolations()
+			}
+		}
+		require.Len(t, violations, 1)
+		assert.Equal(t, "/numbers", violations[0].GetField())
+	})
+
+	t.Run("Privacy budgets are charged per consumer", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-consumer-username", "analyst")
+		lower, upper := 0.0, 10.0
+		request := &apiv1.SumRequest{
+			Numbers: []float64{1, 2, 30},
+			Privacy: &apiv1.PrivacyParams{Mechanism: "laplace", Epsilon: 0.6, Lower: &lower, Upper: &upper},
+		}
+
+		response, err := client.Sum(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, 0.6, response.GetPrivacy().GetEpsilonSpent())
+		assert.InDelta(t, 0.4, response.GetPrivacy().GetBudgetRemaining(), 1e-9)
+
+		_, err = client.Sum(ctx, request)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, models.CodePrivacyBudgetExhausted, errorInfo(t, err).GetReason())
+	})
+
+	t.Run("Anonymous calls get no private sums", func(t *testing.T) {
+		lower, upper := 0.0, 10.0
+		_, err := client.Sum(context.Background(), &apiv1.SumRequest{
+			Numbers: []float64{1, 2},
+			Privacy: &apiv1.PrivacyParams{Mechanism: "laplace", Epsilon: 0.1, Lower: &lower, Upper: &upper},
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, models.CodeConsumerRequired, errorInfo(t, err).GetReason())
+	})
+}
+
+// TestLinalgService tests the gRPC linear algebra service
+func TestLinalgService(t *testing.T) {
+	_, conn := setupTestServer(t, alwaysReady)
+	client := apiv1.NewLinalgServiceClient(conn)
+
+	t.Run("Matrix multiply", func(t *testing.T) {
+		response, err := client.MatrixMultiply(context.Background(), &apiv1.MatrixMultiplyRequest{
+			A: matrixToProto([][]float64{{1, 2}, {3, 4}}),
+			B: matrixToProto([][]float64{{5}, {6}}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, [][]float64{{17}, {39}}, matrixFromProto(response.GetResult()))
+		assert.Equal(t, int64(2), response.GetRows())
+		assert.Equal(t, int64(1), response.GetCols())
+	})
+
+	t.Run("Scale requires a scalar", func(t *testing.T) {
+		_, err := client.VectorScale(context.Background(), &apiv1.ScaleRequest{Vector: []float64{1}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "INVALID_REQUEST_BODY", errorInfo(t, err).GetReason())
+	})
+
+	t.Run("Shape mismatches are refused", func(t *testing.T) {
+		_, err := client.VectorDot(context.Background(), &apiv1.DotRequest{A: []float64{1, 2}, B: []float64{1}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// TestModularService tests the gRPC modular arithmetic service
+func TestModularService(t *testing.T) {
+	_, conn := setupTestServer(t, alwaysReady)
+	client := apiv1.NewModularServiceClient(conn)
+
+	t.Run("Modular sum", func(t *testing.T) {
+		response, err := client.ModularSum(context.Background(), &apiv1.ModularRequest{
+			Modulus: "97",
+			Values:  []string{"50", "60", "-3"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "10", response.GetResult())
+		assert.Equal(t, int64(3), response.GetCount())
+	})
+
+	t.Run("Ring multiply wraps around X^n+1", func(t *testing.T) {
+		response, err := client.RingMultiply(context.Background(), &apiv1.RingRequest{
+			Modulus: "17",
+			Degree:  2,
+			Polynomials: []*apiv1.Polynomial{
+				{Coefficients: []string{"0", "1"}},
+				{Coefficients: []string{"0", "1"}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"16", "0"}, response.GetCoefficients())
+	})
+
+	t.Run("Invalid integers are refused", func(t *testing.T) {
+		_, err := client.ModularSum(context.Background(), &apiv1.ModularRequest{Modulus: "97", Values: []string{"1.5"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "INVALID_REQUEST_BODY", errorInfo(t, err).GetReason())
+	})
+
+	t.Run("Invalid modulus is refused", func(t *testing.T) {
+		_, err := client.ModularSum(context.Background(), &apiv1.ModularRequest{Modulus: "1", Values: []string{"1"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, models.CodeInvalidModulus, errorInfo(t, err).GetReason())
+	})
+}
+
+// TestHealthService tests the standard gRPC health service
+func TestHealthService(t *testing.T) {
+	var ready atomic.Bool
+	ready.Store(true)
+	server, conn := setupTestServer(t, func() (bool, map[string]string) {
+		return ready.Load(), nil
+	})
+	client := healthpb.NewHealthClient(conn)
+
+	check := func(service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+		response, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		return response.GetStatus(), err
+	}
+
+	serving, err := check("")
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, serving)
+
+	serving, err = check(apiv1.SumService_ServiceDesc.ServiceName)
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, serving)
+
+	_, err = check("unknown.Service")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	ready.Store(false)
+	serving, err = check("")
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, serving)
+
+	ready.Store(true)
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	update, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, update.GetStatus())
+
+	server.health.Shutdown()
+	update, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, update.GetStatus())
+}
+
+// TestRecoveryInterceptor tests that panics become Internal errors
+func TestRecoveryInterceptor(t *testing.T) {
+	interceptors := unaryInterceptors(logger.New("error", "json"), nil)
+	recovery := interceptors[len(interceptors)-1]
+
+	_, err := recovery(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test/Panic"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("boom")
+		})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "INTERNAL_SERVER_ERROR", errorInfo(t, err).GetReason())
+}
+
+// TestIdentify tests that consumer metadata is only trusted from configured peers
+func TestIdentify(t *testing.T) {
+	trusted, err := parseTrustedPeers([]string{"10.0.0.0/8", "127.0.0.1"})
+	require.NoError(t, err)
+
+	call := func(address string) context.Context {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-consumer-username", "analyst"))
+		return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(address), Port: 50000}})
+	}
+
+	assert.Equal(t, "analyst", Consumer(identify(call("10.1.2.3"), trusted)))
+	assert.Equal(t, "analyst", Consumer(identify(call("127.0.0.1"), trusted)))
+	assert.Equal(t, "anonymous", Consumer(identify(call("127.0.0.2"), trusted)))
+	assert.Equal(t, "anonymous", Consumer(identify(call("203.0.113.7"), trusted)))
+	assert.Equal(t, "anonymous", Consumer(identify(call("10.1.2.3"), nil)))
+
+	_, err = parseTrustedPeers([]string{"not-a-network"})
+	assert.Error(t, err)
+}
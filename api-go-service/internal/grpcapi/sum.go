@@ -0,0 +1,82 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/privacy"
+	"github.com/katvio/api-go-service/pkg/logger"
+	apiv1 "github.com/katvio/api-go-service/proto/zama/api/v1"
+	"google.golang.org/grpc/codes"
+)
+
+// SumService implements zama.api.v1.SumService
+type SumService struct {
+	apiv1.UnimplementedSumServiceServer
+
+	logger     *logger.Logger
+	accountant *privacy.Accountant
+}
+
+// NewSumService creates the sum service
+// It shares the privacy accountant of the HTTP API, so both spend the same budgets
+func NewSumService(logger *logger.Logger, accountant *privacy.Accountant) *SumService {
+	return &SumService{
+		logger:     logger,
+		accountant: accountant,
+	}
+}
+
+// Sum adds numbers, exactly or with differential privacy
+func (s *SumService) Sum(ctx context.Context, req *apiv1.SumRequest) (*apiv1.SumResponse, error) {
+	request := models.SumRequestFromProto(req)
+
+	if err := request.Validate(); err != nil {
+		return nil, reject(ctx, s.logger, "grpc_sum", "validate_request", codes.InvalidArgument, err, models.CodeValidation)
+	}
+
+	if request.Privacy != nil {
+		return s.privateSum(ctx, request)
+	}
+
+	return models.NewSumResponse(request.Numbers, RequestID(ctx)).Proto(), nil
+}
+
+// privateSum answers with calibrated noise and charges the caller's privacy budget
+// Anonymous callers share one budget, so they are refused as on the HTTP API
+func (s *SumService) privateSum(ctx context.Context, request *models.SumRequest) (*apiv1.SumResponse, error) {
+	if err := request.Privacy.Validate(); err != nil {
+		return nil, reject(ctx, s.logger, "grpc_sum", "validate_privacy", codes.InvalidArgument, err, models.ErrorCode(err, models.CodeValidation))
+	}
+
+	if Consumer(ctx) == middleware.AnonymousConsumer {
+		err := models.NewAPIError(models.CodeConsumerRequired, nil)
+		return nil, reject(ctx, s.logger, "grpc_sum", "check_consumer", codes.PermissionDenied, err, models.CodeConsumerRequired)
+	}
+
+	params := request.Privacy.SumParams()
+
+	remaining, err := s.accountant.Spend(Consumer(ctx), params.Epsilon)
+	if err != nil {
+		if errors.Is(err, privacy.ErrBudgetExhausted) {
+			return nil, reject(ctx, s.logger, "grpc_sum", "spend_budget", codes.ResourceExhausted, err, models.CodePrivacyBudgetExhausted)
+		}
+		return nil, reject(ctx, s.logger, "grpc_sum", "spend_budget", codes.Internal, err, models.CodeInternal)
+	}
+
+	// Nothing is released when the sum fails, so the query is not charged
+	result, err := privacy.Sum(request.Numbers, params)
+	if err != nil {
+		if refundErr := s.accountant.Refund(Consumer(ctx), params.Epsilon); refundErr != nil {
+			err = errors.Join(err, refundErr)
+		}
+		if errors.Is(err, privacy.ErrNotFinite) {
+			return nil, reject(ctx, s.logger, "grpc_sum", "add_noise", codes.OutOfRange, models.NewAPIError(models.CodeResultOverflow, nil), models.CodeResultOverflow)
+		}
+		return nil, reject(ctx, s.logger, "grpc_sum", "add_noise", codes.Internal, err, models.CodeInternal)
+	}
+
+	return models.NewPrivateSumResponse(len(request.Numbers), params, result, remaining, RequestID(ctx)).Proto(), nil
+}
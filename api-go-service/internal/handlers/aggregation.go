@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/modring"
+	"github.com/katvio/api-go-service/internal/secagg"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// AggregationHandler handles secure aggregation sessions over additive secret shares
+type AggregationHandler struct {
+	logger         *logger.Logger
+	config         config.AggregationConfig
+	maxModulusBits int
+	sessions       *secagg.Manager
+}
+
+// NewAggregationHandler creates a new secure aggregation handler
+func NewAggregationHandler(logger *logger.Logger, cfg config.AggregationConfig, maxModulusBits int, sessions *secagg.Manager) *AggregationHandler {
+	return &AggregationHandler{
+		logger:         logger,
+		config:         cfg,
+		maxModulusBits: maxModulusBits,
+		sessions:       sessions,
+	}
+}
+
+// HandleCreateSession handles POST /api/v1/aggregation/sessions requests
+func (h *AggregationHandler) HandleCreateSession(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	var request models.AggregationSessionRequest
+	if statusCode, code, err := bindJSON(c, &request); err != nil {
+		h.reject(c, reqID, "bind_request", statusCode, err, code)
+		return
+	}
+
+	if err := request.Validate(h.config.MaxParticipants, h.config.MaxTimeout); err != nil {
+		h.reject(c, reqID, "validate_request", http.StatusBadRequest, err, models.ErrorCode(err, models.CodeValidation))
+		return
+	}
+
+	if _, err := modring.NewModulus(request.Modulus.Int(), h.maxModulusBits); err != nil {
+		h.reject(c, reqID, "validate_modulus", http.StatusBadRequest, models.ErrorAt(err, models.CodeInvalidModulus, "modulus"), models.CodeInvalidModulus)
+		return
+	}
+
+	if !h.identified(c, reqID, "create_session") {
+		return
+	}
+
+	snap, err := h.sessions.Create(middleware.GetConsumer(c), request.Options(h.config.DefaultTimeout))
+	if err != nil {
+		statusCode, code, reported := sessionErrorStatus(err, "")
+		h.reject(c, reqID, "create_session", statusCode, reported, code)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.NewAggregationSessionResponse(snap, reqID))
+}
+
+// HandleSubmitShares handles POST /api/v1/aggregation/sessions/:id/shares requests
+// The caller's consumer identity is the participant identity, so anonymous callers are refused
+func (h *AggregationHandler) HandleSubmitShares(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	var request models.AggregationSharesRequest
+	if statusCode, code, err := bindJSON(c, &request); err != nil {
+		h.reject(c, reqID, "bind_request", statusCode, err, code)
+		return
+	}
+
+	if err := request.Validate(h.config.MaxShares, h.maxModulusBits); err != nil {
+		h.reject(c, reqID, "validate_request", http.StatusBadRequest, err, models.ErrorCode(err, models.CodeValidation))
+		return
+	}
+
+	if !h.identified(c, reqID, "submit_shares") {
+		return
+	}
+
+	snap, err := h.sessions.Submit(c.Param("id"), middleware.GetConsumer(c), models.BigInts(request.Shares))
+	if err != nil {
+		statusCode, code, reported := sessionErrorStatus(err, c.Param("id"))
+		h.reject(c, reqID, "submit_shares", statusCode, reported, code)
+		return
+	}
+
+	statusCode := http.StatusAccepted
+	if snap.State == secagg.StateComplete {
+		statusCode = http.StatusOK
+	}
+
+	c.JSON(statusCode, models.NewAggregationSessionResponse(snap, reqID))
+}
+
+// HandleGetSession handles GET /api/v1/aggregation/sessions/:id requests
+func (h *AggregationHandler) HandleGetSession(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	snap, err := h.sessions.Get(c.Param("id"), middleware.GetConsumer(c))
+	if err != nil {
+		statusCode, code, reported := sessionErrorStatus(err, c.Param("id"))
+		h.reject(c, reqID, "get_session", statusCode, reported, code)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewAggregationSessionResponse(snap, reqID))
+}
+
+// identified refuses anonymous callers, who would all own the same sessions and
+// count as the same participant. On refusal the error response is written.
+func (h *AggregationHandler) identified(c *gin.Context, reqID, operation string) bool {
+	if middleware.GetConsumer(c) != middleware.AnonymousConsumer {
+		return true
+	}
+	err := models.NewAPIError(models.CodeConsumerRequired, nil)
+	h.reject(c, reqID, operation, http.StatusForbidden, err, models.CodeConsumerRequired)
+	return false
+}
+
+// reject logs a failed request and writes the error response
+func (h *AggregationHandler) reject(c *gin.Context, reqID, operation string, statusCode int, err error, code string) {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "aggregation_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"consumer":   middleware.GetConsumer(c),
+		"code":       code,
+	}).Error("Aggregation request failed")
+
+	middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+}
+
+// sessionErrorStatus maps session manager errors to HTTP status codes, error codes and reported errors
+func sessionErrorStatus(err error, sessionID string) (int, string, error) {
+	var statusCode int
+	var code string
+	switch {
+	case errors.Is(err, secagg.ErrSessionNotFound):
+		return http.StatusNotFound, models.CodeSessionNotFound, models.NewAPIError(models.CodeSessionNotFound, nil).WithParam("session_id", sessionID)
+	case errors.Is(err, secagg.ErrSessionClosed):
+		statusCode, code = http.StatusConflict, models.CodeSessionClosed
+	case errors.Is(err, secagg.ErrDuplicateShare):
+		statusCode, code = http.StatusConflict, models.CodeDuplicateShare
+	case errors.Is(err, secagg.ErrUnknownParticipant):
+		statusCode, code = http.StatusForbidden, models.CodeUnknownParticipant
+	case errors.Is(err, secagg.ErrInvalidShare):
+		statusCode, code = http.StatusBadRequest, models.CodeInvalidShare
+	case errors.Is(err, secagg.ErrConsumerLimit):
+		statusCode, code = http.StatusTooManyRequests, models.CodeSessionLimitReached
+	case errors.Is(err, secagg.ErrTooManySessions):
+		statusCode, code = http.StatusServiceUnavailable, models.CodeTooManySessions
+	default:
+		return http.StatusBadRequest, models.CodeValidation, err
+	}
+	return statusCode, code, models.NewAPIError(code, nil)
+}
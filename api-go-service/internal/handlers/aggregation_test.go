@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/secagg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAggregationHandler tests the secure aggregation endpoints
+func TestAggregationHandler(t *testing.T) {
+	log := setupTestLogger()
+	cfg := config.AggregationConfig{MaxParticipants: 10, MaxSessions: 10, MaxShares: 10, DefaultTimeout: time.Minute, MaxTimeout: time.Hour}
+	handler := NewAggregationHandler(log, cfg, 256, secagg.NewManager(log, time.Minute, 10, 10))
+	router := setupTestRouter()
+	router.Use(middleware.ConsumerMiddleware())
+
+	router.POST("/api/v1/aggregation/sessions", handler.HandleCreateSession)
+	router.GET("/api/v1/aggregation/sessions/:id", handler.HandleGetSession)
+	router.POST("/api/v1/aggregation/sessions/:id/shares", handler.HandleSubmitShares)
+
+	request := func(method, path, consumer, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Consumer-Username", consumer)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("POST", "/api/v1/aggregation/sessions", "coordinator",
+		`{"participants": 2, "participant_ids": ["alice", "bob"], "modulus": "1000003"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var session models.AggregationSessionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+	assert.Equal(t, "collecting", session.State)
+	assert.Equal(t, "abort", session.DropoutPolicy)
+	sharesPath := "/api/v1/aggregation/sessions/" + session.SessionID + "/shares"
+
+	t.Run("Negative and oversized shares are refused", func(t *testing.T) {
+		w := request("POST", sharesPath, "alice", `{"shares": ["1", "-5"]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertErrorPointer(t, w, models.CodeInvalidShare, "/shares/1")
+
+		w = request("POST", sharesPath, "alice", `{"shares": ["`+new(big.Int).Lsh(big.NewInt(1), 256).String()+`"]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertErrorPointer(t, w, models.CodeInvalidShare, "/shares/0")
+
+		// 2^20 is within the configured bound but wider than the session modulus
+		w = request("POST", sharesPath, "alice", `{"shares": ["1048576"]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeInvalidShare, response.Code)
+	})
+
+	t.Run("Aggregate is withheld until every share arrives", func(t *testing.T) {
+		w := request("POST", sharesPath, "alice", `{"shares": ["999999", "12"]}`)
+		require.Equal(t, http.StatusAccepted, w.Code)
+
+		var response models.AggregationSessionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Empty(t, response.Aggregate)
+		assert.Equal(t, 1, response.Received)
+	})
+
+	t.Run("Participants outside the session are rejected", func(t *testing.T) {
+		w := request("POST", sharesPath, "mallory", `{"shares": ["1"]}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeUnknownParticipant, response.Code)
+	})
+
+	t.Run("Last share reveals the aggregate", func(t *testing.T) {
+		w := request("POST", sharesPath, "bob", `{"shares": ["1000000"]}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.AggregationSessionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "complete", response.State)
+		assert.Equal(t, "5", response.Aggregate) // (999999 + 12 + 1000000) mod 1000003
+		assert.NotNil(t, response.ClosedAt)
+	})
+
+	t.Run("Sessions are only visible to their owner", func(t *testing.T) {
+		w := request("GET", "/api/v1/aggregation/sessions/"+session.SessionID, "coordinator", "")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = request("GET", "/api/v1/aggregation/sessions/"+session.SessionID, "alice", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeSessionNotFound, response.Code)
+		assert.Equal(t, `session "`+session.SessionID+`" not found`, response.Error)
+	})
+
+	t.Run("Duplicate submissions conflict", func(t *testing.T) {
+		w := request("POST", sharesPath, "bob", `{"shares": ["1"]}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Anonymous callers are refused", func(t *testing.T) {
+		w := request("POST", "/api/v1/aggregation/sessions", "", `{"participants": 2, "modulus": "97"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeConsumerRequired, response.Code)
+
+		w = request("POST", sharesPath, "", `{"shares": ["1"]}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Invalid session parameters", func(t *testing.T) {
+		w := request("POST", "/api/v1/aggregation/sessions", "coordinator",
+			`{"participants": 3, "modulus": "97", "dropout_policy": "partial", "min_participants": 5}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertErrorPointer(t, w, models.CodeValidation, "/min_participants")
+
+		w = request("POST", "/api/v1/aggregation/sessions", "coordinator", `{"participants": 3, "modulus": "0"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertErrorPointer(t, w, models.CodeInvalidModulus, "/modulus")
+
+		w = request("GET", "/api/v1/aggregation/sessions/agg_missing", "coordinator", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// ErrorCatalogHandler serves the descriptions of the error codes
+// Problem type URIs resolve to these routes. It also answers requests that match no route
+type ErrorCatalogHandler struct {
+	logger *logger.Logger
+	routes func() gin.RoutesInfo
+}
+
+// NewErrorCatalogHandler creates a new error catalog handler
+// routes lists the registered routes, from which the methods allowed on a path are found
+func NewErrorCatalogHandler(logger *logger.Logger, routes func() gin.RoutesInfo) *ErrorCatalogHandler {
+	return &ErrorCatalogHandler{logger: logger, routes: routes}
+}
+
+// HandleList handles GET /errors requests
+func (h *ErrorCatalogHandler) HandleList(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	response := models.NewErrorCatalogResponse(reqID)
+	locale := middleware.GetLocale(c)
+	for i, errorType := range response.Errors {
+		response.Errors[i] = errorType.Localize(locale)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// HandleGet handles GET /errors/:code requests
+func (h *ErrorCatalogHandler) HandleGet(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	code := c.Param("code")
+	errorType, ok := models.LookupErrorType(code)
+	if !ok {
+		h.logger.WithFields(map[string]interface{}{
+			"component":  "error_catalog_handler",
+			"operation":  "get_error_type",
+			"request_id": reqID,
+			"code":       code,
+		}).Warn("Unknown error code requested")
+
+		middleware.Fail(c, models.NewAPIError(models.CodeErrorTypeNotFound, nil).WithParam("code", code))
+		return
+	}
+
+	c.JSON(http.StatusOK, errorType.Localize(middleware.GetLocale(c)))
+}
+
+// HandleNoRoute handles requests whose path matches no route
+func (h *ErrorCatalogHandler) HandleNoRoute(c *gin.Context) {
+	middleware.Fail(c, models.NewAPIError(models.CodeNotFound, nil).
+		WithParam("method", c.Request.Method).
+		WithParam("path", c.Request.URL.Path))
+}
+
+// HandleNoMethod handles requests whose path matches a route of another method
+// The methods allowed on the path are listed in the Allow header and the details
+func (h *ErrorCatalogHandler) HandleNoMethod(c *gin.Context) {
+	allowed := strings.Join(h.allowedMethods(c.Request.URL.Path), ", ")
+	c.Header("Allow", allowed)
+
+	middleware.Fail(c, models.NewAPIError(models.CodeMethodNotAllowed, nil).
+		WithParam("method", c.Request.Method).
+		WithParam("path", c.Request.URL.Path).
+		WithDetail("allowed_methods", allowed))
+}
+
+// allowedMethods returns the sorted methods of the routes matching path
+func (h *ErrorCatalogHandler) allowedMethods(path string) []string {
+	seen := make(map[string]bool)
+	var methods []string
+	for _, route := range h.routes() {
+		if !seen[route.Method] && matchRoute(route.Path, path) {
+			seen[route.Method] = true
+			methods = append(methods, route.Method)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// matchRoute reports whether path matches a route pattern with :param and *catchall segments
+func matchRoute(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "*") {
+			return true
+		}
+		if i >= len(pathParts) {
+			return false
+		}
+		if strings.HasPrefix(part, ":") {
+			if pathParts[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return len(patternParts) == len(pathParts)
+}
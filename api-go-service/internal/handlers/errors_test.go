@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationErrors(t *testing.T) {
+	log := setupTestLogger()
+	router := setupTestRouter()
+	router.POST("/api/v1/sum", setupTestSumHandler(log).HandleSum)
+	router.POST("/api/v1/linalg/matrix/transpose", NewLinalgHandler(log, 100).HandleMatrixTranspose)
+
+	post := func(path, body, accept string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		body    string
+		code    string
+		pointer string
+		rule    string
+	}{
+		{"Non-numeric element", "/api/v1/sum", `{"numbers": [1, 2, 3, "four"]}`, "INVALID_REQUEST_BODY", "/numbers/3", models.RuleType},
+		{"Missing field", "/api/v1/sum", `{}`, "INVALID_REQUEST_BODY", "/numbers", "required"},
+		{"Too few numbers", "/api/v1/sum", `{"numbers": [1]}`, "VALIDATION_ERROR", "/numbers", models.RuleMinItems},
+		{"Invalid privacy parameter", "/api/v1/sum", `{"numbers": [1, 2], "privacy": {"mechanism": "laplace", "epsilon": -1, "lower": 0, "upper": 1}}`, models.CodeInvalidPrivacyParams, "/privacy/epsilon", "gt"},
+		{"Ragged matrix", "/api/v1/linalg/matrix/transpose", `{"matrix": [[1, 2], [3]]}`, models.CodeInvalidShape, "/matrix", "invalid_shape"},
+		{"Non-numeric matrix element", "/api/v1/linalg/matrix/transpose", `{"matrix": [[1, 2], [3, null, "x"]]}`, "INVALID_REQUEST_BODY", "/matrix/1/2", models.RuleType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(tt.path, tt.body, "")
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.code, response.Code)
+			require.Len(t, response.Errors, 1)
+			assert.Equal(t, tt.pointer, response.Errors[0].Pointer)
+			assert.Equal(t, tt.rule, response.Errors[0].Rule)
+			assert.Equal(t, response.Errors[0].Message, response.Details[tt.pointer])
+		})
+	}
+
+	t.Run("Problem details", func(t *testing.T) {
+		w := post("/api/v1/sum", `{"numbers": [1, "two"]}`, "application/problem+json")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+		var problem models.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "/errors/INVALID_REQUEST_BODY", problem.Type)
+		assert.Equal(t, "Invalid request body", problem.Title)
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, "expected a number, got string", problem.Detail)
+		assert.Equal(t, "/api/v1/sum", problem.Instance)
+		assert.Equal(t, "INVALID_REQUEST_BODY", problem.Code)
+		assert.Equal(t, "test-request-id", problem.RequestID)
+		assert.Equal(t, []models.FieldError{{Pointer: "/numbers/1", Rule: models.RuleType, Message: "expected a number, got string"}}, problem.Errors)
+	})
+
+	t.Run("Successful responses are JSON", func(t *testing.T) {
+		w := post("/api/v1/sum", `{"numbers": [1, 2]}`, "application/problem+json")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	})
+}
+
+func TestErrorCatalogHandler(t *testing.T) {
+	router := setupTestRouter()
+	handler := NewErrorCatalogHandler(setupTestLogger(), router.Routes)
+	router.GET("/errors", handler.HandleList)
+	router.GET("/errors/:code", handler.HandleGet)
+
+	t.Run("Problem types resolve", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", models.ErrorTypeURI("VALIDATION_ERROR"), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var errorType models.ErrorType
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorType))
+		assert.Equal(t, "VALIDATION_ERROR", errorType.Code)
+		assert.Equal(t, http.StatusBadRequest, errorType.Status)
+		assert.NotEmpty(t, errorType.Description)
+		assert.NotEmpty(t, errorType.Message)
+		assert.False(t, errorType.Retryable)
+		assert.Equal(t, "/errors/VALIDATION_ERROR", errorType.Docs)
+	})
+
+	t.Run("Catalog lists every code", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/errors", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.ErrorCatalogResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		codes := make([]string, 0, len(response.Errors))
+		for _, errorType := range response.Errors {
+			codes = append(codes, errorType.Code)
+		}
+		assert.Contains(t, codes, models.CodeNotAcceptable)
+		assert.Contains(t, codes, models.CodeInvalidShape)
+	})
+
+	t.Run("Unknown codes", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/errors/NO_SUCH_CODE", nil)
+		req.Header.Set("Accept", "application/problem+json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		var problem models.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, models.CodeErrorTypeNotFound, problem.Code)
+		assert.Equal(t, models.ErrorTypeURI(models.CodeErrorTypeNotFound), problem.Type)
+		assert.Equal(t, `error code "NO_SUCH_CODE" is not in the catalog`, problem.Detail)
+	})
+}
+
+func TestNoRouteAndNoMethod(t *testing.T) {
+	router := setupTestRouter()
+	handler := NewErrorCatalogHandler(setupTestLogger(), router.Routes)
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(handler.HandleNoRoute)
+	router.NoMethod(handler.HandleNoMethod)
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/v1/jobs/:id", ok)
+	router.DELETE("/api/v1/jobs/:id", ok)
+	router.POST("/api/v1/jobs", ok)
+
+	serve := func(method, path string) (*httptest.ResponseRecorder, models.ErrorResponse) {
+		req, _ := http.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("Unknown paths", func(t *testing.T) {
+		w, response := serve("GET", "/api/v1/nothing")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, models.CodeNotFound, response.Code)
+		assert.Equal(t, "no route matches GET /api/v1/nothing", response.Error)
+		assert.Equal(t, "test-request-id", response.RequestID)
+	})
+
+	t.Run("Unsupported methods list the allowed ones", func(t *testing.T) {
+		w, response := serve("PUT", "/api/v1/jobs/job_1")
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, "DELETE, GET", w.Header().Get("Allow"))
+		assert.Equal(t, models.CodeMethodNotAllowed, response.Code)
+		assert.Equal(t, "DELETE, GET", response.Details["allowed_methods"])
+		assert.Equal(t, "/errors/METHOD_NOT_ALLOWED", response.Docs)
+	})
+}
+
+func TestLocalizedErrors(t *testing.T) {
+	router := setupTestRouter()
+	router.Use(middleware.LocaleMiddleware())
+	router.POST("/api/v1/sum", setupTestSumHandler(setupTestLogger()).HandleSum)
+
+	post := func(body, acceptLanguage, accept string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/api/v1/sum", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", acceptLanguage)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("French", func(t *testing.T) {
+		w := post(`{"numbers": [1, "two"]}`, "fr-CA, en;q=0.5", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "fr", w.Header().Get("Content-Language"))
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeInvalidRequestBody, response.Code)
+		assert.Equal(t, "type attendu : nombre, reçu : chaîne", response.Error)
+		assert.Equal(t, "type attendu : nombre, reçu : chaîne", response.Errors[0].Message)
+	})
+
+	t.Run("Problem titles", func(t *testing.T) {
+		w := post(`{"numbers": [1]}`, "fr", "application/problem+json")
+		var problem models.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, models.CodeValidation, problem.Code)
+		assert.Equal(t, "Échec de la validation", problem.Title)
+		assert.Equal(t, "au moins 2 nombres sont requis, 1 reçu(s)", problem.Detail)
+	})
+
+	t.Run("Unsupported languages fall back to English", func(t *testing.T) {
+		w := post(`{"numbers": [1]}`, "de-DE", "")
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "at least 2 numbers are required, got 1", response.Error)
+	})
+}
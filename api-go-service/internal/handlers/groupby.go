@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/groupby"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// GroupByHandler handles grouped aggregation over keyed records
+type GroupByHandler struct {
+	logger *logger.Logger
+	config config.GroupByConfig
+}
+
+// NewGroupByHandler creates a new grouped aggregation handler
+func NewGroupByHandler(logger *logger.Logger, cfg config.GroupByConfig) *GroupByHandler {
+	return &GroupByHandler{
+		logger: logger,
+		config: cfg,
+	}
+}
+
+// HandleAggregate handles POST /api/v1/aggregate requests
+// The body is a JSON array of records or an NDJSON stream, read one record at a time
+// so that memory grows with the number of groups rather than of records
+func (h *GroupByHandler) HandleAggregate(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	var params models.GroupByParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		h.reject(c, reqID, "bind_query", http.StatusBadRequest, err, models.CodeValidation)
+		return
+	}
+	fields, order, err := params.Validate(h.config.MaxFields)
+	if err != nil {
+		h.reject(c, reqID, "validate_query", http.StatusBadRequest, err, models.CodeValidation)
+		return
+	}
+
+	contentType := c.ContentType()
+	ndjson := models.IsNDJSON(contentType)
+	if !ndjson && contentType != "" && contentType != "application/json" {
+		err := fmt.Errorf("content type %q is not supported; send a JSON array or NDJSON", contentType)
+		h.reject(c, reqID, "decode_records", http.StatusUnsupportedMediaType, err, models.CodeUnsupportedMediaType)
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, h.config.MaxBodyBytes)
+	decoder := groupby.NewDecoder(body, ndjson)
+	aggregator := groupby.New(fields, h.config.MaxGroups)
+
+	for {
+		index := decoder.Index()
+		record, err := decoder.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			statusCode, code, reported := recordErrorStatus(err)
+			h.reject(c, reqID, "decode_records", statusCode, reported, code)
+			return
+		}
+
+		if index >= h.config.MaxRecords {
+			err := &groupby.RecordError{Index: index, Err: &groupby.LimitError{Err: groupby.ErrRecordLimit, Max: h.config.MaxRecords}}
+			h.reject(c, reqID, "decode_records", http.StatusRequestEntityTooLarge, err, models.CodeRecordLimitExceeded)
+			return
+		}
+
+		key, values, err := groupby.Extract(record, params.GroupBy, fields)
+		if err != nil {
+			h.reject(c, reqID, "validate_record", http.StatusBadRequest, &groupby.RecordError{Index: index, Err: err}, models.CodeInvalidRecord)
+			return
+		}
+		if err := aggregator.Add(key, values); err != nil {
+			statusCode, code, _ := recordErrorStatus(err)
+			h.reject(c, reqID, "aggregate", statusCode, &groupby.RecordError{Index: index, Err: err}, code)
+			return
+		}
+	}
+
+	groups, err := aggregator.Groups(order, params.Top)
+	if err != nil {
+		h.reject(c, reqID, "order_groups", http.StatusBadRequest, err, models.CodeValidation)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewAggregateResponse(params.GroupBy, fields, groups, aggregator.Len(), decoder.Index(), reqID))
+}
+
+// reject logs a failed aggregation request and writes the error response
+// Errors about a record name its index in the details
+func (h *GroupByHandler) reject(c *gin.Context, reqID, operation string, statusCode int, err error, code string) {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "groupby_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"consumer":   middleware.GetConsumer(c),
+		"code":       code,
+	}).Error("Aggregate request failed")
+
+	var recordErr *groupby.RecordError
+	if errors.As(err, &recordErr) {
+		err = models.NewValidationError(code, models.RecordViolation(recordErr))
+	}
+	apiErr := models.NewAPIError(code, err).WithStatus(statusCode)
+	if recordErr != nil {
+		apiErr.WithDetail("record_index", strconv.Itoa(recordErr.Index))
+	}
+	middleware.Fail(c, apiErr)
+}
+
+// recordErrorStatus maps errors met while reading records to HTTP status codes, error codes and reported errors
+func recordErrorStatus(err error) (int, string, error) {
+	var maxBytesErr *http.MaxBytesError
+	var recordErr *groupby.RecordError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return http.StatusRequestEntityTooLarge, models.CodeRequestTooLarge, models.NewAPIError(models.CodeRequestTooLarge, nil)
+	case errors.Is(err, groupby.ErrGroupLimit):
+		return http.StatusUnprocessableEntity, models.CodeGroupLimitExceeded, err
+	case errors.Is(err, groupby.ErrSumOverflow):
+		return http.StatusUnprocessableEntity, models.CodeResultOverflow, err
+	case errors.As(err, &recordErr):
+		return http.StatusBadRequest, models.CodeInvalidRecord, err
+	default:
+		return http.StatusBadRequest, models.CodeInvalidRequestBody, err
+	}
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestGroupByRouter() *gin.Engine {
+	handler := NewGroupByHandler(setupTestLogger(), config.GroupByConfig{
+		MaxGroups:    3,
+		MaxRecords:   5,
+		MaxFields:    2,
+		MaxBodyBytes: 1024,
+	})
+
+	router := setupTestRouter()
+	router.POST("/api/v1/aggregate", handler.HandleAggregate)
+	return router
+}
+
+func aggregateRequest(router *gin.Engine, query, contentType, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", "/api/v1/aggregate"+query, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestGroupByHandler_Aggregate tests grouped aggregation of JSON arrays and NDJSON streams
+func TestGroupByHandler_Aggregate(t *testing.T) {
+	router := setupTestGroupByRouter()
+	records := []string{
+		`{"key": "eu-west", "value": 3.2, "latency": 10}`,
+		`{"key": "us-east", "value": 1, "latency": 40}`,
+		`{"key": "eu-west", "value": 0.8, "latency": 30}`,
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"JSON array", "application/json", "[" + strings.Join(records, ",") + "]"},
+		{"NDJSON stream", "application/x-ndjson", strings.Join(records, "\n") + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := aggregateRequest(router, "", tt.contentType, tt.body)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var response models.AggregateResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "key", response.GroupBy)
+			assert.Equal(t, []string{"value"}, response.Fields)
+			assert.Equal(t, 3, response.Records)
+			assert.Equal(t, 2, response.TotalGroups)
+			require.Len(t, response.Groups, 2)
+			assert.Equal(t, "eu-west", response.Groups[0].Key)
+			assert.Equal(t, 2, response.Groups[0].Count)
+			assert.Equal(t, models.FieldStats{Sum: 4, Min: 0.8, Max: 3.2}, response.Groups[0].Fields["value"])
+		})
+	}
+
+	t.Run("Several fields, ordering and top", func(t *testing.T) {
+		body := "[" + strings.Join(records, ",") + "]"
+		w := aggregateRequest(router, "?fields=value,latency&order_by=max:latency&top=1", "application/json", body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response models.AggregateResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 2, response.TotalGroups)
+		require.Len(t, response.Groups, 1)
+		assert.Equal(t, "us-east", response.Groups[0].Key)
+		assert.Equal(t, 40.0, response.Groups[0].Fields["latency"].Max)
+	})
+}
+
+// TestGroupByHandler_Errors tests that refused requests name the offending record
+func TestGroupByHandler_Errors(t *testing.T) {
+	router := setupTestGroupByRouter()
+
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		status      int
+		code        string
+		record      string
+	}{
+		{"Non-numeric field", "", "application/json", `[{"key": "a", "value": 1}, {"key": "a", "value": "x"}]`, http.StatusBadRequest, models.CodeInvalidRecord, "1"},
+		{"Missing key", "?group_by=region", "application/json", `[{"key": "a", "value": 1}]`, http.StatusBadRequest, models.CodeInvalidRecord, "0"},
+		{"Malformed NDJSON line", "", "application/x-ndjson", "{\"key\": \"a\", \"value\": 1}\n{\"key\": \n", http.StatusBadRequest, models.CodeInvalidRecord, "1"},
+		{"Sum overflow", "", "application/json", `[{"key": "a", "value": 1e308}, {"key": "a", "value": 1e308}]`, http.StatusUnprocessableEntity, models.CodeResultOverflow, "1"},
+		{"Group limit", "", "application/json", `[{"key": "a", "value": 1}, {"key": "b", "value": 1}, {"key": "c", "value": 1}, {"key": "d", "value": 1}]`, http.StatusUnprocessableEntity, models.CodeGroupLimitExceeded, "3"},
+		{"Record limit", "", "application/json", "[" + strings.Repeat(`{"key": "a", "value": 1},`, 5) + `{"key": "a", "value": 1}]`, http.StatusRequestEntityTooLarge, models.CodeRecordLimitExceeded, "5"},
+		{"Body too large", "", "application/json", "[" + strings.Repeat(" ", 2048) + "]", http.StatusRequestEntityTooLarge, models.CodeRequestTooLarge, ""},
+		{"Not an array", "", "application/json", `{"key": "a", "value": 1}`, http.StatusBadRequest, "INVALID_REQUEST_BODY", ""},
+		{"Unsupported content type", "", "text/csv", "key,value\na,1\n", http.StatusUnsupportedMediaType, models.CodeUnsupportedMediaType, ""},
+		{"Too many fields", "?fields=a,b,c", "application/json", `[]`, http.StatusBadRequest, "VALIDATION_ERROR", ""},
+		{"Unknown ordering", "?order_by=median", "application/json", `[]`, http.StatusBadRequest, "VALIDATION_ERROR", ""},
+		{"Ordering on a field not aggregated", "?order_by=sum:latency", "application/json", `[]`, http.StatusBadRequest, "VALIDATION_ERROR", ""},
+		{"Invalid top", "?top=-1", "application/json", `[]`, http.StatusBadRequest, "VALIDATION_ERROR", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := aggregateRequest(router, tt.query, tt.contentType, tt.body)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.code, response.Code)
+			assert.Equal(t, tt.record, response.Details["record_index"])
+			if tt.record != "" {
+				assert.Contains(t, response.Error, "records["+tt.record+"]")
+			}
+		})
+	}
+}
+
+// TestGroupByHandler_FieldErrors tests that record errors point at the field and are localized
+func TestGroupByHandler_FieldErrors(t *testing.T) {
+	router := setupTestRouter()
+	router.Use(middleware.LocaleMiddleware())
+	router.POST("/api/v1/aggregate", NewGroupByHandler(setupTestLogger(), config.GroupByConfig{MaxGroups: 3, MaxRecords: 5, MaxFields: 2, MaxBodyBytes: 1024}).HandleAggregate)
+
+	request := func(query, body, language string) models.ErrorResponse {
+		req, _ := http.NewRequest("POST", "/api/v1/aggregate"+query, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", language)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	response := request("?fields=v", `[{"key": "a", "v": "x"}]`, "en")
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "/0/v", response.Errors[0].Pointer)
+	assert.Equal(t, `records[0]: field "v" must be a number`, response.Errors[0].Message)
+
+	response = request("?fields=v", `[{"key": "a", "v": "x"}]`, "fr")
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, `records[0] : le champ "v" doit être un nombre`, response.Errors[0].Message)
+	assert.Equal(t, response.Errors[0].Message, response.Error)
+
+	response = request("?group_by=region", `[{"key": "a", "value": 1}]`, "fr")
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "/0/region", response.Errors[0].Pointer)
+	assert.Equal(t, `records[0] : la clé de groupe "region" est absente`, response.Errors[0].Message)
+
+	response = request("", `[{"key": "a", "value": 1e308}, {"key": "a", "value": 1e308}]`, "en")
+	assert.Equal(t, models.CodeResultOverflow, response.Code)
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "/1/value", response.Errors[0].Pointer)
+
+	response = request("", "["+strings.Repeat(`{"key": "a", "value": 1},`, 5)+`{"key": "a", "value": 1}]`, "fr")
+	assert.Equal(t, models.CodeRecordLimitExceeded, response.Code)
+	assert.Equal(t, "records[5] : 5 enregistrements au maximum", response.Error)
+
+	response = request("", `[{"key": "a", "value": 1}, {"key": "b", "value": 1}, {"key": "c", "value": 1}, {"key": "d", "value": 1}]`, "fr")
+	assert.Equal(t, models.CodeGroupLimitExceeded, response.Code)
+	assert.Equal(t, "records[3] : 3 groupes au maximum", response.Error)
+}
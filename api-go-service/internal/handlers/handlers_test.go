@@ -11,6 +11,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/katvio/api-go-service/internal/middleware"
 	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/privacy"
+	"github.com/katvio/api-go-service/internal/storage"
 	"github.com/katvio/api-go-service/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,6 +36,20 @@ func setupTestLogger() *logger.Logger {
 	return logger.New("error", "json") // Use error level to reduce test noise
 }
 
+// assertErrorPointer checks the code of an error response and the pointer of its only field error
+func assertErrorPointer(t *testing.T, w *httptest.ResponseRecorder, code, pointer string) {
+	t.Helper()
+	var response models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, code, response.Code)
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, pointer, response.Errors[0].Pointer)
+}
+
+func setupTestSumHandler(log *logger.Logger) *SumHandler {
+	return NewSumHandler(log, privacy.NewAccountant(1))
+}
+
 // TestHealthHandler tests the health check endpoints
 func TestHealthHandler(t *testing.T) {
 	log := setupTestLogger()
@@ -96,6 +112,26 @@ func TestHealthHandler(t *testing.T) {
 	}
 }
 
+func TestHealthHandler_ReadinessChecks(t *testing.T) {
+	db := storage.NewMemoryStore()
+	handler := NewHealthHandler(setupTestLogger(), "1.0.0-test")
+	handler.AddReadinessCheck("storage", db.Ping)
+
+	router := setupTestRouter()
+	router.GET("/healthz/ready", handler.HandleReadiness)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/healthz/ready", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"storage":"ok"`)
+
+	require.NoError(t, db.Close())
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/healthz/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"storage":"unavailable"`)
+}
+
 func TestHealthHandler_StartTime(t *testing.T) {
 	log := setupTestLogger()
 	handler := NewHealthHandler(log, "1.0.0-test")
@@ -107,11 +143,10 @@ func TestHealthHandler_StartTime(t *testing.T) {
 // TestSumHandler tests the sum calculation endpoints
 func TestSumHandler(t *testing.T) {
 	log := setupTestLogger()
-	handler := NewSumHandler(log)
+	handler := setupTestSumHandler(log)
 	router := setupTestRouter()
 
 	router.POST("/api/v1/sum", handler.HandleSum)
-	router.GET("/api/v1/sum", handler.HandleSumGet)
 
 	t.Run("POST /api/v1/sum - Valid request", func(t *testing.T) {
 		request := models.SumRequest{
@@ -201,31 +236,12 @@ func TestSumHandler(t *testing.T) {
 		assert.Equal(t, "VALIDATION_ERROR", response.Code)
 		assert.Contains(t, response.Error, "maximum 100 numbers allowed")
 	})
-
-	t.Run("GET /api/v1/sum - Returns endpoint info", func(t *testing.T) {
-		req, _ := http.NewRequest("GET", "/api/v1/sum", nil)
-
-		w := httptest.NewRecorder()
-		router.ServeHTTP(w, req)
-
-		assert.Equal(t, http.StatusOK, w.Code)
-
-		var response map[string]interface{}
-		err := json.Unmarshal(w.Body.Bytes(), &response)
-		require.NoError(t, err)
-
-		assert.Equal(t, "/api/v1/sum", response["endpoint"])
-		assert.Equal(t, "POST", response["method"])
-		assert.Contains(t, response, "description")
-		assert.Contains(t, response, "example_request")
-		assert.Contains(t, response, "example_response")
-	})
 }
 
 // TestSumHandler_EdgeCases tests edge cases for sum calculation
 func TestSumHandler_EdgeCases(t *testing.T) {
 	log := setupTestLogger()
-	handler := NewSumHandler(log)
+	handler := setupTestSumHandler(log)
 	router := setupTestRouter()
 	router.POST("/api/v1/sum", handler.HandleSum)
 
@@ -300,7 +316,7 @@ func TestSumHandler_EdgeCases(t *testing.T) {
 // BenchmarkSumHandler benchmarks the sum calculation endpoint
 func BenchmarkSumHandler(b *testing.B) {
 	log := setupTestLogger()
-	handler := NewSumHandler(log)
+	handler := setupTestSumHandler(log)
 	router := setupTestRouter()
 	router.POST("/api/v1/sum", handler.HandleSum)
 
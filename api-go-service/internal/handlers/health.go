@@ -17,6 +17,13 @@ type HealthHandler struct {
 	logger    *logger.Logger
 	startTime time.Time
 	version   string
+	readiness []readinessCheck
+}
+
+// readinessCheck is a dependency checked by the readiness probe
+type readinessCheck struct {
+	name  string
+	check func() error
 }
 
 // NewHealthHandler creates a new health handler
@@ -28,26 +35,18 @@ func NewHealthHandler(logger *logger.Logger, version string) *HealthHandler {
 	}
 }
 
+// AddReadinessCheck makes readiness depend on check, reported under name
+// It must be called before the handler serves requests
+func (h *HealthHandler) AddReadinessCheck(name string, check func() error) {
+	h.readiness = append(h.readiness, readinessCheck{name: name, check: check})
+}
+
 // HandleHealth handles GET /healthz requests
 func (h *HealthHandler) HandleHealth(c *gin.Context) {
 	requestID, _ := c.Get(middleware.RequestIDKey)
 	reqID, _ := requestID.(string)
 
-	// Perform health checks
-	checks := h.performHealthChecks()
-
-	// Calculate uptime
-	uptime := time.Since(h.startTime).String()
-
-	// Create response
-	response := models.NewHealthResponse(h.version, uptime, checks, reqID)
-
-	// Log health check
-	h.logger.WithFields(logger.HealthCheckFields()).WithFields(map[string]interface{}{
-		"status":     response.Status,
-		"request_id": reqID,
-		"checks":     checks,
-	}).Info("Health check performed")
+	response := h.Report(reqID)
 
 	// Determine HTTP status code
 	statusCode := http.StatusOK
@@ -84,15 +83,7 @@ func (h *HealthHandler) HandleReadiness(c *gin.Context) {
 	reqID, _ := requestID.(string)
 
 	// Readiness check - check if service is ready to serve traffic
-	checks := h.performReadinessChecks()
-
-	isReady := true
-	for _, status := range checks {
-		if status != "ok" {
-			isReady = false
-			break
-		}
-	}
+	isReady, checks := h.Ready()
 
 	status := "ready"
 	statusCode := http.StatusOK
@@ -118,6 +109,40 @@ func (h *HealthHandler) HandleReadiness(c *gin.Context) {
 	c.JSON(statusCode, response)
 }
 
+// Report performs the health checks and builds the health response
+// The JSON-RPC health method returns the same document
+func (h *HealthHandler) Report(reqID string) *models.HealthResponse {
+	// Perform health checks
+	checks := h.performHealthChecks()
+
+	// Calculate uptime
+	uptime := time.Since(h.startTime).String()
+
+	// Create response
+	response := models.NewHealthResponse(h.version, uptime, checks, reqID)
+
+	// Log health check
+	h.logger.WithFields(logger.HealthCheckFields()).WithFields(map[string]interface{}{
+		"status":     response.Status,
+		"request_id": reqID,
+		"checks":     checks,
+	}).Info("Health check performed")
+
+	return response
+}
+
+// Ready runs the readiness checks and reports whether all of them passed
+// The gRPC health service reports the same result
+func (h *HealthHandler) Ready() (bool, map[string]string) {
+	checks := h.performReadinessChecks()
+	for _, status := range checks {
+		if status != "ok" {
+			return false, checks
+		}
+	}
+	return true, checks
+}
+
 // performHealthChecks performs all health checks
 func (h *HealthHandler) performHealthChecks() map[string]string {
 	checks := make(map[string]string)
@@ -140,8 +165,14 @@ func (h *HealthHandler) performReadinessChecks() map[string]string {
 	checks["service"] = "ok"
 	checks["configuration"] = h.checkConfiguration()
 
-	// Add more readiness checks as needed
-	// (database connections, required external services, etc.)
+	// Dependencies such as storage
+	for _, r := range h.readiness {
+		checks[r.name] = "ok"
+		if err := r.check(); err != nil {
+			h.logger.LogError(err, "health_handler", "readiness_"+r.name, nil)
+			checks[r.name] = "unavailable"
+		}
+	}
 
 	return checks
 }
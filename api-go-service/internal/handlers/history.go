@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/history"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// HistoryHandler serves the recorded requests of the calling consumer
+type HistoryHandler struct {
+	logger *logger.Logger
+	config config.HistoryConfig
+	store  history.Store
+}
+
+// NewHistoryHandler creates a new history handler
+func NewHistoryHandler(logger *logger.Logger, cfg config.HistoryConfig, store history.Store) *HistoryHandler {
+	return &HistoryHandler{
+		logger: logger,
+		config: cfg,
+		store:  store,
+	}
+}
+
+// HandleList handles GET /api/v1/history requests
+// Entries are returned newest first, without their bodies
+func (h *HistoryHandler) HandleList(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	query, err := parseHistoryQuery(c, h.config.MaxPage)
+	if err != nil {
+		h.reject(c, reqID, "parse_query", http.StatusBadRequest, err, models.CodeInvalidHistoryQuery)
+		return
+	}
+
+	entries, next, err := h.store.List(c.Request.Context(), middleware.GetConsumer(c), query)
+	if err != nil {
+		statusCode, code := historyErrorStatus(err)
+		h.reject(c, reqID, "list", statusCode, err, code)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewHistoryListResponse(entries, next, reqID))
+}
+
+// HandleGet handles GET /api/v1/history/:request_id requests
+func (h *HistoryHandler) HandleGet(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	entry, err := h.store.Get(c.Request.Context(), middleware.GetConsumer(c), c.Param("request_id"))
+	if err != nil {
+		statusCode, code := historyErrorStatus(err)
+		h.reject(c, reqID, "get", statusCode, err, code)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewHistoryEntryResponse(entry, reqID))
+}
+
+// reject logs a failed history request and writes the error response
+func (h *HistoryHandler) reject(c *gin.Context, reqID, operation string, statusCode int, err error, code string) {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "history_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"consumer":   middleware.GetConsumer(c),
+		"code":       code,
+	}).Error("History request failed")
+
+	middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+}
+
+// parseHistoryQuery reads the from, to, cursor and limit query parameters
+// Times are RFC 3339; the limit defaults to and may not exceed maxPage
+func parseHistoryQuery(c *gin.Context, maxPage int) (history.Query, error) {
+	query := history.Query{Cursor: c.Query("cursor"), Limit: maxPage}
+
+	var err error
+	if from := c.Query("from"); from != "" {
+		if query.From, err = time.Parse(time.RFC3339Nano, from); err != nil {
+			return query, fmt.Errorf("from: %w", err)
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if query.To, err = time.Parse(time.RFC3339Nano, to); err != nil {
+			return query, fmt.Errorf("to: %w", err)
+		}
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return query, errors.New("from must be before to")
+	}
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > maxPage {
+			return query, fmt.Errorf("limit must be between 1 and %d", maxPage)
+		}
+		query.Limit = n
+	}
+	return query, nil
+}
+
+// historyErrorStatus maps history store errors to HTTP status codes and error codes
+func historyErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, history.ErrNotFound):
+		return http.StatusNotFound, models.CodeHistoryNotFound
+	case errors.Is(err, history.ErrInvalidCursor):
+		return http.StatusBadRequest, models.CodeInvalidHistoryQuery
+	default:
+		return http.StatusInternalServerError, models.CodeInternal
+	}
+}
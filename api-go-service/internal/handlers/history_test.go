@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/history"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var historyBase = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func setupTestHistoryRouter(t *testing.T) *gin.Engine {
+	store := history.NewMemoryStore(100)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.Put(context.Background(), &history.Entry{
+			RequestID:   fmt.Sprintf("req-%d", i),
+			Owner:       "alice",
+			Method:      "POST",
+			Path:        "/api/v1/sum",
+			Status:      http.StatusOK,
+			Request:     []byte(`{"numbers": [1, 2]}`),
+			RequestSize: 19,
+			Response:    []byte(`{"result": 3}`),
+			CreatedAt:   historyBase.Add(time.Duration(i) * time.Hour),
+			Duration:    1500 * time.Microsecond,
+		}))
+	}
+	require.NoError(t, store.Put(context.Background(), &history.Entry{
+		RequestID: "req-text",
+		Owner:     "bob",
+		Request:   []byte("a,b\n1,2\n"),
+		Response:  []byte{0xff, 0xfe},
+		CreatedAt: historyBase,
+	}))
+
+	handler := NewHistoryHandler(setupTestLogger(), config.HistoryConfig{MaxPage: 2}, store)
+
+	router := setupTestRouter()
+	router.Use(middleware.ConsumerMiddleware())
+	router.GET("/api/v1/history", handler.HandleList)
+	router.GET("/api/v1/history/:request_id", handler.HandleGet)
+	return router
+}
+
+func historyRequest(router *gin.Engine, consumer, target string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", target, nil)
+	req.Header.Set("X-Consumer-Username", consumer)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestHistoryHandler_List tests paging and filtering of the history
+func TestHistoryHandler_List(t *testing.T) {
+	router := setupTestHistoryRouter(t)
+
+	t.Run("Pages", func(t *testing.T) {
+		w := historyRequest(router, "alice", "/api/v1/history")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var page models.HistoryListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Equal(t, 2, page.Count)
+		assert.Equal(t, "req-2", page.Entries[0].RequestID)
+		assert.Equal(t, 1.5, page.Entries[0].DurationMs)
+		assert.Nil(t, page.Entries[0].Request)
+		require.NotEmpty(t, page.NextCursor)
+
+		w = historyRequest(router, "alice", "/api/v1/history?cursor="+url.QueryEscape(page.NextCursor))
+		require.Equal(t, http.StatusOK, w.Code)
+		page = models.HistoryListResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Equal(t, 1, page.Count)
+		assert.Equal(t, "req-0", page.Entries[0].RequestID)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Time range", func(t *testing.T) {
+		from := url.QueryEscape(historyBase.Add(time.Hour).Format(time.RFC3339))
+		w := historyRequest(router, "alice", "/api/v1/history?limit=1&from="+from)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var page models.HistoryListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Equal(t, "req-2", page.Entries[0].RequestID)
+		assert.NotEmpty(t, page.NextCursor)
+	})
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"Invalid from", "?from=yesterday"},
+		{"Empty range", "?from=2026-01-02T00:00:00Z&to=2026-01-01T00:00:00Z"},
+		{"Limit above page size", "?limit=3"},
+		{"Invalid cursor", "?cursor=%25%25"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := historyRequest(router, "alice", "/api/v1/history"+tt.query)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), models.CodeInvalidHistoryQuery)
+		})
+	}
+}
+
+// TestHistoryHandler_Get tests fetching a single entry with its bodies
+func TestHistoryHandler_Get(t *testing.T) {
+	router := setupTestHistoryRouter(t)
+
+	w := historyRequest(router, "alice", "/api/v1/history/req-1")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp models.HistoryEntryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.JSONEq(t, `{"numbers": [1, 2]}`, string(resp.Entry.Request))
+	assert.JSONEq(t, `{"result": 3}`, string(resp.Entry.Response))
+
+	// Text is returned as a string and binary bodies are left out
+	w = historyRequest(router, "bob", "/api/v1/history/req-text")
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = models.HistoryEntryResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, `"a,b\n1,2\n"`, string(resp.Entry.Request))
+	assert.Nil(t, resp.Entry.Response)
+
+	// Other consumers' entries are not found
+	w = historyRequest(router, "bob", "/api/v1/history/req-1")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), models.CodeHistoryNotFound)
+}
@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/jobs"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/katvio/api-go-service/pkg/paillier"
+)
+
+// jobChunkSize is the number of values processed between cancellation checks and progress updates
+const jobChunkSize = 10000
+
+// JobsHandler handles asynchronous jobs for long-running computations
+type JobsHandler struct {
+	logger   *logger.Logger
+	config   config.JobsConfig
+	webhook  config.WebhookConfig
+	jobs     *jobs.Manager
+	paillier *PaillierHandler
+}
+
+// NewJobsHandler creates a new jobs handler
+// Encrypted sum jobs use the public keys registered with the paillier handler
+func NewJobsHandler(logger *logger.Logger, cfg config.JobsConfig, webhookCfg config.WebhookConfig, manager *jobs.Manager, paillier *PaillierHandler) *JobsHandler {
+	return &JobsHandler{
+		logger:   logger,
+		config:   cfg,
+		webhook:  webhookCfg,
+		jobs:     manager,
+		paillier: paillier,
+	}
+}
+
+// HandleSubmitJob handles POST /api/v1/jobs requests
+func (h *JobsHandler) HandleSubmitJob(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	var request models.JobRequest
+	if statusCode, code, err := bindJSON(c, &request); err != nil {
+		h.reject(c, reqID, "bind_request", statusCode, err, code)
+		return
+	}
+
+	if request.CallbackURL != "" && (h.webhook.Secret == "" || len(h.webhook.AllowedHosts) == 0) {
+		err := errors.New("callbacks are not enabled on this service")
+		h.reject(c, reqID, "validate_callback", http.StatusBadRequest, err, models.CodeCallbacksDisabled)
+		return
+	}
+
+	// Anonymous callers share one signing secret, so none of them may register a callback
+	if request.CallbackURL != "" && middleware.GetConsumer(c) == middleware.AnonymousConsumer {
+		err := models.NewAPIError(models.CodeConsumerRequired, nil)
+		h.reject(c, reqID, "validate_callback", http.StatusForbidden, err, models.CodeConsumerRequired)
+		return
+	}
+
+	if err := request.ValidateCallback(h.webhook.AllowedHosts); err != nil {
+		h.reject(c, reqID, "validate_callback", http.StatusBadRequest, err, models.ErrorCode(err, models.CodeValidation))
+		return
+	}
+
+	run, statusCode, err := h.prepare(&request, middleware.GetConsumer(c))
+	if err != nil {
+		h.reject(c, reqID, "validate_request", statusCode, err, models.ErrorCode(err, models.CodeValidation))
+		return
+	}
+
+	snap, err := h.jobs.Submit(jobs.Spec{
+		Type:      request.Type,
+		Owner:     middleware.GetConsumer(c),
+		RequestID: reqID,
+		Callback:  request.CallbackURL,
+		Run:       run,
+		Ephemeral: request.Type == models.JobTypePaillierKeygen,
+	})
+	if err != nil {
+		statusCode, code := jobErrorStatus(err)
+		h.reject(c, reqID, "submit_job", statusCode, err, code)
+		return
+	}
+
+	c.Header("Location", "/api/v1/jobs/"+snap.ID)
+	c.JSON(http.StatusAccepted, models.NewJobResponse(snap, reqID))
+}
+
+// HandleGetJob handles GET /api/v1/jobs/:id requests
+func (h *JobsHandler) HandleGetJob(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	snap, err := h.owned(c)
+	if err != nil {
+		statusCode, code := jobErrorStatus(err)
+		h.reject(c, reqID, "get_job", statusCode, err, code)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewJobResponse(snap, reqID))
+}
+
+// HandleCancelJob handles DELETE /api/v1/jobs/:id requests
+func (h *JobsHandler) HandleCancelJob(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	if _, err := h.owned(c); err != nil {
+		statusCode, code := jobErrorStatus(err)
+		h.reject(c, reqID, "cancel_job", statusCode, err, code)
+		return
+	}
+
+	snap, err := h.jobs.Cancel(c.Param("id"))
+	if err != nil {
+		statusCode, code := jobErrorStatus(err)
+		h.reject(c, reqID, "cancel_job", statusCode, err, code)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewJobResponse(snap, reqID))
+}
+
+// owned returns the job named in the path if it belongs to the caller
+// Jobs of other consumers are reported as not found
+func (h *JobsHandler) owned(c *gin.Context) (*jobs.Snapshot, error) {
+	snap, err := h.jobs.Get(c.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+	if snap.Owner != middleware.GetConsumer(c) {
+		return nil, jobs.ErrJobNotFound
+	}
+	return snap, nil
+}
+
+// prepare decodes and validates the job payload and returns the work to run
+func (h *JobsHandler) prepare(request *models.JobRequest, owner string) (jobs.Func, int, error) {
+	switch request.Type {
+	case models.JobTypeSum:
+		var payload models.SumJobPayload
+		if err := json.Unmarshal(request.Payload, &payload); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid payload: %w", models.Nest(models.DecodeError(request.Payload, err), "payload"))
+		}
+		if err := payload.Validate(h.config.MaxNumbers); err != nil {
+			return nil, http.StatusBadRequest, models.Nest(err, "payload")
+		}
+		return sumJob(payload.Numbers), 0, nil
+
+	case models.JobTypePaillierSum:
+		var payload models.PaillierSumRequest
+		if err := json.Unmarshal(request.Payload, &payload); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid payload: %w", models.Nest(models.DecodeError(request.Payload, err), "payload"))
+		}
+		if err := payload.Validate(h.config.MaxCiphertexts); err != nil {
+			return nil, http.StatusBadRequest, models.Nest(err, "payload")
+		}
+		pk, ok := h.paillier.lookup(owner, payload.KeyID)
+		if !ok {
+			return nil, http.StatusNotFound, &models.CodedError{Code: models.CodeKeyNotFound, Message: fmt.Sprintf("key %q not found", payload.KeyID), Pointer: models.JSONPointer("payload", "key_id")}
+		}
+		return paillierSumJob(payload.KeyID, pk.Add, models.BigInts(payload.Ciphertexts)), 0, nil
+
+	case models.JobTypePaillierKeygen:
+		if !h.paillier.config.DevKeyGeneration {
+			return nil, http.StatusForbidden, &models.CodedError{Code: models.CodeKeygenDisabled, Message: "server-side key generation is disabled"}
+		}
+		if request.CallbackURL != "" {
+			return nil, http.StatusBadRequest, &models.CodedError{Code: models.CodeInvalidCallback, Message: "callbacks are not sent for paillier_keygen jobs", Pointer: models.JSONPointer("callback_url")}
+		}
+		payload := models.PaillierGenerateRequest{Bits: h.paillier.config.MinKeyBits}
+		if len(request.Payload) > 0 && string(request.Payload) != "null" {
+			if err := json.Unmarshal(request.Payload, &payload); err != nil {
+				return nil, http.StatusBadRequest, fmt.Errorf("invalid payload: %w", models.Nest(models.DecodeError(request.Payload, err), "payload"))
+			}
+		}
+		if err := payload.Validate(h.paillier.config.MinKeyBits, h.paillier.config.MaxKeyBits); err != nil {
+			return nil, http.StatusBadRequest, models.Nest(err, "payload")
+		}
+		return paillierKeygenJob(h.paillier, owner, payload.Bits), 0, nil
+
+	default:
+		return nil, http.StatusBadRequest, &models.CodedError{
+			Code:    models.CodeUnknownJobType,
+			Message: fmt.Sprintf("type must be %q, %q or %q, got %q", models.JobTypeSum, models.JobTypePaillierSum, models.JobTypePaillierKeygen, request.Type),
+			Pointer: models.JSONPointer("type"),
+		}
+	}
+}
+
+// sumJob adds numbers in chunks, checking for cancellation between chunks
+// A sum that overflows fails the job with RESULT_OVERFLOW
+func sumJob(numbers []float64) jobs.Func {
+	return func(ctx context.Context, progress func(float64)) (interface{}, error) {
+		sum := 0.0
+		for start := 0; start < len(numbers); start += jobChunkSize {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			end := min(start+jobChunkSize, len(numbers))
+			for _, num := range numbers[start:end] {
+				sum += num
+			}
+			if math.IsInf(sum, 0) {
+				return nil, models.NewAPIError(models.CodeResultOverflow, nil)
+			}
+			progress(float64(end) / float64(len(numbers)))
+		}
+
+		return models.SumJobResult{Sum: sum, Count: len(numbers)}, nil
+	}
+}
+
+// paillierSumJob multiplies ciphertexts in chunks, checking for cancellation between chunks
+func paillierSumJob(keyID string, add func(...*big.Int) (*big.Int, error), ciphertexts []*big.Int) jobs.Func {
+	return func(ctx context.Context, progress func(float64)) (interface{}, error) {
+		var sum *big.Int
+		for start := 0; start < len(ciphertexts); start += jobChunkSize {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			end := min(start+jobChunkSize, len(ciphertexts))
+			chunk := ciphertexts[start:end]
+			if sum != nil {
+				chunk = append([]*big.Int{sum}, chunk...)
+			}
+
+			var err error
+			if sum, err = add(chunk...); err != nil {
+				return nil, fmt.Errorf("ciphertexts %d-%d: %w", start, end-1, err)
+			}
+			progress(float64(end) / float64(len(ciphertexts)))
+		}
+
+		return models.PaillierSumJobResult{KeyID: keyID, Ciphertext: sum.String(), Count: len(ciphertexts)}, nil
+	}
+}
+
+// paillierKeygenJob generates a key pair and registers its public key for the job owner
+// The private key only leaves the job through its result, which is never archived
+func paillierKeygenJob(registry *PaillierHandler, owner string, bits int) jobs.Func {
+	return func(ctx context.Context, progress func(float64)) (interface{}, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		sk, err := paillier.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return nil, err
+		}
+		keyID, _, err := registry.register(owner, &sk.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		progress(1)
+
+		return models.PaillierKeygenJobResult{
+			KeyID:      keyID,
+			N:          sk.N.String(),
+			Bits:       sk.N.BitLen(),
+			PrivateKey: &models.PaillierPrivateKey{Lambda: sk.Lambda.String(), Mu: sk.Mu.String()},
+		}, nil
+	}
+}
+
+// reject logs a failed request and writes the error response
+func (h *JobsHandler) reject(c *gin.Context, reqID, operation string, statusCode int, err error, code string) {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "jobs_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"consumer":   middleware.GetConsumer(c),
+		"code":       code,
+	}).Error("Job request failed")
+
+	middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+}
+
+// jobErrorStatus maps job manager errors to HTTP status codes and error codes
+func jobErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, jobs.ErrJobNotFound):
+		return http.StatusNotFound, models.CodeJobNotFound
+	case errors.Is(err, jobs.ErrJobFinished):
+		return http.StatusConflict, models.CodeJobFinished
+	case errors.Is(err, jobs.ErrConsumerLimit):
+		return http.StatusTooManyRequests, models.CodeJobLimitReached
+	case errors.Is(err, jobs.ErrQueueFull):
+		return http.StatusServiceUnavailable, models.CodeJobQueueFull
+	case errors.Is(err, jobs.ErrShuttingDown):
+		return http.StatusServiceUnavailable, models.CodeShuttingDown
+	default:
+		return http.StatusInternalServerError, models.CodeInternal
+	}
+}
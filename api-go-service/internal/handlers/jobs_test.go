@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/jobs"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/paillier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJobsHandler tests the asynchronous job endpoints
+func TestJobsHandler(t *testing.T) {
+	log := setupTestLogger()
+	cfg := config.JobsConfig{Workers: 2, QueueSize: 10, MaxPerConsumer: 5, Retention: time.Minute, MaxNumbers: 50000, MaxCiphertexts: 10}
+	manager := jobs.NewManager(log, jobs.Options{Workers: cfg.Workers, QueueSize: cfg.QueueSize, MaxPerConsumer: cfg.MaxPerConsumer, Retention: cfg.Retention})
+	manager.Start(time.Hour)
+	defer manager.Stop(context.Background())
+
+	paillierHandler := NewPaillierHandler(log, config.PaillierConfig{MinKeyBits: 512, MaxKeyBits: 1024, MaxCiphertexts: 10, MaxKeys: 10, DevKeyGeneration: true})
+	handler := NewJobsHandler(log, cfg, config.WebhookConfig{}, manager, paillierHandler)
+	router := setupTestRouter()
+	router.Use(middleware.ConsumerMiddleware())
+
+	router.POST("/api/v1/paillier/keys", paillierHandler.HandleRegisterKey)
+	router.POST("/api/v1/jobs", handler.HandleSubmitJob)
+	router.GET("/api/v1/jobs/:id", handler.HandleGetJob)
+	router.DELETE("/api/v1/jobs/:id", handler.HandleCancelJob)
+
+	request := func(method, path, consumer string, body interface{}) *httptest.ResponseRecorder {
+		jsonData, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Consumer-Username", consumer)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// await polls the job until it finishes
+	await := func(t *testing.T, id string) models.JobResponse {
+		var response models.JobResponse
+		require.Eventually(t, func() bool {
+			w := request("GET", "/api/v1/jobs/"+id, "analyst", nil)
+			require.Equal(t, http.StatusOK, w.Code)
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			return response.FinishedAt != nil
+		}, 2*time.Second, 5*time.Millisecond)
+		return response
+	}
+
+	t.Run("Sum job runs asynchronously", func(t *testing.T) {
+		numbers := make([]float64, 25000)
+		for i := range numbers {
+			numbers[i] = 2
+		}
+
+		w := request("POST", "/api/v1/jobs", "analyst", map[string]interface{}{"type": "sum", "payload": map[string]interface{}{"numbers": numbers}})
+		require.Equal(t, http.StatusAccepted, w.Code)
+
+		var submitted models.JobResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &submitted))
+		assert.Equal(t, "/api/v1/jobs/"+submitted.JobID, w.Header().Get("Location"))
+
+		response := await(t, submitted.JobID)
+		assert.Equal(t, "succeeded", response.State)
+		assert.Equal(t, 1.0, response.Progress)
+		result := response.Result.(map[string]interface{})
+		assert.Equal(t, 50000.0, result["sum"])
+		assert.NotNil(t, response.ExpiresAt)
+
+		// Other consumers cannot see the job
+		w = request("GET", "/api/v1/jobs/"+submitted.JobID, "intruder", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		// Finished jobs cannot be cancelled
+		w = request("DELETE", "/api/v1/jobs/"+submitted.JobID, "analyst", nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Sum job that overflows fails with RESULT_OVERFLOW", func(t *testing.T) {
+		w := request("POST", "/api/v1/jobs", "analyst", map[string]interface{}{"type": "sum", "payload": map[string]interface{}{"numbers": []float64{1e308, 1e308}}})
+		require.Equal(t, http.StatusAccepted, w.Code)
+
+		var submitted models.JobResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &submitted))
+
+		response := await(t, submitted.JobID)
+		assert.Equal(t, "failed", response.State)
+		assert.Equal(t, models.CodeResultOverflow, response.ErrorCode)
+		assert.Nil(t, response.Result)
+	})
+
+	t.Run("Encrypted sum job", func(t *testing.T) {
+		sk, err := paillier.GenerateKey(rand.Reader, 512)
+		require.NoError(t, err)
+
+		w := request("POST", "/api/v1/paillier/keys", "analyst", map[string]string{"n": sk.N.String()})
+		require.Equal(t, http.StatusCreated, w.Code)
+		var key models.PaillierKeyResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &key))
+
+		var ciphertexts []string
+		for _, v := range []int64{10, 20, 12} {
+			c, err := sk.Encrypt(rand.Reader, big.NewInt(v))
+			require.NoError(t, err)
+			ciphertexts = append(ciphertexts, c.String())
+		}
+
+		w = request("POST", "/api/v1/jobs", "analyst", map[string]interface{}{
+			"type":    "paillier_sum",
+			"payload": map[string]interface{}{"key_id": key.KeyID, "ciphertexts": ciphertexts},
+		})
+		require.Equal(t, http.StatusAccepted, w.Code)
+		var submitted models.JobResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &submitted))
+
+		response := await(t, submitted.JobID)
+		require.Equal(t, "succeeded", response.State)
+
+		sum, ok := new(big.Int).SetString(response.Result.(map[string]interface{})["ciphertext"].(string), 10)
+		require.True(t, ok)
+		plain, err := sk.Decrypt(sum)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), plain.Int64())
+	})
+
+	t.Run("Key generation job", func(t *testing.T) {
+		w := request("POST", "/api/v1/jobs", "analyst", map[string]interface{}{"type": "paillier_keygen", "payload": map[string]int{"bits": 512}})
+		require.Equal(t, http.StatusAccepted, w.Code)
+		var submitted models.JobResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &submitted))
+
+		response := await(t, submitted.JobID)
+		require.Equal(t, "succeeded", response.State)
+		result := response.Result.(map[string]interface{})
+		assert.NotEmpty(t, result["private_key"].(map[string]interface{})["lambda"])
+
+		// The public key is registered
+		_, ok := paillierHandler.lookup("analyst", result["key_id"].(string))
+		assert.True(t, ok)
+
+		w = request("POST", "/api/v1/jobs", "analyst", map[string]interface{}{"type": "paillier_keygen", "payload": map[string]int{"bits": 256}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// Without the dev flag the private key never exists on the server
+		paillierHandler.config.DevKeyGeneration = false
+		defer func() { paillierHandler.config.DevKeyGeneration = true }()
+		w = request("POST", "/api/v1/jobs", "analyst", map[string]interface{}{"type": "paillier_keygen", "payload": nil})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		var rejected models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rejected))
+		assert.Equal(t, models.CodeKeygenDisabled, rejected.Code)
+	})
+
+	t.Run("Invalid submissions", func(t *testing.T) {
+		w := request("POST", "/api/v1/jobs", "analyst", map[string]interface{}{"type": "sort", "payload": map[string]interface{}{}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeUnknownJobType, response.Code)
+
+		w = request("POST", "/api/v1/jobs", "analyst", map[string]interface{}{"type": "sum", "payload": map[string]interface{}{"numbers": []float64{1}}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = request("POST", "/api/v1/jobs", "analyst", map[string]interface{}{
+			"type":    "paillier_sum",
+			"payload": map[string]interface{}{"key_id": "pk_missing", "ciphertexts": []string{"1", "2"}},
+		})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = request("DELETE", "/api/v1/jobs/job_missing", "analyst", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+// TestJobsHandler_CallbacksDisabled tests that callbacks require a signing secret
+func TestJobsHandler_CallbacksDisabled(t *testing.T) {
+	log := setupTestLogger()
+	handler := NewJobsHandler(log, config.JobsConfig{MaxNumbers: 10}, config.WebhookConfig{}, jobs.NewManager(log, jobs.Options{}), nil)
+	router := setupTestRouter()
+	router.POST("/api/v1/jobs", handler.HandleSubmitJob)
+
+	body := `{"type": "sum", "payload": {"numbers": [1, 2]}, "callback_url": "https://example.com/hook"}`
+	req, _ := http.NewRequest("POST", "/api/v1/jobs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.CodeCallbacksDisabled, response.Code)
+}
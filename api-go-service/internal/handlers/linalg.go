@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/linalg"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// linalgRequest is implemented by all linear algebra request models
+type linalgRequest interface {
+	Validate(maxElements int) error
+}
+
+// LinalgHandler handles vector and matrix arithmetic requests
+type LinalgHandler struct {
+	logger      *logger.Logger
+	maxElements int
+}
+
+// NewLinalgHandler creates a new linear algebra handler
+func NewLinalgHandler(logger *logger.Logger, maxElements int) *LinalgHandler {
+	return &LinalgHandler{
+		logger:      logger,
+		maxElements: maxElements,
+	}
+}
+
+// HandleVectorAdd handles POST /api/v1/linalg/vector/add requests
+func (h *LinalgHandler) HandleVectorAdd(c *gin.Context) {
+	var request models.VectorsRequest
+	reqID, ok := h.bind(c, "vector_add", &request)
+	if !ok {
+		return
+	}
+
+	vectors := make([]linalg.Vector, len(request.Vectors))
+	for i, v := range request.Vectors {
+		vectors[i] = v
+	}
+
+	result := linalg.AddVectors(vectors)
+	h.respond(c, reqID, "vector_add", result.Finite(), models.NewVectorResponse("vector_add", result, reqID))
+}
+
+// HandleVectorScale handles POST /api/v1/linalg/vector/scale requests
+func (h *LinalgHandler) HandleVectorScale(c *gin.Context) {
+	var request models.ScaleRequest
+	reqID, ok := h.bind(c, "vector_scale", &request)
+	if !ok {
+		return
+	}
+
+	result := linalg.Scale(request.Vector, *request.Scalar)
+	h.respond(c, reqID, "vector_scale", result.Finite(), models.NewVectorResponse("vector_scale", result, reqID))
+}
+
+// HandleVectorDot handles POST /api/v1/linalg/vector/dot requests
+func (h *LinalgHandler) HandleVectorDot(c *gin.Context) {
+	var request models.DotRequest
+	reqID, ok := h.bind(c, "vector_dot", &request)
+	if !ok {
+		return
+	}
+
+	result := linalg.Dot(request.A, request.B)
+	h.respond(c, reqID, "vector_dot", linalg.Vector{result}.Finite(), models.NewScalarResponse("vector_dot", result, reqID))
+}
+
+// HandleMatrixAdd handles POST /api/v1/linalg/matrix/add requests
+func (h *LinalgHandler) HandleMatrixAdd(c *gin.Context) {
+	var request models.MatricesRequest
+	reqID, ok := h.bind(c, "matrix_add", &request)
+	if !ok {
+		return
+	}
+
+	matrices := make([]linalg.Matrix, len(request.Matrices))
+	for i, m := range request.Matrices {
+		matrices[i] = m
+	}
+
+	result := linalg.AddMatrices(matrices)
+	h.respond(c, reqID, "matrix_add", result.Finite(), models.NewMatrixResponse("matrix_add", result, reqID))
+}
+
+// HandleMatrixMultiply handles POST /api/v1/linalg/matrix/multiply requests
+func (h *LinalgHandler) HandleMatrixMultiply(c *gin.Context) {
+	var request models.MatrixMultiplyRequest
+	reqID, ok := h.bind(c, "matrix_multiply", &request)
+	if !ok {
+		return
+	}
+
+	result := linalg.Multiply(request.A, request.B)
+	h.respond(c, reqID, "matrix_multiply", result.Finite(), models.NewMatrixResponse("matrix_multiply", result, reqID))
+}
+
+// HandleMatrixTranspose handles POST /api/v1/linalg/matrix/transpose requests
+func (h *LinalgHandler) HandleMatrixTranspose(c *gin.Context) {
+	var request models.MatrixRequest
+	reqID, ok := h.bind(c, "matrix_transpose", &request)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewMatrixResponse("matrix_transpose", linalg.Transpose(request.Matrix), reqID))
+}
+
+// HandleRowSums handles POST /api/v1/linalg/matrix/row-sums requests
+func (h *LinalgHandler) HandleRowSums(c *gin.Context) {
+	var request models.MatrixRequest
+	reqID, ok := h.bind(c, "matrix_row_sums", &request)
+	if !ok {
+		return
+	}
+
+	result := linalg.RowSums(request.Matrix)
+	h.respond(c, reqID, "matrix_row_sums", result.Finite(), models.NewVectorResponse("matrix_row_sums", result, reqID))
+}
+
+// HandleColumnSums handles POST /api/v1/linalg/matrix/column-sums requests
+func (h *LinalgHandler) HandleColumnSums(c *gin.Context) {
+	var request models.MatrixRequest
+	reqID, ok := h.bind(c, "matrix_column_sums", &request)
+	if !ok {
+		return
+	}
+
+	result := linalg.ColumnSums(request.Matrix)
+	h.respond(c, reqID, "matrix_column_sums", result.Finite(), models.NewVectorResponse("matrix_column_sums", result, reqID))
+}
+
+// bind parses and validates a linear algebra request
+// On failure the error response is written and ok is false
+func (h *LinalgHandler) bind(c *gin.Context, operation string, request linalgRequest) (string, bool) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	if statusCode, code, err := bindJSON(c, request); err != nil {
+		h.logger.WithError(err).WithFields(map[string]interface{}{
+			"component":  "linalg_handler",
+			"operation":  operation,
+			"request_id": reqID,
+		}).Error("Failed to bind request")
+
+		middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+		return reqID, false
+	}
+
+	if err := request.Validate(h.maxElements); err != nil {
+		code := models.ErrorCode(err, models.CodeValidation)
+
+		h.logger.WithError(err).WithFields(map[string]interface{}{
+			"component":  "linalg_handler",
+			"operation":  operation,
+			"request_id": reqID,
+			"code":       code,
+		}).Error("Request validation failed")
+
+		middleware.Fail(c, models.NewAPIError(code, err).WithStatus(http.StatusBadRequest))
+		return reqID, false
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"component":  "linalg_handler",
+		"operation":  operation,
+		"request_id": reqID,
+	}).Info("Processing linear algebra operation")
+
+	return reqID, true
+}
+
+// respond writes the result of an operation
+// Finite inputs can overflow to infinity, which JSON cannot carry; such results are refused
+func (h *LinalgHandler) respond(c *gin.Context, reqID, operation string, finite bool, response interface{}) {
+	if !finite {
+		err := models.NewAPIError(models.CodeResultOverflow, nil)
+		h.logger.WithError(err).WithFields(map[string]interface{}{
+			"component":  "linalg_handler",
+			"operation":  operation,
+			"request_id": reqID,
+		}).Error("Linear algebra result overflowed")
+
+		middleware.Fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
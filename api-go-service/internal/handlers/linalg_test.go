@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLinalgHandler tests the linear algebra endpoints
+func TestLinalgHandler(t *testing.T) {
+	log := setupTestLogger()
+	handler := NewLinalgHandler(log, 20)
+	router := setupTestRouter()
+
+	router.POST("/api/v1/linalg/vector/add", handler.HandleVectorAdd)
+	router.POST("/api/v1/linalg/vector/scale", handler.HandleVectorScale)
+	router.POST("/api/v1/linalg/vector/dot", handler.HandleVectorDot)
+	router.POST("/api/v1/linalg/matrix/add", handler.HandleMatrixAdd)
+	router.POST("/api/v1/linalg/matrix/multiply", handler.HandleMatrixMultiply)
+	router.POST("/api/v1/linalg/matrix/transpose", handler.HandleMatrixTranspose)
+	router.POST("/api/v1/linalg/matrix/row-sums", handler.HandleRowSums)
+	router.POST("/api/v1/linalg/matrix/column-sums", handler.HandleColumnSums)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Vector add", func(t *testing.T) {
+		w := post("/api/v1/linalg/vector/add", `{"vectors": [[1, 2, 3], [4, 5, 6], [0.5, 0.5, 0.5]]}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.VectorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []float64{5.5, 7.5, 9.5}, response.Result)
+		assert.Equal(t, 3, response.Length)
+		assert.Equal(t, "test-request-id", response.RequestID)
+	})
+
+	t.Run("Vector scale", func(t *testing.T) {
+		w := post("/api/v1/linalg/vector/scale", `{"scalar": -2, "vector": [1, 2.5]}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.VectorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []float64{-2, -5}, response.Result)
+	})
+
+	t.Run("Dot product", func(t *testing.T) {
+		w := post("/api/v1/linalg/vector/dot", `{"a": [1, 2, 3], "b": [4, 5, 6]}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.ScalarResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 32.0, response.Result)
+	})
+
+	t.Run("Matrix multiply", func(t *testing.T) {
+		w := post("/api/v1/linalg/matrix/multiply", `{"a": [[1, 2, 3], [4, 5, 6]], "b": [[7, 8], [9, 10], [11, 12]]}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.MatrixResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, [][]float64{{58, 64}, {139, 154}}, response.Result)
+		assert.Equal(t, 2, response.Rows)
+		assert.Equal(t, 2, response.Cols)
+	})
+
+	t.Run("Matrix transpose and sums", func(t *testing.T) {
+		body := `{"matrix": [[1, 2, 3], [4, 5, 6]]}`
+
+		var transposed models.MatrixResponse
+		w := post("/api/v1/linalg/matrix/transpose", body)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &transposed))
+		assert.Equal(t, [][]float64{{1, 4}, {2, 5}, {3, 6}}, transposed.Result)
+
+		var rows models.VectorResponse
+		w = post("/api/v1/linalg/matrix/row-sums", body)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rows))
+		assert.Equal(t, []float64{6, 15}, rows.Result)
+
+		var cols models.VectorResponse
+		w = post("/api/v1/linalg/matrix/column-sums", body)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cols))
+		assert.Equal(t, []float64{5, 7, 9}, cols.Result)
+	})
+
+	t.Run("Overflowing results are refused", func(t *testing.T) {
+		requests := map[string]string{
+			"/api/v1/linalg/vector/add":         `{"vectors": [[1e308], [1e308]]}`,
+			"/api/v1/linalg/vector/scale":       `{"scalar": 1e10, "vector": [1e300]}`,
+			"/api/v1/linalg/vector/dot":         `{"a": [1e200], "b": [1e200]}`,
+			"/api/v1/linalg/matrix/add":         `{"matrices": [[[1e308]], [[1e308]]]}`,
+			"/api/v1/linalg/matrix/multiply":    `{"a": [[1e200]], "b": [[1e200]]}`,
+			"/api/v1/linalg/matrix/row-sums":    `{"matrix": [[1e308, 1e308]]}`,
+			"/api/v1/linalg/matrix/column-sums": `{"matrix": [[1e308], [1e308]]}`,
+		}
+		for path, body := range requests {
+			w := post(path, body)
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code, path)
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), path)
+			assert.Equal(t, models.CodeResultOverflow, response.Code, path)
+		}
+	})
+
+	errorTests := []struct {
+		name         string
+		path         string
+		body         string
+		expectedCode string
+	}{
+		{
+			name:         "Vector length mismatch",
+			path:         "/api/v1/linalg/vector/add",
+			body:         `{"vectors": [[1, 2], [1, 2, 3]]}`,
+			expectedCode: models.CodeDimensionMismatch,
+		},
+		{
+			name:         "Dot product length mismatch",
+			path:         "/api/v1/linalg/vector/dot",
+			body:         `{"a": [1, 2], "b": [1]}`,
+			expectedCode: models.CodeDimensionMismatch,
+		},
+		{
+			name:         "Matrix shape mismatch",
+			path:         "/api/v1/linalg/matrix/add",
+			body:         `{"matrices": [[[1, 2]], [[1], [2]]]}`,
+			expectedCode: models.CodeDimensionMismatch,
+		},
+		{
+			name:         "Incompatible matrix product",
+			path:         "/api/v1/linalg/matrix/multiply",
+			body:         `{"a": [[1, 2]], "b": [[1, 2]]}`,
+			expectedCode: models.CodeDimensionMismatch,
+		},
+		{
+			name:         "Ragged matrix",
+			path:         "/api/v1/linalg/matrix/transpose",
+			body:         `{"matrix": [[1, 2], [3]]}`,
+			expectedCode: models.CodeInvalidShape,
+		},
+		{
+			name:         "Too many elements",
+			path:         "/api/v1/linalg/vector/add",
+			body:         `{"vectors": [[1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11], [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11]]}`,
+			expectedCode: models.CodeTooManyElements,
+		},
+		{
+			name:         "Missing scalar",
+			path:         "/api/v1/linalg/vector/scale",
+			body:         `{"vector": [1, 2]}`,
+			expectedCode: "INVALID_REQUEST_BODY",
+		},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(tt.path, tt.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Equal(t, "test-request-id", response.RequestID)
+		})
+	}
+}
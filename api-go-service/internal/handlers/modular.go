@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/modring"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// modularRequest is implemented by all modular and ring request models
+type modularRequest interface {
+	Validate(maxValues int) error
+}
+
+// ringRequest is implemented by request models that operate in Z_q[X]/(X^n+1)
+type ringRequest interface {
+	modularRequest
+	RingParameters() (*models.BigInt, int)
+}
+
+// ModularHandler handles arithmetic over Z_q and Z_q[X]/(X^n+1)
+type ModularHandler struct {
+	logger *logger.Logger
+	config config.ModularConfig
+}
+
+// NewModularHandler creates a new modular arithmetic handler
+func NewModularHandler(logger *logger.Logger, cfg config.ModularConfig) *ModularHandler {
+	return &ModularHandler{
+		logger: logger,
+		config: cfg,
+	}
+}
+
+// HandleModularSum handles POST /api/v1/modular/sum requests
+func (h *ModularHandler) HandleModularSum(c *gin.Context) {
+	var request models.ModularRequest
+	reqID, ok := h.bind(c, "modular_sum", &request)
+	if !ok {
+		return
+	}
+
+	modulus, ok := h.modulus(c, reqID, request.Modulus)
+	if !ok {
+		return
+	}
+
+	sum := modulus.Sum(models.BigInts(request.Values))
+	c.JSON(http.StatusOK, models.NewModularResponse("modular_sum", modulus.Value(), sum, len(request.Values), reqID))
+}
+
+// HandleModularProduct handles POST /api/v1/modular/product requests
+func (h *ModularHandler) HandleModularProduct(c *gin.Context) {
+	var request models.ModularRequest
+	reqID, ok := h.bind(c, "modular_product", &request)
+	if !ok {
+		return
+	}
+
+	modulus, ok := h.modulus(c, reqID, request.Modulus)
+	if !ok {
+		return
+	}
+
+	product := modulus.Product(models.BigInts(request.Values))
+	c.JSON(http.StatusOK, models.NewModularResponse("modular_product", modulus.Value(), product, len(request.Values), reqID))
+}
+
+// HandleModularReduce handles POST /api/v1/modular/reduce requests
+func (h *ModularHandler) HandleModularReduce(c *gin.Context) {
+	var request models.ModularRequest
+	reqID, ok := h.bind(c, "modular_reduce", &request)
+	if !ok {
+		return
+	}
+
+	modulus, ok := h.modulus(c, reqID, request.Modulus)
+	if !ok {
+		return
+	}
+
+	reduced := modulus.ReduceAll(models.BigInts(request.Values))
+	c.JSON(http.StatusOK, models.NewModularVectorResponse("modular_reduce", modulus.Value(), reduced, reqID))
+}
+
+// HandleRingAdd handles POST /api/v1/ring/add requests
+func (h *ModularHandler) HandleRingAdd(c *gin.Context) {
+	var request models.RingRequest
+	reqID, ring, modulus, ok := h.bindRing(c, "ring_add", &request)
+	if !ok {
+		return
+	}
+
+	polys := make([][]*big.Int, len(request.Polynomials))
+	for i, p := range request.Polynomials {
+		polys[i] = models.BigInts(p)
+	}
+
+	c.JSON(http.StatusOK, models.NewRingResponse("ring_add", modulus.Value(), ring.Add(polys), "", reqID))
+}
+
+// HandleRingMultiply handles POST /api/v1/ring/multiply requests
+func (h *ModularHandler) HandleRingMultiply(c *gin.Context) {
+	var request models.RingRequest
+	reqID, ring, modulus, ok := h.bindRing(c, "ring_multiply", &request)
+	if !ok {
+		return
+	}
+
+	product := models.BigInts(request.Polynomials[0])
+	for _, p := range request.Polynomials[1:] {
+		product = ring.Multiply(product, models.BigInts(p))
+	}
+
+	algorithm := "schoolbook"
+	if ring.NTTEnabled() {
+		algorithm = "ntt"
+	}
+
+	c.JSON(http.StatusOK, models.NewRingResponse("ring_multiply", modulus.Value(), product, algorithm, reqID))
+}
+
+// HandleRingReduce handles POST /api/v1/ring/reduce requests
+func (h *ModularHandler) HandleRingReduce(c *gin.Context) {
+	var request models.RingReduceRequest
+	reqID, ring, modulus, ok := h.bindRing(c, "ring_reduce", &request)
+	if !ok {
+		return
+	}
+
+	reduced := ring.Reduce(models.BigInts(request.Polynomial))
+	c.JSON(http.StatusOK, models.NewRingResponse("ring_reduce", modulus.Value(), reduced, "", reqID))
+}
+
+// bind parses and validates a modular arithmetic request
+// On failure the error response is written and ok is false
+func (h *ModularHandler) bind(c *gin.Context, operation string, request modularRequest) (string, bool) {
+	reqID, ok := h.decode(c, operation, request)
+	if !ok {
+		return reqID, false
+	}
+
+	if err := request.Validate(h.config.MaxValues); err != nil {
+		h.rejectParameters(c, reqID, operation, err, models.ErrorCode(err, models.CodeValidation))
+		return reqID, false
+	}
+
+	return reqID, true
+}
+
+// bindRing parses a ring request and builds the ring it operates in
+// Shapes are validated only once the degree itself is known to be valid
+func (h *ModularHandler) bindRing(c *gin.Context, operation string, request ringRequest) (string, *modring.Ring, *modring.Modulus, bool) {
+	reqID, ok := h.decode(c, operation, request)
+	if !ok {
+		return reqID, nil, nil, false
+	}
+
+	q, degree := request.RingParameters()
+	modulus, ok := h.modulus(c, reqID, q)
+	if !ok {
+		return reqID, nil, nil, false
+	}
+
+	ring, err := modring.NewRing(modulus, degree, h.config.MaxDegree, h.config.MaxRingBits)
+	if err != nil {
+		h.rejectParameters(c, reqID, operation, models.ErrorAt(err, models.CodeInvalidDegree, "degree"), models.CodeInvalidDegree)
+		return reqID, nil, nil, false
+	}
+
+	if err := request.Validate(h.config.MaxValues); err != nil {
+		h.rejectParameters(c, reqID, operation, err, models.ErrorCode(err, models.CodeValidation))
+		return reqID, nil, nil, false
+	}
+
+	return reqID, ring, modulus, true
+}
+
+// decode parses the JSON request body
+func (h *ModularHandler) decode(c *gin.Context, operation string, request interface{}) (string, bool) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	if statusCode, code, err := bindJSON(c, request); err != nil {
+		h.logger.WithError(err).WithFields(map[string]interface{}{
+			"component":  "modular_handler",
+			"operation":  operation,
+			"request_id": reqID,
+		}).Error("Failed to bind request")
+
+		middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+		return reqID, false
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"component":  "modular_handler",
+		"operation":  operation,
+		"request_id": reqID,
+	}).Info("Processing modular arithmetic operation")
+
+	return reqID, true
+}
+
+// modulus validates q, the modulus field of the request, against the configured bounds
+func (h *ModularHandler) modulus(c *gin.Context, reqID string, q *models.BigInt) (*modring.Modulus, bool) {
+	modulus, err := modring.NewModulus(q.Int(), h.config.MaxModulusBits)
+	if err != nil {
+		h.rejectParameters(c, reqID, "validate_modulus", models.ErrorAt(err, models.CodeInvalidModulus, "modulus"), models.CodeInvalidModulus)
+		return nil, false
+	}
+
+	return modulus, true
+}
+
+// rejectParameters logs a validation failure and writes the error response
+func (h *ModularHandler) rejectParameters(c *gin.Context, reqID, operation string, err error, code string) {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "modular_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"code":       code,
+	}).Error("Request validation failed")
+
+	middleware.Fail(c, models.NewAPIError(code, err).WithStatus(http.StatusBadRequest))
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestModularHandler tests the modular and polynomial ring endpoints
+func TestModularHandler(t *testing.T) {
+	log := setupTestLogger()
+	handler := NewModularHandler(log, config.ModularConfig{MaxModulusBits: 256, MaxDegree: 1024, MaxRingBits: 4096, MaxValues: 4096})
+	router := setupTestRouter()
+
+	router.POST("/api/v1/modular/sum", handler.HandleModularSum)
+	router.POST("/api/v1/modular/product", handler.HandleModularProduct)
+	router.POST("/api/v1/modular/reduce", handler.HandleModularReduce)
+	router.POST("/api/v1/ring/add", handler.HandleRingAdd)
+	router.POST("/api/v1/ring/multiply", handler.HandleRingMultiply)
+	router.POST("/api/v1/ring/reduce", handler.HandleRingReduce)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Modular sum keeps big integers exact", func(t *testing.T) {
+		// 2^64 + 2^64 mod (2^127 - 1) must not lose precision through float64
+		w := post("/api/v1/modular/sum", `{"modulus": "170141183460469231731687303715884105727", "values": ["18446744073709551616", "18446744073709551616"]}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.ModularResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "36893488147419103232", response.Result)
+		assert.Equal(t, 2, response.Count)
+		assert.Equal(t, "test-request-id", response.RequestID)
+	})
+
+	t.Run("Modular product and reduce", func(t *testing.T) {
+		var product models.ModularResponse
+		w := post("/api/v1/modular/product", `{"modulus": 17, "values": [5, "5", -1]}`)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &product))
+		assert.Equal(t, "9", product.Result)
+
+		var reduced models.ModularResponse
+		w = post("/api/v1/modular/reduce", `{"modulus": "17", "values": ["-1", "34", "20"]}`)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &reduced))
+		assert.Equal(t, []string{"16", "0", "3"}, reduced.Results)
+	})
+
+	t.Run("Ring multiply uses NTT for friendly primes", func(t *testing.T) {
+		w := post("/api/v1/ring/multiply", `{"modulus": "7681", "degree": 4, "polynomials": [["0", "0", "0", "1"], ["0", "1", "0", "0"]]}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.RingResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []string{"7680", "0", "0", "0"}, response.Coefficients)
+		assert.Equal(t, "ntt", response.Algorithm)
+		assert.Equal(t, 4, response.Degree)
+	})
+
+	t.Run("Ring add and reduce", func(t *testing.T) {
+		var sum models.RingResponse
+		w := post("/api/v1/ring/add", `{"modulus": "10", "degree": 2, "polynomials": [["7", "8"], ["5", "5"]]}`)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &sum))
+		assert.Equal(t, []string{"2", "3"}, sum.Coefficients)
+
+		var reduced models.RingResponse
+		w = post("/api/v1/ring/reduce", `{"modulus": "10", "degree": 2, "polynomial": ["1", "2", "3", "4", "5"]}`)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &reduced))
+		assert.Equal(t, []string{"3", "8"}, reduced.Coefficients)
+	})
+
+	errorTests := []struct {
+		name         string
+		path         string
+		body         string
+		expectedCode string
+		pointer      string
+	}{
+		{
+			name:         "Modulus too small",
+			path:         "/api/v1/modular/sum",
+			body:         `{"modulus": "1", "values": ["1"]}`,
+			expectedCode: models.CodeInvalidModulus,
+			pointer:      "/modulus",
+		},
+		{
+			name:         "Modulus too large",
+			path:         "/api/v1/modular/sum",
+			body:         `{"modulus": "1` + string(bytes.Repeat([]byte("0"), 100)) + `", "values": ["1"]}`,
+			expectedCode: models.CodeInvalidModulus,
+			pointer:      "/modulus",
+		},
+		{
+			name:         "Non-integer value",
+			path:         "/api/v1/modular/sum",
+			body:         `{"modulus": "17", "values": ["1.5"]}`,
+			expectedCode: "INVALID_REQUEST_BODY",
+		},
+		{
+			name:         "Degree not a power of two",
+			path:         "/api/v1/ring/add",
+			body:         `{"modulus": "17", "degree": 3, "polynomials": [["1", "2", "3"], ["1", "2", "3"]]}`,
+			expectedCode: models.CodeInvalidDegree,
+			pointer:      "/degree",
+		},
+		{
+			name:         "Coefficient count mismatch",
+			path:         "/api/v1/ring/multiply",
+			body:         `{"modulus": "17", "degree": 4, "polynomials": [["1", "2", "3", "4"], ["1"]]}`,
+			expectedCode: models.CodeDimensionMismatch,
+			pointer:      "/polynomials/1",
+		},
+		{
+			name:         "Ring too large",
+			path:         "/api/v1/ring/multiply",
+			body:         `{"modulus": "7681", "degree": 1024, "polynomials": [["1"], ["1"]]}`,
+			expectedCode: models.CodeInvalidDegree,
+			pointer:      "/degree",
+		},
+		{
+			name:         "Coefficient wider than the modulus",
+			path:         "/api/v1/ring/multiply",
+			body:         `{"modulus": "17", "degree": 2, "polynomials": [["1", "2"], ["1", "100"]]}`,
+			expectedCode: models.CodeValidation,
+			pointer:      "/polynomials/1/1",
+		},
+		{
+			name:         "Value wider than twice the modulus",
+			path:         "/api/v1/modular/reduce",
+			body:         `{"modulus": "17", "values": ["1", "1024"]}`,
+			expectedCode: models.CodeValidation,
+			pointer:      "/values/1",
+		},
+		{
+			name:         "Reduced coefficient wider than twice the modulus",
+			path:         "/api/v1/ring/reduce",
+			body:         `{"modulus": "17", "degree": 2, "polynomial": ["-1024", "1"]}`,
+			expectedCode: models.CodeValidation,
+			pointer:      "/polynomial/0",
+		},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(tt.path, tt.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			if tt.pointer != "" {
+				require.Len(t, response.Errors, 1)
+				assert.Equal(t, tt.pointer, response.Errors[0].Pointer)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/negotiation"
+)
+
+// negotiate selects the response format from the Accept header
+// It must run before any work is done so that unacceptable requests have no effect
+func negotiate(c *gin.Context) error {
+	format, err := negotiation.ResponseFormat(c.GetHeader("Accept"))
+	if err != nil {
+		return err
+	}
+	c.Set(middleware.ResponseFormatKey, format)
+	return nil
+}
+
+// bindNegotiated decodes the request body in the format named by Content-Type
+// and checks its binding tags. It returns the status and error code to report on failure.
+func bindNegotiated(c *gin.Context, v interface{}) (int, string, error) {
+	format, err := negotiation.RequestFormat(c.ContentType())
+	if err != nil {
+		return http.StatusUnsupportedMediaType, models.CodeUnsupportedMediaType, err
+	}
+
+	body, err := middleware.ReadBody(c)
+	if err != nil {
+		return bodyErrorStatus(err)
+	}
+
+	if err := format.Decode(body, v); err != nil {
+		if errors.Is(err, negotiation.ErrUnsupportedMessage) {
+			return http.StatusUnsupportedMediaType, models.CodeUnsupportedMediaType, err
+		}
+		if format == negotiation.JSON {
+			err = models.DecodeError(body, err)
+		}
+		return http.StatusBadRequest, models.CodeInvalidRequestBody, err
+	}
+
+	if err := binding.Validator.ValidateStruct(v); err != nil {
+		return http.StatusBadRequest, models.CodeInvalidRequestBody, models.BindingError(v, err)
+	}
+	return 0, "", nil
+}
+
+// bindJSON decodes a JSON request body and checks its binding tags. Failures are
+// reported as validation errors naming the invalid elements, with the status and
+// error code to report.
+func bindJSON(c *gin.Context, v interface{}) (int, string, error) {
+	if c.Request.Body == nil {
+		return http.StatusBadRequest, models.CodeInvalidRequestBody, models.DecodeError(nil, io.EOF)
+	}
+	body, err := middleware.ReadBody(c)
+	if err != nil {
+		return bodyErrorStatus(err)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return http.StatusBadRequest, models.CodeInvalidRequestBody, models.DecodeError(body, err)
+	}
+	if err := binding.Validator.ValidateStruct(v); err != nil {
+		return http.StatusBadRequest, models.CodeInvalidRequestBody, models.BindingError(v, err)
+	}
+	return 0, "", nil
+}
+
+// bodyErrorStatus maps a failure to read the request body to its status, error code and error
+func bodyErrorStatus(err error) (int, string, error) {
+	apiErr := middleware.BodyError(err)
+	return apiErr.HTTPStatus(), apiErr.Code, apiErr
+}
+
+// respond writes v in the negotiated response format, or as JSON if none was negotiated
+func respond(c *gin.Context, statusCode int, v interface{}) {
+	format := negotiation.JSON
+	if value, ok := c.Get(middleware.ResponseFormatKey); ok {
+		format = value.(*negotiation.Format)
+	}
+
+	body, err := format.Encode(v)
+	if err != nil {
+		middleware.Fail(c, models.NewAPIError(models.CodeInternal, err))
+		return
+	}
+
+	c.Header("Vary", "Accept, Accept-Language")
+	c.Data(statusCode, format.ContentType(), body)
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/negotiation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSumHandler_ContentNegotiation tests request and response formats of the sum endpoint
+func TestSumHandler_ContentNegotiation(t *testing.T) {
+	log := setupTestLogger()
+	handler := setupTestSumHandler(log)
+	router := setupTestRouter()
+	router.POST("/api/v1/sum", handler.HandleSum)
+
+	post := func(contentType, accept string, body []byte) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/api/v1/sum", bytes.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	formats := []struct {
+		name      string
+		mediaType string
+	}{
+		{"CBOR", negotiation.MediaTypeCBOR},
+		{"MessagePack", negotiation.MediaTypeMsgPack},
+		{"Protobuf", negotiation.MediaTypeProtobuf},
+	}
+
+	for _, tt := range formats {
+		t.Run(tt.name+" round trip", func(t *testing.T) {
+			format, err := negotiation.RequestFormat(tt.mediaType)
+			require.NoError(t, err)
+			body, err := format.Encode(&models.SumRequest{Numbers: []float64{1.5, 2.5, 3}})
+			require.NoError(t, err)
+
+			w := post(tt.mediaType, tt.mediaType, body)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, tt.mediaType, w.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept, Accept-Language", w.Header().Get("Vary"))
+
+			var response models.SumResponse
+			require.NoError(t, format.Decode(w.Body.Bytes(), &response))
+			assert.Equal(t, 7.0, response.Sum)
+			assert.Equal(t, 3, response.Count)
+			assert.Equal(t, "test-request-id", response.RequestID)
+			assert.False(t, response.Timestamp.IsZero())
+		})
+	}
+
+	t.Run("CSV request with a header row", func(t *testing.T) {
+		w := post("text/csv", "text/csv", []byte("value\n1.5\n2.5\n3\n"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "sum,count,timestamp,request_id\n7,3,")
+	})
+
+	t.Run("CSV request answered in JSON", func(t *testing.T) {
+		w := post("text/csv", "", []byte("1,2,3"))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.SumResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 6.0, response.Sum)
+	})
+
+	t.Run("Invalid CSV cell", func(t *testing.T) {
+		w := post("text/csv", "", []byte("1,two,3"))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_REQUEST_BODY")
+	})
+
+	t.Run("CSV validation errors list each field error", func(t *testing.T) {
+		w := post("application/json", "text/csv", []byte(`{"numbers": [1, 2, 3, "four"]}`))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		header := records[0]
+		assert.Equal(t, []string{"pointer", "parameter", "rule", "message"}, header[len(header)-4:])
+		assert.Contains(t, header, "details./numbers/3")
+		assert.Equal(t, "/numbers/3", records[1][len(header)-4])
+	})
+
+	t.Run("Validation errors use the negotiated format", func(t *testing.T) {
+		w := post("application/json", negotiation.MediaTypeProtobuf, []byte(`{"numbers": [1]}`))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, negotiation.MediaTypeProtobuf, w.Header().Get("Content-Type"))
+
+		var response models.ErrorResponse
+		require.NoError(t, response.UnmarshalProto(w.Body.Bytes()))
+		assert.Equal(t, "VALIDATION_ERROR", response.Code)
+		assert.Equal(t, "test-request-id", response.RequestID)
+	})
+
+	t.Run("Unsupported Content-Type returns 415", func(t *testing.T) {
+		w := post("application/xml", "", []byte(`<numbers/>`))
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeUnsupportedMediaType, response.Code)
+	})
+
+	t.Run("Oversized body returns 413", func(t *testing.T) {
+		models.SetLimits(models.Limits{models.LimitRequestMaxBytes: 16})
+		defer models.SetLimits(nil)
+
+		w := post("application/json", "", []byte(`{"numbers": [1, 2, 3, 4, 5, 6]}`))
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeRequestTooLarge, response.Code)
+	})
+
+	t.Run("Unsupported Accept returns 406", func(t *testing.T) {
+		w := post("application/json", "application/xml, application/json;q=0", []byte(`{"numbers": [1, 2]}`))
+		require.Equal(t, http.StatusNotAcceptable, w.Code)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeNotAcceptable, response.Code)
+	})
+}
+
+// TestBindJSON tests that JSON bodies are read up to the request size limit
+func TestBindJSON(t *testing.T) {
+	models.SetLimits(models.Limits{models.LimitRequestMaxBytes: 16})
+	defer models.SetLimits(nil)
+
+	router := setupTestRouter()
+	router.POST("/bind", func(c *gin.Context) {
+		var request models.SumRequest
+		statusCode, code, err := bindJSON(c, &request)
+		if err != nil {
+			middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/bind", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusNoContent, post(`{"numbers":[1]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`{"numbers":`).Code)
+
+	w := post(`{"numbers": [1, 2, 3, 4, 5, 6]}`)
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var response models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.CodeRequestTooLarge, response.Code)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/openapi"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// Paths of the API description
+const (
+	OpenAPIJSONPath = "/openapi.json"
+	OpenAPIYAMLPath = "/openapi.yaml"
+	DocsPath        = "/docs"
+)
+
+// OpenAPIHandler serves the OpenAPI document of the service and its docs page
+// The document is generated on first use, once every route is registered
+type OpenAPIHandler struct {
+	logger *logger.Logger
+	build  func() *openapi.Document
+
+	once     sync.Once
+	doc      *openapi.Document
+	jsonBody []byte
+	yamlBody []byte
+	err      error
+}
+
+// NewOpenAPIHandler creates a new OpenAPI handler serving the document returned by build
+func NewOpenAPIHandler(logger *logger.Logger, build func() *openapi.Document) *OpenAPIHandler {
+	return &OpenAPIHandler{logger: logger, build: build}
+}
+
+// Document returns the generated document with its JSON and YAML encodings
+func (h *OpenAPIHandler) Document() (*openapi.Document, error) {
+	h.generate()
+	return h.doc, h.err
+}
+
+// generate builds and encodes the document once
+func (h *OpenAPIHandler) generate() {
+	h.once.Do(func() {
+		h.doc = h.build()
+		if h.jsonBody, h.err = h.doc.JSON(); h.err != nil {
+			return
+		}
+		h.yamlBody, h.err = h.doc.YAML()
+	})
+}
+
+// HandleJSON handles GET /openapi.json requests
+func (h *OpenAPIHandler) HandleJSON(c *gin.Context) {
+	if h.failed(c, "get_openapi_json") {
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.jsonBody)
+}
+
+// HandleYAML handles GET /openapi.yaml requests
+func (h *OpenAPIHandler) HandleYAML(c *gin.Context) {
+	if h.failed(c, "get_openapi_yaml") {
+		return
+	}
+	c.Data(http.StatusOK, "application/yaml; charset=utf-8", h.yamlBody)
+}
+
+// HandleDocs handles GET /docs requests with the interactive documentation page
+func (h *OpenAPIHandler) HandleDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.DocsPage())
+}
+
+// HandleOperation returns a handler describing the operation of method on path
+// with the schemas it refers to, taken from the generated document
+func (h *OpenAPIHandler) HandleOperation(method, path string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID, _ := c.Get(middleware.RequestIDKey)
+		reqID, _ := requestID.(string)
+
+		if h.failed(c, "describe_operation") {
+			return
+		}
+		op, ok := h.doc.Operation(method, path)
+		if !ok {
+			h.reject(c, reqID, "describe_operation", http.StatusNotFound, fmt.Errorf("no %s %s operation in the OpenAPI document", method, path), models.CodeNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"endpoint":   path,
+			"method":     method,
+			"operation":  op,
+			"schemas":    h.doc.Referenced(op),
+			"openapi":    OpenAPIJSONPath,
+			"docs":       DocsPath,
+			"request_id": reqID,
+		})
+	}
+}
+
+// failed reports an error generating the document, which is then sent to the client
+func (h *OpenAPIHandler) failed(c *gin.Context, operation string) bool {
+	if _, err := h.Document(); err != nil {
+		requestID, _ := c.Get(middleware.RequestIDKey)
+		reqID, _ := requestID.(string)
+		h.reject(c, reqID, operation, http.StatusInternalServerError, err, models.CodeInternal)
+		return true
+	}
+	return false
+}
+
+// reject logs a failed request and sends the error response
+func (h *OpenAPIHandler) reject(c *gin.Context, reqID, operation string, statusCode int, err error, code string) {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "openapi_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"code":       code,
+	}).Error("OpenAPI request failed")
+
+	middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func setupTestOpenAPIRouter() *gin.Engine {
+	log := setupTestLogger()
+	router := setupTestRouter()
+	handler := NewOpenAPIHandler(log, func() *openapi.Document {
+		b := openapi.NewBuilder(openapi.Info{Title: "test", Version: "1.0.0"})
+		b.SetErrorModels(models.ErrorResponse{}, models.Problem{})
+		b.SetLimits(models.ResolveLimit)
+		b.Describe(http.MethodPost, "/api/v1/sum", openapi.Route{Summary: "Sum of numbers", Request: models.SumRequest{}, Response: models.SumResponse{}})
+		b.Describe(http.MethodGet, "/health", openapi.Route{Response: models.HealthResponse{}, Public: true})
+		return b.Build(router.Routes())
+	})
+
+	sumHandler := setupTestSumHandler(log)
+	router.GET("/health", NewHealthHandler(log, "1.0.0").HandleHealth)
+	router.POST("/api/v1/sum", sumHandler.HandleSum)
+	router.GET("/api/v1/sum", handler.HandleOperation(http.MethodPost, "/api/v1/sum"))
+	router.GET("/api/v1/missing", handler.HandleOperation(http.MethodPost, "/api/v1/missing"))
+	router.GET(OpenAPIJSONPath, handler.HandleJSON)
+	router.GET(OpenAPIYAMLPath, handler.HandleYAML)
+	router.GET(DocsPath, handler.HandleDocs)
+	return router
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	router := setupTestOpenAPIRouter()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("JSON document", func(t *testing.T) {
+		w := get(OpenAPIJSONPath)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+		var doc openapi.Document
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, openapi.Version, doc.OpenAPI)
+		assert.Contains(t, doc.Paths, OpenAPIYAMLPath)
+
+		request := doc.Components.Schemas["SumRequest"]
+		require.NotNil(t, request)
+		assert.Equal(t, []string{"numbers"}, request.Required)
+		assert.Equal(t, models.Limit(models.LimitSumMinNumbers), *request.Properties["numbers"].MinItems)
+		assert.Equal(t, models.Limit(models.LimitSumMaxNumbers), *request.Properties["numbers"].MaxItems)
+		assert.Contains(t, doc.Components.Schemas, "HealthResponse")
+		assert.Contains(t, doc.Components.Schemas, "ErrorResponse")
+	})
+
+	t.Run("YAML document", func(t *testing.T) {
+		w := get(OpenAPIYAMLPath)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/yaml")
+
+		var doc map[string]interface{}
+		require.NoError(t, yaml.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, openapi.Version, doc["openapi"])
+	})
+
+	t.Run("Docs page", func(t *testing.T) {
+		w := get(DocsPath)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+		assert.True(t, strings.Contains(w.Body.String(), OpenAPIJSONPath))
+	})
+
+	t.Run("Operation description", func(t *testing.T) {
+		w := get("/api/v1/sum")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "/api/v1/sum", response["endpoint"])
+		assert.Equal(t, "POST", response["method"])
+		assert.Equal(t, "test-request-id", response["request_id"])
+		assert.Equal(t, "Sum of numbers", response["operation"].(map[string]interface{})["summary"])
+		assert.Contains(t, response["schemas"], "SumRequest")
+		assert.Contains(t, response["schemas"], "PrivacyParams")
+	})
+
+	t.Run("Unknown operation", func(t *testing.T) {
+		w := get("/api/v1/missing")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeNotFound, response.Code)
+	})
+}
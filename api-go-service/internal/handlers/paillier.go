@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/katvio/api-go-service/pkg/paillier"
+)
+
+// errKeyLimitReached is returned when a consumer cannot register more public keys
+var errKeyLimitReached = errors.New("maximum number of registered keys reached")
+
+// PaillierHandler handles encrypted sum requests
+// The server only ever holds public keys, so it can add ciphertexts but not read them
+// Keys are registered per consumer and forgotten once they sit unused for the idle timeout
+type PaillierHandler struct {
+	logger *logger.Logger
+	config config.PaillierConfig
+
+	mu   sync.Mutex
+	keys map[string]map[string]*registeredKey // consumer -> key ID -> key
+}
+
+// registeredKey is a public key with the last time it was used
+type registeredKey struct {
+	pk       *paillier.PublicKey
+	lastUsed time.Time
+}
+
+// NewPaillierHandler creates a new encrypted sum handler
+func NewPaillierHandler(logger *logger.Logger, cfg config.PaillierConfig) *PaillierHandler {
+	return &PaillierHandler{
+		logger: logger,
+		config: cfg,
+		keys:   make(map[string]map[string]*registeredKey),
+	}
+}
+
+// HandleRegisterKey handles POST /api/v1/paillier/keys requests
+func (h *PaillierHandler) HandleRegisterKey(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	var request models.PaillierRegisterRequest
+	if statusCode, code, err := bindJSON(c, &request); err != nil {
+		h.reject(c, reqID, "bind_request", statusCode, err, code)
+		return
+	}
+
+	if err := request.Validate(h.config.MinKeyBits, h.config.MaxKeyBits); err != nil {
+		h.reject(c, reqID, "validate_request", http.StatusBadRequest, err, models.ErrorCode(err, models.CodeValidation))
+		return
+	}
+
+	pk, err := paillier.NewPublicKey(request.N.Int())
+	if err != nil {
+		h.reject(c, reqID, "validate_key", http.StatusBadRequest, models.ErrorAt(err, models.CodeInvalidPublicKey, "n"), models.CodeInvalidPublicKey)
+		return
+	}
+
+	keyID, created, err := h.register(middleware.GetConsumer(c), pk)
+	if err != nil {
+		h.reject(c, reqID, "register_key", http.StatusTooManyRequests, err, models.CodeKeyLimitReached)
+		return
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"component":  "paillier_handler",
+		"operation":  "register_key",
+		"request_id": reqID,
+		"key_id":     keyID,
+		"created":    created,
+	}).Info("Paillier public key registered")
+
+	statusCode := http.StatusOK
+	if created {
+		statusCode = http.StatusCreated
+	}
+
+	c.JSON(statusCode, models.NewPaillierKeyResponse(keyID, pk, reqID))
+}
+
+// HandleGetKey handles GET /api/v1/paillier/keys/:id requests
+func (h *PaillierHandler) HandleGetKey(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	keyID := c.Param("id")
+	pk, ok := h.lookup(middleware.GetConsumer(c), keyID)
+	if !ok {
+		h.reject(c, reqID, "get_key", http.StatusNotFound, fmt.Errorf("key %q not found", keyID), models.CodeKeyNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewPaillierKeyResponse(keyID, pk, reqID))
+}
+
+// HandleEncryptedSum handles POST /api/v1/paillier/sum requests
+func (h *PaillierHandler) HandleEncryptedSum(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	var request models.PaillierSumRequest
+	if statusCode, code, err := bindJSON(c, &request); err != nil {
+		h.reject(c, reqID, "bind_request", statusCode, err, code)
+		return
+	}
+
+	if err := request.Validate(h.config.MaxCiphertexts); err != nil {
+		h.reject(c, reqID, "validate_request", http.StatusBadRequest, err, models.ErrorCode(err, models.CodeValidation))
+		return
+	}
+
+	pk, ok := h.lookup(middleware.GetConsumer(c), request.KeyID)
+	if !ok {
+		h.reject(c, reqID, "lookup_key", http.StatusNotFound, models.ErrorAt(fmt.Errorf("key %q not found", request.KeyID), models.CodeKeyNotFound, "key_id"), models.CodeKeyNotFound)
+		return
+	}
+
+	sum, err := pk.Add(models.BigInts(request.Ciphertexts)...)
+	if err != nil {
+		var ciphertextErr *paillier.CiphertextError
+		if errors.As(err, &ciphertextErr) {
+			err = models.ErrorAt(err, models.CodeInvalidCiphertext, "ciphertexts", ciphertextErr.Index)
+		}
+		h.reject(c, reqID, "encrypted_sum", http.StatusBadRequest, err, models.CodeInvalidCiphertext)
+		return
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"component":  "paillier_handler",
+		"operation":  "encrypted_sum",
+		"request_id": reqID,
+		"key_id":     request.KeyID,
+		"count":      len(request.Ciphertexts),
+	}).Info("Encrypted sum calculated")
+
+	c.JSON(http.StatusOK, models.NewPaillierSumResponse(request.KeyID, sum, len(request.Ciphertexts), reqID))
+}
+
+// register stores a consumer's public key under an ID derived from its modulus
+// Registering the same key twice returns the existing ID with created set to false
+func (h *PaillierHandler) register(consumer string, pk *paillier.PublicKey) (keyID string, created bool, err error) {
+	keyID = paillierKeyID(pk.N)
+	now := time.Now()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.evictIdle(now)
+
+	owned := h.keys[consumer]
+	if key, exists := owned[keyID]; exists {
+		key.lastUsed = now
+		return keyID, false, nil
+	}
+
+	if h.config.MaxKeys > 0 && len(owned) >= h.config.MaxKeys {
+		return "", false, errKeyLimitReached
+	}
+
+	if owned == nil {
+		owned = make(map[string]*registeredKey)
+		h.keys[consumer] = owned
+	}
+	owned[keyID] = &registeredKey{pk: pk, lastUsed: now}
+	return keyID, true, nil
+}
+
+// lookup returns a public key registered by the consumer and marks it as used
+func (h *PaillierHandler) lookup(consumer, keyID string) (*paillier.PublicKey, bool) {
+	now := time.Now()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key, ok := h.keys[consumer][keyID]
+	if !ok || h.idle(key, now) {
+		return nil, false
+	}
+	key.lastUsed = now
+	return key.pk, true
+}
+
+// evictIdle forgets the keys that have not been used for the idle timeout
+func (h *PaillierHandler) evictIdle(now time.Time) {
+	for consumer, owned := range h.keys {
+		for keyID, key := range owned {
+			if h.idle(key, now) {
+				delete(owned, keyID)
+			}
+		}
+		if len(owned) == 0 {
+			delete(h.keys, consumer)
+		}
+	}
+}
+
+// idle reports whether a key has gone unused for longer than the idle timeout
+func (h *PaillierHandler) idle(key *registeredKey, now time.Time) bool {
+	return h.config.KeyIdleTimeout > 0 && now.Sub(key.lastUsed) > h.config.KeyIdleTimeout
+}
+
+// reject logs a failed request and writes the error response
+func (h *PaillierHandler) reject(c *gin.Context, reqID, operation string, statusCode int, err error, code string) {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "paillier_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"code":       code,
+	}).Error("Encrypted sum request failed")
+
+	middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+}
+
+// paillierKeyID derives a stable key ID from the public modulus
+func paillierKeyID(n *big.Int) string {
+	digest := sha256.Sum256(n.Bytes())
+	return "pk_" + hex.EncodeToString(digest[:12])
+}
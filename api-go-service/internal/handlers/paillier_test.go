@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/paillier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPaillierHandler tests the encrypted sum endpoints
+func TestPaillierHandler(t *testing.T) {
+	log := setupTestLogger()
+	handler := NewPaillierHandler(log, config.PaillierConfig{MinKeyBits: 512, MaxKeyBits: 1024, MaxCiphertexts: 10, MaxKeys: 10})
+	router := setupTestRouter()
+
+	router.POST("/api/v1/paillier/keys", handler.HandleRegisterKey)
+	router.GET("/api/v1/paillier/keys/:id", handler.HandleGetKey)
+	router.POST("/api/v1/paillier/sum", handler.HandleEncryptedSum)
+
+	post := func(path string, body interface{}) *httptest.ResponseRecorder {
+		jsonData, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", path, bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	sk, err := paillier.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	var keyID string
+	t.Run("Register public key", func(t *testing.T) {
+		w := post("/api/v1/paillier/keys", map[string]string{"n": sk.N.String()})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var response models.PaillierKeyResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, sk.N.String(), response.N)
+		assert.Equal(t, 512, response.Bits)
+		keyID = response.KeyID
+
+		// Registering the same key again is idempotent
+		w = post("/api/v1/paillier/keys", map[string]string{"n": sk.N.String()})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ := http.NewRequest("GET", "/api/v1/paillier/keys/"+keyID, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Encrypted sum decrypts to the plaintext sum", func(t *testing.T) {
+		var ciphertexts []string
+		for _, v := range []int64{7, 35, -2} {
+			c, err := sk.Encrypt(rand.Reader, big.NewInt(v))
+			require.NoError(t, err)
+			ciphertexts = append(ciphertexts, c.String())
+		}
+
+		w := post("/api/v1/paillier/sum", map[string]interface{}{"key_id": keyID, "ciphertexts": ciphertexts})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.PaillierSumResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 3, response.Count)
+
+		sum, ok := new(big.Int).SetString(response.Ciphertext, 10)
+		require.True(t, ok)
+		plaintext, err := sk.DecryptSigned(sum)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(40), plaintext)
+	})
+
+	errorTests := []struct {
+		name           string
+		path           string
+		body           interface{}
+		expectedStatus int
+		expectedCode   string
+		pointer        string
+	}{
+		{
+			name:           "Key too small",
+			path:           "/api/v1/paillier/keys",
+			body:           map[string]string{"n": new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1)).String()},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   models.CodeInvalidKeySize,
+			pointer:        "/n",
+		},
+		{
+			name:           "Even modulus",
+			path:           "/api/v1/paillier/keys",
+			body:           map[string]string{"n": new(big.Int).Lsh(big.NewInt(1), 600).String()},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   models.CodeInvalidPublicKey,
+			pointer:        "/n",
+		},
+		{
+			name:           "Unknown key",
+			path:           "/api/v1/paillier/sum",
+			body:           map[string]interface{}{"key_id": "pk_missing", "ciphertexts": []string{"2", "3"}},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   models.CodeKeyNotFound,
+			pointer:        "/key_id",
+		},
+		{
+			name:           "Ciphertext out of range",
+			path:           "/api/v1/paillier/sum",
+			body:           map[string]interface{}{"key_id": keyID, "ciphertexts": []string{"2", "0"}},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   models.CodeInvalidCiphertext,
+			pointer:        "/ciphertexts/1",
+		},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(tt.path, tt.body)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			require.Len(t, response.Errors, 1)
+			assert.Equal(t, tt.pointer, response.Errors[0].Pointer)
+		})
+	}
+}
+
+// TestPaillierHandler_Keys tests that keys are scoped per consumer and forgotten when idle
+func TestPaillierHandler_Keys(t *testing.T) {
+	handler := NewPaillierHandler(setupTestLogger(), config.PaillierConfig{MaxKeys: 1, KeyIdleTimeout: time.Hour})
+
+	alice, err := paillier.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+	bob, err := paillier.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	keyID, created, err := handler.register("alice", &alice.PublicKey)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	// Other consumers neither see the key nor lose their quota to it
+	_, ok := handler.lookup("bob", keyID)
+	assert.False(t, ok)
+	_, _, err = handler.register("bob", &bob.PublicKey)
+	require.NoError(t, err)
+
+	_, _, err = handler.register("alice", &bob.PublicKey)
+	assert.ErrorIs(t, err, errKeyLimitReached)
+
+	// Once the key sits unused past the timeout it is forgotten and the quota is free again
+	handler.keys["alice"][keyID].lastUsed = time.Now().Add(-2 * time.Hour)
+	_, ok = handler.lookup("alice", keyID)
+	assert.False(t, ok)
+	_, created, err = handler.register("alice", &bob.PublicKey)
+	require.NoError(t, err)
+	assert.True(t, created)
+}
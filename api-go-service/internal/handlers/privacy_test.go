@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSumHandler_Privacy tests the differentially private sum mode
+func TestSumHandler_Privacy(t *testing.T) {
+	log := setupTestLogger()
+	handler := setupTestSumHandler(log)
+	router := setupTestRouter()
+	router.Use(middleware.ConsumerMiddleware())
+	router.POST("/api/v1/sum", handler.HandleSum)
+	router.GET("/api/v1/privacy/budget", handler.HandlePrivacyBudget)
+
+	request := func(method, path, consumer, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Consumer-Username", consumer)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Noisy sum reports the mechanism and epsilon spent", func(t *testing.T) {
+		w := request("POST", "/api/v1/sum", "analyst",
+			`{"numbers": [1, 2, 30], "privacy": {"mechanism": "laplace", "epsilon": 0.6, "lower": 0, "upper": 10}}`)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+		var response models.SumResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.Privacy)
+		assert.Equal(t, "laplace", response.Privacy.Mechanism)
+		assert.Equal(t, 0.6, response.Privacy.EpsilonSpent)
+		assert.Equal(t, 10.0, response.Privacy.Sensitivity)
+		assert.InDelta(t, 0.4, response.Privacy.BudgetRemaining, 1e-9)
+		assert.Equal(t, 3, response.Count)
+		assert.Empty(t, response.Numbers)
+	})
+
+	t.Run("Budget exhaustion is refused", func(t *testing.T) {
+		w := request("POST", "/api/v1/sum", "analyst",
+			`{"numbers": [1, 2], "privacy": {"mechanism": "gaussian", "epsilon": 0.5, "delta": 0.00001, "lower": 0, "upper": 10}}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodePrivacyBudgetExhausted, response.Code)
+
+		// Other consumers keep their own budget
+		w = request("POST", "/api/v1/sum", "other",
+			`{"numbers": [1, 2], "privacy": {"mechanism": "gaussian", "epsilon": 0.5, "delta": 0.00001, "lower": 0, "upper": 10}}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Anonymous callers are refused", func(t *testing.T) {
+		body := `{"numbers": [1, 2], "privacy": {"mechanism": "laplace", "epsilon": 0.1, "lower": 0, "upper": 10}}`
+		w := request("POST", "/api/v1/sum", "", body)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeConsumerRequired, response.Code)
+
+		// No budget was charged
+		w = request("GET", "/api/v1/privacy/budget", "", "")
+		var budget models.PrivacyBudgetResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &budget))
+		assert.Equal(t, budget.Budget, budget.Remaining)
+	})
+
+	t.Run("Budget endpoint reports the remaining epsilon", func(t *testing.T) {
+		w := request("GET", "/api/v1/privacy/budget", "analyst", "")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response models.PrivacyBudgetResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "analyst", response.Consumer)
+		assert.InDelta(t, 0.4, response.Remaining, 1e-9)
+	})
+
+	t.Run("Non-finite noise is refused without charging the budget", func(t *testing.T) {
+		tests := []struct {
+			body    string
+			status  int
+			code    string
+			pointer string
+		}{
+			{`{"numbers": [1, 2], "privacy": {"mechanism": "laplace", "epsilon": 0.1, "lower": -1e308, "upper": 1e308}}`, http.StatusBadRequest, models.CodeInvalidPrivacyParams, "/privacy"},
+			{`{"numbers": [1, 2], "privacy": {"mechanism": "laplace", "epsilon": 5e-324, "lower": 0, "upper": 10}}`, http.StatusBadRequest, models.CodeInvalidPrivacyParams, "/privacy/epsilon"},
+			{`{"numbers": [1e308, 1e308], "privacy": {"mechanism": "laplace", "epsilon": 1, "lower": 0, "upper": 1e308}}`, http.StatusUnprocessableEntity, models.CodeResultOverflow, ""},
+		}
+		for _, tt := range tests {
+			w := request("POST", "/api/v1/sum", "wide", tt.body)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.code, response.Code)
+			if tt.pointer != "" {
+				require.Len(t, response.Errors, 1)
+				assert.Equal(t, tt.pointer, response.Errors[0].Pointer)
+			}
+		}
+
+		w := request("GET", "/api/v1/privacy/budget", "wide", "")
+		var budget models.PrivacyBudgetResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &budget))
+		assert.Equal(t, budget.Budget, budget.Remaining)
+	})
+
+	t.Run("Invalid privacy parameters", func(t *testing.T) {
+		bodies := []string{
+			`{"numbers": [1, 2], "privacy": {"mechanism": "laplace", "epsilon": 0.1}}`,
+			`{"numbers": [1, 2], "privacy": {"mechanism": "laplace", "epsilon": 5, "lower": 0, "upper": 1}}`,
+			`{"numbers": [1, 2], "privacy": {"mechanism": "gaussian", "epsilon": 0.1, "lower": 0, "upper": 1}}`,
+			`{"numbers": [1, 2], "privacy": {"mechanism": "exponential", "epsilon": 0.1, "lower": 0, "upper": 1}}`,
+			`{"numbers": [1, 2], "privacy": {"mechanism": "laplace", "epsilon": 0.1, "lower": 1, "upper": 0}}`,
+		}
+		for _, body := range bodies {
+			w := request("POST", "/api/v1/sum", "fresh", body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, models.CodeInvalidPrivacyParams, response.Code)
+		}
+	})
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// rpcMethod runs one JSON-RPC call and returns its result
+type rpcMethod func(c *gin.Context, request *models.RPCRequest, reqID string) (interface{}, *models.RPCError)
+
+// RPCHandler handles JSON-RPC 2.0 calls
+// Methods are dispatched to the same logic as the REST endpoints
+type RPCHandler struct {
+	logger   *logger.Logger
+	sum      *SumHandler
+	health   *HealthHandler
+	maxBatch int
+	methods  map[string]rpcMethod
+}
+
+// NewRPCHandler creates a new JSON-RPC handler
+func NewRPCHandler(logger *logger.Logger, maxBatch int, sum *SumHandler, health *HealthHandler) *RPCHandler {
+	h := &RPCHandler{
+		logger:   logger,
+		sum:      sum,
+		health:   health,
+		maxBatch: maxBatch,
+	}
+	h.methods = map[string]rpcMethod{
+		"sum":    h.callSum,
+		"health": h.callHealth,
+	}
+	return h
+}
+
+// HandleRPC handles POST /rpc requests
+// A single call or a batch is answered with 200; when every call is a notification
+// there is nothing to return and the answer is 204
+func (h *RPCHandler) HandleRPC(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	// An oversized body is refused at the HTTP level, as on the REST endpoints
+	body, err := middleware.ReadBody(c)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, h.reject(c, reqID, nil, "read_request", models.RPCInvalidRequest, middleware.BodyError(err)))
+		return
+	}
+	if err == nil {
+		var value json.RawMessage
+		if err = json.Unmarshal(body, &value); err != nil {
+			err = models.DecodeError(body, err)
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusOK, h.reject(c, reqID, nil, "parse_request", models.RPCParseError, models.NewAPIError(models.CodeInvalidRequestBody, err).WithStatus(http.StatusBadRequest)))
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	if body[0] != '[' {
+		if response := h.call(c, body, reqID); response != nil {
+			c.JSON(http.StatusOK, response)
+			return
+		}
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(body, &batch); err != nil {
+		c.JSON(http.StatusOK, h.reject(c, reqID, nil, "parse_batch", models.RPCParseError, models.NewAPIError(models.CodeInvalidRequestBody, models.DecodeError(body, err)).WithStatus(http.StatusBadRequest)))
+		return
+	}
+	if len(batch) == 0 {
+		err := models.NewValidationError(models.CodeInvalidRequestBody, models.FieldViolation("", models.RuleMinItems, "batch is empty"))
+		c.JSON(http.StatusOK, h.reject(c, reqID, nil, "parse_batch", models.RPCInvalidRequest, models.NewAPIError(models.CodeInvalidRequestBody, err).WithStatus(http.StatusBadRequest)))
+		return
+	}
+	if len(batch) > h.maxBatch {
+		err := models.NewAPIError(models.CodeBatchTooLarge, nil).
+			WithParam("count", strconv.Itoa(len(batch))).
+			WithParam("max", strconv.Itoa(h.maxBatch))
+		c.JSON(http.StatusOK, h.reject(c, reqID, nil, "parse_batch", models.RPCInvalidRequest, err))
+		return
+	}
+
+	responses := make([]*models.RPCResponse, 0, len(batch))
+	for _, raw := range batch {
+		if response := h.call(c, raw, reqID); response != nil {
+			responses = append(responses, response)
+		}
+	}
+
+	if len(responses) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// call runs one call of a request and returns its response, or nil for a notification
+// Calls that are not valid requests are always answered, as their ID cannot be trusted
+func (h *RPCHandler) call(c *gin.Context, raw json.RawMessage, reqID string) *models.RPCResponse {
+	var request models.RPCRequest
+	if err := json.Unmarshal(raw, &request); err != nil {
+		return h.reject(c, reqID, nil, "parse_call", models.RPCInvalidRequest, invalidCall(models.DecodeError(raw, err)))
+	}
+	if !validRPCID(request.ID) {
+		err := models.NewValidationError(models.CodeInvalidRequestBody, models.FieldViolation(models.JSONPointer("id"), models.RuleType, "id must be a string, a number or null"))
+		return h.reject(c, reqID, nil, "parse_call", models.RPCInvalidRequest, invalidCall(err))
+	}
+	if request.JSONRPC != models.JSONRPCVersion {
+		err := models.NewValidationError(models.CodeInvalidRequestBody, models.FieldViolation(models.JSONPointer("jsonrpc"), "const", "jsonrpc must be %q", models.JSONRPCVersion))
+		return h.reject(c, reqID, request.ID, "parse_call", models.RPCInvalidRequest, invalidCall(err))
+	}
+
+	method, ok := h.methods[request.Method]
+	if !ok {
+		err := models.NewAPIError(models.CodeMethodNotFound, nil).WithParam("method", request.Method)
+		response := h.reject(c, reqID, request.ID, "dispatch", models.RPCMethodNotFound, err)
+		if request.IsNotification() {
+			return nil
+		}
+		return response
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"component":    "rpc_handler",
+		"operation":    "dispatch",
+		"request_id":   reqID,
+		"method":       request.Method,
+		"notification": request.IsNotification(),
+	}).Info("Processing JSON-RPC call")
+
+	result, rpcErr := method(c, &request, reqID)
+	if request.IsNotification() {
+		return nil
+	}
+	if rpcErr != nil {
+		return models.NewRPCError(request.ID, rpcErr)
+	}
+	return models.NewRPCResult(request.ID, result)
+}
+
+// callSum runs the sum method
+// Params are either a SumRequest object or the array of numbers. A notification's
+// result is never sent, so it may not spend privacy budget on a noisy sum.
+func (h *RPCHandler) callSum(c *gin.Context, call *models.RPCRequest, reqID string) (interface{}, *models.RPCError) {
+	request, from, rpcErr := h.bindSumParams(c, call.Params, reqID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if request.Privacy != nil && call.IsNotification() {
+		err := models.NewValidationError(models.CodeInvalidRequestBody, models.FieldViolation(models.JSONPointer("params", "privacy"), models.RuleExclusive, "privacy is not accepted in notifications"))
+		return nil, h.reject(c, reqID, nil, "bind_params", models.RPCInvalidParams, invalidCall(err)).Error
+	}
+
+	response, statusCode, code, err := h.sum.calculate(request, middleware.GetConsumer(c), reqID)
+	if err != nil {
+		return nil, rpcError(c, models.NewAPIError(code, models.Rebase(err, from, models.JSONPointer("params"))).WithStatus(statusCode), reqID)
+	}
+	return response, nil
+}
+
+// bindSumParams reads the params of the sum method
+// Params are either a SumRequest object or the array of numbers. from is the
+// pointer the request's field errors are rooted at, to be moved under params
+func (h *RPCHandler) bindSumParams(c *gin.Context, params json.RawMessage, reqID string) (*models.SumRequest, string, *models.RPCError) {
+	var request models.SumRequest
+	from := ""
+
+	var err error
+	switch params = bytes.TrimSpace(params); {
+	case len(params) == 0:
+		err = models.NewValidationError(models.CodeInvalidRequestBody, models.FieldViolation(models.JSONPointer("params"), "required", "%s is required", "params"))
+	case params[0] == '[':
+		from = models.JSONPointer("numbers")
+		if err = json.Unmarshal(params, &request.Numbers); err != nil {
+			err = models.Nest(models.DecodeError(params, err), "params")
+		}
+	default:
+		if err = json.Unmarshal(params, &request); err != nil {
+			err = models.Nest(models.DecodeError(params, err), "params")
+		} else if err = binding.Validator.ValidateStruct(&request); err != nil {
+			err = models.Nest(models.BindingError(&request, err), "params")
+		}
+	}
+	if err != nil {
+		return nil, "", h.reject(c, reqID, nil, "bind_params", models.RPCInvalidParams, invalidCall(err)).Error
+	}
+	return &request, from, nil
+}
+
+// callHealth runs the health method, which takes no params
+func (h *RPCHandler) callHealth(c *gin.Context, call *models.RPCRequest, reqID string) (interface{}, *models.RPCError) {
+	return h.health.Report(reqID), nil
+}
+
+// reject logs a call that could not be dispatched and returns its error response
+func (h *RPCHandler) reject(c *gin.Context, reqID string, id json.RawMessage, operation string, rpcCode int, err *models.APIError) *models.RPCResponse {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "rpc_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"code":       err.Code,
+	}).Error("JSON-RPC call rejected")
+
+	rpcErr := rpcError(c, err, reqID)
+	rpcErr.Code = rpcCode
+	return models.NewRPCError(id, rpcErr)
+}
+
+// invalidCall reports err as an invalid request body
+func invalidCall(err error) *models.APIError {
+	return models.NewAPIError(models.CodeInvalidRequestBody, err).WithStatus(http.StatusBadRequest)
+}
+
+// rpcError maps a failure of the REST logic to a JSON-RPC error object
+// The message is localized like the REST error responses; the service's error
+// code, HTTP status and field errors are kept in data
+func rpcError(c *gin.Context, err *models.APIError, reqID string) *models.RPCError {
+	statusCode := err.HTTPStatus()
+	rpcCode := models.RPCServerError
+	switch {
+	case statusCode == http.StatusBadRequest:
+		rpcCode = models.RPCInvalidParams
+	case statusCode >= http.StatusInternalServerError:
+		rpcCode = models.RPCInternalError
+	}
+
+	response := err.Response(middleware.GetLocale(c), c.Request.URL.Path, reqID)
+	return models.RPCErrorFromResponse(rpcCode, statusCode, response)
+}
+
+// validRPCID reports whether an ID is absent, a string, a number or null
+func validRPCID(id json.RawMessage) bool {
+	if id == nil {
+		return true
+	}
+	var value interface{}
+	if err := json.Unmarshal(id, &value); err != nil {
+		return false
+	}
+	switch value.(type) {
+	case nil, string, float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rpcResult is an RPCResponse whose result is kept raw
+type rpcResult struct {
+	JSONRPC string           `json:"jsonrpc"`
+	Result  json.RawMessage  `json:"result"`
+	Error   *models.RPCError `json:"error"`
+	ID      json.RawMessage  `json:"id"`
+}
+
+// TestRPCHandler tests the JSON-RPC 2.0 endpoint
+func TestRPCHandler(t *testing.T) {
+	log := setupTestLogger()
+	handler := NewRPCHandler(log, 3, setupTestSumHandler(log), NewHealthHandler(log, "1.0.0"))
+	router := setupTestRouter()
+	router.Use(middleware.ConsumerMiddleware(), middleware.LocaleMiddleware())
+	router.POST("/rpc", handler.HandleRPC)
+
+	language := "en"
+	call := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/rpc", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", language)
+		req.Header.Set("X-Consumer-Username", "analyst")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	single := func(t *testing.T, body string) rpcResult {
+		w := call(body)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response rpcResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "2.0", response.JSONRPC)
+		return response
+	}
+
+	t.Run("Sum with named params", func(t *testing.T) {
+		response := single(t, `{"jsonrpc": "2.0", "method": "sum", "params": {"numbers": [1, 2, 3.5]}, "id": 1}`)
+		require.Nil(t, response.Error)
+		assert.JSONEq(t, `1`, string(response.ID))
+
+		var sum models.SumResponse
+		require.NoError(t, json.Unmarshal(response.Result, &sum))
+		assert.Equal(t, 6.5, sum.Sum)
+		assert.Equal(t, 3, sum.Count)
+	})
+
+	t.Run("Sum with positional params", func(t *testing.T) {
+		response := single(t, `{"jsonrpc": "2.0", "method": "sum", "params": [4, 5], "id": "a"}`)
+		require.Nil(t, response.Error)
+		assert.JSONEq(t, `"a"`, string(response.ID))
+
+		var sum models.SumResponse
+		require.NoError(t, json.Unmarshal(response.Result, &sum))
+		assert.Equal(t, 9.0, sum.Sum)
+	})
+
+	t.Run("Health", func(t *testing.T) {
+		response := single(t, `{"jsonrpc": "2.0", "method": "health", "id": null}`)
+		require.Nil(t, response.Error)
+		assert.JSONEq(t, `null`, string(response.ID))
+
+		var health models.HealthResponse
+		require.NoError(t, json.Unmarshal(response.Result, &health))
+		assert.Equal(t, "healthy", health.Status)
+		assert.Equal(t, "1.0.0", health.Version)
+	})
+
+	t.Run("Validation errors carry the service error code", func(t *testing.T) {
+		response := single(t, `{"jsonrpc": "2.0", "method": "sum", "params": {"numbers": [1]}, "id": 2}`)
+		require.NotNil(t, response.Error)
+		assert.Equal(t, models.RPCInvalidParams, response.Error.Code)
+		require.NotNil(t, response.Error.Data)
+		assert.Equal(t, "VALIDATION_ERROR", response.Error.Data.Code)
+		assert.Equal(t, http.StatusBadRequest, response.Error.Data.Status)
+		require.NotEmpty(t, response.Error.Data.Errors)
+		assert.Equal(t, "/params/numbers", response.Error.Data.Errors[0].Pointer)
+	})
+
+	t.Run("Errors are localized", func(t *testing.T) {
+		language = "fr"
+		defer func() { language = "en" }()
+
+		response := single(t, `{"jsonrpc": "2.0", "method": "nope", "id": 6}`)
+		require.NotNil(t, response.Error)
+		assert.Equal(t, `méthode "nope" introuvable`, response.Error.Message)
+
+		response = single(t, `{"jsonrpc": "1.0", "method": "sum", "id": 7}`)
+		require.NotNil(t, response.Error)
+		require.Len(t, response.Error.Data.Errors, 1)
+		assert.Equal(t, "/jsonrpc", response.Error.Data.Errors[0].Pointer)
+		assert.Equal(t, `jsonrpc doit valoir "2.0"`, response.Error.Data.Errors[0].Message)
+	})
+
+	t.Run("Params errors point into params", func(t *testing.T) {
+		response := single(t, `{"jsonrpc": "2.0", "method": "sum", "params": {"numbers": "x"}, "id": 8}`)
+		require.NotNil(t, response.Error)
+		assert.Equal(t, models.RPCInvalidParams, response.Error.Code)
+		require.NotEmpty(t, response.Error.Data.Errors)
+		assert.Equal(t, "/params/numbers", response.Error.Data.Errors[0].Pointer)
+
+		response = single(t, `{"jsonrpc": "2.0", "method": "sum", "params": [1], "id": 9}`)
+		require.NotNil(t, response.Error)
+		require.NotEmpty(t, response.Error.Data.Errors)
+		assert.Equal(t, "/params", response.Error.Data.Errors[0].Pointer)
+
+		response = single(t, `{"jsonrpc": "2.0", "method": "sum", "id": 10}`)
+		require.NotNil(t, response.Error)
+		require.Len(t, response.Error.Data.Errors, 1)
+		assert.Equal(t, "/params", response.Error.Data.Errors[0].Pointer)
+	})
+
+	t.Run("Budget exhaustion is a server error", func(t *testing.T) {
+		body := `{"jsonrpc": "2.0", "method": "sum", "params": {"numbers": [1, 2], "privacy": {"mechanism": "laplace", "epsilon": 0.6, "lower": 0, "upper": 10}}, "id": 3}`
+		require.Nil(t, single(t, body).Error)
+
+		response := single(t, body)
+		require.NotNil(t, response.Error)
+		assert.Equal(t, models.RPCServerError, response.Error.Code)
+		assert.Equal(t, models.CodePrivacyBudgetExhausted, response.Error.Data.Code)
+	})
+
+	t.Run("Unknown methods", func(t *testing.T) {
+		response := single(t, `{"jsonrpc": "2.0", "method": "nope", "id": 4}`)
+		require.NotNil(t, response.Error)
+		assert.Equal(t, models.RPCMethodNotFound, response.Error.Code)
+		assert.Equal(t, models.CodeMethodNotFound, response.Error.Data.Code)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		response := single(t, `{"jsonrpc": "1.0", "method": "sum", "id": 5}`)
+		assert.Equal(t, models.RPCInvalidRequest, response.Error.Code)
+		assert.JSONEq(t, `5`, string(response.ID))
+
+		response = single(t, `{"jsonrpc": "2.0", "method": "sum", "id": {"x": 1}}`)
+		assert.Equal(t, models.RPCInvalidRequest, response.Error.Code)
+		assert.JSONEq(t, `null`, string(response.ID))
+
+		response = single(t, `1`)
+		assert.Equal(t, models.RPCInvalidRequest, response.Error.Code)
+	})
+
+	t.Run("Parse errors", func(t *testing.T) {
+		response := single(t, `{"jsonrpc": "2.0", "method"`)
+		require.NotNil(t, response.Error)
+		assert.Equal(t, models.RPCParseError, response.Error.Code)
+		assert.JSONEq(t, `null`, string(response.ID))
+	})
+
+	t.Run("Oversized bodies are refused with 413", func(t *testing.T) {
+		models.SetLimits(models.Limits{models.LimitRequestMaxBytes: 16})
+		defer models.SetLimits(nil)
+
+		w := call(`{"jsonrpc": "2.0", "method": "sum", "params": [1, 2], "id": 1}`)
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+		var response rpcResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.Error)
+		assert.Equal(t, models.RPCInvalidRequest, response.Error.Code)
+	})
+
+	t.Run("Notifications get no response", func(t *testing.T) {
+		w := call(`{"jsonrpc": "2.0", "method": "sum", "params": [1, 2]}`)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+
+		w = call(`[{"jsonrpc": "2.0", "method": "health"}, {"jsonrpc": "2.0", "method": "unknown"}]`)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Private sum notifications are refused without spending budget", func(t *testing.T) {
+		before := handler.sum.accountant.Remaining("analyst")
+		w := call(`{"jsonrpc": "2.0", "method": "sum", "params": {"numbers": [1, 2], "privacy": {"mechanism": "laplace", "epsilon": 0.1, "lower": 0, "upper": 10}}}`)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, before, handler.sum.accountant.Remaining("analyst"))
+	})
+
+	t.Run("Batches answer every call that is not a notification", func(t *testing.T) {
+		w := call(`[
+			{"jsonrpc": "2.0", "method": "sum", "params": [1, 2], "id": 1},
+			{"jsonrpc": "2.0", "method": "health"},
+			{"jsonrpc": "2.0", "method": "nope", "id": 2}
+		]`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var responses []rpcResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responses))
+		require.Len(t, responses, 2)
+		assert.JSONEq(t, `1`, string(responses[0].ID))
+		assert.Nil(t, responses[0].Error)
+		assert.JSONEq(t, `2`, string(responses[1].ID))
+		assert.Equal(t, models.RPCMethodNotFound, responses[1].Error.Code)
+	})
+
+	t.Run("Empty and oversized batches are invalid", func(t *testing.T) {
+		response := single(t, `[]`)
+		assert.Equal(t, models.RPCInvalidRequest, response.Error.Code)
+
+		response = single(t, `[{}, {}, {}, {}]`)
+		assert.Equal(t, models.RPCInvalidRequest, response.Error.Code)
+		assert.Equal(t, models.CodeBatchTooLarge, response.Error.Data.Code)
+	})
+}
@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/stream"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// streamWriteWait bounds every write to a streaming client
+const streamWriteWait = 10 * time.Second
+
+// StreamHandler serves running-sum streams over WebSocket and Server-Sent Events
+// Both transports drive the same stream.Session
+type StreamHandler struct {
+	logger   *logger.Logger
+	config   config.StreamConfig
+	manager  *stream.Manager
+	upgrader websocket.Upgrader
+}
+
+// NewStreamHandler creates a new stream handler
+func NewStreamHandler(logger *logger.Logger, cfg config.StreamConfig, manager *stream.Manager) *StreamHandler {
+	h := &StreamHandler{
+		logger:  logger,
+		config:  cfg,
+		manager: manager,
+	}
+	h.upgrader = websocket.Upgrader{
+		HandshakeTimeout: streamWriteWait,
+		CheckOrigin:      h.checkOrigin,
+	}
+	return h
+}
+
+// HandleWebSocket handles GET /api/v1/stream/sum/ws requests
+// Clients send {"numbers": [...]} messages and receive the running state after each one
+func (h *StreamHandler) HandleWebSocket(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		h.reject(c, reqID, "upgrade", http.StatusBadRequest, errors.New("a WebSocket upgrade is required"), models.CodeInvalidRequest)
+		return
+	}
+
+	session, err := h.manager.Open(middleware.GetConsumer(c), stream.TransportWebSocket)
+	if err != nil {
+		statusCode, code, reported := streamErrorStatus(err, "")
+		h.reject(c, reqID, "open_stream", statusCode, reported, code)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already answered the request
+		h.logger.WithError(err).WithFields(map[string]interface{}{
+			"component":  "stream_handler",
+			"operation":  "upgrade",
+			"request_id": reqID,
+		}).Warn("WebSocket upgrade failed")
+		h.manager.Release(session, stream.ReasonClientClosed)
+		return
+	}
+	defer conn.Close()
+	defer h.manager.Release(session, stream.ReasonClientClosed)
+
+	h.serveWebSocket(conn, session)
+}
+
+// serveWebSocket forwards updates and keepalive pings until the session or the connection ends
+// Reads happen on a separate goroutine; all writes but pings happen here
+func (h *StreamHandler) serveWebSocket(conn *websocket.Conn, session *stream.Session) {
+	pongWait := 2 * h.config.PingInterval
+	conn.SetReadLimit(h.config.MaxMessageBytes)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	refusals := make(chan *models.StreamErrorMessage)
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		h.readWebSocket(conn, session, pongWait, refusals)
+	}()
+
+	ticker := time.NewTicker(h.config.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case update := <-session.Updates():
+			err = writeWebSocketJSON(conn, models.NewStreamUpdate(session.ID, update))
+		case refusal := <-refusals:
+			err = writeWebSocketJSON(conn, refusal)
+		case <-ticker.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait))
+		case <-readDone:
+			session.Close(stream.ReasonClientClosed)
+			return
+		case <-session.Done():
+			reason := session.Reason()
+			closeCode := websocket.CloseNormalClosure
+			switch reason {
+			case stream.ReasonShutdown:
+				closeCode = websocket.CloseGoingAway
+			case stream.ReasonMessageLimit:
+				closeCode = websocket.ClosePolicyViolation
+			}
+			_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, reason), time.Now().Add(streamWriteWait))
+			return
+		}
+		if err != nil {
+			session.Close(stream.ReasonClientClosed)
+			return
+		}
+	}
+}
+
+// readWebSocket pushes the numbers of each message into the session
+// Malformed or refused messages are reported to the client without closing the stream
+func (h *StreamHandler) readWebSocket(conn *websocket.Conn, session *stream.Session, pongWait time.Duration, refusals chan<- *models.StreamErrorMessage) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		var request models.StreamPushRequest
+		var refusal error
+		code := models.CodeInvalidRequestBody
+		if err := json.Unmarshal(data, &request); err != nil {
+			refusal = err
+		} else if _, err := session.Push(request.Numbers); err != nil {
+			if errors.Is(err, stream.ErrSessionClosed) || errors.Is(err, stream.ErrMessageLimit) {
+				return
+			}
+			refusal = err
+			_, code, _ = streamErrorStatus(err, session.ID)
+		}
+
+		if refusal != nil {
+			select {
+			case refusals <- models.NewStreamErrorMessage(refusal, code):
+			case <-session.Done():
+				return
+			}
+		}
+	}
+}
+
+// HandleEvents handles GET /api/v1/stream/sum/events requests
+// The first event names the URL that numbers are posted to; an update event follows each push
+func (h *StreamHandler) HandleEvents(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	session, err := h.manager.Open(middleware.GetConsumer(c), stream.TransportSSE)
+	if err != nil {
+		statusCode, code, reported := streamErrorStatus(err, "")
+		h.reject(c, reqID, "open_stream", statusCode, reported, code)
+		return
+	}
+	defer h.manager.Release(session, stream.ReasonClientClosed)
+
+	// The stream outlives the server's write timeout; each write sets its own deadline
+	controller := http.NewResponseController(c.Writer)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-store")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	send := func(event string, v interface{}) error {
+		err := controller.SetWriteDeadline(time.Now().Add(streamWriteWait))
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		if event == "" {
+			_, err = fmt.Fprint(c.Writer, ": ping\n\n")
+		} else {
+			var data []byte
+			if data, err = json.Marshal(v); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, data)
+		}
+		if err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	pushURL := "/api/v1/stream/sum/" + url.PathEscape(session.ID)
+	if err := send(models.StreamMessageSession, models.NewStreamSessionMessage(session, pushURL)); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(h.config.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case update := <-session.Updates():
+			err = send(models.StreamMessageUpdate, models.NewStreamUpdate(session.ID, update))
+		case <-ticker.C:
+			err = send("", nil)
+		case <-c.Request.Context().Done():
+			return
+		case <-session.Done():
+			_ = send(models.StreamMessageClose, &models.StreamCloseMessage{Type: models.StreamMessageClose, Reason: session.Reason()})
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// HandlePush handles POST /api/v1/stream/sum/:id requests
+// It pushes numbers into an SSE stream of the same consumer and returns the new state
+func (h *StreamHandler) HandlePush(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	session, err := h.manager.Get(c.Param("id"), middleware.GetConsumer(c))
+	if err != nil {
+		statusCode, code, reported := streamErrorStatus(err, c.Param("id"))
+		h.reject(c, reqID, "get_stream", statusCode, reported, code)
+		return
+	}
+
+	var request models.StreamPushRequest
+	if statusCode, code, err := bindJSON(c, &request); err != nil {
+		h.reject(c, reqID, "bind_request", statusCode, err, code)
+		return
+	}
+
+	update, err := session.Push(request.Numbers)
+	if err != nil {
+		statusCode, code, reported := streamErrorStatus(err, session.ID)
+		h.reject(c, reqID, "push", statusCode, reported, code)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewStreamUpdate(session.ID, update))
+}
+
+// checkOrigin accepts same-origin WebSocket handshakes and those from configured origins
+func (h *StreamHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range h.config.AllowedOrigins {
+		if origin == allowed {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// reject logs a failed stream request and writes the error response
+func (h *StreamHandler) reject(c *gin.Context, reqID, operation string, statusCode int, err error, code string) {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "stream_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"consumer":   middleware.GetConsumer(c),
+		"code":       code,
+	}).Error("Stream request failed")
+
+	middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+}
+
+// writeWebSocketJSON writes one JSON message within the write deadline
+func writeWebSocketJSON(conn *websocket.Conn, v interface{}) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(v)
+}
+
+// streamErrorStatus maps stream errors to HTTP status codes, error codes and reported errors
+func streamErrorStatus(err error, streamID string) (int, string, error) {
+	var statusCode int
+	var code string
+	switch {
+	case errors.Is(err, stream.ErrSessionNotFound):
+		return http.StatusNotFound, models.CodeStreamNotFound, models.NewAPIError(models.CodeStreamNotFound, nil).WithParam("stream_id", streamID)
+	case errors.Is(err, stream.ErrSessionClosed):
+		statusCode, code = http.StatusConflict, models.CodeStreamClosed
+	case errors.Is(err, stream.ErrConsumerLimit):
+		statusCode, code = http.StatusTooManyRequests, models.CodeStreamLimitReached
+	case errors.Is(err, stream.ErrConnectionLimit):
+		statusCode, code = http.StatusServiceUnavailable, models.CodeStreamLimitReached
+	case errors.Is(err, stream.ErrMessageLimit):
+		statusCode, code = http.StatusTooManyRequests, models.CodeStreamMessageLimit
+	case errors.Is(err, stream.ErrInvalidMessage):
+		return http.StatusBadRequest, models.CodeValidation, err
+	case errors.Is(err, stream.ErrShuttingDown):
+		statusCode, code = http.StatusServiceUnavailable, models.CodeShuttingDown
+	default:
+		return http.StatusInternalServerError, models.CodeInternal, err
+	}
+	return statusCode, code, models.NewAPIError(code, nil)
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestStreamServer(t *testing.T) (*httptest.Server, *stream.Manager) {
+	log := setupTestLogger()
+	cfg := config.StreamConfig{
+		MaxPerConsumer:  2,
+		MaxMessages:     3,
+		MaxNumbers:      10,
+		MaxMessageBytes: 4096,
+		PingInterval:    time.Minute,
+	}
+	manager := stream.NewManager(log, stream.Options{
+		MaxPerConsumer: cfg.MaxPerConsumer,
+		MaxMessages:    cfg.MaxMessages,
+		MaxNumbers:     cfg.MaxNumbers,
+	})
+	handler := NewStreamHandler(log, cfg, manager)
+
+	router := setupTestRouter()
+	router.Use(middleware.ConsumerMiddleware())
+	router.GET("/api/v1/stream/sum/ws", handler.HandleWebSocket)
+	router.GET("/api/v1/stream/sum/events", handler.HandleEvents)
+	router.POST("/api/v1/stream/sum/:id", handler.HandlePush)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, manager
+}
+
+// readEvent reads the next named Server-Sent Event, skipping comments
+func readEvent(t *testing.T, reader *bufio.Reader) (string, []byte) {
+	var event string
+	var data []byte
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+
+		switch {
+		case line == "" && event != "":
+			return event, data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = []byte(strings.TrimPrefix(line, "data: "))
+		}
+	}
+}
+
+// TestStreamHandler_WebSocket tests running sums over WebSocket
+func TestStreamHandler_WebSocket(t *testing.T) {
+	server, manager := setupTestStreamServer(t)
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/stream/sum/ws"
+
+	header := http.Header{"X-Consumer-Username": []string{"dashboard"}}
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var update models.StreamUpdate
+	require.NoError(t, conn.WriteJSON(models.StreamPushRequest{Numbers: []float64{1, 2, 3}}))
+	require.NoError(t, conn.ReadJSON(&update))
+	assert.Equal(t, models.StreamMessageUpdate, update.Type)
+	assert.Equal(t, 6.0, update.Sum)
+	assert.Equal(t, 3, update.Count)
+	assert.Equal(t, 2.0, update.Mean)
+
+	t.Run("Refused messages keep the stream open", func(t *testing.T) {
+		var refusal models.StreamErrorMessage
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"numbers": "nope"}`)))
+		require.NoError(t, conn.ReadJSON(&refusal))
+		assert.Equal(t, models.StreamMessageError, refusal.Type)
+		assert.Equal(t, "INVALID_REQUEST_BODY", refusal.Code)
+
+		require.NoError(t, conn.WriteJSON(models.StreamPushRequest{Numbers: make([]float64, 11)}))
+		require.NoError(t, conn.ReadJSON(&refusal))
+		assert.Equal(t, "VALIDATION_ERROR", refusal.Code)
+
+		require.NoError(t, conn.WriteJSON(models.StreamPushRequest{Numbers: []float64{1e308, 1e308}}))
+		require.NoError(t, conn.ReadJSON(&refusal))
+		assert.Equal(t, "VALIDATION_ERROR", refusal.Code)
+
+		require.NoError(t, conn.WriteJSON(models.StreamPushRequest{Numbers: []float64{4}}))
+		require.NoError(t, conn.ReadJSON(&update))
+		assert.Equal(t, 10.0, update.Sum)
+		assert.Equal(t, 2, update.Messages)
+	})
+
+	t.Run("Closing the manager ends the connection", func(t *testing.T) {
+		manager.Close()
+
+		_, _, err := conn.ReadMessage()
+		var closeErr *websocket.CloseError
+		require.ErrorAs(t, err, &closeErr)
+		assert.Equal(t, websocket.CloseGoingAway, closeErr.Code)
+		assert.Equal(t, stream.ReasonShutdown, closeErr.Text)
+
+		assert.Eventually(t, func() bool { return manager.Len() == 0 }, time.Second, 10*time.Millisecond)
+	})
+}
+
+// TestStreamHandler_WebSocketLimits tests the per-consumer connection limit
+func TestStreamHandler_WebSocketLimits(t *testing.T) {
+	server, _ := setupTestStreamServer(t)
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/stream/sum/ws"
+	header := http.Header{"X-Consumer-Username": []string{"dashboard"}}
+
+	for i := 0; i < 2; i++ {
+		conn, _, err := websocket.DefaultDialer.Dial(url, header)
+		require.NoError(t, err)
+		defer conn.Close()
+	}
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, header)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	// Plain requests are not upgraded
+	resp, err = http.Get(server.URL + "/api/v1/stream/sum/ws")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestStreamHandler_SSE tests running sums over Server-Sent Events
+func TestStreamHandler_SSE(t *testing.T) {
+	server, _ := setupTestStreamServer(t)
+
+	req, _ := http.NewRequest("GET", server.URL+"/api/v1/stream/sum/events", nil)
+	req.Header.Set("X-Consumer-Username", "dashboard")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	event, data := readEvent(t, reader)
+	require.Equal(t, models.StreamMessageSession, event)
+
+	var session models.StreamSessionMessage
+	require.NoError(t, json.Unmarshal(data, &session))
+	require.NotEmpty(t, session.PushURL)
+
+	push := func(consumer, body string) *http.Response {
+		req, _ := http.NewRequest("POST", server.URL+session.PushURL, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Consumer-Username", consumer)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp = push("dashboard", `{"numbers": [2, 4]}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	event, data = readEvent(t, reader)
+	require.Equal(t, models.StreamMessageUpdate, event)
+	var update models.StreamUpdate
+	require.NoError(t, json.Unmarshal(data, &update))
+	assert.Equal(t, 6.0, update.Sum)
+	assert.Equal(t, 3.0, update.Mean)
+
+	t.Run("Other consumers cannot push", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, push("intruder", `{"numbers": [1]}`).StatusCode)
+	})
+
+	t.Run("Invalid pushes are refused", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, push("dashboard", `{"numbers": []}`).StatusCode)
+		assert.Equal(t, http.StatusBadRequest, push("dashboard", `{"numbers": [1e308, 1e308]}`).StatusCode)
+	})
+
+	t.Run("The message limit closes the stream", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, push("dashboard", `{"numbers": [1]}`).StatusCode)
+		assert.Equal(t, http.StatusOK, push("dashboard", `{"numbers": [1]}`).StatusCode)
+		assert.Equal(t, http.StatusTooManyRequests, push("dashboard", `{"numbers": [1]}`).StatusCode)
+
+		for {
+			event, data = readEvent(t, reader)
+			if event != models.StreamMessageUpdate {
+				break
+			}
+		}
+		assert.Equal(t, models.StreamMessageClose, event)
+		assert.Contains(t, string(data), stream.ReasonMessageLimit)
+	})
+}
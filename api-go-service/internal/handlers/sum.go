@@ -1,23 +1,28 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/katvio/api-go-service/internal/middleware"
 	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/privacy"
 	"github.com/katvio/api-go-service/pkg/logger"
 )
 
 // SumHandler handles sum calculation requests
 type SumHandler struct {
-	logger *logger.Logger
+	logger     *logger.Logger
+	accountant *privacy.Accountant
 }
 
 // NewSumHandler creates a new sum handler
-func NewSumHandler(logger *logger.Logger) *SumHandler {
+// The accountant tracks the privacy budget spent by differentially private sums
+func NewSumHandler(logger *logger.Logger, accountant *privacy.Accountant) *SumHandler {
 	return &SumHandler{
-		logger: logger,
+		logger:     logger,
+		accountant: accountant,
 	}
 }
 
@@ -26,42 +31,63 @@ func (s *SumHandler) HandleSum(c *gin.Context) {
 	requestID, _ := c.Get(middleware.RequestIDKey)
 	reqID, _ := requestID.(string)
 
-	// Parse request body
-	var request models.SumRequest
-	if err := c.ShouldBindJSON(&request); err != nil {
+	// Select the response format before doing any work
+	if err := negotiate(c); err != nil {
 		s.logger.WithError(err).WithFields(map[string]interface{}{
 			"component":  "sum_handler",
-			"operation":  "bind_request",
+			"operation":  "negotiate",
 			"request_id": reqID,
+			"accept":     c.GetHeader("Accept"),
+		}).Error("No acceptable response format")
+
+		middleware.Fail(c, models.NewAPIError(models.CodeNotAcceptable, err))
+		return
+	}
+
+	// Parse request body in the format named by Content-Type
+	var request models.SumRequest
+	if statusCode, code, err := bindNegotiated(c, &request); err != nil {
+		s.logger.WithError(err).WithFields(map[string]interface{}{
+			"component":    "sum_handler",
+			"operation":    "bind_request",
+			"request_id":   reqID,
+			"content_type": c.ContentType(),
 		}).Error("Failed to bind request")
 
-		errorResponse := models.NewErrorResponse(
-			err,
-			"INVALID_REQUEST_BODY",
-			c.Request.URL.Path,
-			reqID,
-		)
-		c.JSON(http.StatusBadRequest, errorResponse)
+		middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
 		return
 	}
 
+	response, statusCode, code, err := s.calculate(&request, middleware.GetConsumer(c), reqID)
+	if err != nil {
+		middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+		return
+	}
+
+	// Every private response carries fresh noise and must not be replayed from a cache
+	if response.Privacy != nil {
+		c.Header("Cache-Control", "no-store")
+	}
+	respond(c, http.StatusOK, response)
+}
+
+// calculate validates a sum request and computes its response
+// It is shared by the REST and JSON-RPC endpoints and returns the status and error code to report on failure
+func (s *SumHandler) calculate(request *models.SumRequest, consumer, reqID string) (*models.SumResponse, int, string, error) {
 	// Validate request
 	if err := request.Validate(); err != nil {
 		s.logger.WithError(err).WithFields(map[string]interface{}{
-			"component":  "sum_handler",
-			"operation":  "validate_request",
-			"request_id": reqID,
-			"numbers":    request.Numbers,
+			"component":    "sum_handler",
+			"operation":    "validate_request",
+			"request_id":   reqID,
+			"number_count": len(request.Numbers),
 		}).Error("Request validation failed")
 
-		errorResponse := models.NewErrorResponse(
-			err,
-			"VALIDATION_ERROR",
-			c.Request.URL.Path,
-			reqID,
-		)
-		c.JSON(http.StatusBadRequest, errorResponse)
-		return
+		return nil, http.StatusBadRequest, models.CodeValidation, err
+	}
+
+	if request.Privacy != nil {
+		return s.privateSum(request, consumer, reqID)
 	}
 
 	// Log the operation
@@ -84,39 +110,79 @@ func (s *SumHandler) HandleSum(c *gin.Context) {
 		"count":      response.Count,
 	}).Info("Sum calculation completed")
 
-	c.JSON(http.StatusOK, response)
+	return response, http.StatusOK, "", nil
 }
 
-// HandleSumGet handles GET /api/v1/sum requests (for testing/demo purposes)
-func (s *SumHandler) HandleSumGet(c *gin.Context) {
-	requestID, _ := c.Get(middleware.RequestIDKey)
-	reqID, _ := requestID.(string)
+// privateSum answers a sum request with calibrated noise and charges the caller's privacy budget
+// Anonymous callers would all draw on one budget, so they are refused.
+// Neither the submitted numbers nor the exact sum are logged
+func (s *SumHandler) privateSum(request *models.SumRequest, consumer, reqID string) (*models.SumResponse, int, string, error) {
+	if err := request.Privacy.Validate(); err != nil {
+		return s.rejectPrivate(reqID, consumer, "validate_privacy", http.StatusBadRequest, err, models.ErrorCode(err, models.CodeValidation))
+	}
 
-	// Return information about the sum endpoint
-	info := map[string]interface{}{
-		"endpoint":    "/api/v1/sum",
-		"method":      "POST",
-		"description": "Calculate the sum of an array of numbers",
-		"request_format": map[string]interface{}{
-			"numbers": "array of numbers (min: 2, max: 100)",
-		},
-		"example_request": map[string]interface{}{
-			"numbers": []float64{1.5, 2.5, 3.0},
-		},
-		"example_response": map[string]interface{}{
-			"sum":       7.0,
-			"count":     3,
-			"numbers":   []float64{1.5, 2.5, 3.0},
-			"timestamp": "2024-01-01T00:00:00Z",
-		},
-		"request_id": reqID,
+	if consumer == middleware.AnonymousConsumer {
+		err := models.NewAPIError(models.CodeConsumerRequired, nil)
+		return s.rejectPrivate(reqID, consumer, "check_consumer", http.StatusForbidden, err, models.CodeConsumerRequired)
+	}
+
+	params := request.Privacy.SumParams()
+
+	// Budget is charged before any noise is drawn so concurrent requests cannot overspend
+	remaining, err := s.accountant.Spend(consumer, params.Epsilon)
+	if err != nil {
+		statusCode, code := http.StatusInternalServerError, models.CodeInternal
+		if errors.Is(err, privacy.ErrBudgetExhausted) {
+			statusCode, code = http.StatusForbidden, models.CodePrivacyBudgetExhausted
+		}
+		return s.rejectPrivate(reqID, consumer, "spend_budget", statusCode, err, code)
+	}
+
+	// Nothing is released when the sum fails, so the query is not charged
+	result, err := privacy.Sum(request.Numbers, params)
+	if err != nil {
+		if refundErr := s.accountant.Refund(consumer, params.Epsilon); refundErr != nil {
+			err = errors.Join(err, refundErr)
+		}
+		if errors.Is(err, privacy.ErrNotFinite) {
+			return s.rejectPrivate(reqID, consumer, "add_noise", http.StatusUnprocessableEntity, models.NewAPIError(models.CodeResultOverflow, nil), models.CodeResultOverflow)
+		}
+		return s.rejectPrivate(reqID, consumer, "add_noise", http.StatusInternalServerError, err, models.CodeInternal)
 	}
 
 	s.logger.WithFields(map[string]interface{}{
+		"component":        "sum_handler",
+		"operation":        "private_sum_calculated",
+		"request_id":       reqID,
+		"consumer":         consumer,
+		"mechanism":        params.Mechanism,
+		"epsilon":          params.Epsilon,
+		"count":            len(request.Numbers),
+		"budget_remaining": remaining,
+	}).Info("Differentially private sum completed")
+
+	return models.NewPrivateSumResponse(len(request.Numbers), params, result, remaining, reqID), http.StatusOK, "", nil
+}
+
+// HandlePrivacyBudget handles GET /api/v1/privacy/budget requests
+func (s *SumHandler) HandlePrivacyBudget(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	consumer := middleware.GetConsumer(c)
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, models.NewPrivacyBudgetResponse(consumer, s.accountant.Budget(), s.accountant.Remaining(consumer), reqID))
+}
+
+// rejectPrivate logs a failed differentially private sum and returns its status and error code
+func (s *SumHandler) rejectPrivate(reqID, consumer, operation string, statusCode int, err error, code string) (*models.SumResponse, int, string, error) {
+	s.logger.WithError(err).WithFields(map[string]interface{}{
 		"component":  "sum_handler",
-		"operation":  "get_info",
+		"operation":  operation,
 		"request_id": reqID,
-	}).Info("Sum endpoint info requested")
+		"consumer":   consumer,
+		"code":       code,
+	}).Error("Differentially private sum failed")
 
-	c.JSON(http.StatusOK, info)
+	return nil, statusCode, code, err
 }
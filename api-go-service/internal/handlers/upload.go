@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/ingest"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// uploadFieldLimit bounds the size of a form field other than the file
+const uploadFieldLimit = 4096
+
+// UploadHandler sums the columns of uploaded CSV and JSON Lines files
+type UploadHandler struct {
+	logger *logger.Logger
+	config config.UploadConfig
+}
+
+// NewUploadHandler creates a new upload handler
+func NewUploadHandler(logger *logger.Logger, cfg config.UploadConfig) *UploadHandler {
+	return &UploadHandler{
+		logger: logger,
+		config: cfg,
+	}
+}
+
+// HandleSumUpload handles POST /api/v1/sum/upload requests
+// The multipart body is parsed as it arrives: parameters are read from the query string
+// and from form fields preceding the "file" part, which is streamed through the parser
+func (h *UploadHandler) HandleSumUpload(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	if c.ContentType() != "multipart/form-data" {
+		err := fmt.Errorf("content type %q is not supported; send multipart/form-data", c.ContentType())
+		h.reject(c, reqID, "read_upload", http.StatusUnsupportedMediaType, err, models.CodeUnsupportedMediaType)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.config.MaxBytes)
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		h.reject(c, reqID, "read_upload", http.StatusBadRequest, err, models.CodeInvalidRequestBody)
+		return
+	}
+
+	var params models.UploadParams
+	for name, values := range c.Request.URL.Query() {
+		params.Set(name, values[0])
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			err := errors.New(`a file field named "file" is required`)
+			h.reject(c, reqID, "read_upload", http.StatusBadRequest, err, models.CodeInvalidUpload)
+			return
+		}
+		if err != nil {
+			statusCode, code, reported := uploadErrorStatus(err)
+			h.reject(c, reqID, "read_upload", statusCode, reported, code)
+			return
+		}
+
+		if part.FormName() == "file" {
+			h.sum(c, reqID, part, &params)
+			return
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, uploadFieldLimit+1))
+		if err != nil {
+			statusCode, code, reported := uploadErrorStatus(err)
+			h.reject(c, reqID, "read_upload", statusCode, reported, code)
+			return
+		}
+		if len(value) > uploadFieldLimit {
+			err := fmt.Errorf("form field %q is longer than %d bytes", part.FormName(), uploadFieldLimit)
+			h.reject(c, reqID, "read_upload", http.StatusBadRequest, err, models.CodeInvalidUpload)
+			return
+		}
+		params.Set(part.FormName(), string(value))
+	}
+}
+
+// sum parses the file part and writes the summary
+func (h *UploadHandler) sum(c *gin.Context, reqID string, part *multipart.Part, params *models.UploadParams) {
+	format, opts, err := params.Options(part.FileName())
+	if err != nil {
+		h.reject(c, reqID, "validate_params", http.StatusBadRequest, err, models.CodeInvalidUpload)
+		return
+	}
+	opts.MaxColumns = h.config.MaxColumns
+	opts.MaxErrors = h.config.MaxErrors
+	opts.MaxLineBytes = h.config.MaxLineBytes
+
+	var summary *ingest.Summary
+	if format == ingest.FormatJSONL {
+		summary, err = ingest.SumJSONL(part, opts)
+	} else {
+		summary, err = ingest.SumCSV(part, opts)
+	}
+	if err != nil {
+		statusCode, code, reported := uploadErrorStatus(err)
+		h.reject(c, reqID, "parse_file", statusCode, reported, code)
+		return
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"component":   "upload_handler",
+		"operation":   "sum_upload",
+		"request_id":  reqID,
+		"format":      format,
+		"rows":        summary.Rows,
+		"skipped":     summary.Skipped,
+		"columns":     len(summary.Columns),
+		"error_count": summary.ErrorCount,
+	}).Info("Upload summed")
+
+	c.JSON(http.StatusOK, models.NewUploadSumResponse(part.FileName(), format, summary, reqID))
+}
+
+// reject logs a failed upload and writes the error response
+func (h *UploadHandler) reject(c *gin.Context, reqID, operation string, statusCode int, err error, code string) {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "upload_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"consumer":   middleware.GetConsumer(c),
+		"code":       code,
+	}).Error("Upload request failed")
+
+	middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+}
+
+// uploadErrorStatus maps upload errors to HTTP status codes, error codes and reported errors
+func uploadErrorStatus(err error) (int, string, error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return http.StatusRequestEntityTooLarge, models.CodeRequestTooLarge, models.NewAPIError(models.CodeRequestTooLarge, nil)
+	case errors.Is(err, ingest.ErrInvalidOptions):
+		return http.StatusBadRequest, models.CodeInvalidUpload, err
+	case errors.Is(err, ingest.ErrInvalidFile):
+		return http.StatusBadRequest, models.CodeInvalidFile, err
+	case errors.Is(err, ingest.ErrOverflow):
+		return http.StatusUnprocessableEntity, models.CodeResultOverflow, models.NewAPIError(models.CodeResultOverflow, nil)
+	default:
+		return http.StatusBadRequest, models.CodeInvalidRequestBody, err
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestUploadRouter() *gin.Engine {
+	handler := NewUploadHandler(setupTestLogger(), config.UploadConfig{
+		MaxBytes:     1024,
+		MaxColumns:   5,
+		MaxErrors:    10,
+		MaxLineBytes: 256,
+	})
+
+	router := setupTestRouter()
+	router.POST("/api/v1/sum/upload", handler.HandleSumUpload)
+	return router
+}
+
+// uploadRequest sends fields, in order, followed by a file part
+func uploadRequest(router *gin.Engine, query string, fields [][2]string, filename, content string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, field := range fields {
+		_ = writer.WriteField(field[0], field[1])
+	}
+	if filename != "" {
+		part, _ := writer.CreateFormFile("file", filename)
+		_, _ = part.Write([]byte(content))
+	}
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", "/api/v1/sum/upload"+query, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestUploadHandler_SumUpload tests column totals of uploaded files
+func TestUploadHandler_SumUpload(t *testing.T) {
+	router := setupTestUploadRouter()
+
+	t.Run("CSV with form parameters", func(t *testing.T) {
+		fields := [][2]string{{"delimiter", ";"}, {"decimal", ","}, {"columns", "amount"}}
+		w := uploadRequest(router, "", fields, "export.csv", "name;amount\na;1,5\nb;oops\nc;2,5\n")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response models.UploadSumResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "export.csv", response.File)
+		assert.Equal(t, "csv", response.Format)
+		assert.Equal(t, 2, response.Rows)
+		assert.Equal(t, 1, response.Skipped)
+		require.Len(t, response.Columns, 1)
+		assert.Equal(t, 4.0, response.Columns[0].Sum)
+		require.NotNil(t, response.Columns[0].Mean)
+		assert.Equal(t, 2.0, *response.Columns[0].Mean)
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, 3, response.Errors[0].Line)
+		assert.Equal(t, "amount", response.Errors[0].Column)
+	})
+
+	t.Run("JSON Lines detected from the extension", func(t *testing.T) {
+		w := uploadRequest(router, "", nil, "events.jsonl", "{\"v\": 1}\n{\"v\": 2.5}\n")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response models.UploadSumResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "jsonl", response.Format)
+		assert.Equal(t, 3.5, response.Columns[0].Sum)
+		assert.Empty(t, response.Errors)
+	})
+
+	t.Run("Query parameters", func(t *testing.T) {
+		w := uploadRequest(router, "?header=false&columns=2", nil, "data.txt", "a,1\nb,2\n")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"name":"2"`)
+	})
+}
+
+// TestUploadHandler_Errors tests refused uploads
+func TestUploadHandler_Errors(t *testing.T) {
+	router := setupTestUploadRouter()
+
+	tests := []struct {
+		name     string
+		query    string
+		fields   [][2]string
+		filename string
+		content  string
+		status   int
+		code     string
+	}{
+		{"No file", "", [][2]string{{"columns", "a"}}, "", "", http.StatusBadRequest, models.CodeInvalidUpload},
+		{"Unknown format", "?format=xlsx", nil, "data.csv", "a\n1\n", http.StatusBadRequest, models.CodeInvalidUpload},
+		{"Multi-character delimiter", "", [][2]string{{"delimiter", "::"}}, "data.csv", "a\n1\n", http.StatusBadRequest, models.CodeInvalidUpload},
+		{"Same delimiter and decimal", "?decimal=,", nil, "data.csv", "a\n1\n", http.StatusBadRequest, models.CodeInvalidUpload},
+		{"Unknown column", "?columns=b", nil, "data.csv", "a\n1\n", http.StatusBadRequest, models.CodeInvalidFile},
+		{"No numeric column", "", nil, "data.csv", "a\nx\n", http.StatusBadRequest, models.CodeInvalidFile},
+		{"Line too long", "", nil, "data.csv", "a\n\"" + strings.Repeat("1", 300) + "\"\n", http.StatusBadRequest, models.CodeInvalidFile},
+		{"Sum overflow", "", nil, "data.csv", "a\n1e308\n1e308\n", http.StatusUnprocessableEntity, models.CodeResultOverflow},
+		{"Too large", "", nil, "data.csv", "a\n" + strings.Repeat("1\n", 1024), http.StatusRequestEntityTooLarge, models.CodeRequestTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := uploadRequest(router, tt.query, tt.fields, tt.filename, tt.content)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.code)
+		})
+	}
+
+	t.Run("Not multipart", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/api/v1/sum/upload", strings.NewReader("a\n1\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+}
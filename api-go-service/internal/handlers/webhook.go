@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/webhook"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// WebhookHandler exposes the callback signing secrets and the callbacks that could not be delivered
+type WebhookHandler struct {
+	logger     *logger.Logger
+	dispatcher *webhook.Dispatcher
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(logger *logger.Logger, dispatcher *webhook.Dispatcher) *WebhookHandler {
+	return &WebhookHandler{
+		logger:     logger,
+		dispatcher: dispatcher,
+	}
+}
+
+// HandleGetSecret handles GET /api/v1/webhooks/secret requests
+// Each consumer receives the secret its own callbacks are signed with; anonymous
+// callers would all share one secret, so they receive none
+func (h *WebhookHandler) HandleGetSecret(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	if !h.dispatcher.Enabled() {
+		err := errors.New("callbacks are not enabled on this service")
+		h.logger.WithError(err).WithFields(map[string]interface{}{
+			"component":  "webhook_handler",
+			"operation":  "get_secret",
+			"request_id": reqID,
+		}).Error("Webhook secret lookup failed")
+
+		middleware.Fail(c, models.NewAPIError(models.CodeCallbacksDisabled, err))
+		return
+	}
+
+	consumer := middleware.GetConsumer(c)
+	if consumer == middleware.AnonymousConsumer {
+		err := models.NewAPIError(models.CodeConsumerRequired, nil)
+		h.logger.WithError(err).WithFields(map[string]interface{}{
+			"component":  "webhook_handler",
+			"operation":  "get_secret",
+			"request_id": reqID,
+		}).Error("Webhook secret lookup failed")
+
+		middleware.Fail(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, models.NewWebhookSecretResponse(consumer, h.dispatcher.Secret(consumer), reqID))
+}
+
+// HandleListDeadLetters handles GET /api/v1/webhooks/dead-letters requests
+func (h *WebhookHandler) HandleListDeadLetters(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	letters := h.dispatcher.DeadLetters(middleware.GetConsumer(c))
+	c.JSON(http.StatusOK, models.NewDeadLetterListResponse(letters, reqID))
+}
+
+// HandleGetDeadLetter handles GET /api/v1/webhooks/dead-letters/:id requests
+func (h *WebhookHandler) HandleGetDeadLetter(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	letter, err := h.dispatcher.DeadLetter(middleware.GetConsumer(c), c.Param("id"))
+	if err != nil {
+		h.logger.WithError(err).WithFields(map[string]interface{}{
+			"component":  "webhook_handler",
+			"operation":  "get_dead_letter",
+			"request_id": reqID,
+		}).Error("Dead letter lookup failed")
+
+		middleware.Fail(c, models.NewAPIError(models.CodeDeadLetterNotFound, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewDeadLetterDetailResponse(letter, reqID))
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/jobs"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWebhookHandler tests job completion callbacks and the dead-letter endpoints
+func TestWebhookHandler(t *testing.T) {
+	log := setupTestLogger()
+	webhookCfg := config.WebhookConfig{Secret: "s3cret", MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Timeout: time.Second, AllowedHosts: []string{"127.0.0.1"}}
+	dispatcher := webhook.NewDispatcher(log, webhook.Options{
+		Secret:         webhookCfg.Secret,
+		MaxAttempts:    webhookCfg.MaxAttempts,
+		InitialBackoff: webhookCfg.InitialBackoff,
+		MaxBackoff:     webhookCfg.MaxBackoff,
+		Timeout:        webhookCfg.Timeout,
+
+		AllowPrivateNetworks: true,
+	})
+
+	manager := jobs.NewManager(log, jobs.Options{Workers: 1, QueueSize: 10, Retention: time.Minute, OnFinish: func(snap *jobs.Snapshot) {
+		body, _ := json.Marshal(models.NewJobResponse(snap, snap.RequestID))
+		dispatcher.Enqueue(webhook.Delivery{URL: snap.Callback, Owner: snap.Owner, RequestID: snap.RequestID, Subject: snap.ID, Body: body})
+	}})
+	manager.Start(time.Hour)
+
+	jobsCfg := config.JobsConfig{MaxNumbers: 100}
+	jobsHandler := NewJobsHandler(log, jobsCfg, webhookCfg, manager, nil)
+	handler := NewWebhookHandler(log, dispatcher)
+	router := setupTestRouter()
+	router.Use(middleware.ConsumerMiddleware())
+	router.POST("/api/v1/jobs", jobsHandler.HandleSubmitJob)
+	router.GET("/api/v1/webhooks/secret", handler.HandleGetSecret)
+	router.GET("/api/v1/webhooks/dead-letters", handler.HandleListDeadLetters)
+	router.GET("/api/v1/webhooks/dead-letters/:id", handler.HandleGetDeadLetter)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		jsonData, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Consumer-Username", "analyst")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	received := make(chan models.JobResponse, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		_, _ = body.ReadFrom(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		assert.True(t, webhook.Verify(webhook.ConsumerSecret("s3cret", "analyst"), timestamp, body.Bytes(), r.Header.Get(webhook.HeaderSignature)))
+
+		var job models.JobResponse
+		assert.NoError(t, json.Unmarshal(body.Bytes(), &job))
+		received <- job
+	}))
+	defer receiver.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	t.Run("Finished job is posted to the callback URL", func(t *testing.T) {
+		w := request("POST", "/api/v1/jobs", map[string]interface{}{
+			"type": "sum", "payload": map[string]interface{}{"numbers": []float64{1, 2}}, "callback_url": receiver.URL,
+		})
+		require.Equal(t, http.StatusAccepted, w.Code)
+
+		select {
+		case job := <-received:
+			assert.Equal(t, "succeeded", job.State)
+			assert.Equal(t, "test-request-id", job.RequestID)
+		case <-time.After(2 * time.Second):
+			t.Fatal("callback was not delivered")
+		}
+	})
+
+	t.Run("Consumers read their own signing secret", func(t *testing.T) {
+		w := request("GET", "/api/v1/webhooks/secret", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+		var response models.WebhookSecretResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "analyst", response.Consumer)
+		assert.Equal(t, webhook.ConsumerSecret("s3cret", "analyst"), response.Secret)
+		assert.NotEqual(t, "s3cret", response.Secret)
+	})
+
+	t.Run("Anonymous callers get no secret and register no callback", func(t *testing.T) {
+		anonymous := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+			jsonData, _ := json.Marshal(body)
+			req, _ := http.NewRequest(method, path, bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w := anonymous("GET", "/api/v1/webhooks/secret", nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.NotContains(t, w.Body.String(), webhook.ConsumerSecret("s3cret", middleware.AnonymousConsumer))
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeConsumerRequired, response.Code)
+
+		w = anonymous("POST", "/api/v1/jobs", map[string]interface{}{
+			"type": "sum", "payload": map[string]interface{}{"numbers": []float64{1, 2}}, "callback_url": receiver.URL,
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeConsumerRequired, response.Code)
+	})
+
+	t.Run("Undeliverable callbacks are dead-lettered", func(t *testing.T) {
+		w := request("POST", "/api/v1/jobs", map[string]interface{}{
+			"type": "sum", "payload": map[string]interface{}{"numbers": []float64{1, 2}}, "callback_url": failing.URL,
+		})
+		require.Equal(t, http.StatusAccepted, w.Code)
+
+		var list models.DeadLetterListResponse
+		require.Eventually(t, func() bool {
+			w := request("GET", "/api/v1/webhooks/dead-letters", nil)
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+			return list.Count == 1
+		}, 2*time.Second, 5*time.Millisecond)
+
+		letter := list.DeadLetters[0]
+		assert.Equal(t, 2, letter.Attempts)
+		assert.Equal(t, http.StatusBadGateway, letter.LastStatus)
+		assert.Equal(t, "test-request-id", letter.JobRequestID)
+
+		w = request("GET", "/api/v1/webhooks/dead-letters/"+letter.DeliveryID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var detail models.DeadLetterDetailResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+		assert.Equal(t, letter.DeliveryID, detail.DeliveryID)
+		assert.Equal(t, "test-request-id", detail.RequestID)
+		assert.False(t, detail.Timestamp.IsZero())
+		w = request("GET", "/api/v1/webhooks/dead-letters/whd_missing", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid callback URLs are rejected", func(t *testing.T) {
+		for _, callback := range []string{"ftp://127.0.0.1/hook", "/relative", "https://evil.example.com/hook"} {
+			w := request("POST", "/api/v1/jobs", map[string]interface{}{
+				"type": "sum", "payload": map[string]interface{}{"numbers": []float64{1, 2}}, "callback_url": callback,
+			})
+			assert.Equal(t, http.StatusBadRequest, w.Code, callback)
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, models.CodeInvalidCallback, response.Code)
+		}
+	})
+
+	t.Run("Callbacks require an allow-list", func(t *testing.T) {
+		open := NewJobsHandler(log, jobsCfg, config.WebhookConfig{Secret: "s3cret"}, manager, nil)
+		engine := setupTestRouter()
+		engine.POST("/api/v1/jobs", open.HandleSubmitJob)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"type": "sum", "payload": map[string]interface{}{"numbers": []float64{1, 2}}, "callback_url": receiver.URL,
+		})
+		req, _ := http.NewRequest("POST", "/api/v1/jobs", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.CodeCallbacksDisabled, response.Code)
+	})
+
+	manager.Stop(context.Background())
+	dispatcher.Stop(context.Background())
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/window"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// WindowHandler handles time-windowed aggregation over named streams of timestamped values
+// Streams are private to the consumer that appends to them
+type WindowHandler struct {
+	logger *logger.Logger
+	config config.WindowConfig
+	store  *window.Store
+}
+
+// NewWindowHandler creates a new window handler
+func NewWindowHandler(logger *logger.Logger, cfg config.WindowConfig, store *window.Store) *WindowHandler {
+	return &WindowHandler{
+		logger: logger,
+		config: cfg,
+		store:  store,
+	}
+}
+
+// HandleAppend handles POST /api/v1/windows/:name/points requests
+// Points behind the lateness bound are reported by index rather than failing the request
+func (h *WindowHandler) HandleAppend(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	name := c.Param("name")
+	if err := models.ValidateStreamName(name); err != nil {
+		h.reject(c, reqID, "validate_name", http.StatusBadRequest, err, models.ErrorCode(err, models.CodeValidation))
+		return
+	}
+
+	var request models.WindowAppendRequest
+	if statusCode, code, err := bindJSON(c, &request); err != nil {
+		h.reject(c, reqID, "bind_request", statusCode, err, code)
+		return
+	}
+
+	if err := request.Validate(time.Now().Add(h.config.MaxSkew)); err != nil {
+		h.reject(c, reqID, "validate_request", http.StatusBadRequest, err, models.CodeValidation)
+		return
+	}
+
+	result, err := h.store.Append(middleware.GetConsumer(c), name, request.WindowPoints())
+	if err != nil {
+		statusCode, code, reported := windowErrorStatus(err, name)
+		h.reject(c, reqID, "append", statusCode, reported, code)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewWindowAppendResponse(name, result, reqID))
+}
+
+// HandleQuery handles GET /api/v1/windows/:name requests
+// Query parameters: type (sliding or tumbling), size, step, from and to
+func (h *WindowHandler) HandleQuery(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	name := c.Param("name")
+	if err := models.ValidateStreamName(name); err != nil {
+		h.reject(c, reqID, "validate_name", http.StatusBadRequest, err, models.ErrorCode(err, models.CodeValidation))
+		return
+	}
+
+	query, err := parseWindowQuery(c)
+	if err != nil {
+		h.reject(c, reqID, "parse_query", http.StatusBadRequest, err, models.CodeInvalidWindowQuery)
+		return
+	}
+
+	results, err := h.store.Aggregate(middleware.GetConsumer(c), name, query)
+	if err != nil {
+		statusCode, code, reported := windowErrorStatus(err, name)
+		h.reject(c, reqID, "aggregate", statusCode, reported, code)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewWindowQueryResponse(name, query, results, reqID))
+}
+
+// HandleList handles GET /api/v1/windows requests
+func (h *WindowHandler) HandleList(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	names := h.store.Names(middleware.GetConsumer(c))
+	c.JSON(http.StatusOK, &models.WindowStreamsResponse{
+		Streams:   names,
+		Count:     len(names),
+		Timestamp: time.Now().UTC(),
+		RequestID: reqID,
+	})
+}
+
+// HandleDelete handles DELETE /api/v1/windows/:name requests
+func (h *WindowHandler) HandleDelete(c *gin.Context) {
+	requestID, _ := c.Get(middleware.RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	name := c.Param("name")
+	if err := h.store.Delete(middleware.GetConsumer(c), name); err != nil {
+		statusCode, code, reported := windowErrorStatus(err, name)
+		h.reject(c, reqID, "delete", statusCode, reported, code)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// reject logs a failed window request and writes the error response
+func (h *WindowHandler) reject(c *gin.Context, reqID, operation string, statusCode int, err error, code string) {
+	h.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":  "window_handler",
+		"operation":  operation,
+		"request_id": reqID,
+		"consumer":   middleware.GetConsumer(c),
+		"code":       code,
+	}).Error("Window request failed")
+
+	middleware.Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+}
+
+// parseWindowQuery reads the window query parameters
+// Durations use Go syntax (30s, 5m, 1h) and times RFC 3339
+func parseWindowQuery(c *gin.Context) (window.Query, error) {
+	query := window.Query{Type: c.DefaultQuery("type", window.TypeSliding)}
+
+	size := c.Query("size")
+	if size == "" {
+		return query, errors.New("size is required")
+	}
+	var err error
+	if query.Size, err = time.ParseDuration(size); err != nil {
+		return query, fmt.Errorf("size: %w", err)
+	}
+	if step := c.Query("step"); step != "" {
+		if query.Step, err = time.ParseDuration(step); err != nil {
+			return query, fmt.Errorf("step: %w", err)
+		}
+	}
+	if from := c.Query("from"); from != "" {
+		if query.From, err = time.Parse(time.RFC3339Nano, from); err != nil {
+			return query, fmt.Errorf("from: %w", err)
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if query.To, err = time.Parse(time.RFC3339Nano, to); err != nil {
+			return query, fmt.Errorf("to: %w", err)
+		}
+	}
+	return query, nil
+}
+
+// windowErrorStatus maps window store errors to HTTP status codes, error codes and reported errors
+func windowErrorStatus(err error, name string) (int, string, error) {
+	var statusCode int
+	var code string
+	switch {
+	case errors.Is(err, window.ErrStreamNotFound):
+		return http.StatusNotFound, models.CodeWindowStreamNotFound, models.NewAPIError(models.CodeWindowStreamNotFound, nil).WithParam("name", name)
+	case errors.Is(err, window.ErrStreamLimit):
+		statusCode, code = http.StatusTooManyRequests, models.CodeWindowStreamLimit
+	case errors.Is(err, window.ErrInvalidQuery):
+		return http.StatusBadRequest, models.CodeInvalidWindowQuery, err
+	case errors.Is(err, window.ErrOverflow):
+		statusCode, code = http.StatusUnprocessableEntity, models.CodeResultOverflow
+	default:
+		return http.StatusInternalServerError, models.CodeInternal, err
+	}
+	return statusCode, code, models.NewAPIError(code, nil)
+}
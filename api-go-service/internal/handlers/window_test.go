@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/window"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestWindowRouter() *gin.Engine {
+	log := setupTestLogger()
+	cfg := config.WindowConfig{
+		Retention:  time.Hour,
+		Lateness:   time.Minute,
+		MaxSkew:    time.Minute,
+		MaxPoints:  1000,
+		MaxStreams: 2,
+		MaxBatch:   10,
+		MaxWindows: 100,
+	}
+	handler := NewWindowHandler(log, cfg, window.NewStore(log, window.Options{
+		Retention:  cfg.Retention,
+		Lateness:   cfg.Lateness,
+		MaxPoints:  cfg.MaxPoints,
+		MaxStreams: cfg.MaxStreams,
+		MaxWindows: cfg.MaxWindows,
+	}))
+
+	router := setupTestRouter()
+	router.Use(middleware.LocaleMiddleware(), middleware.ConsumerMiddleware())
+	router.GET("/api/v1/windows", handler.HandleList)
+	router.GET("/api/v1/windows/:name", handler.HandleQuery)
+	router.DELETE("/api/v1/windows/:name", handler.HandleDelete)
+	router.POST("/api/v1/windows/:name/points", handler.HandleAppend)
+	return router
+}
+
+func windowRequest(router *gin.Engine, method, path, consumer, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Consumer-Username", consumer)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestWindowHandler tests appending to and querying a window stream
+func TestWindowHandler(t *testing.T) {
+	router := setupTestWindowRouter()
+	now := time.Now().UTC().Truncate(time.Minute)
+	ts := func(offset time.Duration) string { return now.Add(offset).Format(time.RFC3339) }
+
+	body := fmt.Sprintf(`{"points": [
+		{"timestamp": %q, "value": 1},
+		{"timestamp": %q, "value": 2},
+		{"timestamp": %q, "value": 3},
+		{"timestamp": %q, "value": 4}
+	]}`, ts(-3*time.Minute), ts(-30*time.Second), ts(-20*time.Second), ts(-10*time.Minute))
+	w := windowRequest(router, "POST", "/api/v1/windows/sensor-1/points", "alice", body)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var appended models.WindowAppendResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &appended))
+	assert.Equal(t, 3, appended.Accepted)
+	assert.Equal(t, []int{3}, appended.Late)
+	assert.Equal(t, 3, appended.Points)
+
+	t.Run("Tumbling windows", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v1/windows/sensor-1?type=tumbling&size=1m&from=%s&to=%s", ts(-3*time.Minute), ts(0))
+		w := windowRequest(router, "GET", path, "alice", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response models.WindowQueryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "tumbling", response.Type)
+		require.Len(t, response.Windows, 3)
+		assert.Equal(t, 1.0, response.Windows[0].Sum)
+		assert.Nil(t, response.Windows[1].Mean)
+		assert.Equal(t, 2, response.Windows[2].Count)
+		require.NotNil(t, response.Windows[2].Mean)
+		assert.Equal(t, 2.5, *response.Windows[2].Mean)
+	})
+
+	t.Run("Sliding window ending now", func(t *testing.T) {
+		w := windowRequest(router, "GET", "/api/v1/windows/sensor-1?size=5m", "alice", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response models.WindowQueryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "sliding", response.Type)
+		require.Len(t, response.Windows, 1)
+		assert.Equal(t, 6.0, response.Windows[0].Sum)
+	})
+
+	t.Run("Streams are private to their consumer", func(t *testing.T) {
+		w := windowRequest(router, "GET", "/api/v1/windows/sensor-1?size=5m", "bob", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), models.CodeWindowStreamNotFound)
+
+		w = windowRequest(router, "GET", "/api/v1/windows", "alice", "")
+		assert.Contains(t, w.Body.String(), `"streams":["sensor-1"]`)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, windowRequest(router, "DELETE", "/api/v1/windows/sensor-1", "alice", "").Code)
+		assert.Equal(t, http.StatusNotFound, windowRequest(router, "DELETE", "/api/v1/windows/sensor-1", "alice", "").Code)
+	})
+}
+
+// TestWindowHandler_Errors tests refused appends and queries
+func TestWindowHandler_Errors(t *testing.T) {
+	router := setupTestWindowRouter()
+	now := time.Now().UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{"Invalid stream name", "POST", "/api/v1/windows/a%20b/points", fmt.Sprintf(`{"points": [{"timestamp": %q, "value": 1}]}`, now), http.StatusBadRequest, models.CodeInvalidStreamName},
+		{"No points", "POST", "/api/v1/windows/s/points", `{"points": []}`, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"Missing value", "POST", "/api/v1/windows/s/points", fmt.Sprintf(`{"points": [{"timestamp": %q}]}`, now), http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"Future timestamp", "POST", "/api/v1/windows/s/points", fmt.Sprintf(`{"points": [{"timestamp": %q, "value": 1}]}`, future), http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"Malformed body", "POST", "/api/v1/windows/s/points", `{"points": "nope"}`, http.StatusBadRequest, "INVALID_REQUEST_BODY"},
+		{"Overflowing values", "POST", "/api/v1/windows/s/points", fmt.Sprintf(`{"points": [{"timestamp": %q, "value": 1e308}, {"timestamp": %q, "value": 1e308}]}`, now, now), http.StatusUnprocessableEntity, models.CodeResultOverflow},
+		{"Missing size", "GET", "/api/v1/windows/s", "", http.StatusBadRequest, models.CodeInvalidWindowQuery},
+		{"Malformed from", "GET", "/api/v1/windows/s?size=1m&from=yesterday", "", http.StatusBadRequest, models.CodeInvalidWindowQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := windowRequest(router, tt.method, tt.path, "alice", tt.body)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.code)
+		})
+	}
+
+	t.Run("Stream limit", func(t *testing.T) {
+		body := fmt.Sprintf(`{"points": [{"timestamp": %q, "value": 1}]}`, now)
+		for _, name := range []string{"a", "b"} {
+			require.Equal(t, http.StatusOK, windowRequest(router, "POST", "/api/v1/windows/"+name+"/points", "alice", body).Code)
+		}
+		w := windowRequest(router, "POST", "/api/v1/windows/c/points", "alice", body)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Contains(t, w.Body.String(), models.CodeWindowStreamLimit)
+
+		w = windowRequest(router, "GET", "/api/v1/windows/a?type=tumbling&size=1ms&from=2020-01-01T00:00:00Z", "alice", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), models.CodeInvalidWindowQuery)
+	})
+
+	t.Run("Unknown streams are named in the caller's language", func(t *testing.T) {
+		for language, message := range map[string]string{
+			"en": `window stream "missing" not found`,
+			"fr": `flux fenêtré "missing" introuvable`,
+		} {
+			req, _ := http.NewRequest("DELETE", "/api/v1/windows/missing", nil)
+			req.Header.Set("X-Consumer-Username", "alice")
+			req.Header.Set("Accept-Language", language)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusNotFound, w.Code)
+
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, models.CodeWindowStreamNotFound, response.Code)
+			assert.Equal(t, message, response.Error)
+		}
+	})
+}
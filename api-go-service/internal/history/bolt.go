@@ -0,0 +1,276 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Top-level buckets of a bolt store
+// Entries and IDs hold one nested bucket per owner
+var (
+	entriesBucket = []byte("entries") // position key -> JSON entry
+	idsBucket     = []byte("ids")     // request ID -> position key
+	countsBucket  = []byte("counts")  // owner -> number of entries
+)
+
+// BoltStore is a Store kept in a bbolt database file
+// Entries survive restarts but the file can only be opened by one process at a time
+type BoltStore struct {
+	db         *bolt.DB
+	maxEntries int
+}
+
+// OpenBoltStore opens or creates the database at path
+// At most maxEntries entries are kept per owner, the oldest being dropped first
+func OpenBoltStore(path string, maxEntries int) (*BoltStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{entriesBucket, idsBucket, countsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialise history database: %w", err)
+	}
+
+	return &BoltStore{db: db, maxEntries: maxEntries}, nil
+}
+
+// Put implements Store
+func (s *BoltStore) Put(_ context.Context, entry *Entry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	key := positionOf(entry).key()
+	owner := []byte(entry.Owner)
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		entries, err := tx.Bucket(entriesBucket).CreateBucketIfNotExists(owner)
+		if err != nil {
+			return err
+		}
+		ids, err := tx.Bucket(idsBucket).CreateBucketIfNotExists(owner)
+		if err != nil {
+			return err
+		}
+
+		count := ownerCount(tx, owner) + 1
+		if previous := ids.Get([]byte(entry.RequestID)); previous != nil {
+			if err := entries.Delete(previous); err != nil {
+				return err
+			}
+			count--
+		}
+		if err := entries.Put(key, value); err != nil {
+			return err
+		}
+		if err := ids.Put([]byte(entry.RequestID), key); err != nil {
+			return err
+		}
+
+		var oldest [][]byte
+		if s.maxEntries > 0 {
+			c := entries.Cursor()
+			for k, _ := c.First(); k != nil && count-len(oldest) > s.maxEntries; k, _ = c.Next() {
+				oldest = append(oldest, append([]byte(nil), k...))
+			}
+		}
+		if err := deleteKeys(entries, ids, oldest); err != nil {
+			return err
+		}
+		return setOwnerCount(tx, owner, count-len(oldest))
+	})
+}
+
+// Get implements Store
+func (s *BoltStore) Get(_ context.Context, owner, requestID string) (*Entry, error) {
+	var entry *Entry
+	err := s.db.View(func(tx *bolt.Tx) error {
+		ids := tx.Bucket(idsBucket).Bucket([]byte(owner))
+		entries := tx.Bucket(entriesBucket).Bucket([]byte(owner))
+		if ids == nil || entries == nil {
+			return ErrNotFound
+		}
+		key := ids.Get([]byte(requestID))
+		if key == nil {
+			return ErrNotFound
+		}
+		value := entries.Get(key)
+		if value == nil {
+			return ErrNotFound
+		}
+		entry = &Entry{}
+		return json.Unmarshal(value, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// List implements Store
+func (s *BoltStore) List(_ context.Context, owner string, q Query) ([]*Entry, string, error) {
+	upper, bounded, err := upperBound(q)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page := make([]*Entry, 0)
+	next := ""
+	err = s.db.View(func(tx *bolt.Tx) error {
+		entries := tx.Bucket(entriesBucket).Bucket([]byte(owner))
+		if entries == nil {
+			return nil
+		}
+
+		c := entries.Cursor()
+		k, v := c.Last()
+		if bounded {
+			if k, v = c.Seek(upper.key()); k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		}
+
+		var from []byte
+		if !q.From.IsZero() {
+			from = position{nanos: q.From.UnixNano()}.key()
+		}
+		for ; k != nil; k, v = c.Prev() {
+			if from != nil && bytes.Compare(k, from) < 0 {
+				break
+			}
+			if q.Limit > 0 && len(page) == q.Limit {
+				next = encodeCursor(positionOf(page[len(page)-1]))
+				break
+			}
+			entry := &Entry{}
+			if err := json.Unmarshal(v, entry); err != nil {
+				return err
+			}
+			page = append(page, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return page, next, nil
+}
+
+// Prune implements Store
+func (s *BoltStore) Prune(_ context.Context, before time.Time) (int, error) {
+	limit := position{nanos: before.UnixNano()}.key()
+	pruned := 0
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(entriesBucket)
+		idsRoot := tx.Bucket(idsBucket)
+
+		var owners [][]byte
+		err := root.ForEach(func(owner, _ []byte) error {
+			owners = append(owners, append([]byte(nil), owner...))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, owner := range owners {
+			entries := root.Bucket(owner)
+			ids := idsRoot.Bucket(owner)
+			if entries == nil || ids == nil {
+				continue
+			}
+
+			var expired [][]byte
+			c := entries.Cursor()
+			for k, _ := c.First(); k != nil && bytes.Compare(k, limit) < 0; k, _ = c.Next() {
+				expired = append(expired, append([]byte(nil), k...))
+			}
+			if err := deleteKeys(entries, ids, expired); err != nil {
+				return err
+			}
+			pruned += len(expired)
+
+			count := ownerCount(tx, owner) - len(expired)
+			if err := setOwnerCount(tx, owner, count); err != nil {
+				return err
+			}
+			if count <= 0 {
+				if err := root.DeleteBucket(owner); err != nil {
+					return err
+				}
+				if err := idsRoot.DeleteBucket(owner); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
+
+// Close implements Store
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// ownerCount returns the number of entries held for owner
+func ownerCount(tx *bolt.Tx, owner []byte) int {
+	value := tx.Bucket(countsBucket).Get(owner)
+	if len(value) != 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(value))
+}
+
+// setOwnerCount records the number of entries held for owner
+func setOwnerCount(tx *bolt.Tx, owner []byte, count int) error {
+	if count <= 0 {
+		return tx.Bucket(countsBucket).Delete(owner)
+	}
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(count))
+	return tx.Bucket(countsBucket).Put(owner, value)
+}
+
+// deleteKeys removes the entries at keys along with their request ID index
+func deleteKeys(entries, ids *bolt.Bucket, keys [][]byte) error {
+	for _, key := range keys {
+		if p, ok := positionFromKey(key); ok {
+			if err := ids.Delete([]byte(p.requestID)); err != nil {
+				return err
+			}
+		}
+		if err := entries.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package history
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Supported storage backends
+const (
+	BackendMemory = "memory"
+	BackendBolt   = "bolt"
+)
+
+// Errors returned by history stores
+var (
+	ErrNotFound      = errors.New("history entry not found")
+	ErrInvalidCursor = errors.New("invalid history cursor")
+)
+
+var (
+	// Entries recorded, by outcome
+	recordedEntries = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "history_entries_recorded_total",
+			Help: "Total number of requests recorded in the computation history",
+		},
+		[]string{"outcome"},
+	)
+
+	// Entries removed by the retention sweep
+	prunedEntries = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "history_entries_pruned_total",
+			Help: "Total number of history entries removed after the retention period",
+		},
+	)
+)
+
+// Entry is one recorded request and its result
+// Request is nil when the input was redacted or exceeded the capture limit
+type Entry struct {
+	RequestID           string        `json:"request_id"`
+	Owner               string        `json:"owner"`
+	Method              string        `json:"method"`
+	Path                string        `json:"path"`
+	Status              int           `json:"status"`
+	RequestContentType  string        `json:"request_content_type,omitempty"`
+	Request             []byte        `json:"request,omitempty"`
+	RequestSize         int64         `json:"request_size"`
+	RequestSHA256       string        `json:"request_sha256,omitempty"`
+	ResponseContentType string        `json:"response_content_type,omitempty"`
+	Response            []byte        `json:"response,omitempty"`
+	Redacted            bool          `json:"redacted,omitempty"`
+	Truncated           bool          `json:"truncated,omitempty"`
+	CreatedAt           time.Time     `json:"created_at"`
+	Duration            time.Duration `json:"duration"`
+}
+
+// Query selects a page of entries, newest first
+// From and To bound CreatedAt to [From, To); zero values leave the range open
+type Query struct {
+	From   time.Time
+	To     time.Time
+	Cursor string // from the previous page, empty for the first one
+	Limit  int
+}
+
+// Store keeps the history entries of each consumer
+type Store interface {
+	// Put records an entry, replacing any entry of the same owner and request ID
+	Put(ctx context.Context, entry *Entry) error
+
+	// Get returns the entry of owner with the given request ID
+	Get(ctx context.Context, owner, requestID string) (*Entry, error)
+
+	// List returns a page of the entries of owner and the cursor of the next page,
+	// empty after the last page
+	List(ctx context.Context, owner string, q Query) ([]*Entry, string, error)
+
+	// Prune removes the entries created before the given time
+	Prune(ctx context.Context, before time.Time) (int, error)
+
+	// Close releases the resources held by the store
+	Close() error
+}
+
+// position orders entries by creation time, then request ID
+type position struct {
+	nanos     int64
+	requestID string
+}
+
+// positionOf returns the position of an entry
+func positionOf(e *Entry) position {
+	return position{nanos: e.CreatedAt.UnixNano(), requestID: e.RequestID}
+}
+
+// before reports whether p sorts before other
+func (p position) before(other position) bool {
+	if p.nanos != other.nanos {
+		return p.nanos < other.nanos
+	}
+	return p.requestID < other.requestID
+}
+
+// key encodes the position so that byte order matches position order
+func (p position) key() []byte {
+	key := make([]byte, 8, 8+len(p.requestID))
+	binary.BigEndian.PutUint64(key, uint64(p.nanos))
+	return append(key, p.requestID...)
+}
+
+// positionFromKey decodes a key made by position.key
+func positionFromKey(key []byte) (position, bool) {
+	if len(key) < 8 {
+		return position{}, false
+	}
+	return position{nanos: int64(binary.BigEndian.Uint64(key)), requestID: string(key[8:])}, true
+}
+
+// encodeCursor makes the opaque cursor of the page following p
+func encodeCursor(p position) string {
+	return base64.RawURLEncoding.EncodeToString(p.key())
+}
+
+// decodeCursor reads a cursor made by encodeCursor
+func decodeCursor(cursor string) (position, error) {
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return position{}, ErrInvalidCursor
+	}
+	p, ok := positionFromKey(key)
+	if !ok {
+		return position{}, ErrInvalidCursor
+	}
+	return p, nil
+}
+
+// upperBound returns the exclusive upper position of a query, and whether there is one
+// The cursor, if any, takes precedence over To since it is always within the range
+func upperBound(q Query) (position, bool, error) {
+	if q.Cursor != "" {
+		p, err := decodeCursor(q.Cursor)
+		return p, true, err
+	}
+	if !q.To.IsZero() {
+		return position{nanos: q.To.UnixNano()}, true, nil
+	}
+	return position{}, false, nil
+}
+
+// Sweeper removes entries older than the retention period in the background
+type Sweeper struct {
+	logger    *logger.Logger
+	store     Store
+	retention time.Duration
+	now       func() time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewSweeper creates a retention sweeper for store
+func NewSweeper(log *logger.Logger, store Store, retention time.Duration) *Sweeper {
+	return &Sweeper{
+		logger:    log,
+		store:     store,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Start launches the background sweep
+func (s *Sweeper) Start(interval time.Duration) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.sweep()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the background sweep
+func (s *Sweeper) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+		s.stop = nil
+	}
+}
+
+// sweep prunes expired entries once
+func (s *Sweeper) sweep() {
+	pruned, err := s.store.Prune(context.Background(), s.now().Add(-s.retention))
+	if err != nil {
+		s.logger.LogError(err, "history", "prune", nil)
+		return
+	}
+	prunedEntries.Add(float64(pruned))
+	if pruned > 0 {
+		s.logger.WithFields(map[string]interface{}{
+			"component": "history",
+			"operation": "prune",
+			"pruned":    pruned,
+		}).Debug("Expired history entries pruned")
+	}
+}
+
+// Recorded counts an attempt to record an entry
+func Recorded(err error) {
+	if err != nil {
+		recordedEntries.WithLabelValues("error").Inc()
+		return
+	}
+	recordedEntries.WithLabelValues("ok").Inc()
+}
@@ -0,0 +1,164 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var base = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// entry makes an entry of owner created minutes after base
+func entry(owner, id string, minutes int) *Entry {
+	return &Entry{
+		RequestID: id,
+		Owner:     owner,
+		Method:    "POST",
+		Path:      "/api/v1/sum",
+		Status:    200,
+		Request:   []byte(`{"numbers": [1, 2]}`),
+		Response:  []byte(`{"result": 3}`),
+		CreatedAt: base.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+// ids returns the request IDs of entries in order
+func ids(entries []*Entry) []string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.RequestID
+	}
+	return out
+}
+
+// backends opens a store of each kind keeping maxEntries entries per owner
+func backends(t *testing.T, maxEntries int) map[string]Store {
+	bolt, err := OpenBoltStore(filepath.Join(t.TempDir(), "history.db"), maxEntries)
+	require.NoError(t, err)
+	t.Cleanup(func() { bolt.Close() })
+
+	return map[string]Store{
+		BackendMemory: NewMemoryStore(maxEntries),
+		BackendBolt:   bolt,
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	for name, store := range backends(t, 100) {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 5; i++ {
+				require.NoError(t, store.Put(ctx, entry("alice", fmt.Sprintf("req-%d", i), i)))
+			}
+			require.NoError(t, store.Put(ctx, entry("bob", "req-bob", 0)))
+
+			got, err := store.Get(ctx, "alice", "req-2")
+			require.NoError(t, err)
+			assert.Equal(t, "/api/v1/sum", got.Path)
+			assert.JSONEq(t, `{"result": 3}`, string(got.Response))
+			assert.True(t, got.CreatedAt.Equal(base.Add(2*time.Minute)))
+
+			// Entries are private to their owner
+			_, err = store.Get(ctx, "bob", "req-2")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			t.Run("Pages newest first", func(t *testing.T) {
+				page, next, err := store.List(ctx, "alice", Query{Limit: 2})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"req-4", "req-3"}, ids(page))
+				require.NotEmpty(t, next)
+
+				page, next, err = store.List(ctx, "alice", Query{Limit: 2, Cursor: next})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"req-2", "req-1"}, ids(page))
+
+				page, next, err = store.List(ctx, "alice", Query{Limit: 2, Cursor: next})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"req-0"}, ids(page))
+				assert.Empty(t, next)
+			})
+
+			t.Run("Time range", func(t *testing.T) {
+				page, _, err := store.List(ctx, "alice", Query{
+					From:  base.Add(time.Minute),
+					To:    base.Add(3 * time.Minute),
+					Limit: 10,
+				})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"req-2", "req-1"}, ids(page))
+
+				page, _, err = store.List(ctx, "carol", Query{Limit: 10})
+				require.NoError(t, err)
+				assert.Empty(t, page)
+			})
+
+			t.Run("Invalid cursor", func(t *testing.T) {
+				_, _, err := store.List(ctx, "alice", Query{Cursor: "%%%"})
+				assert.ErrorIs(t, err, ErrInvalidCursor)
+			})
+
+			t.Run("Prune", func(t *testing.T) {
+				pruned, err := store.Prune(ctx, base.Add(2*time.Minute))
+				require.NoError(t, err)
+				assert.Equal(t, 3, pruned) // req-0, req-1 and req-bob
+
+				_, err = store.Get(ctx, "alice", "req-1")
+				assert.ErrorIs(t, err, ErrNotFound)
+				page, _, err := store.List(ctx, "alice", Query{Limit: 10})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"req-4", "req-3", "req-2"}, ids(page))
+			})
+		})
+	}
+}
+
+func TestStore_Limits(t *testing.T) {
+	ctx := context.Background()
+
+	for name, store := range backends(t, 2) {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, store.Put(ctx, entry("alice", "a", 0)))
+			require.NoError(t, store.Put(ctx, entry("alice", "b", 1)))
+
+			// Recording the same request ID again replaces the entry
+			replaced := entry("alice", "a", 2)
+			replaced.Status = 500
+			require.NoError(t, store.Put(ctx, replaced))
+			got, err := store.Get(ctx, "alice", "a")
+			require.NoError(t, err)
+			assert.Equal(t, 500, got.Status)
+
+			// The oldest entry is dropped beyond the per-owner limit
+			require.NoError(t, store.Put(ctx, entry("alice", "c", 3)))
+			page, _, err := store.List(ctx, "alice", Query{})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"c", "a"}, ids(page))
+			_, err = store.Get(ctx, "alice", "b")
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestBoltStore_Reopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	store, err := OpenBoltStore(path, 10)
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, entry("alice", "req-1", 0)))
+	require.NoError(t, store.Close())
+
+	store, err = OpenBoltStore(path, 10)
+	require.NoError(t, err)
+	defer store.Close()
+
+	got, err := store.Get(ctx, "alice", "req-1")
+	require.NoError(t, err)
+	assert.Equal(t, 200, got.Status)
+}
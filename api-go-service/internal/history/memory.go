@@ -0,0 +1,149 @@
+package history
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-memory Store
+// Entries are lost on restart and are not shared between replicas
+type MemoryStore struct {
+	maxEntries int
+
+	mu     sync.Mutex
+	owners map[string]*ownerEntries
+}
+
+// ownerEntries holds the entries of one owner, oldest first
+type ownerEntries struct {
+	entries []*Entry
+	byID    map[string]*Entry
+}
+
+// NewMemoryStore creates an in-memory store keeping at most maxEntries entries per owner
+// The oldest entries of an owner are dropped once the limit is reached
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		owners:     make(map[string]*ownerEntries),
+	}
+}
+
+// Put implements Store
+func (s *MemoryStore) Put(_ context.Context, entry *Entry) error {
+	copied := *entry
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.owners[entry.Owner]
+	if !ok {
+		o = &ownerEntries{byID: make(map[string]*Entry)}
+		s.owners[entry.Owner] = o
+	}
+	if existing, ok := o.byID[entry.RequestID]; ok {
+		o.remove(existing)
+	}
+
+	pos := positionOf(&copied)
+	i := sort.Search(len(o.entries), func(i int) bool { return pos.before(positionOf(o.entries[i])) })
+	o.entries = append(o.entries, nil)
+	copy(o.entries[i+1:], o.entries[i:])
+	o.entries[i] = &copied
+	o.byID[copied.RequestID] = &copied
+
+	if s.maxEntries > 0 {
+		for len(o.entries) > s.maxEntries {
+			o.remove(o.entries[0])
+		}
+	}
+	return nil
+}
+
+// Get implements Store
+func (s *MemoryStore) Get(_ context.Context, owner, requestID string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.owners[owner]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	entry, ok := o.byID[requestID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *entry
+	return &copied, nil
+}
+
+// List implements Store
+func (s *MemoryStore) List(_ context.Context, owner string, q Query) ([]*Entry, string, error) {
+	upper, bounded, err := upperBound(q)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.owners[owner]
+	if !ok {
+		return []*Entry{}, "", nil
+	}
+
+	end := len(o.entries)
+	if bounded {
+		end = sort.Search(len(o.entries), func(i int) bool { return !positionOf(o.entries[i]).before(upper) })
+	}
+
+	page := make([]*Entry, 0)
+	for i := end - 1; i >= 0; i-- {
+		entry := o.entries[i]
+		if !q.From.IsZero() && entry.CreatedAt.Before(q.From) {
+			break
+		}
+		if q.Limit > 0 && len(page) == q.Limit {
+			return page, encodeCursor(positionOf(page[len(page)-1])), nil
+		}
+		copied := *entry
+		page = append(page, &copied)
+	}
+	return page, "", nil
+}
+
+// Prune implements Store
+func (s *MemoryStore) Prune(_ context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pruned := 0
+	for owner, o := range s.owners {
+		for len(o.entries) > 0 && o.entries[0].CreatedAt.Before(before) {
+			o.remove(o.entries[0])
+			pruned++
+		}
+		if len(o.entries) == 0 {
+			delete(s.owners, owner)
+		}
+	}
+	return pruned, nil
+}
+
+// Close implements Store
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// remove drops an entry held by o
+func (o *ownerEntries) remove(entry *Entry) {
+	delete(o.byID, entry.RequestID)
+	for i, e := range o.entries {
+		if e == entry {
+			o.entries = append(o.entries[:i], o.entries[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package i18n
+
+// french translates the client messages into French
+var french = map[string]string{
+	// Titles of the error catalog
+	"Validation failed":           "Échec de la validation",
+	"Invalid request body":        "Corps de requête invalide",
+	"Invalid request":             "Requête invalide",
+	"Internal error":              "Erreur interne",
+	"Internal server error":       "Erreur interne du serveur",
+	"Not found":                   "Introuvable",
+	"Method not allowed":          "Méthode non autorisée",
+	"Consumer required":           "Consommateur requis",
+	"Result overflow":             "Dépassement de capacité",
+	"Unsupported media type":      "Type de média non pris en charge",
+	"Not acceptable":              "Non acceptable",
+	"Invalid shape":               "Forme invalide",
+	"Dimension mismatch":          "Dimensions incompatibles",
+	"Too many elements":           "Trop d'éléments",
+	"Invalid modulus":             "Module invalide",
+	"Invalid degree":              "Degré invalide",
+	"Invalid key size":            "Taille de clé invalide",
+	"Invalid public key":          "Clé publique invalide",
+	"Invalid ciphertext":          "Chiffré invalide",
+	"Key not found":               "Clé introuvable",
+	"Key limit reached":           "Limite de clés atteinte",
+	"Invalid privacy parameters":  "Paramètres de confidentialité invalides",
+	"Privacy budget exhausted":    "Budget de confidentialité épuisé",
+	"Session not found":           "Session introuvable",
+	"Session closed":              "Session fermée",
+	"Duplicate share":             "Part en double",
+	"Unknown participant":         "Participant inconnu",
+	"Too many sessions":           "Trop de sessions",
+	"Session limit reached":       "Limite de sessions atteinte",
+	"Invalid share":               "Part invalide",
+	"Invalid idempotency key":     "Clé d'idempotence invalide",
+	"Idempotency key mismatch":    "Clé d'idempotence incohérente",
+	"Request in progress":         "Requête en cours",
+	"Idempotency store full":      "Stockage d'idempotence plein",
+	"Job not found":               "Tâche introuvable",
+	"Job finished":                "Tâche terminée",
+	"Job limit reached":           "Limite de tâches atteinte",
+	"Job queue full":              "File des tâches pleine",
+	"Shutting down":               "Arrêt en cours",
+	"Unknown job type":            "Type de tâche inconnu",
+	"Invalid callback URL":        "URL de rappel invalide",
+	"Callbacks disabled":          "Rappels désactivés",
+	"Key generation disabled":     "Génération de clés désactivée",
+	"Dead letter not found":       "Lettre morte introuvable",
+	"Stream not found":            "Flux introuvable",
+	"Stream closed":               "Flux fermé",
+	"Stream limit reached":        "Limite de flux atteinte",
+	"Message limit reached":       "Limite de messages atteinte",
+	"Window stream not found":     "Flux fenêtré introuvable",
+	"Window stream limit reached": "Limite de flux fenêtrés atteinte",
+	"Invalid stream name":         "Nom de flux invalide",
+	"Invalid window query":        "Requête de fenêtre invalide",
+	"Invalid record":              "Enregistrement invalide",
+	"Group limit exceeded":        "Limite de groupes dépassée",
+	"Record limit exceeded":       "Limite d'enregistrements dépassée",
+	"Request too large":           "Requête trop volumineuse",
+	"Invalid upload":              "Envoi invalide",
+	"Invalid file":                "Fichier invalide",
+	"History entry not found":     "Entrée d'historique introuvable",
+	"Invalid history query":       "Requête d'historique invalide",
+	"Error type not found":        "Type d'erreur introuvable",
+	"Method not found":            "Méthode introuvable",
+	"Batch too large":             "Lot trop volumineux",
+	"Contract drift":              "Écart au contrat",
+
+	// Messages of the error catalog
+	"the request is invalid":                                "la requête est invalide",
+	"the request body could not be decoded":                 "le corps de la requête n'a pas pu être décodé",
+	"the request cannot be served by this endpoint":         "cette route ne peut pas traiter la requête",
+	"internal error":                                        "erreur interne",
+	"internal server error":                                 "erreur interne du serveur",
+	"no route matches {method} {path}":                      "aucune route ne correspond à {method} {path}",
+	"method {method} is not allowed on {path}":              "la méthode {method} n'est pas autorisée sur {path}",
+	"this operation requires an authenticated consumer":     "cette opération nécessite un consommateur authentifié",
+	"the result is too large to be represented":             "le résultat est trop grand pour être représenté",
+	"the media type of the request body is not supported":   "le type de média du corps de la requête n'est pas pris en charge",
+	"none of the accepted media types can be produced":      "aucun des types de média acceptés ne peut être produit",
+	"the matrix or vector shape is invalid":                 "la forme de la matrice ou du vecteur est invalide",
+	"the operand dimensions do not match":                   "les dimensions des opérandes ne correspondent pas",
+	"the request holds too many elements":                   "la requête contient trop d'éléments",
+	"the modulus is invalid":                                "le module est invalide",
+	"the polynomial degree is invalid":                      "le degré du polynôme est invalide",
+	"the key size is invalid":                               "la taille de clé est invalide",
+	"the public key is invalid":                             "la clé publique est invalide",
+	"a ciphertext is invalid":                               "un chiffré est invalide",
+	`key "{key_id}" not found`:                              `clé "{key_id}" introuvable`,
+	"the key limit is reached":                              "la limite de clés est atteinte",
+	"the privacy parameters are invalid":                    "les paramètres de confidentialité sont invalides",
+	"the privacy budget is exhausted":                       "le budget de confidentialité est épuisé",
+	`session "{session_id}" not found`:                      `session "{session_id}" introuvable`,
+	"the session is closed":                                 "la session est fermée",
+	"the participant already submitted a share":             "le participant a déjà soumis une part",
+	"the participant is not part of the session":            "le participant ne fait pas partie de la session",
+	"too many open sessions":                                "trop de sessions ouvertes",
+	"too many sessions are held for this consumer":          "trop de sessions sont conservées pour ce client",
+	"the share is negative or wider than the modulus":       "la part est négative ou plus large que le module",
+	"the idempotency key is invalid":                        "la clé d'idempotence est invalide",
+	"the idempotency key was used with a different request": "la clé d'idempotence a été utilisée avec une autre requête",
+	"a request with this idempotency key is in progress":    "une requête avec cette clé d'idempotence est en cours",
+	"the idempotency store is full":                         "le stockage d'idempotence est plein",
+	`job "{job_id}" not found`:                              `tâche "{job_id}" introuvable`,
+	"the job has already finished":                          "la tâche est déjà terminée",
+	"too many jobs are pending for this consumer":           "trop de tâches sont en attente pour ce client",
+	"the job queue is full":                                 "la file des tâches est pleine",
+	"the service is shutting down":                          "le service est en cours d'arrêt",
+	`unknown job type "{type}"`:                             `type de tâche "{type}" inconnu`,
+	"the callback URL is invalid":                           "l'URL de rappel est invalide",
+	"callbacks are disabled":                                "les rappels sont désactivés",
+	"server-side key generation is disabled":                "la génération de clés côté serveur est désactivée",
+	`dead letter "{id}" not found`:                          `lettre morte "{id}" introuvable`,
+	`stream "{stream_id}" not found`:                        `flux "{stream_id}" introuvable`,
+	"the stream is closed":                                  "le flux est fermé",
+	"too many open streams":                                 "trop de flux ouverts",
+	"the stream message limit is reached":                   "la limite de messages du flux est atteinte",
+	`window stream "{name}" not found`:                      `flux fenêtré "{name}" introuvable`,
+	"too many window streams":                               "trop de flux fenêtrés",
+	"the stream name is invalid":                            "le nom du flux est invalide",
+	"the window query is invalid":                           "la requête de fenêtre est invalide",
+	"record {index} is invalid":                             "l'enregistrement {index} est invalide",
+	"the records form too many groups":                      "les enregistrements forment trop de groupes",
+	"the request holds too many records":                    "la requête contient trop d'enregistrements",
+	"the request body is too large":                         "le corps de la requête est trop volumineux",
+	"the upload parameters are invalid":                     "les paramètres de l'envoi sont invalides",
+	"the uploaded file cannot be parsed":                    "le fichier envoyé ne peut pas être analysé",
+	`history entry "{request_id}" not found`:                `entrée d'historique "{request_id}" introuvable`,
+	"the history query is invalid":                          "la requête d'historique est invalide",
+	`error code "{code}" is not in the catalog`:             `le code d'erreur "{code}" n'est pas dans le catalogue`,
+	`method "{method}" not found`:                           `méthode "{method}" introuvable`,
+	"the batch holds {count} calls, the limit is {max}":     "le lot contient {count} appels, la limite est de {max}",
+	"the response does not match the API contract":          "la réponse ne correspond pas au contrat d'API",
+
+	// Validation messages
+	"validation failed":                                          "la validation a échoué",
+	"%s (and %d more)":                                           "%s (et %d autre(s))",
+	"privacy is not accepted in notifications":                   "privacy n'est pas accepté dans les notifications",
+	"%s is required":                                             "%s est obligatoire",
+	"%s must be at least %s":                                     "%s doit valoir au moins %s",
+	"%s must be at most %s":                                      "%s doit valoir au plus %s",
+	"%s must be one of %s":                                       "%s doit valoir l'une des valeurs %s",
+	"%s failed the %s rule":                                      "%s ne respecte pas la règle %s",
+	"expected %s, got %s":                                        "type attendu : %s, reçu : %s",
+	"invalid JSON at offset %d: %s":                              "JSON invalide à la position %d : %s",
+	"request body is empty or truncated":                         "le corps de la requête est vide ou tronqué",
+	"at least %d %s are required, got %d":                        "au moins %d %s sont requis, %d reçu(s)",
+	"maximum %d %s allowed, got %d":                              "%d %s au maximum, %d reçus",
+	"%s must be a finite number":                                 "%s doit être un nombre fini",
+	"%s must be greater than %s":                                 "%s doit être supérieur à %s",
+	"%s must be between %g and %g, got %v":                       "%s doit être compris entre %g et %g, %v reçu",
+	"%s and %s are mutually exclusive":                           "%s et %s s'excluent mutuellement",
+	"points[%d]: timestamp is required":                          "points[%d] : l'horodatage est obligatoire",
+	"points[%d]: timestamp %s is in the future":                  "points[%d] : l'horodatage %s est dans le futur",
+	"stream names are 1 to 128 letters, digits, '.', '_' or '-'": "les noms de flux comptent 1 à 128 lettres, chiffres, '.', '_' ou '-'",
+	"records[%d]: field %q is missing":                           "records[%d] : le champ %q est absent",
+	"records[%d]: field %q must be a string":                     "records[%d] : le champ %q doit être une chaîne",
+	"records[%d]: field %q must be a number":                     "records[%d] : le champ %q doit être un nombre",
+	"records[%d]: field %q is out of range":                      "records[%d] : le champ %q est hors limites",
+	"records[%d]: field %q overflows the sum of its group":       "records[%d] : le champ %q fait déborder la somme de son groupe",
+	"records[%d]: group key %q is missing":                       "records[%d] : la clé de groupe %q est absente",
+	"records[%d]: group key %q must be a string":                 "records[%d] : la clé de groupe %q doit être une chaîne",
+	"records[%d]: maximum %d groups allowed":                     "records[%d] : %d groupes au maximum",
+	"records[%d]: maximum %d records allowed":                    "records[%d] : %d enregistrements au maximum",
+	"records[%d]: record must be an object":                      "records[%d] : l'enregistrement doit être un objet",
+	"at least 2 ciphertexts are required, got %d":                "au moins 2 chiffrés sont requis, %d reçu(s)",
+	"key size must be between %d and %d bits, got %d":            "la taille de clé doit être comprise entre %d et %d bits, %d reçu",
+	"at least 1 value is required":                               "au moins 1 valeur est requise",
+	"at least 2 polynomials are required, got %d":                "au moins 2 polynômes sont requis, %d reçu(s)",
+	"polynomial %d has %d coefficients, expected %d":             "le polynôme %d a %d coefficients, %d attendus",
+	"polynomial is empty":                                        "le polynôme est vide",
+	"coefficient %d of polynomial %d is wider than the modulus":  "le coefficient %d du polynôme %d est plus large que le module",
+	"value %d is wider than twice the modulus":                   "la valeur %d est plus large que le double du module",
+	"coefficient %d is wider than twice the modulus":             "le coefficient %d est plus large que le double du module",
+	"at least 2 vectors are required, got %d":                    "au moins 2 vecteurs sont requis, %d reçu(s)",
+	"vector %d is empty":                                         "le vecteur %d est vide",
+	"vector %d has length %d, expected %d":                       "le vecteur %d est de longueur %d, %d attendue",
+	"vector is empty":                                            "le vecteur est vide",
+	"vectors a and b must not be empty":                          "les vecteurs a et b ne doivent pas être vides",
+	"vector a has length %d but vector b has length %d":          "le vecteur a est de longueur %d mais le vecteur b de longueur %d",
+	"at least 2 matrices are required, got %d":                   "au moins 2 matrices sont requises, %d reçue(s)",
+	"matrix %d is %dx%d, expected %dx%d":                         "la matrice %d est de taille %dx%d, %dx%d attendue",
+	"cannot multiply %dx%d matrix by %dx%d matrix":               "impossible de multiplier une matrice %dx%d par une matrice %dx%d",
+	"%s is empty": "%s est vide",
+	"%s is not rectangular: all rows must have %d columns":       "%s n'est pas rectangulaire : toutes les lignes doivent avoir %d colonnes",
+	"maximum %d elements allowed, got %d":                        "%d éléments au maximum, %d reçus",
+	"callback_url must be an absolute http or https URL":         "callback_url doit être une URL http ou https absolue",
+	"callback_url must not contain credentials":                  "callback_url ne doit pas contenir d'identifiants",
+	"callback host %q is not allowed":                            "l'hôte de rappel %q n'est pas autorisé",
+	"the gaussian mechanism requires a delta in (0, 1), got %g":  "le mécanisme gaussien exige un delta dans (0, 1), %g reçu",
+	"the gaussian mechanism requires an epsilon below 1, got %g": "le mécanisme gaussien exige un epsilon inférieur à 1, %g reçu",
+	"delta is only accepted by the gaussian mechanism":           "delta n'est accepté que par le mécanisme gaussien",
+	"bounds are too far apart, got [%g, %g]":                     "les bornes sont trop éloignées, [%g, %g] reçu",
+	"epsilon %g is too small for the bounds":                     "epsilon %g est trop petit pour les bornes",
+	"bounds must be finite with lower < upper, got [%g, %g]":     "les bornes doivent être finies avec lower < upper, [%g, %g] reçu",
+	"at least 1 share is required":                               "au moins 1 part est requise",
+	"shares must not be negative":                                "les parts ne doivent pas être négatives",
+	"shares must be at most %d bits wide":                        "les parts doivent tenir sur au plus %d bits",
+	"batch is empty":                                             "le lot est vide",
+	"id must be a string, a number or null":                      "id doit être une chaîne, un nombre ou null",
+	"jsonrpc must be %q":                                         "jsonrpc doit valoir %q",
+	"at least 2 participants are required, got %d":               "au moins 2 participants sont requis, %d reçu(s)",
+	"maximum %d participants allowed, got %d":                    "%d participants au maximum, %d reçus",
+	"%d participant_ids given for %d participants":               "%d participant_ids fournis pour %d participants",
+	"participant_ids must be unique and non-empty":               "les participant_ids doivent être uniques et non vides",
+	"partial policy needs min_participants between 2 and %d":     "la politique partial exige un min_participants entre 2 et %d",
+	"dropout_policy must be %q or %q":                            "dropout_policy doit valoir %q ou %q",
+	"timeout %q is not a duration such as 30s":                   "timeout %q n'est pas une durée telle que 30s",
+	"timeout must be positive and at most %s":                    "timeout doit être positif et au plus %s",
+	"%s must match the pattern %s":                               "%s doit correspondre au motif %s",
+	"%s is not a valid %s value":                                 "%s n'est pas une valeur %s valide",
+	"%s does not match any allowed schema":                       "%s ne correspond à aucun des schémas autorisés",
+	"status %d is not documented":                                "le statut %d n'est pas documenté",
+	"response body is not valid JSON: %s":                        "le corps de la réponse n'est pas un JSON valide : %s",
+
+	// Phrases naming JSON types
+	"a value":    "valeur",
+	"a boolean":  "booléen",
+	"an integer": "entier",
+	"a number":   "nombre",
+	"a string":   "chaîne",
+	"an array":   "tableau",
+	"an object":  "objet",
+	"bool":       "booléen",
+	"number":     "nombre",
+	"string":     "chaîne",
+	"array":      "tableau",
+	"object":     "objet",
+
+	// Phrases naming collections in count messages
+	"numbers": "nombres",
+
+	// Phrases naming parts of a message
+	"the body": "le corps",
+}
@@ -0,0 +1,108 @@
+// Package i18n renders client messages in the locale chosen by Accept-Language
+// Messages are keyed by their English format string, so that untranslated
+// messages fall back to English
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported locales
+const (
+	English = "en"
+	French  = "fr"
+)
+
+// Default is the locale used when the client accepts none of the supported ones
+const Default = English
+
+// catalogs maps a locale to the translations of English format strings
+var catalogs = map[string]map[string]string{
+	French: french,
+}
+
+// Phrase is a message argument that is translated along with the message
+type Phrase string
+
+// Supported returns the supported locales, the default first
+func Supported() []string {
+	locales := []string{Default}
+	for locale := range catalogs {
+		if locale != Default {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// Negotiate selects a locale from an Accept-Language header (RFC 9110)
+// The highest-quality supported language range wins; ties keep the client's order.
+// A region such as fr-CA falls back to its language, and anything else to Default
+func Negotiate(acceptLanguage string) string {
+	type languageRange struct {
+		tag     string
+		quality float64
+	}
+
+	var ranges []languageRange
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if q, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				var err error
+				if quality, err = strconv.ParseFloat(q, 64); err != nil {
+					quality = 0
+				}
+			}
+		}
+		if quality > 0 {
+			ranges = append(ranges, languageRange{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	for _, r := range ranges {
+		if r.tag == "*" {
+			return Default
+		}
+		language, _, _ := strings.Cut(r.tag, "-")
+		if language == Default {
+			return Default
+		}
+		if _, ok := catalogs[language]; ok {
+			return language
+		}
+	}
+	return Default
+}
+
+// Translate returns the translation of an English format string, or the format itself
+func Translate(locale, format string) string {
+	if translated, ok := catalogs[locale][format]; ok {
+		return translated
+	}
+	return format
+}
+
+// Sprintf formats a message in locale
+// Phrase arguments are translated as well
+func Sprintf(locale, format string, args ...interface{}) string {
+	translated := make([]interface{}, len(args))
+	for i, arg := range args {
+		if phrase, ok := arg.(Phrase); ok {
+			arg = Translate(locale, string(phrase))
+		}
+		translated[i] = arg
+	}
+	return fmt.Sprintf(Translate(locale, format), translated...)
+}
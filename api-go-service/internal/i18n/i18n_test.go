@@ -0,0 +1,40 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		expected       string
+	}{
+		{"", English},
+		{"fr", French},
+		{"FR-ca", French},
+		{"de-DE, fr;q=0.8, en;q=0.5", French},
+		{"en;q=0.5, fr;q=0.9", French},
+		{"fr;q=0, en", English},
+		{"de, *;q=0.1", English},
+		{"de, ja", English},
+		{"fr;q=invalid, en-GB", English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Negotiate(tt.acceptLanguage))
+		})
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	assert.Equal(t, "au moins 2 nombres sont requis, 1 reçu(s)", Sprintf(French, "at least %d %s are required, got %d", 2, Phrase("numbers"), 1))
+	assert.Equal(t, "type attendu : nombre, reçu : chaîne", Sprintf(French, "expected %s, got %s", Phrase("a number"), Phrase("string")))
+	assert.Equal(t, "expected a number, got string", Sprintf(English, "expected %s, got %s", Phrase("a number"), Phrase("string")))
+
+	// Messages without a translation fall back to English
+	assert.Equal(t, "no translation for 3", Sprintf(French, "no translation for %d", 3))
+	assert.Equal(t, []string{English, French}, Supported())
+}
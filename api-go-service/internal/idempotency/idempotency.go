@@ -0,0 +1,172 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrStoreFull is returned when no more keys can be reserved or no more responses stored
+var ErrStoreFull = errors.New("idempotency store is full")
+
+// Record is the stored outcome of a request made with an idempotency key
+// Until Completed is set the original request is still in flight
+type Record struct {
+	Fingerprint string
+	Completed   bool
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Store keeps idempotency records
+// Implementations must make Reserve atomic so that exactly one concurrent
+// request wins a key
+type Store interface {
+	// Reserve claims key for a new request with the given fingerprint
+	// If the key is already held and has not expired, the existing record
+	// is returned and reserved is false
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (existing *Record, reserved bool, err error)
+
+	// Complete stores the response of the request holding key
+	// It returns ErrStoreFull when the response does not fit in the store
+	Complete(ctx context.Context, key string, record Record) error
+
+	// Release drops a reservation so the key can be retried
+	Release(ctx context.Context, key string) error
+}
+
+// MemoryStore is an in-memory Store
+// Records are lost on restart and are not shared between replicas
+type MemoryStore struct {
+	maxKeys  int
+	maxBytes int
+	now      func() time.Time
+
+	mu      sync.Mutex
+	records map[string]*Record
+	bytes   int // stored size of all records
+}
+
+// NewMemoryStore creates an in-memory store holding at most maxKeys records
+// whose responses take at most maxBytes in total; zero disables either bound
+func NewMemoryStore(maxKeys, maxBytes int) *MemoryStore {
+	return &MemoryStore{
+		maxKeys:  maxKeys,
+		maxBytes: maxBytes,
+		now:      time.Now,
+		records:  make(map[string]*Record),
+	}
+}
+
+// Reserve implements Store
+func (s *MemoryStore) Reserve(_ context.Context, key, fingerprint string, ttl time.Duration) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if record, ok := s.records[key]; ok {
+		if now.Before(record.ExpiresAt) {
+			copied := *record
+			return &copied, false, nil
+		}
+		s.remove(key)
+	}
+
+	if s.maxKeys > 0 && len(s.records) >= s.maxKeys {
+		s.sweep(now)
+		if len(s.records) >= s.maxKeys {
+			return nil, false, ErrStoreFull
+		}
+	}
+
+	record := &Record{
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+	s.records[key] = record
+	s.bytes += record.size()
+	return nil, true, nil
+}
+
+// Complete implements Store
+func (s *MemoryStore) Complete(_ context.Context, key string, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.records[key]
+	if !ok {
+		return nil
+	}
+
+	if s.maxBytes > 0 && s.bytes-existing.size()+record.size() > s.maxBytes {
+		s.sweep(s.now())
+		if s.bytes-existing.size()+record.size() > s.maxBytes {
+			return ErrStoreFull
+		}
+	}
+
+	record.Completed = true
+	record.CreatedAt = existing.CreatedAt
+	record.ExpiresAt = existing.ExpiresAt
+	s.bytes += record.size() - existing.size()
+	s.records[key] = &record
+	return nil
+}
+
+// Release implements Store
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(key)
+	return nil
+}
+
+// Len returns the number of records held
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}
+
+// Bytes returns the stored size of the records held
+func (s *MemoryStore) Bytes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bytes
+}
+
+// remove deletes a record
+func (s *MemoryStore) remove(key string) {
+	if record, ok := s.records[key]; ok {
+		s.bytes -= record.size()
+		delete(s.records, key)
+	}
+}
+
+// sweep removes expired records
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, record := range s.records {
+		if !now.Before(record.ExpiresAt) {
+			s.remove(key)
+		}
+	}
+}
+
+// size approximates the memory held by a record's response
+func (r *Record) size() int {
+	n := len(r.Fingerprint) + len(r.Body)
+	for name, values := range r.Header {
+		n += len(name)
+		for _, v := range values {
+			n += len(v)
+		}
+	}
+	return n
+}
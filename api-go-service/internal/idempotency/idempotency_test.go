@@ -0,0 +1,115 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore(2, 0)
+	testStore(t, s, func(now func() time.Time) { s.now = now })
+}
+
+func TestStoreBytes(t *testing.T) {
+	db := storage.NewMemoryStore()
+	_, _, err := storage.Migrate(db, storage.Migrations)
+	require.NoError(t, err)
+	persistent, err := NewPersistentStore(db, 0, 512)
+	require.NoError(t, err)
+
+	for name, s := range map[string]interface {
+		Store
+		Bytes() int
+	}{"Memory": NewMemoryStore(0, 256), "Persistent": persistent} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for _, key := range []string{"a", "b"} {
+				_, reserved, err := s.Reserve(ctx, key, "fp", time.Minute)
+				require.NoError(t, err)
+				require.True(t, reserved)
+			}
+
+			require.NoError(t, s.Complete(ctx, "a", Record{Fingerprint: "fp", StatusCode: 200, Body: make([]byte, 100)}))
+			used := s.Bytes()
+
+			// A response that does not fit is refused and the reservation is kept for the caller to release
+			assert.ErrorIs(t, s.Complete(ctx, "b", Record{Fingerprint: "fp", StatusCode: 200, Body: make([]byte, 1000)}), ErrStoreFull)
+			assert.Equal(t, used, s.Bytes())
+
+			require.NoError(t, s.Release(ctx, "a"))
+			require.NoError(t, s.Release(ctx, "b"))
+			assert.Zero(t, s.Bytes())
+		})
+	}
+}
+
+func TestPersistentStore(t *testing.T) {
+	db := storage.NewMemoryStore()
+	_, _, err := storage.Migrate(db, storage.Migrations)
+	require.NoError(t, err)
+
+	s, err := NewPersistentStore(db, 2, 0)
+	require.NoError(t, err)
+	testStore(t, s, func(now func() time.Time) { s.now = now })
+
+	// Records and their count are read back from storage
+	reopened, err := NewPersistentStore(db, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reopened.Len())
+	assert.Equal(t, s.Bytes(), reopened.Bytes())
+	existing, reserved, err := reopened.Reserve(context.Background(), "c", "fp", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Equal(t, "fp", existing.Fingerprint)
+}
+
+// testStore runs the behaviour shared by Store implementations on a store bounded to two keys
+func testStore(t *testing.T, s Store, setNow func(func() time.Time)) {
+	ctx := context.Background()
+
+	existing, reserved, err := s.Reserve(ctx, "a", "fp1", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Nil(t, existing)
+
+	t.Run("Concurrent reservation sees the in-flight record", func(t *testing.T) {
+		existing, reserved, err := s.Reserve(ctx, "a", "fp1", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.False(t, existing.Completed)
+	})
+
+	t.Run("Completed record is returned to retries", func(t *testing.T) {
+		require.NoError(t, s.Complete(ctx, "a", Record{Fingerprint: "fp1", StatusCode: 200, Body: []byte("ok")}))
+
+		existing, reserved, err := s.Reserve(ctx, "a", "fp1", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.True(t, existing.Completed)
+		assert.Equal(t, []byte("ok"), existing.Body)
+	})
+
+	t.Run("Store is bounded", func(t *testing.T) {
+		_, _, err := s.Reserve(ctx, "b", "fp", time.Minute)
+		require.NoError(t, err)
+		_, _, err = s.Reserve(ctx, "c", "fp", time.Minute)
+		assert.ErrorIs(t, err, ErrStoreFull)
+
+		require.NoError(t, s.Release(ctx, "b"))
+		_, reserved, err := s.Reserve(ctx, "c", "fp", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+
+	t.Run("Expired records are replaced", func(t *testing.T) {
+		setNow(func() time.Time { return time.Now().Add(2 * time.Minute) })
+		_, reserved, err := s.Reserve(ctx, "a", "fp2", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+}
@@ -0,0 +1,204 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/storage"
+)
+
+// PersistentStore is a Store kept in the service storage
+// Records survive restarts when the storage backend does. The store must be
+// the only writer of its bucket, since it keeps count of the records held
+// and of their encoded size
+type PersistentStore struct {
+	db       storage.Store
+	maxKeys  int
+	maxBytes int
+	now      func() time.Time
+
+	mu    sync.Mutex
+	held  int
+	bytes int
+}
+
+// NewPersistentStore creates a store holding at most maxKeys records in db,
+// taking at most maxBytes once encoded; zero disables either bound
+func NewPersistentStore(db storage.Store, maxKeys, maxBytes int) (*PersistentStore, error) {
+	s := &PersistentStore{
+		db:       db,
+		maxKeys:  maxKeys,
+		maxBytes: maxBytes,
+		now:      time.Now,
+	}
+	err := db.View(func(tx storage.Tx) error {
+		return tx.ForEach(storage.BucketIdempotency, func(_ string, value []byte) error {
+			s.held++
+			s.bytes += len(value)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reserve implements Store
+func (s *PersistentStore) Reserve(_ context.Context, key, fingerprint string, ttl time.Duration) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var existing *Record
+	reserved := false
+	held, bytes := s.held, s.bytes
+
+	err := s.db.Update(func(tx storage.Tx) error {
+		now := s.now()
+		if value := tx.Get(storage.BucketIdempotency, key); value != nil {
+			var record Record
+			if err := json.Unmarshal(value, &record); err != nil {
+				return err
+			}
+			if now.Before(record.ExpiresAt) {
+				existing = &record
+				return nil
+			}
+			if err := tx.Delete(storage.BucketIdempotency, key); err != nil {
+				return err
+			}
+			held--
+			bytes -= len(value)
+		}
+
+		if s.maxKeys > 0 && held >= s.maxKeys {
+			var err error
+			if held, bytes, err = s.sweep(tx, now); err != nil {
+				return err
+			}
+			if held >= s.maxKeys {
+				return ErrStoreFull
+			}
+		}
+
+		value, err := encode(&Record{
+			Fingerprint: fingerprint,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		})
+		if err != nil {
+			return err
+		}
+		reserved = true
+		held++
+		bytes += len(value)
+		return tx.Put(storage.BucketIdempotency, key, value)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	s.held, s.bytes = held, bytes
+	return existing, reserved, nil
+}
+
+// Complete implements Store
+func (s *PersistentStore) Complete(_ context.Context, key string, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	held, bytes := s.held, s.bytes
+	err := s.db.Update(func(tx storage.Tx) error {
+		previous := tx.Get(storage.BucketIdempotency, key)
+		if previous == nil {
+			return nil
+		}
+		var existing Record
+		if err := json.Unmarshal(previous, &existing); err != nil {
+			return err
+		}
+
+		record.Completed = true
+		record.CreatedAt = existing.CreatedAt
+		record.ExpiresAt = existing.ExpiresAt
+		value, err := encode(&record)
+		if err != nil {
+			return err
+		}
+
+		if s.maxBytes > 0 && bytes-len(previous)+len(value) > s.maxBytes {
+			if held, bytes, err = s.sweep(tx, s.now()); err != nil {
+				return err
+			}
+			// The reservation itself has not expired, so the sweep kept it
+			if bytes-len(previous)+len(value) > s.maxBytes {
+				return ErrStoreFull
+			}
+		}
+
+		bytes += len(value) - len(previous)
+		return tx.Put(storage.BucketIdempotency, key, value)
+	})
+	if err != nil {
+		return err
+	}
+	s.held, s.bytes = held, bytes
+	return nil
+}
+
+// Release implements Store
+func (s *PersistentStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	held, bytes := s.held, s.bytes
+	err := s.db.Update(func(tx storage.Tx) error {
+		value := tx.Get(storage.BucketIdempotency, key)
+		if value == nil {
+			return nil
+		}
+		held--
+		bytes -= len(value)
+		return tx.Delete(storage.BucketIdempotency, key)
+	})
+	if err != nil {
+		return err
+	}
+	s.held, s.bytes = held, bytes
+	return nil
+}
+
+// Len returns the number of records held
+func (s *PersistentStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.held
+}
+
+// Bytes returns the encoded size of the records held
+func (s *PersistentStore) Bytes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bytes
+}
+
+// encode serializes a record for storage
+func encode(record *Record) ([]byte, error) {
+	return json.Marshal(record)
+}
+
+// sweep removes expired records and returns the number and size of the records left
+func (s *PersistentStore) sweep(tx storage.Tx, now time.Time) (int, int, error) {
+	held, bytes := 0, 0
+	err := tx.ForEach(storage.BucketIdempotency, func(key string, value []byte) error {
+		var record Record
+		if err := json.Unmarshal(value, &record); err != nil || !now.Before(record.ExpiresAt) {
+			return tx.Delete(storage.BucketIdempotency, key)
+		}
+		held++
+		bytes += len(value)
+		return nil
+	})
+	return held, bytes, err
+}
@@ -0,0 +1,434 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported file formats
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// Errors returned for uploads that cannot be summed
+var (
+	ErrInvalidOptions = errors.New("invalid upload options")
+	ErrInvalidFile    = errors.New("invalid file")
+	ErrOverflow       = errors.New("column sum overflows")
+)
+
+// Options configures how a file is parsed
+// Without header, columns are named by their 1-based position
+type Options struct {
+	Columns      []string // columns to sum; empty for every numeric column of the first row
+	Header       bool     // CSV only: the first line names the columns
+	Delimiter    rune     // CSV only
+	Decimal      rune     // decimal separator, '.' or ','
+	MaxColumns   int
+	MaxErrors    int // row errors reported; further ones are only counted
+	MaxLineBytes int // longest line accepted, including each line of a quoted CSV field
+}
+
+// Validate checks that the options are consistent
+func (o Options) Validate() error {
+	if o.Decimal != '.' && o.Decimal != ',' {
+		return fmt.Errorf("%w: decimal separator must be '.' or ','", ErrInvalidOptions)
+	}
+	if o.Delimiter == o.Decimal {
+		return fmt.Errorf("%w: delimiter and decimal separator must differ", ErrInvalidOptions)
+	}
+	if o.Delimiter == '"' || o.Delimiter == '\r' || o.Delimiter == '\n' || o.Delimiter == 0 {
+		return fmt.Errorf("%w: delimiter %q is not allowed", ErrInvalidOptions, o.Delimiter)
+	}
+	if o.MaxColumns > 0 && len(o.Columns) > o.MaxColumns {
+		return fmt.Errorf("%w: maximum %d columns allowed, got %d", ErrInvalidOptions, o.MaxColumns, len(o.Columns))
+	}
+	return nil
+}
+
+// Column holds the statistics of one summed column
+// Empty counts the rows where the column was blank or absent
+type Column struct {
+	Name  string
+	Count int
+	Empty int
+	Sum   float64
+	Min   float64
+	Max   float64
+}
+
+// Mean returns the mean of the column, or false when it has no values
+func (c *Column) Mean() (float64, bool) {
+	if c.Count == 0 {
+		return 0, false
+	}
+	return c.Sum / float64(c.Count), true
+}
+
+// add includes one value in the statistics
+func (c *Column) add(v float64) {
+	if c.Count == 0 {
+		c.Min, c.Max = v, v
+	}
+	c.Count++
+	c.Sum += v
+	c.Min = math.Min(c.Min, v)
+	c.Max = math.Max(c.Max, v)
+}
+
+// RowError reports a row that could not be parsed; the row is skipped
+type RowError struct {
+	Line   int
+	Column string
+	Err    error
+}
+
+// Error implements the error interface
+func (e *RowError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("line %d: column %q: %v", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Summary is the outcome of summing a file
+// ErrorCount includes the row errors beyond those listed in Errors
+type Summary struct {
+	Columns    []*Column
+	Rows       int // rows summed
+	Skipped    int // rows skipped because of errors
+	Errors     []*RowError
+	ErrorCount int
+}
+
+// summer accumulates the selected columns row by row
+type summer struct {
+	opts    Options
+	summary Summary
+	index   []int // position of each selected column in a row, CSV only
+}
+
+// fail records a row error and skips the row
+func (s *summer) fail(line int, column string, err error) {
+	s.summary.Skipped++
+	s.summary.ErrorCount++
+	if s.opts.MaxErrors <= 0 || len(s.summary.Errors) < s.opts.MaxErrors {
+		s.summary.Errors = append(s.summary.Errors, &RowError{Line: line, Column: column, Err: err})
+	}
+}
+
+// selectColumns fixes the summed columns
+func (s *summer) selectColumns(names []string) error {
+	if len(names) == 0 {
+		return fmt.Errorf("%w: no numeric column found in the first row", ErrInvalidFile)
+	}
+	if s.opts.MaxColumns > 0 && len(names) > s.opts.MaxColumns {
+		return fmt.Errorf("%w: maximum %d columns allowed, got %d", ErrInvalidFile, s.opts.MaxColumns, len(names))
+	}
+	for _, name := range names {
+		s.summary.Columns = append(s.summary.Columns, &Column{Name: name})
+	}
+	return nil
+}
+
+// parse converts a cell to a number, honouring the decimal separator
+// Blank cells are reported as absent
+func (s *summer) parse(cell string) (float64, bool, error) {
+	cell = strings.TrimSpace(cell)
+	if cell == "" {
+		return 0, false, nil
+	}
+	if s.opts.Decimal == ',' {
+		if strings.Contains(cell, ".") {
+			return 0, false, fmt.Errorf("%q is not a number with ',' as decimal separator", cell)
+		}
+		cell = strings.Replace(cell, ",", ".", 1)
+	}
+	v, err := strconv.ParseFloat(cell, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false, fmt.Errorf("%q is not a number", cell)
+	}
+	return v, true, nil
+}
+
+// SumCSV streams a CSV file and sums the selected columns
+// Malformed rows are reported with their line number and skipped
+func SumCSV(r io.Reader, opts Options) (*Summary, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	s := &summer{opts: opts}
+
+	maxLine := opts.MaxLineBytes
+	if maxLine <= 0 {
+		maxLine = bufio.MaxScanTokenSize
+	}
+	reader := csv.NewReader(&lineLimiter{r: r, max: maxLine, line: 1})
+	reader.Comma = opts.Delimiter
+	reader.ReuseRecord = true
+	reader.FieldsPerRecord = -1
+
+	var names []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// The reader resumes at the line after a malformed record
+			var parseErr *csv.ParseError
+			var tooLong *lineTooLongError
+			if errors.As(err, &tooLong) {
+				return nil, fmt.Errorf("%w: line %d is longer than %d bytes", ErrInvalidFile, tooLong.line, maxLine)
+			}
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			s.fail(parseErr.StartLine, "", parseErr.Err)
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		if names == nil {
+			if opts.Header {
+				names = append([]string(nil), record...)
+			} else {
+				for i := range record {
+					names = append(names, strconv.Itoa(i+1))
+				}
+			}
+			if len(opts.Columns) > 0 {
+				if err := s.selectColumns(opts.Columns); err != nil {
+					return nil, err
+				}
+				if err := s.resolve(names); err != nil {
+					return nil, err
+				}
+			}
+			if opts.Header {
+				continue
+			}
+		}
+
+		if s.index == nil {
+			// Without explicit columns, the numeric cells of the first data row select them
+			var detected []string
+			for i, cell := range record {
+				if _, ok, err := s.parse(cell); ok && err == nil && i < len(names) {
+					detected = append(detected, names[i])
+				}
+			}
+			if err := s.selectColumns(detected); err != nil {
+				return nil, err
+			}
+			if err := s.resolve(names); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.row(line, record); err != nil {
+			return nil, err
+		}
+	}
+
+	if names == nil {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidFile)
+	}
+	return &s.summary, nil
+}
+
+// resolve maps the summed columns to their position in a CSV row
+func (s *summer) resolve(names []string) error {
+	positions := make(map[string]int, len(names))
+	for i, name := range names {
+		if _, ok := positions[name]; !ok {
+			positions[name] = i
+		}
+	}
+	s.index = make([]int, len(s.summary.Columns))
+	for i, column := range s.summary.Columns {
+		position, ok := positions[column.Name]
+		if !ok {
+			return fmt.Errorf("%w: column %q is not in the file", ErrInvalidFile, column.Name)
+		}
+		s.index[i] = position
+	}
+	return nil
+}
+
+// row adds one CSV row, or skips it if any summed cell is not a number
+func (s *summer) row(line int, record []string) error {
+	values := make([]float64, len(s.index))
+	present := make([]bool, len(s.index))
+	for i, position := range s.index {
+		if position >= len(record) {
+			continue
+		}
+		v, ok, err := s.parse(record[position])
+		if err != nil {
+			s.fail(line, s.summary.Columns[i].Name, err)
+			return nil
+		}
+		values[i], present[i] = v, ok
+	}
+	return s.commit(line, values, present)
+}
+
+// commit adds the values of an accepted row
+// A row that would make a column sum overflow fails the whole file,
+// since no total can be reported for that column
+func (s *summer) commit(line int, values []float64, present []bool) error {
+	for i, column := range s.summary.Columns {
+		if present[i] && math.IsInf(column.Sum+values[i], 0) {
+			return fmt.Errorf("%w: line %d: column %q", ErrOverflow, line, column.Name)
+		}
+	}
+	s.summary.Rows++
+	for i, column := range s.summary.Columns {
+		if present[i] {
+			column.add(values[i])
+		} else {
+			column.Empty++
+		}
+	}
+	return nil
+}
+
+// SumJSONL streams a JSON Lines file and sums the selected fields
+// Each line holds one object; blank lines are ignored
+func SumJSONL(r io.Reader, opts Options) (*Summary, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	s := &summer{opts: opts}
+	if len(opts.Columns) > 0 {
+		if err := s.selectColumns(opts.Columns); err != nil {
+			return nil, err
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	maxLine := opts.MaxLineBytes
+	if maxLine <= 0 {
+		maxLine = bufio.MaxScanTokenSize
+	}
+	scanner.Buffer(make([]byte, 0, min(maxLine, 64*1024)), maxLine)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(text, &object); err != nil || object == nil {
+			s.fail(line, "", errors.New("line is not a JSON object"))
+			continue
+		}
+
+		if len(s.summary.Columns) == 0 {
+			var detected []string
+			for name, raw := range object {
+				if isJSONNumber(raw) {
+					detected = append(detected, name)
+				}
+			}
+			sort.Strings(detected)
+			if err := s.selectColumns(detected); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.object(line, object); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, fmt.Errorf("%w: line %d is longer than %d bytes", ErrInvalidFile, line+1, maxLine)
+		}
+		return nil, err
+	}
+	return &s.summary, nil
+}
+
+// object adds one JSON Lines object, or skips it if any summed field is not a number
+// Fields that are absent or null count as empty
+func (s *summer) object(line int, object map[string]json.RawMessage) error {
+	values := make([]float64, len(s.summary.Columns))
+	present := make([]bool, len(s.summary.Columns))
+	for i, column := range s.summary.Columns {
+		raw, ok := object[column.Name]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+		if !isJSONNumber(raw) {
+			s.fail(line, column.Name, errors.New("value is not a number"))
+			return nil
+		}
+		if err := json.Unmarshal(raw, &values[i]); err != nil {
+			s.fail(line, column.Name, errors.New("value is out of range"))
+			return nil
+		}
+		present[i] = true
+	}
+	return s.commit(line, values, present)
+}
+
+// isJSONNumber reports whether raw is a JSON number literal
+func isJSONNumber(raw json.RawMessage) bool {
+	return len(raw) > 0 && (raw[0] == '-' || (raw[0] >= '0' && raw[0] <= '9'))
+}
+
+// lineTooLongError reports a line longer than the limit of a lineLimiter
+type lineTooLongError struct {
+	line int
+}
+
+// Error implements the error interface
+func (e *lineTooLongError) Error() string {
+	return fmt.Sprintf("line %d is too long", e.line)
+}
+
+// lineLimiter fails once a line read from r grows past max bytes
+// It keeps csv.Reader from buffering an unbounded line or quoted field
+type lineLimiter struct {
+	r      io.Reader
+	max    int
+	length int // bytes of the current line read so far
+	line   int // 1-based number of the current line
+}
+
+// Read implements io.Reader
+func (l *lineLimiter) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	for i := 0; i < n; {
+		end := bytes.IndexByte(p[i:n], '\n')
+		if end < 0 {
+			end = n - i
+		}
+		if l.length+end > l.max {
+			return i + l.max - l.length, &lineTooLongError{line: l.line}
+		}
+		if i+end == n {
+			l.length += end
+			break
+		}
+		l.length = 0
+		l.line++
+		i += end + 1
+	}
+	return n, err
+}
@@ -0,0 +1,150 @@
+package ingest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func defaultOptions() Options {
+	return Options{Header: true, Delimiter: ',', Decimal: '.', MaxColumns: 10, MaxErrors: 10}
+}
+
+func TestSumCSV(t *testing.T) {
+	input := "region,sales,units\n" +
+		"eu,10.5,2\n" +
+		"us,4,\n" +
+		"ap,oops,1\n" +
+		"\"bad\"quote,1,1\n" +
+		"eu,0.5,3\n"
+
+	summary, err := SumCSV(strings.NewReader(input), defaultOptions())
+	require.NoError(t, err)
+
+	// Numeric columns of the first data row are selected
+	require.Len(t, summary.Columns, 2)
+	sales, units := summary.Columns[0], summary.Columns[1]
+	assert.Equal(t, "sales", sales.Name)
+	assert.Equal(t, 15.0, sales.Sum)
+	assert.Equal(t, 3, sales.Count)
+	assert.Equal(t, 0.5, sales.Min)
+	assert.Equal(t, 10.5, sales.Max)
+	mean, ok := sales.Mean()
+	assert.True(t, ok)
+	assert.Equal(t, 5.0, mean)
+	assert.Equal(t, 5.0, units.Sum)
+	assert.Equal(t, 1, units.Empty)
+
+	assert.Equal(t, 3, summary.Rows)
+	assert.Equal(t, 2, summary.Skipped)
+	require.Len(t, summary.Errors, 2)
+	assert.Equal(t, 4, summary.Errors[0].Line)
+	assert.Equal(t, "sales", summary.Errors[0].Column)
+	assert.Equal(t, 5, summary.Errors[1].Line)
+}
+
+func TestSumCSV_Options(t *testing.T) {
+	t.Run("Semicolons and decimal commas", func(t *testing.T) {
+		opts := defaultOptions()
+		opts.Delimiter, opts.Decimal = ';', ','
+		opts.Columns = []string{"amount"}
+
+		summary, err := SumCSV(strings.NewReader("name;amount\na;1,5\nb;2,25\nc;3.5\n"), opts)
+		require.NoError(t, err)
+		assert.Equal(t, 3.75, summary.Columns[0].Sum)
+		require.Len(t, summary.Errors, 1)
+		assert.Equal(t, 4, summary.Errors[0].Line)
+	})
+
+	t.Run("Without header, columns are positions", func(t *testing.T) {
+		opts := defaultOptions()
+		opts.Header = false
+		opts.Delimiter = '\t'
+		opts.Columns = []string{"2"}
+
+		summary, err := SumCSV(strings.NewReader("x\t1\ny\t2\n"), opts)
+		require.NoError(t, err)
+		assert.Equal(t, "2", summary.Columns[0].Name)
+		assert.Equal(t, 3.0, summary.Columns[0].Sum)
+		assert.Equal(t, 2, summary.Rows)
+	})
+
+	t.Run("Only the first errors are listed", func(t *testing.T) {
+		opts := defaultOptions()
+		opts.MaxErrors = 2
+		summary, err := SumCSV(strings.NewReader("v\n1\nx\nx\nx\n"), opts)
+		require.NoError(t, err)
+		assert.Len(t, summary.Errors, 2)
+		assert.Equal(t, 3, summary.ErrorCount)
+	})
+
+	t.Run("Invalid files and options", func(t *testing.T) {
+		opts := defaultOptions()
+		opts.Columns = []string{"missing"}
+		_, err := SumCSV(strings.NewReader("a,b\n1,2\n"), opts)
+		assert.True(t, errors.Is(err, ErrInvalidFile))
+
+		_, err = SumCSV(strings.NewReader(""), defaultOptions())
+		assert.True(t, errors.Is(err, ErrInvalidFile))
+
+		_, err = SumCSV(strings.NewReader("a\nx\n"), defaultOptions())
+		assert.True(t, errors.Is(err, ErrInvalidFile))
+
+		opts = defaultOptions()
+		opts.Decimal = ','
+		_, err = SumCSV(strings.NewReader("a\n1\n"), opts)
+		assert.True(t, errors.Is(err, ErrInvalidOptions))
+	})
+
+	t.Run("Long lines are refused, even within a quoted field", func(t *testing.T) {
+		opts := defaultOptions()
+		opts.MaxLineBytes = 16
+		summary, err := SumCSV(strings.NewReader("a,b\n1,\"0123456789\"\n"), opts)
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.Rows)
+
+		_, err = SumCSV(strings.NewReader("a,b\n1,2\n1,\"0123456789\n0123456789012345678\"\n"), opts)
+		assert.True(t, errors.Is(err, ErrInvalidFile))
+		assert.Contains(t, err.Error(), "line 4")
+	})
+
+	t.Run("Overflowing sums are refused", func(t *testing.T) {
+		_, err := SumCSV(strings.NewReader("a\n1e308\n1e308\n"), defaultOptions())
+		assert.True(t, errors.Is(err, ErrOverflow))
+		assert.Contains(t, err.Error(), "line 3")
+	})
+}
+
+func TestSumJSONL(t *testing.T) {
+	input := `{"region": "eu", "sales": 10.5, "units": 2}
+{"region": "us", "sales": 4}
+
+{"region": "ap", "sales": "oops", "units": 1}
+not json
+{"region": "eu", "sales": 0.5, "units": null}
+`
+	summary, err := SumJSONL(strings.NewReader(input), defaultOptions())
+	require.NoError(t, err)
+
+	require.Len(t, summary.Columns, 2)
+	assert.Equal(t, "sales", summary.Columns[0].Name)
+	assert.Equal(t, 15.0, summary.Columns[0].Sum)
+	assert.Equal(t, "units", summary.Columns[1].Name)
+	assert.Equal(t, 2, summary.Columns[1].Empty)
+
+	assert.Equal(t, 3, summary.Rows)
+	require.Len(t, summary.Errors, 2)
+	assert.Equal(t, 4, summary.Errors[0].Line)
+	assert.Equal(t, 5, summary.Errors[1].Line)
+
+	opts := defaultOptions()
+	opts.MaxLineBytes = 16
+	_, err = SumJSONL(strings.NewReader(input), opts)
+	assert.True(t, errors.Is(err, ErrInvalidFile))
+
+	_, err = SumJSONL(strings.NewReader(`{"v": 1e308}`+"\n"+`{"v": 1e308}`+"\n"), defaultOptions())
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/storage"
+)
+
+// StorageArchive is an Archive kept in the service storage
+// Results are stored as JSON and read back as generic JSON values
+type StorageArchive struct {
+	db storage.Store
+}
+
+// NewStorageArchive creates an archive of finished jobs in db
+func NewStorageArchive(db storage.Store) *StorageArchive {
+	return &StorageArchive{db: db}
+}
+
+// Save implements Archive
+func (a *StorageArchive) Save(snap *Snapshot) error {
+	value, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	return a.db.Update(func(tx storage.Tx) error {
+		return tx.Put(storage.BucketJobs, snap.ID, value)
+	})
+}
+
+// Load implements Archive
+func (a *StorageArchive) Load(id string) (*Snapshot, error) {
+	var snap *Snapshot
+	err := a.db.View(func(tx storage.Tx) error {
+		value := tx.Get(storage.BucketJobs, id)
+		if value == nil {
+			return ErrJobNotFound
+		}
+		snap = &Snapshot{}
+		return json.Unmarshal(value, snap)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// Prune implements Archive
+func (a *StorageArchive) Prune(before time.Time) (int, error) {
+	pruned := 0
+	err := a.db.Update(func(tx storage.Tx) error {
+		return tx.ForEach(storage.BucketJobs, func(id string, value []byte) error {
+			var snap Snapshot
+			if err := json.Unmarshal(value, &snap); err != nil || snap.FinishedAt.Before(before) {
+				pruned++
+				return tx.Delete(storage.BucketJobs, id)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
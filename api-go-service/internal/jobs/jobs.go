@@ -0,0 +1,546 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// State is the lifecycle state of a job
+type State string
+
+// Job states
+const (
+	StateQueued    State = "queued"
+	StateRunning   State = "running"
+	StateSucceeded State = "succeeded"
+	StateFailed    State = "failed"
+	StateCancelled State = "cancelled"
+)
+
+// Finished reports whether the state is terminal
+func (s State) Finished() bool {
+	return s == StateSucceeded || s == StateFailed || s == StateCancelled
+}
+
+// Errors returned by the manager
+var (
+	ErrJobNotFound   = errors.New("job not found")
+	ErrJobFinished   = errors.New("job has already finished")
+	ErrQueueFull     = errors.New("job queue is full")
+	ErrConsumerLimit = errors.New("maximum number of concurrent jobs reached for this consumer")
+	ErrShuttingDown  = errors.New("job manager is shutting down")
+	errJobPanicked   = errors.New("job panicked")
+)
+
+var (
+	// Jobs waiting for a worker
+	queueDepth = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "jobs_queue_depth",
+			Help: "Number of jobs waiting for a worker",
+		},
+	)
+
+	// Jobs currently executing
+	runningJobs = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "jobs_running",
+			Help: "Number of jobs currently running",
+		},
+	)
+
+	// Time from start to completion of a job
+	jobDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "job_duration_seconds",
+			Help:    "Duration of jobs from start to completion in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.01, 4, 8),
+		},
+		[]string{"type", "state"},
+	)
+)
+
+// Func is the work performed by a job
+// It must return promptly once ctx is cancelled and may report progress in [0, 1]
+type Func func(ctx context.Context, progress func(float64)) (interface{}, error)
+
+// Spec describes a job to submit
+type Spec struct {
+	Type      string
+	Owner     string // consumer that submitted the job
+	RequestID string // request that submitted the job, for log correlation
+	Callback  string // URL notified when the job finishes, if any
+	Run       Func
+
+	// Ephemeral jobs are never archived, for results that must not reach storage
+	Ephemeral bool
+}
+
+// Options configures the manager
+type Options struct {
+	Workers        int
+	QueueSize      int
+	MaxPerConsumer int           // queued plus running jobs per consumer, 0 for no limit
+	Retention      time.Duration // how long finished jobs remain readable
+
+	// OnFinish is called with the final snapshot of every job that has a callback
+	// It is called with the manager locked and must not block
+	OnFinish func(*Snapshot)
+
+	// Archive, if set, keeps finished jobs so they remain readable after a restart
+	Archive Archive
+}
+
+// Archive keeps the final snapshots of finished jobs
+// Its methods are called with the manager locked
+type Archive interface {
+	// Save stores the snapshot of a finished job
+	Save(snap *Snapshot) error
+
+	// Load returns the snapshot of a finished job, or ErrJobNotFound
+	Load(id string) (*Snapshot, error)
+
+	// Prune removes the jobs that finished before the given time
+	Prune(before time.Time) (int, error)
+}
+
+// Snapshot is a point-in-time copy of a job, safe to hand to callers
+type Snapshot struct {
+	ID         string
+	Type       string
+	Owner      string
+	RequestID  string
+	Callback   string
+	State      State
+	Progress   float64
+	Result     interface{} // nil unless State is StateSucceeded
+	Error      string
+	ErrorCode  string // set when the job function failed with a coded error
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	ExpiresAt  time.Time
+}
+
+// job is the mutable state of a job, guarded by Manager.mu
+type job struct {
+	spec       Spec
+	id         string
+	state      State
+	progress   float64
+	result     interface{}
+	err        string
+	errCode    string
+	cancel     context.CancelFunc
+	createdAt  time.Time
+	startedAt  time.Time
+	finishedAt time.Time
+}
+
+// Manager runs jobs on a bounded worker pool and keeps their results until they expire
+type Manager struct {
+	logger *logger.Logger
+	opts   Options
+	now    func() time.Time
+
+	// ctx is the parent of every job context; cancelling it aborts all jobs
+	ctx       context.Context
+	cancelAll context.CancelFunc
+
+	mu     sync.Mutex
+	jobs   map[string]*job
+	active map[string]int // queued plus running jobs per owner
+	closed bool
+
+	queue   chan *job
+	workers sync.WaitGroup
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewManager creates a job manager
+// Jobs queue up until Start launches the workers
+func NewManager(log *logger.Logger, opts Options) *Manager {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize < 1 {
+		opts.QueueSize = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		logger:    log,
+		opts:      opts,
+		now:       time.Now,
+		ctx:       ctx,
+		cancelAll: cancel,
+		jobs:      make(map[string]*job),
+		active:    make(map[string]int),
+		queue:     make(chan *job, opts.QueueSize),
+	}
+}
+
+// Start launches the workers and the background sweep that removes expired jobs
+func (m *Manager) Start(sweepInterval time.Duration) {
+	for i := 0; i < m.opts.Workers; i++ {
+		m.workers.Add(1)
+		go m.work()
+	}
+
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(sweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.sweep()
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops accepting jobs and drains the queue
+// Jobs still queued or running when ctx is done are cancelled
+func (m *Manager) Stop(ctx context.Context) {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.closed = true
+	close(m.queue)
+	m.mu.Unlock()
+
+	if m.stop != nil {
+		close(m.stop)
+		<-m.done
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		m.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		m.logger.WithFields(map[string]interface{}{
+			"component": "jobs",
+			"operation": "stop",
+		}).Warn("Shutdown deadline reached, cancelling remaining jobs")
+		m.cancelAll()
+		<-drained
+	}
+
+	m.cancelAll()
+
+	// Without workers nothing drained the queue
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, j := range m.jobs {
+		if j.state == StateQueued {
+			queueDepth.Dec()
+			m.finish(j, StateCancelled, "cancelled during shutdown")
+		}
+	}
+}
+
+// Submit queues a job and returns its initial snapshot
+func (m *Manager) Submit(spec Spec) (*Snapshot, error) {
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrShuttingDown
+	}
+	if m.opts.MaxPerConsumer > 0 && m.active[spec.Owner] >= m.opts.MaxPerConsumer {
+		return nil, ErrConsumerLimit
+	}
+
+	j := &job{
+		spec:      spec,
+		id:        id,
+		state:     StateQueued,
+		createdAt: m.now(),
+	}
+
+	select {
+	case m.queue <- j:
+	default:
+		return nil, ErrQueueFull
+	}
+
+	m.jobs[id] = j
+	m.active[spec.Owner]++
+	queueDepth.Inc()
+
+	m.event(j, "job_submitted", nil)
+	return m.snapshot(j), nil
+}
+
+// Get returns a snapshot of the job
+func (m *Manager) Get(id string) (*Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return m.archived(id)
+	}
+	return m.snapshot(j), nil
+}
+
+// Cancel cancels a queued or running job
+// A running job's context is cancelled; its eventual result is discarded
+func (m *Manager) Cancel(id string) (*Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		snap, err := m.archived(id)
+		if err != nil {
+			return nil, err
+		}
+		return snap, ErrJobFinished
+	}
+	if j.state.Finished() {
+		return m.snapshot(j), ErrJobFinished
+	}
+
+	if j.cancel != nil {
+		j.cancel()
+	}
+	m.finish(j, StateCancelled, "cancelled by client")
+
+	return m.snapshot(j), nil
+}
+
+// Len returns the number of jobs held in memory
+func (m *Manager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.jobs)
+}
+
+// work runs queued jobs until the queue is closed
+func (m *Manager) work() {
+	defer m.workers.Done()
+	for j := range m.queue {
+		m.run(j)
+	}
+}
+
+// run executes a single job
+func (m *Manager) run(j *job) {
+	m.mu.Lock()
+	queueDepth.Dec()
+	if j.state != StateQueued {
+		// Cancelled while waiting in the queue
+		m.mu.Unlock()
+		return
+	}
+	if m.ctx.Err() != nil {
+		m.finish(j, StateCancelled, "cancelled during shutdown")
+		m.mu.Unlock()
+		return
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	defer cancel()
+	j.cancel = cancel
+	j.state = StateRunning
+	j.startedAt = m.now()
+	runningJobs.Inc()
+	m.event(j, "job_started", nil)
+	m.mu.Unlock()
+
+	result, err := m.call(ctx, j)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	runningJobs.Dec()
+
+	if j.state != StateRunning {
+		// Cancelled while running
+		return
+	}
+
+	switch {
+	case err == nil:
+		j.result = result
+		j.progress = 1
+		m.finish(j, StateSucceeded, "")
+	case ctx.Err() != nil:
+		m.finish(j, StateCancelled, "cancelled during shutdown")
+	default:
+		var coded interface{ ErrorCode() string }
+		if errors.As(err, &coded) {
+			j.errCode = coded.ErrorCode()
+		}
+		m.finish(j, StateFailed, err.Error())
+	}
+}
+
+// call runs the job function, turning a panic into an error
+func (m *Manager) call(ctx context.Context, j *job) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", errJobPanicked, r)
+		}
+	}()
+
+	return j.spec.Run(ctx, func(p float64) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if j.state == StateRunning && p > j.progress && p <= 1 {
+			j.progress = p
+		}
+	})
+}
+
+// finish moves a job into a terminal state
+func (m *Manager) finish(j *job, to State, reason string) {
+	j.state = to
+	j.err = reason
+	j.finishedAt = m.now()
+
+	m.active[j.spec.Owner]--
+	if m.active[j.spec.Owner] <= 0 {
+		delete(m.active, j.spec.Owner)
+	}
+
+	started := j.startedAt
+	if started.IsZero() {
+		started = j.finishedAt
+	}
+	jobDuration.WithLabelValues(j.spec.Type, string(to)).Observe(j.finishedAt.Sub(started).Seconds())
+
+	m.event(j, "job_finished", map[string]interface{}{
+		"duration_ms": j.finishedAt.Sub(started).Milliseconds(),
+		"reason":      reason,
+	})
+
+	if m.opts.Archive != nil && !j.spec.Ephemeral {
+		if err := m.opts.Archive.Save(m.snapshot(j)); err != nil {
+			m.logger.LogError(err, "jobs", "archive", map[string]interface{}{
+				"job_id": j.id,
+			})
+		}
+	}
+
+	if j.spec.Callback != "" && m.opts.OnFinish != nil {
+		m.opts.OnFinish(m.snapshot(j))
+	}
+}
+
+// archived returns a finished job from the archive unless it has expired
+func (m *Manager) archived(id string) (*Snapshot, error) {
+	if m.opts.Archive == nil {
+		return nil, ErrJobNotFound
+	}
+	snap, err := m.opts.Archive.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if !m.now().Before(snap.ExpiresAt) {
+		return nil, ErrJobNotFound
+	}
+	return snap, nil
+}
+
+// sweep removes finished jobs older than the retention period
+func (m *Manager) sweep() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for id, j := range m.jobs {
+		if j.state.Finished() && now.Sub(j.finishedAt) >= m.opts.Retention {
+			delete(m.jobs, id)
+		}
+	}
+
+	if m.opts.Archive != nil {
+		if _, err := m.opts.Archive.Prune(now.Add(-m.opts.Retention)); err != nil {
+			m.logger.LogError(err, "jobs", "prune_archive", nil)
+		}
+	}
+}
+
+// event logs a job lifecycle event
+// Job inputs and results are never logged
+func (m *Manager) event(j *job, event string, fields map[string]interface{}) {
+	entry := m.logger.WithFields(map[string]interface{}{
+		"component":  "jobs",
+		"event":      event,
+		"job_id":     j.id,
+		"job_type":   j.spec.Type,
+		"owner":      j.spec.Owner,
+		"request_id": j.spec.RequestID,
+		"state":      string(j.state),
+	})
+	if fields != nil {
+		entry = entry.WithFields(fields)
+	}
+	entry.Info("Job event")
+}
+
+// snapshot copies the job state
+func (m *Manager) snapshot(j *job) *Snapshot {
+	snap := &Snapshot{
+		ID:         j.id,
+		Type:       j.spec.Type,
+		Owner:      j.spec.Owner,
+		RequestID:  j.spec.RequestID,
+		Callback:   j.spec.Callback,
+		State:      j.state,
+		Progress:   j.progress,
+		Error:      j.err,
+		ErrorCode:  j.errCode,
+		CreatedAt:  j.createdAt,
+		StartedAt:  j.startedAt,
+		FinishedAt: j.finishedAt,
+	}
+
+	if j.state == StateSucceeded {
+		snap.Result = j.result
+	}
+	if j.state.Finished() {
+		snap.ExpiresAt = j.finishedAt.Add(m.opts.Retention)
+	}
+
+	return snap
+}
+
+// newJobID returns a random, unguessable job ID
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "job_" + hex.EncodeToString(b), nil
+}
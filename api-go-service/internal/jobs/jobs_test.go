@@ -0,0 +1,225 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/storage"
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestManager(opts Options) *Manager {
+	return NewManager(logger.New("error", "json"), opts)
+}
+
+// waitFor polls the job until it reaches a terminal state
+func waitFor(t *testing.T, m *Manager, id string) *Snapshot {
+	t.Helper()
+	var snap *Snapshot
+	require.Eventually(t, func() bool {
+		var err error
+		snap, err = m.Get(id)
+		require.NoError(t, err)
+		return snap.State.Finished()
+	}, 2*time.Second, 5*time.Millisecond)
+	return snap
+}
+
+// blocking returns a job function that runs until ctx is cancelled or release is closed
+func blocking(release <-chan struct{}) Func {
+	return func(ctx context.Context, progress func(float64)) (interface{}, error) {
+		progress(0.5)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-release:
+			return "released", nil
+		}
+	}
+}
+
+func TestManager_Lifecycle(t *testing.T) {
+	m := newTestManager(Options{Workers: 2, QueueSize: 10, Retention: time.Minute})
+	m.Start(time.Hour)
+	defer m.Stop(context.Background())
+
+	t.Run("Successful job exposes its result", func(t *testing.T) {
+		snap, err := m.Submit(Spec{Type: "sum", Owner: "alice", Run: func(ctx context.Context, progress func(float64)) (interface{}, error) {
+			return 42, nil
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, StateQueued, snap.State)
+
+		snap = waitFor(t, m, snap.ID)
+		assert.Equal(t, StateSucceeded, snap.State)
+		assert.Equal(t, 42, snap.Result)
+		assert.Equal(t, 1.0, snap.Progress)
+		assert.False(t, snap.ExpiresAt.IsZero())
+	})
+
+	t.Run("Failures and panics are reported", func(t *testing.T) {
+		snap, err := m.Submit(Spec{Type: "sum", Owner: "alice", Run: func(ctx context.Context, progress func(float64)) (interface{}, error) {
+			return nil, errors.New("boom")
+		}})
+		require.NoError(t, err)
+		snap = waitFor(t, m, snap.ID)
+		assert.Equal(t, StateFailed, snap.State)
+		assert.Equal(t, "boom", snap.Error)
+
+		snap, err = m.Submit(Spec{Type: "sum", Owner: "alice", Run: func(ctx context.Context, progress func(float64)) (interface{}, error) {
+			panic("unexpected")
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, StateFailed, waitFor(t, m, snap.ID).State)
+	})
+
+	t.Run("Cancelling a running job cancels its context", func(t *testing.T) {
+		snap, err := m.Submit(Spec{Type: "sum", Owner: "alice", Run: blocking(nil)})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			s, _ := m.Get(snap.ID)
+			return s.State == StateRunning && s.Progress == 0.5
+		}, time.Second, 5*time.Millisecond)
+
+		snap, err = m.Cancel(snap.ID)
+		require.NoError(t, err)
+		assert.Equal(t, StateCancelled, snap.State)
+
+		_, err = m.Cancel(snap.ID)
+		assert.ErrorIs(t, err, ErrJobFinished)
+	})
+}
+
+func TestManager_Limits(t *testing.T) {
+	m := newTestManager(Options{Workers: 1, QueueSize: 2, MaxPerConsumer: 2, Retention: time.Minute})
+	release := make(chan struct{})
+
+	_, err := m.Submit(Spec{Type: "sum", Owner: "alice", Run: blocking(release)})
+	require.NoError(t, err)
+	_, err = m.Submit(Spec{Type: "sum", Owner: "alice", Run: blocking(release)})
+	require.NoError(t, err)
+
+	_, err = m.Submit(Spec{Type: "sum", Owner: "alice", Run: blocking(release)})
+	assert.ErrorIs(t, err, ErrConsumerLimit)
+
+	_, err = m.Submit(Spec{Type: "sum", Owner: "bob", Run: blocking(release)})
+	assert.ErrorIs(t, err, ErrQueueFull)
+
+	m.Start(time.Hour)
+	close(release)
+	m.Stop(context.Background())
+
+	_, err = m.Submit(Spec{Type: "sum", Owner: "bob", Run: blocking(release)})
+	assert.ErrorIs(t, err, ErrShuttingDown)
+}
+
+func TestManager_StopCancelsAfterDeadline(t *testing.T) {
+	m := newTestManager(Options{Workers: 1, QueueSize: 10, Retention: time.Minute})
+	m.Start(time.Hour)
+
+	running, err := m.Submit(Spec{Type: "sum", Owner: "alice", Run: blocking(nil)})
+	require.NoError(t, err)
+	queued, err := m.Submit(Spec{Type: "sum", Owner: "alice", Run: blocking(nil)})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	m.Stop(ctx)
+
+	for _, id := range []string{running.ID, queued.ID} {
+		snap, err := m.Get(id)
+		require.NoError(t, err)
+		assert.Equal(t, StateCancelled, snap.State)
+	}
+}
+
+func TestManager_Sweep(t *testing.T) {
+	m := newTestManager(Options{Workers: 1, QueueSize: 10, Retention: time.Minute})
+	m.Start(time.Hour)
+	defer m.Stop(context.Background())
+
+	snap, err := m.Submit(Spec{Type: "sum", Owner: "alice", Run: func(ctx context.Context, progress func(float64)) (interface{}, error) {
+		return nil, nil
+	}})
+	require.NoError(t, err)
+	waitFor(t, m, snap.ID)
+
+	m.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	m.sweep()
+
+	_, err = m.Get(snap.ID)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestManager_OnFinish(t *testing.T) {
+	finished := make(chan *Snapshot, 2)
+	m := newTestManager(Options{Workers: 1, QueueSize: 10, Retention: time.Minute, OnFinish: func(snap *Snapshot) {
+		finished <- snap
+	}})
+	m.Start(time.Hour)
+	defer m.Stop(context.Background())
+
+	run := func(ctx context.Context, progress func(float64)) (interface{}, error) { return "done", nil }
+	_, err := m.Submit(Spec{Type: "sum", Owner: "alice", Run: run})
+	require.NoError(t, err)
+	withCallback, err := m.Submit(Spec{Type: "sum", Owner: "alice", Callback: "https://example.com/hook", Run: run})
+	require.NoError(t, err)
+
+	select {
+	case snap := <-finished:
+		// Only jobs with a callback are reported
+		assert.Equal(t, withCallback.ID, snap.ID)
+		assert.Equal(t, StateSucceeded, snap.State)
+		assert.Equal(t, "done", snap.Result)
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnFinish was not called")
+	}
+}
+
+func TestManager_Archive(t *testing.T) {
+	db := storage.NewMemoryStore()
+	_, _, err := storage.Migrate(db, storage.Migrations)
+	require.NoError(t, err)
+	archive := NewStorageArchive(db)
+
+	m := newTestManager(Options{Workers: 1, QueueSize: 10, Retention: time.Minute, Archive: archive})
+	m.Start(time.Hour)
+	snap, err := m.Submit(Spec{Type: "sum", Owner: "alice", Run: func(ctx context.Context, progress func(float64)) (interface{}, error) {
+		return map[string]int{"sum": 42}, nil
+	}})
+	require.NoError(t, err)
+	secret, err := m.Submit(Spec{Type: "keygen", Owner: "alice", Ephemeral: true, Run: func(ctx context.Context, progress func(float64)) (interface{}, error) {
+		return "secret", nil
+	}})
+	require.NoError(t, err)
+	waitFor(t, m, snap.ID)
+	waitFor(t, m, secret.ID)
+	m.Stop(context.Background())
+
+	// Ephemeral jobs never reach the archive
+	_, err = archive.Load(secret.ID)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+
+	// A new manager over the same storage still serves the finished job
+	restarted := newTestManager(Options{Workers: 1, QueueSize: 10, Retention: time.Minute, Archive: archive})
+	archived, err := restarted.Get(snap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StateSucceeded, archived.State)
+	assert.Equal(t, "alice", archived.Owner)
+	assert.Equal(t, map[string]interface{}{"sum": 42.0}, archived.Result)
+
+	_, err = restarted.Cancel(snap.ID)
+	assert.ErrorIs(t, err, ErrJobFinished)
+
+	// Expired jobs are neither served nor kept
+	restarted.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, err = restarted.Get(snap.ID)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	restarted.sweep()
+	_, err = archive.Load(snap.ID)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
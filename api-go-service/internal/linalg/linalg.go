@@ -0,0 +1,170 @@
+package linalg
+
+import "math"
+
+// Vector is a dense vector of float64 values
+type Vector []float64
+
+// Matrix is a dense row-major matrix of float64 values
+type Matrix [][]float64
+
+// Shape returns the dimensions of a matrix
+// ok is false when the matrix is empty or its rows have different lengths
+func Shape(m Matrix) (rows, cols int, ok bool) {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return 0, 0, false
+	}
+
+	cols = len(m[0])
+	for _, row := range m {
+		if len(row) != cols {
+			return len(m), cols, false
+		}
+	}
+
+	return len(m), cols, true
+}
+
+// AddVectors returns the element-wise sum of vectors that all share the same length
+func AddVectors(vectors []Vector) Vector {
+	if len(vectors) == 0 {
+		return Vector{}
+	}
+
+	result := make(Vector, len(vectors[0]))
+	for _, v := range vectors {
+		for i, value := range v {
+			result[i] += value
+		}
+	}
+
+	return result
+}
+
+// Scale returns the vector multiplied by a scalar
+func Scale(v Vector, scalar float64) Vector {
+	result := make(Vector, len(v))
+	for i, value := range v {
+		result[i] = value * scalar
+	}
+
+	return result
+}
+
+// Dot returns the dot product of two vectors of the same length
+func Dot(a, b Vector) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+
+	return sum
+}
+
+// AddMatrices returns the element-wise sum of matrices that all share the same shape
+func AddMatrices(matrices []Matrix) Matrix {
+	if len(matrices) == 0 {
+		return Matrix{}
+	}
+
+	rows, cols, _ := Shape(matrices[0])
+	result := newMatrix(rows, cols)
+	for _, m := range matrices {
+		for i, row := range m {
+			for j, value := range row {
+				result[i][j] += value
+			}
+		}
+	}
+
+	return result
+}
+
+// Multiply returns the matrix product a×b
+// The number of columns of a must equal the number of rows of b
+func Multiply(a, b Matrix) Matrix {
+	rows, inner, _ := Shape(a)
+	_, cols, _ := Shape(b)
+
+	result := newMatrix(rows, cols)
+	for i := 0; i < rows; i++ {
+		for k := 0; k < inner; k++ {
+			aik := a[i][k]
+			for j := 0; j < cols; j++ {
+				result[i][j] += aik * b[k][j]
+			}
+		}
+	}
+
+	return result
+}
+
+// Transpose returns the transpose of a matrix
+func Transpose(m Matrix) Matrix {
+	rows, cols, _ := Shape(m)
+
+	result := newMatrix(cols, rows)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			result[j][i] = m[i][j]
+		}
+	}
+
+	return result
+}
+
+// RowSums returns the sum of each row of a matrix
+func RowSums(m Matrix) Vector {
+	result := make(Vector, len(m))
+	for i, row := range m {
+		for _, value := range row {
+			result[i] += value
+		}
+	}
+
+	return result
+}
+
+// ColumnSums returns the sum of each column of a matrix
+func ColumnSums(m Matrix) Vector {
+	_, cols, _ := Shape(m)
+
+	result := make(Vector, cols)
+	for _, row := range m {
+		for j, value := range row {
+			result[j] += value
+		}
+	}
+
+	return result
+}
+
+// Finite reports whether every element of the vector is finite
+// Sums and products of finite elements can overflow to infinity
+func (v Vector) Finite() bool {
+	for _, value := range v {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// Finite reports whether every element of the matrix is finite
+func (m Matrix) Finite() bool {
+	for _, row := range m {
+		if !Vector(row).Finite() {
+			return false
+		}
+	}
+	return true
+}
+
+// newMatrix allocates a zeroed rows×cols matrix
+func newMatrix(rows, cols int) Matrix {
+	m := make(Matrix, rows)
+	for i := range m {
+		m[i] = make([]float64, cols)
+	}
+	return m
+}
@@ -0,0 +1,95 @@
+package linalg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShape(t *testing.T) {
+	tests := []struct {
+		name       string
+		m          Matrix
+		rows, cols int
+		ok         bool
+	}{
+		{name: "Rectangular", m: Matrix{{1, 2, 3}, {4, 5, 6}}, rows: 2, cols: 3, ok: true},
+		{name: "Single element", m: Matrix{{7}}, rows: 1, cols: 1, ok: true},
+		{name: "Empty", m: Matrix{}, ok: false},
+		{name: "Empty first row", m: Matrix{{}}, ok: false},
+		{name: "Ragged", m: Matrix{{1, 2}, {3}}, rows: 2, cols: 2, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, cols, ok := Shape(tt.m)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.rows, rows)
+			assert.Equal(t, tt.cols, cols)
+		})
+	}
+}
+
+func TestVectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      interface{}
+		expected interface{}
+	}{
+		{name: "Add", got: AddVectors([]Vector{{1, 2, 3}, {4, 5, 6}, {-1, 0, 0.5}}), expected: Vector{4, 7, 9.5}},
+		{name: "Add nothing", got: AddVectors(nil), expected: Vector{}},
+		{name: "Scale", got: Scale(Vector{1, -2, 0.5}, 4), expected: Vector{4, -8, 2}},
+		{name: "Scale by zero", got: Scale(Vector{1, 2}, 0), expected: Vector{0, 0}},
+		{name: "Dot", got: Dot(Vector{1, 2, 3}, Vector{4, -5, 6}), expected: 12.0},
+		{name: "Dot of orthogonal vectors", got: Dot(Vector{1, 0}, Vector{0, 1}), expected: 0.0},
+		{name: "Dot of empty vectors", got: Dot(Vector{}, Vector{}), expected: 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.got)
+		})
+	}
+}
+
+func TestMatrices(t *testing.T) {
+	m := Matrix{{1, 2, 3}, {4, 5, 6}}
+
+	tests := []struct {
+		name     string
+		got      interface{}
+		expected interface{}
+	}{
+		{name: "Add", got: AddMatrices([]Matrix{m, {{1, 1, 1}, {-4, -5, -6}}}), expected: Matrix{{2, 3, 4}, {0, 0, 0}}},
+		{name: "Add nothing", got: AddMatrices(nil), expected: Matrix{}},
+		{name: "Multiply", got: Multiply(m, Matrix{{1, 0}, {0, 1}, {1, 1}}), expected: Matrix{{4, 5}, {10, 11}}},
+		{name: "Multiply row by column", got: Multiply(Matrix{{1, 2, 3}}, Matrix{{4}, {5}, {6}}), expected: Matrix{{32}}},
+		{name: "Multiply column by row", got: Multiply(Matrix{{1}, {2}}, Matrix{{3, 4}}), expected: Matrix{{3, 4}, {6, 8}}},
+		{name: "Transpose", got: Transpose(m), expected: Matrix{{1, 4}, {2, 5}, {3, 6}}},
+		{name: "Transpose twice", got: Transpose(Transpose(m)), expected: m},
+		{name: "Row sums", got: RowSums(m), expected: Vector{6, 15}},
+		{name: "Column sums", got: ColumnSums(m), expected: Vector{5, 7, 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.got)
+		})
+	}
+
+	// Results never share memory with the operands
+	transposed := Transpose(m)
+	transposed[0][0] = 100
+	assert.Equal(t, 1.0, m[0][0])
+}
+
+func TestFinite(t *testing.T) {
+	assert.True(t, Vector{1, -2, 0}.Finite())
+	assert.True(t, Vector{}.Finite())
+	assert.False(t, AddVectors([]Vector{{1e308}, {1e308}}).Finite())
+	assert.False(t, Vector{math.NaN()}.Finite())
+
+	assert.True(t, Matrix{{1, 2}, {3, 4}}.Finite())
+	assert.False(t, Multiply(Matrix{{1e200}}, Matrix{{-1e200}}).Finite())
+}
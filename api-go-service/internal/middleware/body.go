@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/models"
+)
+
+// ReadBody reads the request body, which may not be larger than the
+// request_max_bytes limit; a larger body fails with an *http.MaxBytesError
+func ReadBody(c *gin.Context) ([]byte, error) {
+	if c.Request.Body == nil {
+		return nil, nil
+	}
+	return io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, int64(models.Limit(models.LimitRequestMaxBytes))))
+}
+
+// BodyError reports an error met while reading the request body: an oversized
+// body is REQUEST_TOO_LARGE (413), any other failure an invalid body (400)
+func BodyError(err error) *models.APIError {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return models.NewAPIError(models.CodeRequestTooLarge, nil)
+	}
+	return models.NewAPIError(models.CodeInvalidRequestBody, err).WithStatus(http.StatusBadRequest)
+}
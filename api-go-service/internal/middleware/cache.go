@@ -0,0 +1,296 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CacheStatusHeader reports whether a response was served from the response cache
+const CacheStatusHeader = "X-Cache"
+
+// BodyHashHeader carries the hex SHA-256 of the canonical JSON request body,
+// so that a shared cache such as Kong's proxy-cache can key POST responses on it
+const BodyHashHeader = "X-Body-Hash"
+
+// cacheVary lists the request headers a cacheable response depends on
+const cacheVary = "Accept, Accept-Language, " + BodyHashHeader
+
+var (
+	// Response cache lookups
+	responseCacheLookups = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "response_cache_lookups_total",
+			Help: "Total number of response cache lookups",
+		},
+		[]string{"endpoint", "result"},
+	)
+
+	// Response cache size
+	responseCacheEntries = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "response_cache_entries",
+			Help: "Number of responses held in the response cache",
+		},
+	)
+
+	// Response cache memory
+	responseCacheBytes = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "response_cache_bytes",
+			Help: "Approximate memory held by the response cache in bytes",
+		},
+	)
+)
+
+// ResponseCacheMiddleware creates a gin middleware for deterministic POST endpoints,
+// whose response depends only on the path and the request body
+// Successful JSON responses carry a strong ETag, computed without their
+// request_id and timestamp, and the hash of the canonical body in X-Body-Hash;
+// a matching If-None-Match is answered with 304 Not Modified. They are
+// Cache-Control: public when the request sent a matching X-Body-Hash, which a
+// shared cache can then use as its key, and private otherwise. When store is
+// not nil, responses are also cached per consumer under a canonical hash of the
+// request body, and replayed with the request ID and time of the request they
+// answer. Non-JSON responses and responses that set Cache-Control: no-store are
+// passed through untouched.
+func ResponseCacheMiddleware(store *cache.LRU, maxAge time.Duration) gin.HandlerFunc {
+	privateCacheControl := fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds()))
+	publicCacheControl := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+
+		canonical, ok, err := canonicalBody(c)
+		if err != nil {
+			Fail(c, BodyError(err))
+			return
+		}
+		if !ok {
+			// Not JSON: let the handler report the error
+			c.Next()
+			return
+		}
+		key := GetConsumer(c) + "\x00" + GetLocale(c) + "\x00" + requestKey(c, canonical)
+
+		// A shared cache may only key on the hash it was sent if the hash is right
+		bodyHash := hashBody(canonical)
+		cacheControl := privateCacheControl
+		if c.GetHeader(BodyHashHeader) == bodyHash {
+			cacheControl = publicCacheControl
+		}
+		c.Header(BodyHashHeader, bodyHash)
+
+		endpoint := c.FullPath()
+		if store != nil {
+			if entry, hit := store.Get(key); hit {
+				responseCacheLookups.WithLabelValues(endpoint, "hit").Inc()
+				c.Header(CacheStatusHeader, "HIT")
+				writeCacheable(c, entry, stampFor(c, entry.Body), cacheControl)
+				c.Abort()
+				return
+			}
+			responseCacheLookups.WithLabelValues(endpoint, "miss").Inc()
+		}
+
+		// The original writer is restored even if a handler panics so that the
+		// recovery middleware can still respond
+		original := c.Writer
+		writer := &bufferedWriter{ResponseWriter: original}
+		c.Writer = writer
+		func() {
+			defer func() { c.Writer = original }()
+			c.Next()
+		}()
+
+		if writer.Status() != http.StatusOK || !shareable(writer) {
+			writer.flush()
+			return
+		}
+
+		// The tag identifies the result, whatever request it was computed for
+		unstamped, err := restamp(writer.body.Bytes(), "", time.Time{})
+		if err != nil {
+			writer.flush()
+			return
+		}
+
+		digest := sha256.Sum256(unstamped)
+		entry := &cache.Entry{
+			ETag:        `"` + hex.EncodeToString(digest[:16]) + `"`,
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		}
+
+		if store != nil {
+			store.Add(key, entry)
+			responseCacheEntries.Set(float64(store.Len()))
+			responseCacheBytes.Set(float64(store.Bytes()))
+			c.Header(CacheStatusHeader, "MISS")
+		}
+		writeCacheable(c, entry, entry.Body, cacheControl)
+	}
+}
+
+// writeCacheable writes body as the response for entry, or 304 if the client already holds it
+// The endpoints are free of side effects, so If-None-Match is evaluated as for GET
+func writeCacheable(c *gin.Context, entry *cache.Entry, body []byte, cacheControl string) {
+	c.Header("ETag", entry.ETag)
+	c.Header("Cache-Control", cacheControl)
+	c.Header("Vary", cacheVary)
+
+	if etagMatches(c.GetHeader("If-None-Match"), entry.ETag) {
+		c.Writer.WriteHeader(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	if entry.ContentType != "" {
+		c.Header("Content-Type", entry.ContentType)
+	}
+	c.Writer.WriteHeader(http.StatusOK)
+	_, _ = c.Writer.Write(body)
+}
+
+// etagMatches evaluates an If-None-Match header against a strong ETag
+// Weak validators match by their opaque tag, as RFC 9110 prescribes for If-None-Match
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalRequestKey hashes the method, path, Accept header and canonical JSON form of the body
+// The body is restored for the handler. ok is false if the body is not JSON,
+// and err is set if it cannot be read.
+func canonicalRequestKey(c *gin.Context) (key string, ok bool, err error) {
+	canonical, ok, err := canonicalBody(c)
+	if !ok {
+		return "", false, err
+	}
+	return requestKey(c, canonical), true, nil
+}
+
+// canonicalBody reads the request body and returns its canonical JSON form:
+// object keys sorted, no insignificant whitespace, and number literals and
+// strings kept as sent. The body is restored for the handler. ok is false if
+// the body is not JSON, and err is set if it cannot be read, e.g. because it
+// is too large.
+func canonicalBody(c *gin.Context) (canonical []byte, ok bool, err error) {
+	if contentType := c.ContentType(); contentType != "" && contentType != "application/json" {
+		return nil, false, nil
+	}
+
+	body, err := ReadBody(c)
+	if err != nil {
+		return nil, false, err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return nil, false, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, false, nil
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), true, nil
+}
+
+// hashBody returns the hex SHA-256 of a canonical body, as sent in X-Body-Hash
+func hashBody(canonical []byte) string {
+	digest := sha256.Sum256(canonical)
+	return hex.EncodeToString(digest[:])
+}
+
+// requestKey hashes the method, path, Accept header and canonical body of the request
+func requestKey(c *gin.Context, canonical []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(c.Request.URL.Path))
+	h.Write([]byte{0})
+	h.Write([]byte(c.GetHeader("Accept")))
+	h.Write([]byte{0})
+	h.Write(canonical)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bufferedWriter holds the status and body written by a handler until flushed
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader records the status code
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow is a no-op until the response is flushed
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+// Write implements io.Writer
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// WriteString implements io.StringWriter
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// Status returns the recorded status code
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// Size returns the number of buffered body bytes
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+// Written reports whether the handler wrote a response
+func (w *bufferedWriter) Written() bool {
+	return w.status != 0 || w.body.Len() > 0
+}
+
+// flush writes the buffered response to the underlying writer
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.Status())
+	w.ResponseWriter.WriteHeaderNow()
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/cache"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCacheTestRouter returns a router whose /sum handler stamps its responses like the API handlers
+func newCacheTestRouter(store *cache.LRU, calls *int32) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(RequestIDKey, c.GetHeader("X-Request-ID"))
+		c.Set(ConsumerKey, c.GetHeader("X-Consumer-Username"))
+		c.Next()
+	})
+
+	router.POST("/sum", ResponseCacheMiddleware(store, time.Hour), func(c *gin.Context) {
+		atomic.AddInt32(calls, 1)
+		var request map[string]interface{}
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if _, private := request["privacy"]; private {
+			c.Header("Cache-Control", "no-store")
+		}
+		if _, csv := request["csv"]; csv {
+			c.Data(http.StatusOK, "text/csv", []byte("sum\n3\n"))
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"sum":        3,
+			"timestamp":  time.Now().UTC(),
+			"request_id": c.GetString(RequestIDKey),
+		})
+	})
+	return router
+}
+
+// TestResponseCacheMiddleware tests ETags, conditional requests and caching
+func TestResponseCacheMiddleware(t *testing.T) {
+	var calls int32
+	router := newCacheTestRouter(cache.NewLRU(10, 1<<20), &calls)
+
+	post := func(body, requestID, consumer, ifNoneMatch string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/sum", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", requestID)
+		req.Header.Set("X-Consumer-Username", consumer)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post(`{"numbers": [1, 2], "tag": "a"}`, "req-1", "alice", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "private, max-age=3600", first.Header().Get("Cache-Control"))
+	assert.Equal(t, "MISS", first.Header().Get(CacheStatusHeader))
+
+	t.Run("Equivalent bodies hit the cache with their own request ID", func(t *testing.T) {
+		w := post(`{ "tag": "a",   "numbers": [1, 2] }`, "req-2", "alice", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "HIT", w.Header().Get(CacheStatusHeader))
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "req-2", response["request_id"])
+		assert.Equal(t, float64(3), response["sum"])
+	})
+
+	t.Run("Matching If-None-Match returns 304", func(t *testing.T) {
+		w := post(`{"numbers": [1, 2], "tag": "a"}`, "req-3", "alice", `"other", `+etag)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+	})
+
+	t.Run("Consumers do not share entries", func(t *testing.T) {
+		before := atomic.LoadInt32(&calls)
+		w := post(`{"numbers": [1, 2], "tag": "a"}`, "req-4", "bob", "")
+		assert.Equal(t, "MISS", w.Header().Get(CacheStatusHeader))
+		assert.Equal(t, before+1, atomic.LoadInt32(&calls))
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+	})
+
+	t.Run("No-store responses are neither cached nor tagged", func(t *testing.T) {
+		before := atomic.LoadInt32(&calls)
+		post(`{"numbers": [1, 2], "privacy": {}}`, "req-5", "alice", "")
+		w := post(`{"numbers": [1, 2], "privacy": {}}`, "req-6", "alice", "")
+		assert.Equal(t, before+2, atomic.LoadInt32(&calls))
+		assert.Empty(t, w.Header().Get("ETag"))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("Non-JSON responses are neither cached nor tagged", func(t *testing.T) {
+		before := atomic.LoadInt32(&calls)
+		post(`{"csv": true}`, "req-7", "alice", "")
+		w := post(`{"csv": true}`, "req-8", "alice", "")
+		assert.Equal(t, before+2, atomic.LoadInt32(&calls))
+		assert.Empty(t, w.Header().Get("ETag"))
+		assert.Equal(t, "sum\n3\n", w.Body.String())
+	})
+
+	t.Run("Oversized bodies are refused before the handler runs", func(t *testing.T) {
+		models.SetLimits(models.Limits{models.LimitRequestMaxBytes: 16})
+		defer models.SetLimits(nil)
+
+		before := atomic.LoadInt32(&calls)
+		w := post(`{"numbers": [1, 2, 3, 4, 5, 6]}`, "req-10", "alice", "")
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Equal(t, before, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Errors are passed through", func(t *testing.T) {
+		w := post(`not json`, "req-9", "alice", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+}
+
+// TestResponseCacheMiddleware_Revalidation tests that a fresh computation still matches the client's ETag
+func TestResponseCacheMiddleware_Revalidation(t *testing.T) {
+	var calls int32
+	router := newCacheTestRouter(nil, &calls)
+
+	post := func(requestID, ifNoneMatch string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/sum", bytes.NewBufferString(`{"numbers": [1, 2]}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", requestID)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("req-1", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Empty(t, first.Header().Get(CacheStatusHeader))
+
+	w := post("req-2", first.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+// TestResponseCacheMiddleware_SharedCache tests the headers a shared cache keys POST responses on
+func TestResponseCacheMiddleware_SharedCache(t *testing.T) {
+	var calls int32
+	router := newCacheTestRouter(nil, &calls)
+
+	post := func(body, bodyHash string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/sum", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if bodyHash != "" {
+			req.Header.Set(BodyHashHeader, bodyHash)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// The SHA-256 of the canonical body {"numbers":[1,2],"tag":"<a>"}
+	const canonicalHash = "b841876dca6041d385c2ffedcc10f07f9a63690339795fa69c1fe22da760125a"
+
+	first := post(`{ "tag": "<a>", "numbers": [1, 2] }`, "")
+	require.Equal(t, http.StatusOK, first.Code)
+	hash := first.Header().Get(BodyHashHeader)
+	assert.Equal(t, canonicalHash, hash)
+	assert.Equal(t, "private, max-age=3600", first.Header().Get("Cache-Control"))
+	assert.Equal(t, "Accept, Accept-Language, X-Body-Hash", first.Header().Get("Vary"))
+
+	t.Run("A matching hash makes the response public", func(t *testing.T) {
+		w := post(`{"numbers": [1, 2], "tag": "<a>"}`, hash)
+		assert.Equal(t, "public, max-age=3600", w.Header().Get("Cache-Control"))
+		assert.Equal(t, hash, w.Header().Get(BodyHashHeader))
+	})
+
+	t.Run("A hash of another body keeps the response private", func(t *testing.T) {
+		w := post(`{"numbers": [1, 3], "tag": "<a>"}`, hash)
+		assert.Equal(t, "private, max-age=3600", w.Header().Get("Cache-Control"))
+		assert.NotEqual(t, hash, w.Header().Get(BodyHashHeader))
+	})
+}
@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CoalescedHeader is set on responses that were shared with an identical in-flight request
+const CoalescedHeader = "X-Coalesced"
+
+var (
+	// Requests answered by another caller's computation
+	requestsCoalesced = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "requests_coalesced_total",
+			Help: "Total number of requests answered by an identical in-flight computation",
+		},
+		[]string{"endpoint"},
+	)
+
+	// Computations currently shared by waiting callers
+	coalesceInflight = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "coalesce_inflight_computations",
+			Help: "Number of computations that identical requests can currently join",
+		},
+	)
+)
+
+// sharedResponse is the response of a computation, as handed to waiting callers
+type sharedResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// flight is a computation in progress
+// response is nil when the result must not be shared
+type flight struct {
+	done     chan struct{}
+	response *sharedResponse
+}
+
+// CoalesceMiddleware creates a gin middleware for deterministic POST endpoints
+// that lets identical concurrent requests share one computation. Requests are
+// identical when they come from the same consumer and locale and have the same
+// path and canonical JSON body. Every caller still gets its own request_id and timestamp.
+// Non-JSON responses, responses that set Cache-Control: no-store, server errors
+// and panics are not shared; waiting callers then run the handler themselves.
+func CoalesceMiddleware() gin.HandlerFunc {
+	var mu sync.Mutex
+	flights := make(map[string]*flight)
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+
+		key, ok, err := canonicalRequestKey(c)
+		if err != nil {
+			Fail(c, BodyError(err))
+			return
+		}
+		if !ok {
+			c.Next()
+			return
+		}
+		key = GetConsumer(c) + "\x00" + GetLocale(c) + "\x00" + key
+
+		mu.Lock()
+		if f, exists := flights[key]; exists {
+			mu.Unlock()
+			select {
+			case <-f.done:
+			case <-c.Request.Context().Done():
+				c.Abort()
+				return
+			}
+			if f.response == nil {
+				c.Next()
+				return
+			}
+			requestsCoalesced.WithLabelValues(c.FullPath()).Inc()
+			writeShared(c, f.response)
+			c.Abort()
+			return
+		}
+		f := &flight{done: make(chan struct{})}
+		flights[key] = f
+		coalesceInflight.Inc()
+		mu.Unlock()
+
+		// Waiting callers are released even if the handler panics; the original
+		// writer is restored so that the recovery middleware can still respond
+		original := c.Writer
+		writer := &bufferedWriter{ResponseWriter: original}
+		c.Writer = writer
+		defer func() {
+			c.Writer = original
+			mu.Lock()
+			delete(flights, key)
+			coalesceInflight.Dec()
+			mu.Unlock()
+			close(f.done)
+		}()
+
+		c.Next()
+
+		if shareable(writer) {
+			f.response = &sharedResponse{
+				status: writer.Status(),
+				header: writer.Header().Clone(),
+				body:   writer.body.Bytes(),
+			}
+		}
+		writer.flush()
+	}
+}
+
+// shareable reports whether a response may be handed to waiting callers
+// Only JSON responses can be given the callers' own request ID and timestamp
+func shareable(writer *bufferedWriter) bool {
+	return writer.Status() < http.StatusInternalServerError &&
+		!strings.Contains(writer.Header().Get("Cache-Control"), "no-store") &&
+		strings.HasPrefix(writer.Header().Get("Content-Type"), "application/json")
+}
+
+// writeShared writes a shared response with the caller's request ID and timestamp
+func writeShared(c *gin.Context, response *sharedResponse) {
+	header := c.Writer.Header()
+	for name, values := range response.header {
+		if _, set := header[name]; !set {
+			header[name] = values
+		}
+	}
+	c.Header(CoalescedHeader, "true")
+
+	c.Writer.WriteHeader(response.status)
+	_, _ = c.Writer.Write(stampFor(c, response.body))
+}
+
+// stampFor returns a JSON response body with the request ID of c and the current time
+func stampFor(c *gin.Context, body []byte) []byte {
+	requestID, _ := c.Get(RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	stamped, err := restamp(body, reqID, time.Now().UTC())
+	if err != nil {
+		// Not a JSON object: nothing to personalise
+		return body
+	}
+	return stamped
+}
+
+// restamp replaces the top-level request_id and timestamp fields of a JSON object
+// Other fields are copied verbatim and keep their order
+func restamp(body []byte, requestID string, timestamp time.Time) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, errors.New("response is not a JSON object")
+	}
+
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for i := 0; decoder.More(); i++ {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, _ := token.(string)
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		switch name {
+		case "request_id":
+			value, err = json.Marshal(requestID)
+		case "timestamp":
+			value, err = json.Marshal(timestamp)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		encodedName, _ := json.Marshal(name)
+		out.Write(encodedName)
+		out.WriteByte(':')
+		out.Write(value)
+	}
+	out.WriteByte('}')
+
+	// Closing brace
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
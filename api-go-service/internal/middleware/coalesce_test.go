@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCoalesceMiddleware tests that identical in-flight requests share one computation
+func TestCoalesceMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var calls int32
+	release := make(chan struct{})
+	started := make(chan struct{}, 10)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(RequestIDKey, c.GetHeader("X-Request-ID"))
+		c.Next()
+	})
+	router.Use(ConsumerMiddleware())
+	router.POST("/sum", CoalesceMiddleware(), func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		started <- struct{}{}
+		<-release
+
+		var request map[string]interface{}
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if _, private := request["privacy"]; private {
+			c.Header("Cache-Control", "no-store")
+		}
+		requestID, _ := c.Get(RequestIDKey)
+		c.JSON(http.StatusOK, gin.H{"sum": 3, "request_id": requestID, "timestamp": time.Unix(0, 0).UTC()})
+	})
+
+	post := func(body, requestID, consumer string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/sum", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", requestID)
+		if consumer != "" {
+			req.Header.Set("X-Consumer-Username", consumer)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// concurrently sends the requests, waits until the leaders are running and
+	// the followers are waiting, and releases the handlers
+	concurrently := func(leaders int, requests ...func() *httptest.ResponseRecorder) []*httptest.ResponseRecorder {
+		results := make([]*httptest.ResponseRecorder, len(requests))
+		var wg sync.WaitGroup
+		for i, request := range requests {
+			wg.Add(1)
+			go func(i int, request func() *httptest.ResponseRecorder) {
+				defer wg.Done()
+				results[i] = request()
+			}(i, request)
+		}
+		for i := 0; i < leaders; i++ {
+			<-started
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		release = make(chan struct{})
+		return results
+	}
+
+	t.Run("Identical requests share one computation", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		results := concurrently(1,
+			func() *httptest.ResponseRecorder { return post(`{"numbers": [1, 2]}`, "req-1", "alice") },
+			func() *httptest.ResponseRecorder { return post(`{ "numbers": [1,2] }`, "req-2", "alice") },
+			func() *httptest.ResponseRecorder { return post(`{"numbers": [1, 2]}`, "req-3", "alice") },
+		)
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		coalesced := 0
+		for i, w := range results {
+			require.Equal(t, http.StatusOK, w.Code)
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, float64(3), response["sum"])
+			assert.Equal(t, []string{"req-1", "req-2", "req-3"}[i], response["request_id"])
+			if w.Header().Get(CoalescedHeader) == "true" {
+				coalesced++
+				assert.NotEqual(t, "1970-01-01T00:00:00Z", response["timestamp"])
+			}
+		}
+		assert.Equal(t, 2, coalesced)
+	})
+
+	t.Run("Consumers do not share computations", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		results := concurrently(2,
+			func() *httptest.ResponseRecorder { return post(`{"numbers": [1, 2]}`, "req-1", "alice") },
+			func() *httptest.ResponseRecorder { return post(`{"numbers": [1, 2]}`, "req-2", "bob") },
+		)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+		for _, w := range results {
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get(CoalescedHeader))
+		}
+	})
+
+	t.Run("No-store responses are not shared", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		go func() {
+			// The follower runs the handler itself once the leader is done
+			<-started
+			time.Sleep(50 * time.Millisecond)
+			release <- struct{}{}
+			<-started
+			release <- struct{}{}
+		}()
+
+		var wg sync.WaitGroup
+		for _, id := range []string{"req-1", "req-2"} {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				w := post(`{"numbers": [1, 2], "privacy": {}}`, id, "alice")
+				assert.Equal(t, http.StatusOK, w.Code)
+				assert.Empty(t, w.Header().Get(CoalescedHeader))
+			}(id)
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
+// TestRestamp tests that only the request ID and timestamp are replaced
+func TestRestamp(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	body, err := restamp([]byte(`{"sum":3,"nested":{"request_id":"x"},"timestamp":"old","request_id":"old"}`), "new", now)
+	require.NoError(t, err)
+	assert.Equal(t, `{"sum":3,"nested":{"request_id":"x"},"timestamp":"2024-01-02T03:04:05Z","request_id":"new"}`, string(body))
+
+	_, err = restamp([]byte(`[1, 2]`), "new", now)
+	assert.Error(t, err)
+}
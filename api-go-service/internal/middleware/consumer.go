@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// ConsumerKey is the key used to store the consumer identity in context
+const ConsumerKey = "consumer"
+
+// AnonymousConsumer identifies requests that did not come through an authenticated Kong route
+const AnonymousConsumer = "anonymous"
+
+// ConsumerMiddleware creates a gin middleware that resolves the calling consumer
+// Kong's key-auth plugin authenticates the API key and forwards the consumer
+// identity in the X-Consumer-Username and X-Consumer-ID headers
+func ConsumerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		consumer := ResolveConsumer(c.GetHeader("X-Consumer-Username"), c.GetHeader("X-Consumer-ID"), c.GetHeader("X-Anonymous-Consumer"))
+
+		c.Set(ConsumerKey, consumer)
+		c.Next()
+	}
+}
+
+// ResolveConsumer returns the consumer identity from the values of Kong's consumer headers
+func ResolveConsumer(username, id, anonymous string) string {
+	consumer := username
+	if consumer == "" {
+		consumer = id
+	}
+	if consumer == "" || anonymous == "true" {
+		consumer = AnonymousConsumer
+	}
+	return consumer
+}
+
+// GetConsumer returns the consumer identity stored by ConsumerMiddleware
+func GetConsumer(c *gin.Context) string {
+	if consumer := c.GetString(ConsumerKey); consumer != "" {
+		return consumer
+	}
+	return AnonymousConsumer
+}
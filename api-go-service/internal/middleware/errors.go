@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/i18n"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/negotiation"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// ResponseFormatKey is the context key of the negotiated response format
+const ResponseFormatKey = "response_format"
+
+// Fail reports err to the client and stops the handler chain
+// Errors that are not an APIError are reported as internal errors. The response
+// is written at once so that the middlewares recording responses see its body
+func Fail(c *gin.Context, err error) {
+	apiErr := models.AsAPIError(err)
+	_ = c.Error(apiErr)
+	c.Abort()
+
+	if !c.Writer.Written() {
+		renderAPIError(c, apiErr)
+	}
+}
+
+// ErrorMiddleware creates a gin middleware rendering the errors that handlers
+// attached to the context without writing a response
+func ErrorMiddleware(log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Written() || len(c.Errors) == 0 {
+			return
+		}
+
+		requestID, _ := c.Get(RequestIDKey)
+		reqID, _ := requestID.(string)
+
+		err := c.Errors.Last().Err
+		apiErr := models.AsAPIError(err)
+		log.WithError(err).WithFields(map[string]interface{}{
+			"component":  "error_middleware",
+			"request_id": reqID,
+			"path":       c.Request.URL.Path,
+			"code":       apiErr.Code,
+		}).Warn("Rendering unhandled request error")
+
+		renderAPIError(c, apiErr)
+	}
+}
+
+// renderAPIError writes the error response of err
+func renderAPIError(c *gin.Context, err *models.APIError) {
+	requestID, _ := c.Get(RequestIDKey)
+	reqID, _ := requestID.(string)
+
+	RenderError(c, err.HTTPStatus(), err.Response(GetLocale(c), c.Request.URL.Path, reqID))
+}
+
+// RenderError writes an error response with the given status
+// Clients accepting application/problem+json get RFC 7807 problem details instead;
+// otherwise the response uses the format negotiated by the handler, or JSON
+func RenderError(c *gin.Context, statusCode int, response *models.ErrorResponse) {
+	locale := GetLocale(c)
+	c.Header("Vary", "Accept, Accept-Language")
+	c.Header("Content-Language", locale)
+	if negotiation.AcceptsProblem(c.GetHeader("Accept")) {
+		problem := models.NewProblem(response, statusCode)
+		problem.Title = i18n.Translate(locale, problem.Title)
+		if body, err := json.Marshal(problem); err == nil {
+			c.Data(statusCode, negotiation.MediaTypeProblem, body)
+			return
+		}
+	}
+
+	if value, ok := c.Get(ResponseFormatKey); ok {
+		if format, ok := value.(*negotiation.Format); ok && format != negotiation.JSON {
+			if body, err := format.Encode(response); err == nil {
+				c.Data(statusCode, format.ContentType(), body)
+				return
+			}
+		}
+	}
+	c.JSON(statusCode, response)
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestErrorMiddleware tests that typed errors are rendered from the catalog
+func TestErrorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var recorded int
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(RequestIDKey, "req-1")
+		c.Next()
+	})
+	router.Use(ErrorMiddleware(logger.New("error", "json")))
+	// Stands for the middlewares recording responses after the handlers
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		recorded = c.Writer.Size()
+	})
+
+	router.GET("/failed", func(c *gin.Context) {
+		Fail(c, models.NewAPIError(models.CodeJobNotFound, nil).WithParam("job_id", "job_1"))
+	})
+	router.GET("/attached", func(c *gin.Context) {
+		_ = c.Error(models.NewAPIError(models.CodeJobQueueFull, errors.New("queue holds 100 jobs")))
+	})
+	router.GET("/untyped", func(c *gin.Context) {
+		Fail(c, errors.New("connection refused by 10.0.0.3"))
+	})
+
+	get := func(path string) (*httptest.ResponseRecorder, models.ErrorResponse) {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	t.Run("Failures are rendered before the chain unwinds", func(t *testing.T) {
+		w, response := get("/failed")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, models.CodeJobNotFound, response.Code)
+		assert.Equal(t, `job "job_1" not found`, response.Error)
+		assert.Equal(t, "req-1", response.RequestID)
+		assert.Equal(t, "/errors/JOB_NOT_FOUND", response.Docs)
+		assert.Equal(t, w.Body.Len(), recorded)
+	})
+
+	t.Run("Attached errors are rendered", func(t *testing.T) {
+		w, response := get("/attached")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "queue holds 100 jobs", response.Error)
+		assert.True(t, response.Retryable)
+	})
+
+	t.Run("Untyped errors do not leak", func(t *testing.T) {
+		w, response := get("/untyped")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, models.CodeInternal, response.Code)
+		assert.Equal(t, "internal error", response.Error)
+	})
+}
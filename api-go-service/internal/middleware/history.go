@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/history"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// HistoryRedactHeader lets a client keep the body of one request out of its history
+const HistoryRedactHeader = "X-History-Redact"
+
+// HistoryOptions controls what HistoryMiddleware records
+type HistoryOptions struct {
+	Consumers    []string // consumers whose requests are recorded, all when empty
+	RedactInputs bool     // never store request bodies
+	MaxBodyBytes int      // request and response bytes stored per entry
+}
+
+// HistoryMiddleware creates a gin middleware that records the API requests of
+// authenticated consumers and their responses in store
+// Only POST requests to /api/v1 routes are recorded. Bodies longer than
+// MaxBodyBytes are dropped and the entry is marked truncated; the SHA-256 of a
+// request body is kept even when the body itself is redacted or dropped.
+// Failing to record an entry is logged and does not affect the response.
+func HistoryMiddleware(store history.Store, opts HistoryOptions, log *logger.Logger) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(opts.Consumers))
+	for _, consumer := range opts.Consumers {
+		allowed[consumer] = true
+	}
+
+	return func(c *gin.Context) {
+		consumer := GetConsumer(c)
+		if c.Request.Method != http.MethodPost ||
+			!strings.HasPrefix(c.FullPath(), "/api/v1/") ||
+			consumer == AnonymousConsumer ||
+			(len(allowed) > 0 && !allowed[consumer]) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		body := &historyBody{ReadCloser: c.Request.Body, limit: opts.MaxBodyBytes, hash: sha256.New()}
+		c.Request.Body = body
+		writer := &historyWriter{ResponseWriter: c.Writer, limit: opts.MaxBodyBytes}
+		c.Writer = writer
+
+		c.Next()
+
+		// Decoders stop at the end of the JSON value; read on to complete the digest
+		if !body.eof {
+			_, _ = io.Copy(io.Discard, io.LimitReader(body, int64(opts.MaxBodyBytes)+1))
+		}
+
+		requestID, _ := c.Get(RequestIDKey)
+		reqID, _ := requestID.(string)
+
+		entry := &history.Entry{
+			RequestID:           reqID,
+			Owner:               consumer,
+			Method:              c.Request.Method,
+			Path:                c.Request.URL.Path,
+			Status:              writer.Status(),
+			RequestContentType:  c.ContentType(),
+			RequestSize:         body.size,
+			ResponseContentType: writer.Header().Get("Content-Type"),
+			Truncated:           body.overflow || writer.overflow,
+			CreatedAt:           start.UTC(),
+			Duration:            time.Since(start),
+		}
+		if body.eof {
+			entry.RequestSHA256 = hex.EncodeToString(body.hash.Sum(nil))
+		}
+
+		redact, _ := strconv.ParseBool(c.GetHeader(HistoryRedactHeader))
+		switch {
+		case opts.RedactInputs || redact:
+			entry.Redacted = true
+		case !body.overflow:
+			entry.Request = body.buf.Bytes()
+		}
+		if !writer.overflow {
+			entry.Response = writer.buf.Bytes()
+		}
+
+		err := store.Put(c.Request.Context(), entry)
+		history.Recorded(err)
+		if err != nil {
+			log.LogError(err, "history_middleware", "record", map[string]interface{}{
+				"request_id": reqID,
+				"consumer":   consumer,
+			})
+		}
+	}
+}
+
+// historyBody copies the request body as the handler reads it
+// The copy stops at limit bytes while the size and digest cover the whole body read
+type historyBody struct {
+	io.ReadCloser
+	limit    int
+	buf      bytes.Buffer
+	hash     hash.Hash
+	size     int64
+	overflow bool
+	eof      bool
+}
+
+// Read implements io.Reader
+func (b *historyBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.hash.Write(p[:n])
+		b.size += int64(n)
+		if !b.overflow {
+			if b.buf.Len()+n > b.limit {
+				b.overflow = true
+				b.buf.Reset()
+			} else {
+				b.buf.Write(p[:n])
+			}
+		}
+	}
+	if err == io.EOF {
+		b.eof = true
+	}
+	return n, err
+}
+
+// historyWriter copies the response body up to limit bytes while writing it through
+type historyWriter struct {
+	gin.ResponseWriter
+	limit    int
+	buf      bytes.Buffer
+	overflow bool
+}
+
+// Write implements io.Writer
+func (w *historyWriter) Write(b []byte) (int, error) {
+	w.capture(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// WriteString implements io.StringWriter
+func (w *historyWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// capture appends b to the copy unless it would exceed the limit
+func (w *historyWriter) capture(b []byte) {
+	if w.overflow {
+		return
+	}
+	if w.buf.Len()+len(b) > w.limit {
+		w.overflow = true
+		w.buf.Reset()
+		return
+	}
+	w.buf.Write(b)
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/history"
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHistoryMiddleware tests which requests are recorded and what is kept of them
+func TestHistoryMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := history.NewMemoryStore(100)
+
+	router := gin.New()
+	router.Use(LoggingMiddleware(logger.New("error", "json")))
+	router.Use(ConsumerMiddleware())
+	router.Use(HistoryMiddleware(store, HistoryOptions{Consumers: []string{"alice", "bob"}, MaxBodyBytes: 64}, logger.New("error", "json")))
+
+	router.POST("/api/v1/echo", func(c *gin.Context) {
+		var body map[string]interface{}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, body)
+	})
+	router.GET("/api/v1/echo", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	post := func(method, consumer, body string, header map[string]string) string {
+		req, _ := http.NewRequest(method, "/api/v1/echo", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Consumer-Username", consumer)
+		for name, value := range header {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Header().Get("X-Request-ID")
+	}
+	get := func(consumer, requestID string) *history.Entry {
+		entry, err := store.Get(context.Background(), consumer, requestID)
+		if err != nil {
+			return nil
+		}
+		return entry
+	}
+
+	t.Run("Records request and response", func(t *testing.T) {
+		id := post("POST", "alice", `{"a": 1}`, nil)
+		entry := get("alice", id)
+		require.NotNil(t, entry)
+		assert.Equal(t, http.StatusOK, entry.Status)
+		assert.Equal(t, "/api/v1/echo", entry.Path)
+		assert.Equal(t, `{"a": 1}`, string(entry.Request))
+		assert.JSONEq(t, `{"a": 1}`, string(entry.Response))
+		assert.Equal(t, int64(8), entry.RequestSize)
+		assert.Len(t, entry.RequestSHA256, 64)
+		assert.False(t, entry.Redacted)
+	})
+
+	t.Run("Redacted on request", func(t *testing.T) {
+		id := post("POST", "alice", `{"secret": 1}`, map[string]string{HistoryRedactHeader: "true"})
+		entry := get("alice", id)
+		require.NotNil(t, entry)
+		assert.True(t, entry.Redacted)
+		assert.Nil(t, entry.Request)
+		assert.NotEmpty(t, entry.RequestSHA256)
+		assert.NotNil(t, entry.Response)
+	})
+
+	t.Run("Large bodies are dropped", func(t *testing.T) {
+		id := post("POST", "bob", `{"a": "`+strings.Repeat("x", 100)+`"}`, nil)
+		entry := get("bob", id)
+		require.NotNil(t, entry)
+		assert.True(t, entry.Truncated)
+		assert.Nil(t, entry.Request)
+		assert.Nil(t, entry.Response)
+		assert.Equal(t, int64(109), entry.RequestSize)
+	})
+
+	t.Run("Not recorded", func(t *testing.T) {
+		assert.Nil(t, get("alice", post("GET", "alice", "", nil)))
+		assert.Nil(t, get("carol", post("POST", "carol", `{}`, nil)))
+		assert.Nil(t, get(AnonymousConsumer, post("POST", "", `{}`, nil)))
+
+		// Entries are only visible to their consumer
+		id := post("POST", "alice", `{}`, nil)
+		assert.Nil(t, get("bob", id))
+	})
+}
+
+// TestHistoryMiddleware_RedactInputs tests that no request body is kept when inputs are redacted
+func TestHistoryMiddleware_RedactInputs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := history.NewMemoryStore(100)
+
+	router := gin.New()
+	router.Use(LoggingMiddleware(logger.New("error", "json")))
+	router.Use(ConsumerMiddleware())
+	router.Use(HistoryMiddleware(store, HistoryOptions{RedactInputs: true, MaxBodyBytes: 1024}, logger.New("error", "json")))
+	router.POST("/api/v1/sum", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"result": 3})
+	})
+
+	req, _ := http.NewRequest("POST", "/api/v1/sum", bytes.NewBufferString(`{"numbers": [1, 2]}`))
+	req.Header.Set("X-Consumer-Username", "alice")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	entry, err := store.Get(context.Background(), "alice", w.Header().Get("X-Request-ID"))
+	require.NoError(t, err)
+	assert.True(t, entry.Redacted)
+	assert.Nil(t, entry.Request)
+	// The handler never read the body; it is still hashed
+	assert.Equal(t, int64(19), entry.RequestSize)
+	assert.NotEmpty(t, entry.RequestSHA256)
+	assert.JSONEq(t, `{"result": 3}`, string(entry.Response))
+}
@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/idempotency"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// IdempotencyKeyHeader is the request header carrying the client's idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set on responses replayed from the store
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotencyKeyLength bounds the length of client supplied keys
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyMiddleware creates a gin middleware that makes POST requests carrying
+// an Idempotency-Key header safe to retry
+// The first response for a (consumer, key) pair is stored with a fingerprint of
+// the request and replayed verbatim to retries within ttl. Server errors are not
+// stored so that the request can be retried. Bodies are buffered to fingerprint
+// them, so requests with a key and a body larger than maxBodyBytes are rejected.
+func IdempotencyMiddleware(store idempotency.Store, ttl time.Duration, maxBodyBytes int64, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		requestID, _ := c.Get(RequestIDKey)
+		reqID, _ := requestID.(string)
+
+		reject := func(statusCode int, err error, code string) {
+			log.WithError(err).WithFields(map[string]interface{}{
+				"component":  "idempotency_middleware",
+				"request_id": reqID,
+				"consumer":   GetConsumer(c),
+				"code":       code,
+			}).Warn("Idempotent request rejected")
+
+			Fail(c, models.NewAPIError(code, err).WithStatus(statusCode))
+		}
+
+		if err := validateIdempotencyKey(key); err != nil {
+			reject(http.StatusBadRequest, err, models.CodeInvalidIdempotencyKey)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			reject(http.StatusRequestEntityTooLarge, fmt.Errorf("requests with an idempotency key are limited to %d bytes", maxBodyBytes), models.CodeRequestTooLarge)
+			return
+		case err != nil:
+			reject(http.StatusBadRequest, err, models.CodeInvalidRequestBody)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		storeKey := GetConsumer(c) + "\x00" + key
+		fingerprint := requestFingerprint(c.Request, body)
+
+		existing, reserved, err := store.Reserve(c.Request.Context(), storeKey, fingerprint, ttl)
+		switch {
+		case errors.Is(err, idempotency.ErrStoreFull):
+			reject(http.StatusServiceUnavailable, err, models.CodeIdempotencyStoreFull)
+			return
+		case err != nil:
+			reject(http.StatusInternalServerError, err, models.CodeInternal)
+			return
+		case !reserved && existing.Fingerprint != fingerprint:
+			reject(http.StatusUnprocessableEntity, errors.New("idempotency key was already used with a different request"), models.CodeIdempotencyMismatch)
+			return
+		case !reserved && !existing.Completed:
+			reject(http.StatusConflict, errors.New("a request with this idempotency key is still in progress"), models.CodeIdempotencyInFlight)
+			return
+		case !reserved:
+			replay(c, existing)
+			return
+		}
+
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		// A panic leaves the reservation in place until it expires unless released here
+		completed := false
+		defer func() {
+			if !completed {
+				_ = store.Release(c.Request.Context(), storeKey)
+			}
+		}()
+
+		c.Next()
+
+		if writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		record := idempotency.Record{
+			Fingerprint: fingerprint,
+			StatusCode:  writer.Status(),
+			Header:      writer.Header().Clone(),
+			Body:        writer.body.Bytes(),
+		}
+		if err := store.Complete(c.Request.Context(), storeKey, record); err != nil {
+			log.LogError(err, "idempotency_middleware", "complete", map[string]interface{}{
+				"request_id": reqID,
+			})
+			return
+		}
+		completed = true
+	}
+}
+
+// replay writes a stored response
+// The X-Request-ID header keeps the value of the current request
+func replay(c *gin.Context, record *idempotency.Record) {
+	for name, values := range record.Header {
+		if name == "X-Request-Id" {
+			continue
+		}
+		c.Writer.Header()[name] = values
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Writer.WriteHeader(record.StatusCode)
+	_, _ = c.Writer.Write(record.Body)
+	c.Abort()
+}
+
+// validateIdempotencyKey checks that the key is printable ASCII of bounded length
+func validateIdempotencyKey(key string) error {
+	if len(key) > maxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return errors.New("idempotency key must consist of printable ASCII characters")
+		}
+	}
+	return nil
+}
+
+// requestFingerprint hashes the parts of a request that must match on retry
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.RequestURI()))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// capturingWriter records the response body while writing it through
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write implements io.Writer
+func (w *capturingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// WriteString implements io.StringWriter
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/idempotency"
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIdempotencyMiddleware tests replay, mismatch and in-flight detection
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ConsumerMiddleware())
+	router.Use(IdempotencyMiddleware(idempotency.NewMemoryStore(100, 1<<20), time.Minute, 64, logger.New("error", "json")))
+
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	router.POST("/count", func(c *gin.Context) {
+		n := atomic.AddInt32(&calls, 1)
+		c.JSON(http.StatusOK, gin.H{"call": n})
+	})
+	router.POST("/slow", func(c *gin.Context) {
+		close(started)
+		<-release
+		c.Status(http.StatusNoContent)
+	})
+	router.POST("/fail", func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.Status(http.StatusServiceUnavailable)
+	})
+
+	post := func(path, consumer, key, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("X-Consumer-Username", consumer)
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Retries replay the first response", func(t *testing.T) {
+		first := post("/count", "alice", "key-1", `{"numbers": [1, 2]}`)
+		retry := post("/count", "alice", "key-1", `{"numbers": [1, 2]}`)
+
+		assert.Equal(t, http.StatusOK, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Keys are scoped to the consumer", func(t *testing.T) {
+		w := post("/count", "bob", "key-1", `{"numbers": [1, 2]}`)
+		assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Reusing a key with a different body is rejected", func(t *testing.T) {
+		w := post("/count", "alice", "key-1", `{"numbers": [3, 4]}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Concurrent duplicate conflicts while in flight", func(t *testing.T) {
+		done := make(chan *httptest.ResponseRecorder)
+		go func() { done <- post("/slow", "alice", "key-2", "") }()
+		<-started
+
+		w := post("/slow", "alice", "key-2", "")
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		close(release)
+		assert.Equal(t, http.StatusNoContent, (<-done).Code)
+	})
+
+	t.Run("Server errors are not stored", func(t *testing.T) {
+		before := atomic.LoadInt32(&calls)
+		post("/fail", "alice", "key-3", "")
+		post("/fail", "alice", "key-3", "")
+		assert.Equal(t, before+2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Large bodies are rejected before the handler", func(t *testing.T) {
+		before := atomic.LoadInt32(&calls)
+		w := post("/count", "alice", "key-4", `{"numbers": [`+strings.Repeat("1, ", 30)+`1]}`)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Equal(t, before, atomic.LoadInt32(&calls))
+
+		// Without a key the body is left to the handler
+		w = post("/count", "alice", "", `{"numbers": [`+strings.Repeat("1, ", 30)+`1]}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Invalid keys", func(t *testing.T) {
+		w := post("/count", "alice", "bad key", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/i18n"
+)
+
+// LocaleKey is the context key of the locale of the client messages
+const LocaleKey = "locale"
+
+// LocaleMiddleware creates a gin middleware selecting the locale of the client
+// messages from the Accept-Language header. The locale is echoed in Content-Language
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		c.Set(LocaleKey, locale)
+		c.Header("Content-Language", locale)
+		c.Next()
+	}
+}
+
+// GetLocale returns the locale selected for the request
+// Requests that did not go through LocaleMiddleware use the default locale
+func GetLocale(c *gin.Context) string {
+	if locale, ok := c.Get(LocaleKey); ok {
+		if s, ok := locale.(string); ok {
+			return s
+		}
+	}
+	return i18n.Default
+}
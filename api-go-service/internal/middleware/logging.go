@@ -17,7 +17,7 @@ func LoggingMiddleware(log *logger.Logger) gin.HandlerFunc {
 		// Generate request ID if not present
 		requestID := c.GetHeader("X-Request-ID")
 		if requestID == "" {
-			requestID = generateRequestID()
+			requestID = GenerateRequestID()
 		}
 
 		// Store request ID in context for other handlers
@@ -45,9 +45,9 @@ func LoggingMiddleware(log *logger.Logger) gin.HandlerFunc {
 	}
 }
 
-// generateRequestID generates a simple request ID
+// GenerateRequestID generates a simple request ID
 // In production, you might want to use a more sophisticated approach
-func generateRequestID() string {
+func GenerateRequestID() string {
 	return time.Now().Format("20060102150405") + "-" + randomString(8)
 }
 
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/openapi"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// OpenAPIOptions controls what OpenAPIMiddleware checks
+type OpenAPIOptions struct {
+	ValidateResponses bool // check responses too, meant for non-production environments
+	FailOnDrift       bool // replace responses that drift from the contract with an error
+	MaxBodyBytes      int  // larger request bodies are not validated
+}
+
+// OpenAPIMiddleware creates a gin middleware that checks requests against the
+// operation of the matched route in the OpenAPI document returned by load
+// Requests whose parameters or JSON body the operation does not allow are
+// rejected with VALIDATION_ERROR before reaching the handler. When responses
+// are validated, drift is logged and, with FailOnDrift, reported to the client
+// as CONTRACT_DRIFT. Routes missing from the document are not checked; the
+// document is loaded on first use, once every route is registered.
+func OpenAPIMiddleware(load func() (*openapi.Document, error), opts OpenAPIOptions, log *logger.Logger) gin.HandlerFunc {
+	var (
+		once      sync.Once
+		doc       *openapi.Document
+		validator *openapi.Validator
+	)
+
+	return func(c *gin.Context) {
+		once.Do(func() {
+			var err error
+			if doc, err = load(); err != nil {
+				log.LogError(err, "openapi_middleware", "load_document", nil)
+				return
+			}
+			validator = openapi.NewValidator(doc)
+		})
+		if validator == nil || c.FullPath() == "" {
+			c.Next()
+			return
+		}
+		op, ok := doc.Operation(c.Request.Method, openapi.PathOf(c.FullPath()))
+		if !ok {
+			c.Next()
+			return
+		}
+
+		requestID, _ := c.Get(RequestIDKey)
+		reqID, _ := requestID.(string)
+
+		params := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			params[param.Key] = param.Value
+		}
+		violations := validator.ValidateParameters(op, openapi.Request{
+			Path:   params,
+			Query:  c.Request.URL.Query(),
+			Header: c.Request.Header,
+		})
+		if op.RequestBody != nil && c.Request.Body != nil {
+			if body, complete := peekBody(c, opts.MaxBodyBytes); complete {
+				violations = append(violations, validator.ValidateRequestBody(op, c.ContentType(), body)...)
+			}
+		}
+		if len(violations) > 0 {
+			log.WithFields(map[string]interface{}{
+				"component":  "openapi_middleware",
+				"request_id": reqID,
+				"operation":  op.OperationID,
+				"violations": messages(violations),
+			}).Warn("Request violates the API contract")
+			Fail(c, models.NewAPIError(models.CodeValidation, models.NewValidationError(models.CodeValidation, contractErrors(violations)...)))
+			return
+		}
+
+		if !opts.ValidateResponses || !validator.Declares(op) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		header := original.Header().Clone()
+		writer := &bufferedWriter{ResponseWriter: original}
+		c.Writer = writer
+		func() {
+			defer func() { c.Writer = original }()
+			c.Next()
+		}()
+
+		drift := validator.ValidateResponse(op, writer.Status(), writer.Header().Get("Content-Type"), writer.body.Bytes())
+		if len(drift) == 0 {
+			writer.flush()
+			return
+		}
+		log.WithFields(map[string]interface{}{
+			"component":  "openapi_middleware",
+			"request_id": reqID,
+			"operation":  op.OperationID,
+			"status":     writer.Status(),
+			"violations": messages(drift),
+		}).Warn("Response drifts from the API contract")
+		if !opts.FailOnDrift {
+			writer.flush()
+			return
+		}
+
+		// The headers set while producing the drifting response are dropped with it
+		resetHeader(original.Header(), header)
+		Fail(c, models.NewAPIError(models.CodeContractDrift, models.NewValidationError(models.CodeContractDrift, contractErrors(drift)...)))
+	}
+}
+
+// peekBody reads the request body up to limit bytes and puts it back for the handler
+// It reports whether the whole body was read
+func peekBody(c *gin.Context, limit int) ([]byte, bool) {
+	original := c.Request.Body
+	body, err := io.ReadAll(io.LimitReader(original, int64(limit)+1))
+	c.Request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), original), original}
+	return body, err == nil && len(body) <= limit
+}
+
+// contractErrors converts contract violations into localizable field errors
+func contractErrors(violations []openapi.Violation) []models.FieldError {
+	fieldErrs := make([]models.FieldError, 0, len(violations))
+	for _, v := range violations {
+		fe := models.FieldViolation(v.Pointer, v.Rule, v.Format, v.Args...)
+		fe.Parameter = v.Parameter
+		fieldErrs = append(fieldErrs, fe)
+	}
+	return fieldErrs
+}
+
+// messages lists the English messages of violations for the logs
+func messages(violations []openapi.Violation) []string {
+	list := make([]string, len(violations))
+	for i, v := range violations {
+		if v.Parameter != "" {
+			list[i] = v.In + " " + v.Parameter + v.Pointer + ": " + v.Message()
+		} else {
+			list[i] = v.Pointer + ": " + v.Message()
+		}
+	}
+	return list
+}
+
+// resetHeader replaces the fields of h with those of saved
+func resetHeader(h, saved http.Header) {
+	for key := range h {
+		delete(h, key)
+	}
+	for key, values := range saved {
+		h[key] = values
+	}
+}
@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/openapi"
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contractRequest struct {
+	Numbers []float64 `json:"numbers" binding:"required" validate:"min_items=2"`
+}
+
+type contractResponse struct {
+	Sum float64 `json:"sum"`
+}
+
+// TestOpenAPIMiddleware tests request validation and response drift
+func TestOpenAPIMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func(opts OpenAPIOptions) *gin.Engine {
+		router := gin.New()
+		router.Use(LocaleMiddleware())
+		router.Use(OpenAPIMiddleware(func() (*openapi.Document, error) {
+			b := openapi.NewBuilder(openapi.Info{Title: "test", Version: "1.0.0"})
+			b.SetErrorModels(models.ErrorResponse{}, models.Problem{})
+			b.SetLimits(models.ResolveLimit)
+			b.Describe(http.MethodPost, "/sum/:id", openapi.Route{
+				Request:  contractRequest{},
+				Response: contractResponse{},
+				Parameters: []*openapi.Parameter{
+					{Name: "scale", In: openapi.InQuery, Schema: &openapi.Schema{Type: openapi.Types{"integer"}}},
+				},
+			})
+			return b.Build(router.Routes()), nil
+		}, opts, logger.New("error", "json")))
+		router.Use(ErrorMiddleware(logger.New("error", "json")))
+
+		router.POST("/sum/:id", func(c *gin.Context) {
+			var request map[string]interface{}
+			if err := c.ShouldBindJSON(&request); err != nil {
+				Fail(c, models.NewAPIError(models.CodeInvalidRequestBody, err))
+				return
+			}
+			c.Header("X-Handler", "sum")
+			if c.Query("drift") != "" {
+				c.JSON(http.StatusOK, gin.H{"sum": "three"})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"sum": 3})
+		})
+		router.GET("/undescribed", func(c *gin.Context) {
+			c.String(http.StatusOK, "ok")
+		})
+		return router
+	}
+
+	send := func(router *gin.Engine, method, target, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, target, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	decode := func(w *httptest.ResponseRecorder) models.ErrorResponse {
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	router := setup(OpenAPIOptions{ValidateResponses: true, MaxBodyBytes: 64})
+
+	t.Run("Valid requests reach the handler", func(t *testing.T) {
+		w := send(router, http.MethodPost, "/sum/a?scale=2", `{"numbers": [1, 2]}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"sum": 3}`, w.Body.String())
+		assert.Equal(t, "sum", w.Header().Get("X-Handler"))
+	})
+
+	t.Run("Invalid requests are rejected", func(t *testing.T) {
+		w := send(router, http.MethodPost, "/sum/a?scale=half", `{"numbers": [1]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, w.Header().Get("X-Handler"))
+
+		response := decode(w)
+		assert.Equal(t, models.CodeValidation, response.Code)
+		require.Len(t, response.Errors, 2)
+		assert.Equal(t, models.FieldError{Parameter: "scale", Rule: openapi.RuleType, Message: "expected an integer, got a string"}, response.Errors[0])
+		assert.Equal(t, models.FieldError{Pointer: "/numbers", Rule: openapi.RuleMinItems, Message: "at least 2 numbers are required, got 1"}, response.Errors[1])
+		assert.Equal(t, map[string]string{"scale": "expected an integer, got a string", "/numbers": "at least 2 numbers are required, got 1"}, response.Details)
+	})
+
+	t.Run("Messages are localized", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/sum/a", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", "fr")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []models.FieldError{{Pointer: "/numbers", Rule: openapi.RuleRequired, Message: "numbers est obligatoire"}}, decode(w).Errors)
+	})
+
+	t.Run("Large bodies are left to the handler", func(t *testing.T) {
+		w := send(router, http.MethodPost, "/sum/a", `{"numbers": [1], "padding": "`+string(bytes.Repeat([]byte("x"), 64))+`"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Undescribed routes are not checked", func(t *testing.T) {
+		w := send(router, http.MethodGet, "/undescribed?scale=half", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ok", w.Body.String())
+	})
+
+	t.Run("Drift is logged", func(t *testing.T) {
+		w := send(router, http.MethodPost, "/sum/a?drift=1", `{"numbers": [1, 2]}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"sum": "three"}`, w.Body.String())
+	})
+
+	t.Run("Drift fails the request", func(t *testing.T) {
+		strict := setup(OpenAPIOptions{ValidateResponses: true, FailOnDrift: true, MaxBodyBytes: 64})
+		w := send(strict, http.MethodPost, "/sum/a?drift=1", `{"numbers": [1, 2]}`)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("X-Handler"))
+
+		response := decode(w)
+		assert.Equal(t, models.CodeContractDrift, response.Code)
+		assert.Equal(t, []models.FieldError{{Pointer: "/sum", Rule: openapi.RuleType, Message: "expected a number, got a string"}}, response.Errors)
+
+		// Error responses of the handler are checked against the default response
+		w = send(strict, http.MethodPost, "/sum/a", `{"numbers": [1, 2]`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, models.CodeInvalidRequestBody, decode(w).Code)
+	})
+
+	t.Run("Documents that fail to load disable the checks", func(t *testing.T) {
+		engine := gin.New()
+		engine.Use(OpenAPIMiddleware(func() (*openapi.Document, error) {
+			return nil, errors.New("no document")
+		}, OpenAPIOptions{}, logger.New("error", "json")))
+		engine.POST("/sum/:id", func(c *gin.Context) {
+			body, _ := io.ReadAll(c.Request.Body)
+			c.String(http.StatusOK, string(body))
+		})
+		w := send(engine, http.MethodPost, "/sum/a", `{}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{}`, w.Body.String())
+	})
+}
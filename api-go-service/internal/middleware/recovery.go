@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"fmt"
-	"net/http"
 	"runtime"
 
 	"github.com/gin-gonic/gin"
@@ -40,15 +39,7 @@ func RecoveryMiddleware(log *logger.Logger) gin.HandlerFunc {
 				)
 
 				// Return error response
-				errorResponse := models.NewErrorResponse(
-					fmt.Errorf("internal server error"),
-					"INTERNAL_SERVER_ERROR",
-					c.Request.URL.Path,
-					reqID,
-				)
-
-				c.JSON(http.StatusInternalServerError, errorResponse)
-				c.Abort()
+				Fail(c, models.NewAPIError(models.CodeInternalServer, nil))
 			}
 		}()
 
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"time"
+
+	"github.com/katvio/api-go-service/internal/secagg"
+)
+
+// Error codes returned by the secure aggregation endpoints
+const (
+	CodeSessionNotFound     = "SESSION_NOT_FOUND"
+	CodeSessionClosed       = "SESSION_CLOSED"
+	CodeDuplicateShare      = "DUPLICATE_SHARE"
+	CodeUnknownParticipant  = "UNKNOWN_PARTICIPANT"
+	CodeTooManySessions     = "TOO_MANY_SESSIONS"
+	CodeSessionLimitReached = "SESSION_LIMIT_REACHED"
+	CodeInvalidShare        = "INVALID_SHARE"
+)
+
+// AggregationSessionRequest represents the request payload for opening an aggregation session
+type AggregationSessionRequest struct {
+	Participants    int      `json:"participants" binding:"required"`
+	ParticipantIDs  []string `json:"participant_ids,omitempty"`
+	Modulus         *BigInt  `json:"modulus" binding:"required"`
+	Timeout         string   `json:"timeout,omitempty"`
+	DropoutPolicy   string   `json:"dropout_policy,omitempty"`
+	MinParticipants int      `json:"min_participants,omitempty"`
+}
+
+// AggregationSharesRequest represents the request payload for submitting additive shares
+type AggregationSharesRequest struct {
+	Shares []BigInt `json:"shares" binding:"required"`
+}
+
+// AggregationSessionResponse represents the state of an aggregation session
+// Aggregate is only present once the session is complete
+type AggregationSessionResponse struct {
+	SessionID       string     `json:"session_id"`
+	State           string     `json:"state"`
+	Modulus         string     `json:"modulus"`
+	Participants    int        `json:"participants"`
+	Received        int        `json:"received"`
+	Submitted       []string   `json:"submitted"`
+	Dropped         []string   `json:"dropped,omitempty"`
+	DropoutPolicy   string     `json:"dropout_policy"`
+	MinParticipants int        `json:"min_participants,omitempty"`
+	Aggregate       string     `json:"aggregate,omitempty"`
+	Partial         bool       `json:"partial,omitempty"`
+	Reason          string     `json:"reason,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	Deadline        time.Time  `json:"deadline"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty"`
+	Timestamp       time.Time  `json:"timestamp"`
+	RequestID       string     `json:"request_id,omitempty"`
+}
+
+// Validate checks the session parameters against the configured limits
+func (r *AggregationSessionRequest) Validate(maxParticipants int, maxTimeout time.Duration) error {
+	if r.Participants < 2 {
+		return newFieldError(CodeValidation, JSONPointer("participants"), "at least 2 participants are required, got %d", r.Participants)
+	}
+
+	if r.Participants > maxParticipants {
+		return newFieldError(CodeValidation, JSONPointer("participants"), "maximum %d participants allowed, got %d", maxParticipants, r.Participants)
+	}
+
+	if len(r.ParticipantIDs) > 0 {
+		if len(r.ParticipantIDs) != r.Participants {
+			return newFieldError(CodeValidation, JSONPointer("participant_ids"), "%d participant_ids given for %d participants", len(r.ParticipantIDs), r.Participants)
+		}
+		seen := make(map[string]bool, len(r.ParticipantIDs))
+		for i, id := range r.ParticipantIDs {
+			if id == "" || seen[id] {
+				return newFieldError(CodeValidation, JSONPointer("participant_ids", i), "participant_ids must be unique and non-empty")
+			}
+			seen[id] = true
+		}
+	}
+
+	switch secagg.DropoutPolicy(r.DropoutPolicy) {
+	case "", secagg.PolicyAbort:
+	case secagg.PolicyPartial:
+		if r.MinParticipants < 2 || r.MinParticipants > r.Participants {
+			return newFieldError(CodeValidation, JSONPointer("min_participants"), "partial policy needs min_participants between 2 and %d", r.Participants)
+		}
+	default:
+		return newFieldError(CodeValidation, JSONPointer("dropout_policy"), "dropout_policy must be %q or %q", secagg.PolicyAbort, secagg.PolicyPartial)
+	}
+
+	if r.Timeout != "" {
+		timeout, err := time.ParseDuration(r.Timeout)
+		if err != nil {
+			return newFieldError(CodeValidation, JSONPointer("timeout"), "timeout %q is not a duration such as 30s", r.Timeout)
+		}
+		if timeout <= 0 || timeout > maxTimeout {
+			return newFieldError(CodeValidation, JSONPointer("timeout"), "timeout must be positive and at most %s", maxTimeout)
+		}
+	}
+
+	return nil
+}
+
+// Options converts the request into session options
+func (r *AggregationSessionRequest) Options(defaultTimeout time.Duration) secagg.Options {
+	timeout := defaultTimeout
+	if parsed, err := time.ParseDuration(r.Timeout); err == nil {
+		timeout = parsed
+	}
+
+	policy := secagg.DropoutPolicy(r.DropoutPolicy)
+	if policy == "" {
+		policy = secagg.PolicyAbort
+	}
+
+	return secagg.Options{
+		Participants:    r.Participants,
+		ParticipantIDs:  r.ParticipantIDs,
+		Modulus:         r.Modulus.Int(),
+		Timeout:         timeout,
+		Policy:          policy,
+		MinParticipants: r.MinParticipants,
+	}
+}
+
+// Validate checks the number of shares and that each one is non-negative and
+// no wider than the widest modulus a session may have
+func (r *AggregationSharesRequest) Validate(maxShares, maxModulusBits int) error {
+	if len(r.Shares) == 0 {
+		return newFieldError(CodeInvalidShape, JSONPointer("shares"), "at least 1 share is required")
+	}
+
+	if err := checkElementCount(len(r.Shares), maxShares, "shares"); err != nil {
+		return err
+	}
+
+	for i, share := range r.Shares {
+		if share.value.Sign() < 0 {
+			return newFieldError(CodeInvalidShare, JSONPointer("shares", i), "shares must not be negative")
+		}
+		if share.value.BitLen() > maxModulusBits {
+			return newFieldError(CodeInvalidShare, JSONPointer("shares", i), "shares must be at most %d bits wide", maxModulusBits)
+		}
+	}
+	return nil
+}
+
+// NewAggregationSessionResponse creates a response from a session snapshot
+func NewAggregationSessionResponse(snap *secagg.Snapshot, requestID string) *AggregationSessionResponse {
+	response := &AggregationSessionResponse{
+		SessionID:       snap.ID,
+		State:           string(snap.State),
+		Modulus:         snap.Modulus.String(),
+		Participants:    snap.Expected,
+		Received:        len(snap.Submitted),
+		Submitted:       snap.Submitted,
+		Dropped:         snap.Dropped,
+		DropoutPolicy:   string(snap.Policy),
+		MinParticipants: snap.MinParticipants,
+		Partial:         snap.Partial,
+		Reason:          snap.Reason,
+		CreatedAt:       snap.CreatedAt,
+		Deadline:        snap.Deadline,
+		Timestamp:       time.Now().UTC(),
+		RequestID:       requestID,
+	}
+
+	if snap.Aggregate != nil {
+		response.Aggregate = snap.Aggregate.String()
+	}
+
+	if !snap.ClosedAt.IsZero() {
+		closedAt := snap.ClosedAt
+		response.ClosedAt = &closedAt
+	}
+
+	return response
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UnmarshalCSV reads the numbers of a sum request from CSV records
+// Every non-empty cell is a number; a first row without numbers is a header.
+// Differential privacy parameters cannot be sent as CSV.
+func (s *SumRequest) UnmarshalCSV(records [][]string) error {
+	*s = SumRequest{Numbers: []float64{}}
+
+	for i, record := range records {
+		for j, cell := range record {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+
+			num, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				if i == 0 && isHeader(record) {
+					break
+				}
+				return fmt.Errorf("row %d, column %d: %q is not a number", i+1, j+1, cell)
+			}
+			if math.IsNaN(num) || math.IsInf(num, 0) {
+				return fmt.Errorf("row %d, column %d: %q is not a finite number", i+1, j+1, cell)
+			}
+			s.Numbers = append(s.Numbers, num)
+		}
+	}
+	return nil
+}
+
+// MarshalCSV writes the response as a header row and a value row
+// The submitted numbers are not repeated.
+func (s *SumResponse) MarshalCSV() ([][]string, error) {
+	header := []string{"sum", "count", "timestamp", "request_id"}
+	row := []string{formatFloat(s.Sum), strconv.Itoa(s.Count), formatTime(s.Timestamp), s.RequestID}
+
+	if p := s.Privacy; p != nil {
+		header = append(header, "mechanism", "epsilon_spent", "delta", "lower", "upper", "sensitivity", "scale", "budget_remaining")
+		row = append(row, p.Mechanism, formatFloat(p.EpsilonSpent), formatFloat(p.Delta), formatFloat(p.Lower), formatFloat(p.Upper),
+			formatFloat(p.Sensitivity), formatFloat(p.Scale), formatFloat(p.BudgetRemaining))
+	}
+
+	return [][]string{header, row}, nil
+}
+
+// MarshalCSV writes the error as a header row and a value row
+// Details become additional columns named details.<key>. Field errors add
+// pointer, parameter, rule and message columns and one value row each
+func (e *ErrorResponse) MarshalCSV() ([][]string, error) {
+	header := []string{"error", "code", "path", "timestamp", "request_id"}
+	row := []string{e.Error, e.Code, e.Path, formatTime(e.Timestamp), e.RequestID}
+
+	keys := make([]string, 0, len(e.Details))
+	for key := range e.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		header = append(header, "details."+key)
+		row = append(row, e.Details[key])
+	}
+
+	if len(e.Errors) == 0 {
+		return [][]string{header, row}, nil
+	}
+
+	header = append(header, "pointer", "parameter", "rule", "message")
+	records := [][]string{header}
+	for _, fieldErr := range e.Errors {
+		fields := append(append([]string{}, row...), fieldErr.Pointer, fieldErr.Parameter, fieldErr.Rule, fieldErr.Message)
+		records = append(records, fields)
+	}
+	return records, nil
+}
+
+// isHeader reports whether none of the cells of a record is a number
+func isHeader(record []string) bool {
+	for _, cell := range record {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(cell), 64); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,385 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/i18n"
+)
+
+// ErrorCatalogPath is where the service describes its error codes
+// Problem type URIs are this path followed by the error code
+const ErrorCatalogPath = "/errors"
+
+// Error codes shared by every endpoint
+const (
+	CodeValidation         = "VALIDATION_ERROR"
+	CodeInvalidRequestBody = "INVALID_REQUEST_BODY"
+	CodeInvalidRequest     = "INVALID_REQUEST"
+	CodeInternal           = "INTERNAL_ERROR"
+	CodeInternalServer     = "INTERNAL_SERVER_ERROR"
+	CodeNotFound           = "NOT_FOUND"
+	CodeMethodNotAllowed   = "METHOD_NOT_ALLOWED"
+	CodeConsumerRequired   = "CONSUMER_REQUIRED"
+	CodeResultOverflow     = "RESULT_OVERFLOW"
+)
+
+// CodeErrorTypeNotFound is returned for codes missing from the catalog
+const CodeErrorTypeNotFound = "ERROR_TYPE_NOT_FOUND"
+
+// CodeContractDrift is returned outside production for responses that the
+// OpenAPI document does not allow
+const CodeContractDrift = "CONTRACT_DRIFT"
+
+// ErrorType describes one error code of the catalog
+// Message is the default client message; {name} placeholders are filled from the error parameters
+type ErrorType struct {
+	Code        string `json:"code"`
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Status      int    `json:"status"`
+	Message     string `json:"message"`
+	Retryable   bool   `json:"retryable"`
+	Docs        string `json:"docs"`
+	Description string `json:"description"`
+}
+
+// ErrorCatalogResponse lists the error codes of the service
+type ErrorCatalogResponse struct {
+	Errors    []ErrorType `json:"errors"`
+	Timestamp time.Time   `json:"timestamp"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// errorTypes is the error catalog by code
+var errorTypes = map[string]ErrorType{}
+
+// errorDocsBase is the URL under which each error code has a documentation page
+// It is set once at startup and defaults to the catalog served by the service
+var errorDocsBase = ErrorCatalogPath
+
+// registerErrorTypes adds error codes to the catalog
+func registerErrorTypes(types ...ErrorType) {
+	for _, t := range types {
+		t.Type = ErrorTypeURI(t.Code)
+		errorTypes[t.Code] = t
+	}
+}
+
+func init() {
+	registerErrorTypes(
+		ErrorType{Code: CodeValidation, Status: http.StatusBadRequest, Title: "Validation failed", Message: "the request is invalid",
+			Description: "The request was well formed but some of its values are invalid. The errors member lists each invalid element by JSON pointer."},
+		ErrorType{Code: CodeInvalidRequestBody, Status: http.StatusBadRequest, Title: "Invalid request body", Message: "the request body could not be decoded",
+			Description: "The request body could not be decoded, or a required field is missing or has the wrong type."},
+		ErrorType{Code: CodeInvalidRequest, Status: http.StatusBadRequest, Title: "Invalid request", Message: "the request cannot be served by this endpoint",
+			Description: "The request cannot be served by this endpoint, for example a WebSocket endpoint called without an upgrade."},
+		ErrorType{Code: CodeInternal, Status: http.StatusInternalServerError, Title: "Internal error", Message: "internal error",
+			Description: "The service failed to process a valid request."},
+		ErrorType{Code: CodeInternalServer, Status: http.StatusInternalServerError, Title: "Internal server error", Message: "internal server error",
+			Description: "The service failed unexpectedly while processing the request."},
+		ErrorType{Code: CodeNotFound, Status: http.StatusNotFound, Title: "Not found", Message: "no route matches {method} {path}",
+			Description: "No endpoint of the service has this path."},
+		ErrorType{Code: CodeMethodNotAllowed, Status: http.StatusMethodNotAllowed, Title: "Method not allowed", Message: "method {method} is not allowed on {path}",
+			Description: "The endpoint exists but does not support the request method. The Allow header and the allowed_methods detail list the methods it supports."},
+		ErrorType{Code: CodeConsumerRequired, Status: http.StatusForbidden, Title: "Consumer required", Message: "this operation requires an authenticated consumer",
+			Description: "The operation holds state of its own for each consumer, so it is not available to anonymous callers. Call it with an API key."},
+		ErrorType{Code: CodeResultOverflow, Status: http.StatusUnprocessableEntity, Title: "Result overflow", Message: "the result is too large to be represented",
+			Description: "The inputs are finite but a result exceeds the range of a 64-bit floating-point number, so it cannot be returned. Scale the inputs down."},
+		ErrorType{Code: CodeUnsupportedMediaType, Status: http.StatusUnsupportedMediaType, Title: "Unsupported media type", Message: "the media type of the request body is not supported",
+			Description: "The Content-Type of the request body is not one of the supported formats."},
+		ErrorType{Code: CodeNotAcceptable, Status: http.StatusNotAcceptable, Title: "Not acceptable", Message: "none of the accepted media types can be produced",
+			Description: "None of the media types in the Accept header can be produced."},
+		ErrorType{Code: CodeInvalidShape, Status: http.StatusBadRequest, Title: "Invalid shape", Message: "the matrix or vector shape is invalid",
+			Description: "An operand is empty, has too few elements or is not rectangular."},
+		ErrorType{Code: CodeDimensionMismatch, Status: http.StatusBadRequest, Title: "Dimension mismatch", Message: "the operand dimensions do not match",
+			Description: "The operands do not have compatible dimensions."},
+		ErrorType{Code: CodeTooManyElements, Status: http.StatusBadRequest, Title: "Too many elements", Message: "the request holds too many elements",
+			Description: "The request holds more elements than the configured limit."},
+		ErrorType{Code: CodeInvalidModulus, Status: http.StatusBadRequest, Title: "Invalid modulus", Message: "the modulus is invalid",
+			Description: "The modulus is missing, too small or larger than the configured limit."},
+		ErrorType{Code: CodeInvalidDegree, Status: http.StatusBadRequest, Title: "Invalid degree", Message: "the polynomial degree is invalid",
+			Description: "The ring degree is not a power of two within the configured limit, or the ring is larger than the configured size."},
+		ErrorType{Code: CodeInvalidKeySize, Status: http.StatusBadRequest, Title: "Invalid key size", Message: "the key size is invalid",
+			Description: "The Paillier key size is outside the configured bounds."},
+		ErrorType{Code: CodeInvalidPublicKey, Status: http.StatusBadRequest, Title: "Invalid public key", Message: "the public key is invalid",
+			Description: "The Paillier modulus is not a valid public key."},
+		ErrorType{Code: CodeInvalidCiphertext, Status: http.StatusBadRequest, Title: "Invalid ciphertext", Message: "a ciphertext is invalid",
+			Description: "A ciphertext is not valid for the key it was sent with."},
+		ErrorType{Code: CodeKeyNotFound, Status: http.StatusNotFound, Title: "Key not found", Message: "key \"{key_id}\" not found",
+			Description: "No Paillier key is registered under this identifier."},
+		ErrorType{Code: CodeKeyLimitReached, Status: http.StatusTooManyRequests, Title: "Key limit reached", Message: "the key limit is reached",
+			Description: "The consumer holds the maximum number of Paillier keys; keys unused for a while are forgotten."},
+		ErrorType{Code: CodeInvalidPrivacyParams, Status: http.StatusBadRequest, Title: "Invalid privacy parameters", Message: "the privacy parameters are invalid",
+			Description: "The differential privacy parameters are invalid or exceed the per-request limit."},
+		ErrorType{Code: CodePrivacyBudgetExhausted, Status: http.StatusForbidden, Title: "Privacy budget exhausted", Message: "the privacy budget is exhausted",
+			Description: "The caller has no privacy budget left for this request."},
+		ErrorType{Code: CodeSessionNotFound, Status: http.StatusNotFound, Title: "Session not found", Message: "session \"{session_id}\" not found",
+			Description: "No aggregation session exists under this identifier."},
+		ErrorType{Code: CodeSessionClosed, Status: http.StatusConflict, Title: "Session closed", Message: "the session is closed",
+			Description: "The aggregation session no longer accepts shares."},
+		ErrorType{Code: CodeDuplicateShare, Status: http.StatusConflict, Title: "Duplicate share", Message: "the participant already submitted a share",
+			Description: "The participant already submitted its shares."},
+		ErrorType{Code: CodeUnknownParticipant, Status: http.StatusForbidden, Title: "Unknown participant", Message: "the participant is not part of the session",
+			Description: "The caller is not a participant of the aggregation session."},
+		ErrorType{Code: CodeTooManySessions, Status: http.StatusServiceUnavailable, Title: "Too many sessions", Message: "too many open sessions", Retryable: true,
+			Description: "The service holds the maximum number of open aggregation sessions."},
+		ErrorType{Code: CodeSessionLimitReached, Status: http.StatusTooManyRequests, Title: "Session limit reached", Message: "too many sessions are held for this consumer", Retryable: true,
+			Description: "The caller holds the maximum number of aggregation sessions, counting closed ones until they expire."},
+		ErrorType{Code: CodeInvalidShare, Status: http.StatusBadRequest, Title: "Invalid share", Message: "the share is negative or wider than the modulus",
+			Description: "A submitted share is negative or wider than the modulus of the session."},
+		ErrorType{Code: CodeInvalidIdempotencyKey, Status: http.StatusBadRequest, Title: "Invalid idempotency key", Message: "the idempotency key is invalid",
+			Description: "The Idempotency-Key header is too long or not printable ASCII."},
+		ErrorType{Code: CodeIdempotencyMismatch, Status: http.StatusUnprocessableEntity, Title: "Idempotency key mismatch", Message: "the idempotency key was used with a different request",
+			Description: "The idempotency key was already used with a different request."},
+		ErrorType{Code: CodeIdempotencyInFlight, Status: http.StatusConflict, Title: "Request in progress", Message: "a request with this idempotency key is in progress", Retryable: true,
+			Description: "A request with this idempotency key is still being processed."},
+		ErrorType{Code: CodeIdempotencyStoreFull, Status: http.StatusServiceUnavailable, Title: "Idempotency store full", Message: "the idempotency store is full", Retryable: true,
+			Description: "The service cannot remember more idempotency keys at the moment."},
+		ErrorType{Code: CodeJobNotFound, Status: http.StatusNotFound, Title: "Job not found", Message: "job \"{job_id}\" not found",
+			Description: "No job exists under this identifier."},
+		ErrorType{Code: CodeJobFinished, Status: http.StatusConflict, Title: "Job finished", Message: "the job has already finished",
+			Description: "The job has already finished and can no longer be cancelled."},
+		ErrorType{Code: CodeJobLimitReached, Status: http.StatusTooManyRequests, Title: "Job limit reached", Message: "too many jobs are pending for this consumer", Retryable: true,
+			Description: "The caller has the maximum number of unfinished jobs."},
+		ErrorType{Code: CodeJobQueueFull, Status: http.StatusServiceUnavailable, Title: "Job queue full", Message: "the job queue is full", Retryable: true,
+			Description: "The job queue is full."},
+		ErrorType{Code: CodeShuttingDown, Status: http.StatusServiceUnavailable, Title: "Shutting down", Message: "the service is shutting down", Retryable: true,
+			Description: "The service is shutting down and accepts no new work."},
+		ErrorType{Code: CodeUnknownJobType, Status: http.StatusBadRequest, Title: "Unknown job type", Message: "unknown job type \"{type}\"",
+			Description: "The job type is not supported."},
+		ErrorType{Code: CodeInvalidCallback, Status: http.StatusBadRequest, Title: "Invalid callback URL", Message: "the callback URL is invalid",
+			Description: "The callback URL is not an absolute http(s) URL on an allowed host."},
+		ErrorType{Code: CodeCallbacksDisabled, Status: http.StatusBadRequest, Title: "Callbacks disabled", Message: "callbacks are disabled",
+			Description: "Job callbacks are not enabled on this service."},
+		ErrorType{Code: CodeKeygenDisabled, Status: http.StatusForbidden, Title: "Key generation disabled", Message: "server-side key generation is disabled",
+			Description: "paillier_keygen jobs are only accepted with PAILLIER_DEV_KEYGEN. Generate keys with pkg/paillier and register the public key."},
+		ErrorType{Code: CodeDeadLetterNotFound, Status: http.StatusNotFound, Title: "Dead letter not found", Message: "dead letter \"{id}\" not found",
+			Description: "No failed callback delivery exists under this identifier."},
+		ErrorType{Code: CodeStreamNotFound, Status: http.StatusNotFound, Title: "Stream not found", Message: "stream \"{stream_id}\" not found",
+			Description: "No streaming session exists under this identifier."},
+		ErrorType{Code: CodeStreamClosed, Status: http.StatusConflict, Title: "Stream closed", Message: "the stream is closed",
+			Description: "The streaming session is closed."},
+		ErrorType{Code: CodeStreamLimitReached, Status: http.StatusTooManyRequests, Title: "Stream limit reached", Message: "too many open streams", Retryable: true,
+			Description: "The caller or the service holds the maximum number of streaming sessions."},
+		ErrorType{Code: CodeStreamMessageLimit, Status: http.StatusTooManyRequests, Title: "Message limit reached", Message: "the stream message limit is reached",
+			Description: "The streaming session received the maximum number of messages."},
+		ErrorType{Code: CodeWindowStreamNotFound, Status: http.StatusNotFound, Title: "Window stream not found", Message: "window stream \"{name}\" not found",
+			Description: "The caller has no window stream with this name."},
+		ErrorType{Code: CodeWindowStreamLimit, Status: http.StatusTooManyRequests, Title: "Window stream limit reached", Message: "too many window streams",
+			Description: "The caller holds the maximum number of window streams."},
+		ErrorType{Code: CodeInvalidStreamName, Status: http.StatusBadRequest, Title: "Invalid stream name", Message: "the stream name is invalid",
+			Description: "Stream names are 1 to 128 letters, digits, '.', '_' or '-'."},
+		ErrorType{Code: CodeInvalidWindowQuery, Status: http.StatusBadRequest, Title: "Invalid window query", Message: "the window query is invalid",
+			Description: "The window query parameters are invalid."},
+		ErrorType{Code: CodeInvalidRecord, Status: http.StatusBadRequest, Title: "Invalid record", Message: "record {index} is invalid",
+			Description: "A record of the grouped aggregation is invalid. The details name its index."},
+		ErrorType{Code: CodeGroupLimitExceeded, Status: http.StatusUnprocessableEntity, Title: "Group limit exceeded", Message: "the records form too many groups",
+			Description: "The records form more groups than the configured limit."},
+		ErrorType{Code: CodeRecordLimitExceeded, Status: http.StatusRequestEntityTooLarge, Title: "Record limit exceeded", Message: "the request holds too many records",
+			Description: "The request holds more records than the configured limit."},
+		ErrorType{Code: CodeRequestTooLarge, Status: http.StatusRequestEntityTooLarge, Title: "Request too large", Message: "the request body is too large",
+			Description: "The request body exceeds the configured size limit."},
+		ErrorType{Code: CodeInvalidUpload, Status: http.StatusBadRequest, Title: "Invalid upload", Message: "the upload parameters are invalid",
+			Description: "The upload parameters are invalid."},
+		ErrorType{Code: CodeInvalidFile, Status: http.StatusBadRequest, Title: "Invalid file", Message: "the uploaded file cannot be parsed",
+			Description: "The uploaded file cannot be parsed."},
+		ErrorType{Code: CodeHistoryNotFound, Status: http.StatusNotFound, Title: "History entry not found", Message: "history entry \"{request_id}\" not found",
+			Description: "The caller has no history entry for this request ID."},
+		ErrorType{Code: CodeInvalidHistoryQuery, Status: http.StatusBadRequest, Title: "Invalid history query", Message: "the history query is invalid",
+			Description: "The history query parameters or cursor are invalid."},
+		ErrorType{Code: CodeErrorTypeNotFound, Status: http.StatusNotFound, Title: "Error type not found", Message: "error code \"{code}\" is not in the catalog",
+			Description: "The error code is not described by the catalog."},
+		ErrorType{Code: CodeMethodNotFound, Status: http.StatusNotFound, Title: "Method not found", Message: "method \"{method}\" not found",
+			Description: "The JSON-RPC method does not exist."},
+		ErrorType{Code: CodeBatchTooLarge, Status: http.StatusRequestEntityTooLarge, Title: "Batch too large", Message: "the batch holds {count} calls, the limit is {max}",
+			Description: "The JSON-RPC batch holds more calls than the configured limit."},
+		ErrorType{Code: CodeContractDrift, Status: http.StatusInternalServerError, Title: "Contract drift", Message: "the response does not match the API contract",
+			Description: "The response that the service produced differs from the OpenAPI document. It is only reported outside production, when responses are validated and drift fails requests."},
+	)
+}
+
+// Localize returns the entry with its title and message in locale
+func (t ErrorType) Localize(locale string) ErrorType {
+	t.Title = i18n.Translate(locale, t.Title)
+	t.Message = i18n.Translate(locale, t.Message)
+	return t
+}
+
+// SetErrorDocsBase sets the URL under which each error code has a documentation page
+// An empty base keeps the catalog served by the service
+func SetErrorDocsBase(base string) {
+	if base == "" {
+		base = ErrorCatalogPath
+	}
+	errorDocsBase = strings.TrimSuffix(base, "/")
+}
+
+// ErrorTypeURI returns the problem type URI of an error code
+func ErrorTypeURI(code string) string {
+	return ErrorCatalogPath + "/" + code
+}
+
+// ErrorDocsURL returns the documentation link of an error code
+func ErrorDocsURL(code string) string {
+	return errorDocsBase + "/" + code
+}
+
+// LookupErrorType returns the catalog entry of an error code
+func LookupErrorType(code string) (ErrorType, bool) {
+	t, ok := errorTypes[code]
+	t.Docs = ErrorDocsURL(t.Code)
+	return t, ok
+}
+
+// ErrorTypes returns the catalog ordered by code
+func ErrorTypes() []ErrorType {
+	types := make([]ErrorType, 0, len(errorTypes))
+	for code := range errorTypes {
+		t, _ := LookupErrorType(code)
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Code < types[j].Code })
+	return types
+}
+
+// NewErrorCatalogResponse creates a response listing the error catalog
+func NewErrorCatalogResponse(requestID string) *ErrorCatalogResponse {
+	return &ErrorCatalogResponse{
+		Errors:    ErrorTypes(),
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+}
+
+// APIError is an error reported to clients under a code of the error catalog
+// Status and message default to the catalog entry of the code. Err is the cause;
+// when set its message is reported rather than the message template
+type APIError struct {
+	Code    string
+	Status  int
+	Params  map[string]string
+	Details map[string]string
+	Err     error
+}
+
+// NewAPIError creates an APIError reported with code
+// With a nil err the localized catalog message is reported. Handlers use this for
+// sentinel errors of their stores and managers, whose English text would otherwise
+// reach every client, and keep err for failures whose message names an offending
+// element, such as a line of a file or a parameter of a query.
+func NewAPIError(code string, err error) *APIError {
+	return &APIError{Code: code, Err: err}
+}
+
+// WithStatus overrides the HTTP status of the catalog entry
+func (e *APIError) WithStatus(status int) *APIError {
+	e.Status = status
+	return e
+}
+
+// WithParam sets a parameter of the message template
+func (e *APIError) WithParam(name, value string) *APIError {
+	if e.Params == nil {
+		e.Params = make(map[string]string)
+	}
+	e.Params[name] = value
+	return e
+}
+
+// WithDetail adds a detail to the error response
+func (e *APIError) WithDetail(key, value string) *APIError {
+	if e.Details == nil {
+		e.Details = make(map[string]string)
+	}
+	e.Details[key] = value
+	return e
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.message(i18n.Default)
+}
+
+// Localize implements Localizer
+func (e *APIError) Localize(locale string) string {
+	if e.Err != nil {
+		return Localize(e.Err, locale)
+	}
+	return e.message(locale)
+}
+
+// Unwrap returns the cause of the error
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the error code for the response
+func (e *APIError) ErrorCode() string {
+	return e.Code
+}
+
+// message expands the message template of the code in locale with the error parameters
+// Placeholders without a parameter are left as they are
+func (e *APIError) message(locale string) string {
+	t, ok := LookupErrorType(e.Code)
+	if !ok || t.Message == "" {
+		return strings.ToLower(http.StatusText(e.HTTPStatus()))
+	}
+
+	message := i18n.Translate(locale, t.Message)
+	for name, value := range e.Params {
+		message = strings.ReplaceAll(message, "{"+name+"}", value)
+	}
+	return message
+}
+
+// HTTPStatus returns the status of the error response
+// Codes missing from the catalog are reported as internal errors
+func (e *APIError) HTTPStatus() int {
+	if e.Status != 0 {
+		return e.Status
+	}
+	if t, ok := LookupErrorType(e.Code); ok {
+		return t.Status
+	}
+	return http.StatusInternalServerError
+}
+
+// Retryable reports whether the same request may succeed later
+func (e *APIError) Retryable() bool {
+	t, _ := LookupErrorType(e.Code)
+	return t.Retryable
+}
+
+// Response creates the error response of the error for a request, with its messages in locale
+func (e *APIError) Response(locale, path, requestID string) *ErrorResponse {
+	response := NewLocalizedErrorResponse(e, e.Code, locale, path, requestID)
+	for key, value := range e.Details {
+		if response.Details == nil {
+			response.Details = make(map[string]string)
+		}
+		response.Details[key] = value
+	}
+	response.Retryable = e.Retryable()
+	if _, ok := errorTypes[e.Code]; ok {
+		response.Docs = ErrorDocsURL(e.Code)
+	}
+	return response
+}
+
+// AsAPIError returns the APIError carried by err
+// Other errors are reported as internal errors without their message, which may reveal internals
+func AsAPIError(err error) *APIError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return NewAPIError(CodeInternal, nil)
+}
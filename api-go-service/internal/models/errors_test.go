@@ -0,0 +1,94 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/katvio/api-go-service/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCatalog(t *testing.T) {
+	for _, errorType := range ErrorTypes() {
+		assert.NotEmpty(t, errorType.Title, errorType.Code)
+		assert.NotEmpty(t, errorType.Message, errorType.Code)
+		assert.NotZero(t, errorType.Status, errorType.Code)
+		assert.Equal(t, ErrorCatalogPath+"/"+errorType.Code, errorType.Docs)
+	}
+
+	t.Run("Titles and messages are translated", func(t *testing.T) {
+		for _, errorType := range ErrorTypes() {
+			localized := errorType.Localize(i18n.French)
+			assert.NotEqual(t, errorType.Title, localized.Title, errorType.Code)
+			assert.NotEqual(t, errorType.Message, localized.Message, errorType.Code)
+		}
+	})
+
+	t.Run("Documentation base", func(t *testing.T) {
+		SetErrorDocsBase("https://docs.example.com/errors/")
+		defer SetErrorDocsBase("")
+
+		errorType, ok := LookupErrorType(CodeKeyNotFound)
+		assert.True(t, ok)
+		assert.Equal(t, "https://docs.example.com/errors/KEY_NOT_FOUND", errorType.Docs)
+		assert.Equal(t, "/errors/KEY_NOT_FOUND", errorType.Type)
+	})
+}
+
+func TestAPIError(t *testing.T) {
+	t.Run("Catalog defaults", func(t *testing.T) {
+		err := NewAPIError(CodeSessionNotFound, nil).WithParam("session_id", "s1")
+		assert.Equal(t, http.StatusNotFound, err.HTTPStatus())
+		assert.Equal(t, `session "s1" not found`, err.Error())
+		assert.False(t, err.Retryable())
+	})
+
+	t.Run("Causes and overrides", func(t *testing.T) {
+		cause := errors.New("7 sessions are open")
+		err := NewAPIError(CodeTooManySessions, cause).WithStatus(http.StatusTooManyRequests)
+		assert.Equal(t, http.StatusTooManyRequests, err.HTTPStatus())
+		assert.Equal(t, "7 sessions are open", err.Error())
+		assert.ErrorIs(t, err, cause)
+		assert.True(t, err.Retryable())
+	})
+
+	t.Run("Responses", func(t *testing.T) {
+		err := NewAPIError(CodeValidation, (&SumRequest{}).Validate()).WithDetail("hint", "send two numbers")
+		response := err.Response(i18n.Default, "/api/v1/sum", "req")
+		assert.Equal(t, CodeValidation, response.Code)
+		assert.Equal(t, "send two numbers", response.Details["hint"])
+		assert.NotEmpty(t, response.Details["/numbers"])
+		assert.Len(t, response.Errors, 1)
+		assert.Equal(t, "/errors/VALIDATION_ERROR", response.Docs)
+	})
+
+	t.Run("Localized responses", func(t *testing.T) {
+		err := NewAPIError(CodeValidation, (&SumRequest{Numbers: []float64{1}}).Validate())
+		response := err.Response(i18n.French, "/api/v1/sum", "req")
+		assert.Equal(t, CodeValidation, response.Code)
+		assert.Equal(t, "au moins 2 nombres sont requis, 1 reçu(s)", response.Error)
+		assert.Equal(t, "au moins 2 nombres sont requis, 1 reçu(s)", response.Details["/numbers"])
+
+		notFound := NewAPIError(CodeJobNotFound, nil).WithParam("job_id", "job_1")
+		assert.Equal(t, `tâche "job_1" introuvable`, notFound.Response(i18n.French, "/", "").Error)
+
+		// Wrapped messages carry English text and are not translated
+		wrapped := NewAPIError(CodeValidation, fmt.Errorf("invalid payload: %w", (&SumRequest{}).Validate()))
+		assert.Equal(t, "invalid payload: at least 2 numbers are required, got 0", wrapped.Localize(i18n.French))
+	})
+
+	t.Run("Unknown codes", func(t *testing.T) {
+		err := NewAPIError("SOMETHING_ELSE", nil)
+		assert.Equal(t, http.StatusInternalServerError, err.HTTPStatus())
+		assert.Equal(t, "internal server error", err.Error())
+		assert.Empty(t, err.Response(i18n.Default, "/", "").Docs)
+	})
+
+	t.Run("Untyped errors", func(t *testing.T) {
+		assert.Equal(t, CodeInternal, AsAPIError(errors.New("boom")).Code)
+		wrapped := NewAPIError(CodeJobFinished, nil)
+		assert.Same(t, wrapped, AsAPIError(errors.Join(errors.New("context"), wrapped)))
+	})
+}
@@ -0,0 +1,203 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/groupby"
+)
+
+// Error codes returned by the grouped aggregation endpoint
+const (
+	CodeInvalidRecord       = "INVALID_RECORD"
+	CodeGroupLimitExceeded  = "GROUP_LIMIT_EXCEEDED"
+	CodeRecordLimitExceeded = "RECORD_LIMIT_EXCEEDED"
+	CodeRequestTooLarge     = "REQUEST_TOO_LARGE"
+)
+
+// Media types of record streams accepted besides a JSON array
+var ndjsonMediaTypes = []string{"application/x-ndjson", "application/ndjson", "application/jsonl"}
+
+// IsNDJSON reports whether contentType names a newline-delimited JSON stream
+func IsNDJSON(contentType string) bool {
+	for _, mediaType := range ndjsonMediaTypes {
+		if contentType == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupByParams holds the query parameters of POST /api/v1/aggregate
+// OrderBy is key, count, or sum, min or max optionally followed by ":field"
+type GroupByParams struct {
+	GroupBy string `form:"group_by"`
+	Fields  string `form:"fields"`
+	OrderBy string `form:"order_by"`
+	Order   string `form:"order"`
+	Top     int    `form:"top"`
+}
+
+// Validate checks the parameters and returns the fields to aggregate and the ordering
+// The key field defaults to "key" and the fields to "value"; orderings on values
+// are descending unless asc is requested
+func (p *GroupByParams) Validate(maxFields int) ([]string, groupby.Order, error) {
+	var order groupby.Order
+	if p.GroupBy == "" {
+		p.GroupBy = "key"
+	}
+	if p.Fields == "" {
+		p.Fields = "value"
+	}
+
+	fields := strings.Split(p.Fields, ",")
+	if len(fields) > maxFields {
+		return nil, order, fmt.Errorf("maximum %d fields allowed, got %d", maxFields, len(fields))
+	}
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		switch {
+		case field == "":
+			return nil, order, fmt.Errorf("fields must not be empty")
+		case field == p.GroupBy:
+			return nil, order, fmt.Errorf("field %q is the group key", field)
+		case seen[field]:
+			return nil, order, fmt.Errorf("field %q is listed twice", field)
+		}
+		seen[field] = true
+	}
+
+	if p.Top < 0 {
+		return nil, order, fmt.Errorf("top must not be negative, got %d", p.Top)
+	}
+
+	by, field, hasField := strings.Cut(p.OrderBy, ":")
+	order.By = by
+	switch by {
+	case "", groupby.ByKey, groupby.ByCount:
+		if hasField {
+			return nil, order, fmt.Errorf("order_by %q takes no field", by)
+		}
+	case groupby.BySum, groupby.ByMin, groupby.ByMax:
+		order.Field = -1
+		if !hasField {
+			order.Field = 0
+		}
+		for i, f := range fields {
+			if hasField && f == field {
+				order.Field = i
+			}
+		}
+		if order.Field < 0 {
+			return nil, order, fmt.Errorf("order_by field %q is not aggregated", field)
+		}
+	default:
+		return nil, order, fmt.Errorf("order_by must be key, count, sum, min or max, got %q", by)
+	}
+
+	switch p.Order {
+	case "":
+		order.Desc = by != "" && by != groupby.ByKey
+	case "asc":
+	case "desc":
+		order.Desc = true
+	default:
+		return nil, order, fmt.Errorf("order must be asc or desc, got %q", p.Order)
+	}
+	return fields, order, nil
+}
+
+// recordMessages are the client messages of the reasons a record field is refused
+var recordMessages = map[error]string{
+	groupby.ErrMissing:     "records[%d]: field %q is missing",
+	groupby.ErrNotString:   "records[%d]: field %q must be a string",
+	groupby.ErrNotNumber:   "records[%d]: field %q must be a number",
+	groupby.ErrOutOfRange:  "records[%d]: field %q is out of range",
+	groupby.ErrSumOverflow: "records[%d]: field %q overflows the sum of its group",
+}
+
+// groupKeyMessages are the client messages of the reasons a group key is refused
+var groupKeyMessages = map[error]string{
+	groupby.ErrMissing:   "records[%d]: group key %q is missing",
+	groupby.ErrNotString: "records[%d]: group key %q must be a string",
+}
+
+// limitMessages are the client messages of the limits a record goes over
+var limitMessages = map[error]string{
+	groupby.ErrGroupLimit:  "records[%d]: maximum %d groups allowed",
+	groupby.ErrRecordLimit: "records[%d]: maximum %d records allowed",
+}
+
+// RecordViolation describes an error about a record as a field error
+// Errors about a field point at the field; other errors point at the record
+func RecordViolation(err *groupby.RecordError) FieldError {
+	var fieldErr *groupby.FieldError
+	if errors.As(err.Err, &fieldErr) {
+		messages := recordMessages
+		if fieldErr.Key {
+			messages = groupKeyMessages
+		}
+		if format, ok := messages[fieldErr.Err]; ok {
+			return fieldError(JSONPointer(err.Index, fieldErr.Field), "record", format, err.Index, fieldErr.Field)
+		}
+	}
+	var limitErr *groupby.LimitError
+	if errors.As(err.Err, &limitErr) {
+		if format, ok := limitMessages[limitErr.Err]; ok {
+			return fieldError(JSONPointer(err.Index), "record", format, err.Index, limitErr.Max)
+		}
+	}
+	if errors.Is(err.Err, groupby.ErrNotObject) {
+		return fieldError(JSONPointer(err.Index), "record", "records[%d]: record must be an object", err.Index)
+	}
+	return FieldError{Pointer: JSONPointer(err.Index), Rule: "record", Message: err.Error()}
+}
+
+// FieldStats holds the statistics of one field within a group
+type FieldStats struct {
+	Sum float64 `json:"sum"`
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// AggregateGroup holds the statistics of the records sharing a key
+type AggregateGroup struct {
+	Key    string                `json:"key"`
+	Count  int                   `json:"count"`
+	Fields map[string]FieldStats `json:"fields"`
+}
+
+// AggregateResponse represents the response payload for the grouped aggregation endpoint
+// TotalGroups counts every group, including those cut by top
+type AggregateResponse struct {
+	GroupBy     string           `json:"group_by"`
+	Fields      []string         `json:"fields"`
+	Groups      []AggregateGroup `json:"groups"`
+	TotalGroups int              `json:"total_groups"`
+	Records     int              `json:"records"`
+	Timestamp   time.Time        `json:"timestamp"`
+	RequestID   string           `json:"request_id,omitempty"`
+}
+
+// NewAggregateResponse creates a new AggregateResponse
+func NewAggregateResponse(groupBy string, fields []string, groups []*groupby.Group, totalGroups, records int, requestID string) *AggregateResponse {
+	resp := &AggregateResponse{
+		GroupBy:     groupBy,
+		Fields:      fields,
+		Groups:      make([]AggregateGroup, len(groups)),
+		TotalGroups: totalGroups,
+		Records:     records,
+		Timestamp:   time.Now().UTC(),
+		RequestID:   requestID,
+	}
+	for i, g := range groups {
+		stats := make(map[string]FieldStats, len(fields))
+		for j, field := range fields {
+			stats[field] = FieldStats{Sum: g.Stats[j].Sum, Min: g.Stats[j].Min, Max: g.Stats[j].Max}
+		}
+		resp.Groups[i] = AggregateGroup{Key: g.Key, Count: g.Count, Fields: stats}
+	}
+	return resp
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+	"unicode/utf8"
+
+	"github.com/katvio/api-go-service/internal/history"
+)
+
+// Error codes returned by the history endpoints
+const (
+	CodeHistoryNotFound     = "HISTORY_ENTRY_NOT_FOUND"
+	CodeInvalidHistoryQuery = "INVALID_HISTORY_QUERY"
+)
+
+// HistoryEntry is a recorded request as returned by the history endpoints
+// Request and response bodies are only included when fetching a single entry:
+// JSON bodies are embedded as they were sent, other text as a string
+type HistoryEntry struct {
+	RequestID     string          `json:"request_id"`
+	Method        string          `json:"method"`
+	Path          string          `json:"path"`
+	Status        int             `json:"status"`
+	RequestSize   int64           `json:"request_size"`
+	RequestSHA256 string          `json:"request_sha256,omitempty"`
+	Request       json.RawMessage `json:"request,omitempty"`
+	Response      json.RawMessage `json:"response,omitempty"`
+	Redacted      bool            `json:"redacted"`
+	Truncated     bool            `json:"truncated"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DurationMs    float64         `json:"duration_ms"`
+}
+
+// HistoryEntryResponse represents the response payload for GET /api/v1/history/{request_id}
+type HistoryEntryResponse struct {
+	Entry     HistoryEntry `json:"entry"`
+	Timestamp time.Time    `json:"timestamp"`
+	RequestID string       `json:"request_id,omitempty"`
+}
+
+// HistoryListResponse represents a page of GET /api/v1/history
+// NextCursor is omitted on the last page
+type HistoryListResponse struct {
+	Entries    []HistoryEntry `json:"entries"`
+	Count      int            `json:"count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Timestamp  time.Time      `json:"timestamp"`
+	RequestID  string         `json:"request_id,omitempty"`
+}
+
+// NewHistoryEntryResponse creates a new HistoryEntryResponse including the stored bodies
+func NewHistoryEntryResponse(entry *history.Entry, requestID string) *HistoryEntryResponse {
+	resp := &HistoryEntryResponse{
+		Entry:     newHistoryEntry(entry),
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+	resp.Entry.Request = historyBody(entry.Request)
+	resp.Entry.Response = historyBody(entry.Response)
+	return resp
+}
+
+// NewHistoryListResponse creates a new HistoryListResponse
+func NewHistoryListResponse(entries []*history.Entry, next, requestID string) *HistoryListResponse {
+	resp := &HistoryListResponse{
+		Entries:    make([]HistoryEntry, len(entries)),
+		Count:      len(entries),
+		NextCursor: next,
+		Timestamp:  time.Now().UTC(),
+		RequestID:  requestID,
+	}
+	for i, entry := range entries {
+		resp.Entries[i] = newHistoryEntry(entry)
+	}
+	return resp
+}
+
+// newHistoryEntry converts a stored entry without its bodies
+func newHistoryEntry(entry *history.Entry) HistoryEntry {
+	return HistoryEntry{
+		RequestID:     entry.RequestID,
+		Method:        entry.Method,
+		Path:          entry.Path,
+		Status:        entry.Status,
+		RequestSize:   entry.RequestSize,
+		RequestSHA256: entry.RequestSHA256,
+		Redacted:      entry.Redacted,
+		Truncated:     entry.Truncated,
+		CreatedAt:     entry.CreatedAt.UTC(),
+		DurationMs:    float64(entry.Duration) / float64(time.Millisecond),
+	}
+}
+
+// historyBody renders a stored body as JSON
+// Bodies that are neither JSON nor UTF-8 text, such as file uploads, are left out
+func historyBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return body
+	}
+	if !utf8.Valid(body) {
+		return nil
+	}
+	encoded, _ := json.Marshal(string(body))
+	return encoded
+}
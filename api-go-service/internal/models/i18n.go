@@ -0,0 +1,45 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/katvio/api-go-service/internal/i18n"
+)
+
+// Localizer is implemented by errors whose message can be rendered in several locales
+type Localizer interface {
+	error
+	Localize(locale string) string
+}
+
+// message is a client message kept as its English format and arguments,
+// so that it can be rendered again in the locale of the request
+type message struct {
+	format string
+	args   []interface{}
+}
+
+// newMessage creates a message from a format string and its arguments
+func newMessage(format string, args ...interface{}) *message {
+	return &message{format: format, args: args}
+}
+
+// String renders the message in English
+func (m *message) String() string {
+	return m.Localize(i18n.Default)
+}
+
+// Localize renders the message in locale
+func (m *message) Localize(locale string) string {
+	return i18n.Sprintf(locale, m.format, m.args...)
+}
+
+// Localize returns the message of err in locale
+// Only errors returned as they are can be localized; wrapping adds English text
+func Localize(err error, locale string) string {
+	var localizer Localizer
+	if errors.As(err, &localizer) && localizer.Error() == err.Error() {
+		return localizer.Localize(locale)
+	}
+	return err.Error()
+}
@@ -0,0 +1,9 @@
+package models
+
+// Error codes returned for requests carrying an Idempotency-Key header
+const (
+	CodeInvalidIdempotencyKey = "INVALID_IDEMPOTENCY_KEY"
+	CodeIdempotencyMismatch   = "IDEMPOTENCY_KEY_MISMATCH"
+	CodeIdempotencyInFlight   = "IDEMPOTENCY_REQUEST_IN_PROGRESS"
+	CodeIdempotencyStoreFull  = "IDEMPOTENCY_STORE_FULL"
+)
@@ -0,0 +1,232 @@
+package models
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/jobs"
+	"github.com/katvio/api-go-service/internal/webhook"
+)
+
+// Error codes returned by the job endpoints
+const (
+	CodeJobNotFound        = "JOB_NOT_FOUND"
+	CodeJobFinished        = "JOB_FINISHED"
+	CodeJobLimitReached    = "JOB_LIMIT_REACHED"
+	CodeJobQueueFull       = "JOB_QUEUE_FULL"
+	CodeShuttingDown       = "SHUTTING_DOWN"
+	CodeUnknownJobType     = "UNKNOWN_JOB_TYPE"
+	CodeInvalidCallback    = "INVALID_CALLBACK_URL"
+	CodeCallbacksDisabled  = "CALLBACKS_DISABLED"
+	CodeDeadLetterNotFound = "DEAD_LETTER_NOT_FOUND"
+	CodeKeygenDisabled     = "KEYGEN_DISABLED"
+)
+
+// Supported job types
+const (
+	JobTypeSum            = "sum"
+	JobTypePaillierSum    = "paillier_sum"
+	JobTypePaillierKeygen = "paillier_keygen"
+)
+
+// JobRequest represents the request payload for submitting an asynchronous job
+// Payload is decoded according to Type
+// CallbackURL, if set, receives a signed POST of the final job state
+type JobRequest struct {
+	Type        string          `json:"type" binding:"required"`
+	Payload     json.RawMessage `json:"payload" binding:"required"`
+	CallbackURL string          `json:"callback_url,omitempty"`
+}
+
+// SumJobPayload is the payload of a sum job
+type SumJobPayload struct {
+	Numbers []float64 `json:"numbers" validate:"min_items=2,dive,finite"`
+}
+
+// SumJobResult is the result of a sum job
+type SumJobResult struct {
+	Sum   float64 `json:"sum"`
+	Count int     `json:"count"`
+}
+
+// PaillierSumJobResult is the result of an encrypted sum job
+type PaillierSumJobResult struct {
+	KeyID      string `json:"key_id"`
+	Ciphertext string `json:"ciphertext"`
+	Count      int    `json:"count"`
+}
+
+// PaillierKeygenJobResult is the result of a key generation job
+// The public key is registered; the private key is only held with the job result
+type PaillierKeygenJobResult struct {
+	KeyID      string              `json:"key_id"`
+	N          string              `json:"n"`
+	Bits       int                 `json:"bits"`
+	PrivateKey *PaillierPrivateKey `json:"private_key"`
+}
+
+// JobResponse represents the state of an asynchronous job
+// Result is only present once the job has succeeded
+type JobResponse struct {
+	JobID       string      `json:"job_id"`
+	Type        string      `json:"type"`
+	State       string      `json:"state"`
+	Progress    float64     `json:"progress"`
+	Result      interface{} `json:"result,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	ErrorCode   string      `json:"error_code,omitempty"`
+	CallbackURL string      `json:"callback_url,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	StartedAt   *time.Time  `json:"started_at,omitempty"`
+	FinishedAt  *time.Time  `json:"finished_at,omitempty"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty"`
+	Timestamp   time.Time   `json:"timestamp"`
+	RequestID   string      `json:"request_id,omitempty"`
+}
+
+// DeadLetterResponse represents a callback that could not be delivered
+// JobRequestID is the request that submitted the job
+type DeadLetterResponse struct {
+	DeliveryID   string    `json:"delivery_id"`
+	JobID        string    `json:"job_id"`
+	URL          string    `json:"url"`
+	JobRequestID string    `json:"job_request_id"`
+	Attempts     int       `json:"attempts"`
+	LastStatus   int       `json:"last_status,omitempty"`
+	LastError    string    `json:"last_error"`
+	CreatedAt    time.Time `json:"created_at"`
+	FailedAt     time.Time `json:"failed_at"`
+}
+
+// DeadLetterDetailResponse represents one of the caller's undelivered callbacks
+type DeadLetterDetailResponse struct {
+	DeadLetterResponse
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// DeadLetterListResponse lists the caller's undelivered callbacks
+type DeadLetterListResponse struct {
+	DeadLetters []DeadLetterResponse `json:"dead_letters"`
+	Count       int                  `json:"count"`
+	Timestamp   time.Time            `json:"timestamp"`
+	RequestID   string               `json:"request_id,omitempty"`
+}
+
+// WebhookSecretResponse carries the key the caller's callbacks are signed with
+type WebhookSecretResponse struct {
+	Consumer  string    `json:"consumer"`
+	Secret    string    `json:"secret"`
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// ValidateCallback checks that the callback URL is an absolute http(s) URL
+// whose host is in allowedHosts; no callback is accepted while the list is empty
+func (r *JobRequest) ValidateCallback(allowedHosts []string) error {
+	if r.CallbackURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(r.CallbackURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return newFieldError(CodeInvalidCallback, JSONPointer("callback_url"), "callback_url must be an absolute http or https URL")
+	}
+	if u.User != nil {
+		return newFieldError(CodeInvalidCallback, JSONPointer("callback_url"), "callback_url must not contain credentials")
+	}
+
+	for _, host := range allowedHosts {
+		if strings.EqualFold(u.Hostname(), host) {
+			return nil
+		}
+	}
+	return newFieldError(CodeInvalidCallback, JSONPointer("callback_url"), "callback host %q is not allowed", u.Hostname())
+}
+
+// Validate checks the number of values in a sum job
+func (p *SumJobPayload) Validate(maxNumbers int) error {
+	if err := ValidateStruct(p); err != nil {
+		return err
+	}
+
+	return checkElementCount(len(p.Numbers), maxNumbers, "numbers")
+}
+
+// NewJobResponse creates a response from a job snapshot
+func NewJobResponse(snap *jobs.Snapshot, requestID string) *JobResponse {
+	return &JobResponse{
+		JobID:       snap.ID,
+		Type:        snap.Type,
+		State:       string(snap.State),
+		Progress:    snap.Progress,
+		Result:      snap.Result,
+		Error:       snap.Error,
+		ErrorCode:   snap.ErrorCode,
+		CallbackURL: snap.Callback,
+		CreatedAt:   snap.CreatedAt,
+		StartedAt:   optionalTime(snap.StartedAt),
+		FinishedAt:  optionalTime(snap.FinishedAt),
+		ExpiresAt:   optionalTime(snap.ExpiresAt),
+		Timestamp:   time.Now().UTC(),
+		RequestID:   requestID,
+	}
+}
+
+// NewDeadLetterResponse creates a response from a dead letter
+func NewDeadLetterResponse(letter webhook.DeadLetter) DeadLetterResponse {
+	return DeadLetterResponse{
+		DeliveryID:   letter.ID,
+		JobID:        letter.Subject,
+		URL:          letter.URL,
+		JobRequestID: letter.RequestID,
+		Attempts:     letter.Attempts,
+		LastStatus:   letter.LastStatus,
+		LastError:    letter.LastError,
+		CreatedAt:    letter.CreatedAt,
+		FailedAt:     letter.FailedAt,
+	}
+}
+
+// NewDeadLetterDetailResponse creates a new DeadLetterDetailResponse
+func NewDeadLetterDetailResponse(letter webhook.DeadLetter, requestID string) *DeadLetterDetailResponse {
+	return &DeadLetterDetailResponse{
+		DeadLetterResponse: NewDeadLetterResponse(letter),
+		Timestamp:          time.Now().UTC(),
+		RequestID:          requestID,
+	}
+}
+
+// NewDeadLetterListResponse creates a new DeadLetterListResponse
+func NewDeadLetterListResponse(letters []webhook.DeadLetter, requestID string) *DeadLetterListResponse {
+	response := &DeadLetterListResponse{
+		DeadLetters: make([]DeadLetterResponse, 0, len(letters)),
+		Count:       len(letters),
+		Timestamp:   time.Now().UTC(),
+		RequestID:   requestID,
+	}
+	for _, letter := range letters {
+		response.DeadLetters = append(response.DeadLetters, NewDeadLetterResponse(letter))
+	}
+	return response
+}
+
+// NewWebhookSecretResponse creates a new WebhookSecretResponse
+func NewWebhookSecretResponse(consumer, secret, requestID string) *WebhookSecretResponse {
+	return &WebhookSecretResponse{
+		Consumer:  consumer,
+		Secret:    secret,
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+}
+
+// optionalTime returns nil for the zero time so it is omitted from JSON
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
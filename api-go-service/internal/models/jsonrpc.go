@@ -0,0 +1,107 @@
+package models
+
+import (
+	"encoding/json"
+)
+
+// JSONRPCVersion is the only protocol version accepted by the JSON-RPC endpoint
+const JSONRPCVersion = "2.0"
+
+// JSON-RPC 2.0 error codes
+const (
+	RPCParseError     = -32700
+	RPCInvalidRequest = -32600
+	RPCMethodNotFound = -32601
+	RPCInvalidParams  = -32602
+	RPCInternalError  = -32603
+	RPCServerError    = -32000 // calls refused by the service, e.g. an exhausted privacy budget
+)
+
+// Error codes carried in the data of JSON-RPC errors that have no REST equivalent
+const (
+	CodeMethodNotFound = "METHOD_NOT_FOUND"
+	CodeBatchTooLarge  = "BATCH_TOO_LARGE"
+)
+
+// RPCRequest represents a JSON-RPC 2.0 call
+// ID is nil when the member is absent, which makes the call a notification
+type RPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// IsNotification reports whether the caller expects no response
+func (r *RPCRequest) IsNotification() bool {
+	return r.ID == nil
+}
+
+// RPCResponse represents a JSON-RPC 2.0 response
+// Exactly one of Result and Error is set
+type RPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// RPCError represents a JSON-RPC 2.0 error object
+type RPCError struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    *RPCErrorData `json:"data,omitempty"`
+}
+
+// RPCErrorData carries the service's error code and field errors, as returned by the REST endpoints
+type RPCErrorData struct {
+	Code      string       `json:"code"`
+	Status    int          `json:"status,omitempty"` // HTTP status the REST endpoint would have returned
+	Errors    []FieldError `json:"errors,omitempty"`
+	Retryable bool         `json:"retryable"`
+	Docs      string       `json:"docs,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+}
+
+// NewRPCResult creates a successful JSON-RPC response
+func NewRPCResult(id json.RawMessage, result interface{}) *RPCResponse {
+	return &RPCResponse{
+		JSONRPC: JSONRPCVersion,
+		Result:  result,
+		ID:      rpcID(id),
+	}
+}
+
+// NewRPCError creates a failed JSON-RPC response
+func NewRPCError(id json.RawMessage, rpcErr *RPCError) *RPCResponse {
+	return &RPCResponse{
+		JSONRPC: JSONRPCVersion,
+		Error:   rpcErr,
+		ID:      rpcID(id),
+	}
+}
+
+// RPCErrorFromResponse creates the JSON-RPC error object of an error response
+// The message and field errors are those of the response, already localized
+func RPCErrorFromResponse(rpcCode, statusCode int, response *ErrorResponse) *RPCError {
+	return &RPCError{
+		Code:    rpcCode,
+		Message: response.Error,
+		Data: &RPCErrorData{
+			Code:      response.Code,
+			Status:    statusCode,
+			Errors:    response.Errors,
+			Retryable: response.Retryable,
+			Docs:      response.Docs,
+			RequestID: response.RequestID,
+		},
+	}
+}
+
+// rpcID returns the ID to echo, null when the call's ID could not be determined
+func rpcID(id json.RawMessage) json.RawMessage {
+	if id == nil {
+		return json.RawMessage("null")
+	}
+	return id
+}
@@ -0,0 +1,253 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/linalg"
+)
+
+// Error codes returned by the linear algebra endpoints
+const (
+	CodeDimensionMismatch = "DIMENSION_MISMATCH"
+	CodeInvalidShape      = "INVALID_SHAPE"
+	CodeTooManyElements   = "TOO_MANY_ELEMENTS"
+)
+
+// VectorsRequest represents the request payload for element-wise vector addition
+type VectorsRequest struct {
+	Vectors [][]float64 `json:"vectors" binding:"required" validate:"dive,dive,finite"`
+}
+
+// ScaleRequest represents the request payload for scalar multiplication of a vector
+type ScaleRequest struct {
+	Scalar *float64  `json:"scalar" binding:"required" validate:"finite"`
+	Vector []float64 `json:"vector" binding:"required" validate:"dive,finite"`
+}
+
+// DotRequest represents the request payload for the dot product of two vectors
+type DotRequest struct {
+	A []float64 `json:"a" binding:"required" validate:"dive,finite"`
+	B []float64 `json:"b" binding:"required" validate:"dive,finite"`
+}
+
+// MatricesRequest represents the request payload for element-wise matrix addition
+type MatricesRequest struct {
+	Matrices [][][]float64 `json:"matrices" binding:"required" validate:"dive,dive,dive,finite"`
+}
+
+// MatrixMultiplyRequest represents the request payload for matrix multiplication
+type MatrixMultiplyRequest struct {
+	A [][]float64 `json:"a" binding:"required" validate:"dive,dive,finite"`
+	B [][]float64 `json:"b" binding:"required" validate:"dive,dive,finite"`
+}
+
+// MatrixRequest represents the request payload for single-matrix operations
+// (transpose, row sums and column sums)
+type MatrixRequest struct {
+	Matrix [][]float64 `json:"matrix" binding:"required" validate:"dive,dive,finite"`
+}
+
+// VectorResponse represents a linear algebra result that is a vector
+type VectorResponse struct {
+	Operation string    `json:"operation"`
+	Result    []float64 `json:"result"`
+	Length    int       `json:"length"`
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// ScalarResponse represents a linear algebra result that is a scalar
+type ScalarResponse struct {
+	Operation string    `json:"operation"`
+	Result    float64   `json:"result"`
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// MatrixResponse represents a linear algebra result that is a matrix
+type MatrixResponse struct {
+	Operation string      `json:"operation"`
+	Result    [][]float64 `json:"result"`
+	Rows      int         `json:"rows"`
+	Cols      int         `json:"cols"`
+	Timestamp time.Time   `json:"timestamp"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// Validate checks that at least two vectors of the same non-zero length were sent
+func (r *VectorsRequest) Validate(maxElements int) error {
+	if len(r.Vectors) < 2 {
+		return newFieldError(CodeInvalidShape, JSONPointer("vectors"), "at least 2 vectors are required, got %d", len(r.Vectors))
+	}
+
+	total := 0
+	for i, v := range r.Vectors {
+		if len(v) == 0 {
+			return newFieldError(CodeInvalidShape, JSONPointer("vectors", i), "vector %d is empty", i)
+		}
+		if len(v) != len(r.Vectors[0]) {
+			return newFieldError(CodeDimensionMismatch, JSONPointer("vectors", i), "vector %d has length %d, expected %d", i, len(v), len(r.Vectors[0]))
+		}
+		total += len(v)
+	}
+
+	if err := checkElementCount(total, maxElements); err != nil {
+		return err
+	}
+	return ValidateStruct(r)
+}
+
+// Validate checks that a non-empty vector was sent
+func (r *ScaleRequest) Validate(maxElements int) error {
+	if len(r.Vector) == 0 {
+		return newFieldError(CodeInvalidShape, JSONPointer("vector"), "vector is empty")
+	}
+
+	if err := checkElementCount(len(r.Vector), maxElements); err != nil {
+		return err
+	}
+	return ValidateStruct(r)
+}
+
+// Validate checks that both vectors are non-empty and have the same length
+func (r *DotRequest) Validate(maxElements int) error {
+	if len(r.A) == 0 {
+		return newFieldError(CodeInvalidShape, JSONPointer("a"), "vectors a and b must not be empty")
+	}
+	if len(r.B) == 0 {
+		return newFieldError(CodeInvalidShape, JSONPointer("b"), "vectors a and b must not be empty")
+	}
+
+	if len(r.A) != len(r.B) {
+		return newFieldError(CodeDimensionMismatch, JSONPointer("b"), "vector a has length %d but vector b has length %d", len(r.A), len(r.B))
+	}
+
+	if err := checkElementCount(len(r.A)+len(r.B), maxElements); err != nil {
+		return err
+	}
+	return ValidateStruct(r)
+}
+
+// Validate checks that at least two rectangular matrices of the same shape were sent
+func (r *MatricesRequest) Validate(maxElements int) error {
+	if len(r.Matrices) < 2 {
+		return newFieldError(CodeInvalidShape, JSONPointer("matrices"), "at least 2 matrices are required, got %d", len(r.Matrices))
+	}
+
+	rows, cols, err := matrixShape(r.Matrices[0], "matrix 0", JSONPointer("matrices", 0))
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for i, m := range r.Matrices {
+		mRows, mCols, err := matrixShape(m, fmt.Sprintf("matrix %d", i), JSONPointer("matrices", i))
+		if err != nil {
+			return err
+		}
+		if mRows != rows || mCols != cols {
+			return newFieldError(CodeDimensionMismatch, JSONPointer("matrices", i), "matrix %d is %dx%d, expected %dx%d", i, mRows, mCols, rows, cols)
+		}
+		total += mRows * mCols
+	}
+
+	if err := checkElementCount(total, maxElements); err != nil {
+		return err
+	}
+	return ValidateStruct(r)
+}
+
+// Validate checks that both matrices are rectangular and that their inner dimensions agree
+func (r *MatrixMultiplyRequest) Validate(maxElements int) error {
+	aRows, aCols, err := matrixShape(r.A, "matrix a", JSONPointer("a"))
+	if err != nil {
+		return err
+	}
+
+	bRows, bCols, err := matrixShape(r.B, "matrix b", JSONPointer("b"))
+	if err != nil {
+		return err
+	}
+
+	if aCols != bRows {
+		return newFieldError(CodeDimensionMismatch, JSONPointer("b"), "cannot multiply %dx%d matrix by %dx%d matrix", aRows, aCols, bRows, bCols)
+	}
+
+	// The product is counted too since it is allocated by the server
+	if err := checkElementCount(aRows*aCols+bRows*bCols+aRows*bCols, maxElements); err != nil {
+		return err
+	}
+	return ValidateStruct(r)
+}
+
+// Validate checks that the matrix is rectangular
+func (r *MatrixRequest) Validate(maxElements int) error {
+	rows, cols, err := matrixShape(r.Matrix, "matrix", JSONPointer("matrix"))
+	if err != nil {
+		return err
+	}
+
+	if err := checkElementCount(rows*cols, maxElements); err != nil {
+		return err
+	}
+	return ValidateStruct(r)
+}
+
+// NewVectorResponse creates a new VectorResponse
+func NewVectorResponse(operation string, result []float64, requestID string) *VectorResponse {
+	return &VectorResponse{
+		Operation: operation,
+		Result:    result,
+		Length:    len(result),
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+}
+
+// NewScalarResponse creates a new ScalarResponse
+func NewScalarResponse(operation string, result float64, requestID string) *ScalarResponse {
+	return &ScalarResponse{
+		Operation: operation,
+		Result:    result,
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+}
+
+// NewMatrixResponse creates a new MatrixResponse
+func NewMatrixResponse(operation string, result [][]float64, requestID string) *MatrixResponse {
+	rows, cols, _ := linalg.Shape(result)
+
+	return &MatrixResponse{
+		Operation: operation,
+		Result:    result,
+		Rows:      rows,
+		Cols:      cols,
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+}
+
+// matrixShape returns the shape of a matrix or a CodedError naming the operand at pointer
+func matrixShape(m [][]float64, name, pointer string) (int, int, error) {
+	rows, cols, ok := linalg.Shape(m)
+	if !ok {
+		if rows == 0 {
+			return 0, 0, newFieldError(CodeInvalidShape, pointer, "%s is empty", name)
+		}
+		return 0, 0, newFieldError(CodeInvalidShape, pointer, "%s is not rectangular: all rows must have %d columns", name, cols)
+	}
+
+	return rows, cols, nil
+}
+
+// checkElementCount enforces the configured limit on the total number of elements
+// tokens locate the array holding them; without tokens the error is about the whole request
+func checkElementCount(total, maxElements int, tokens ...interface{}) error {
+	if maxElements > 0 && total > maxElements {
+		return newFieldError(CodeTooManyElements, JSONPointer(tokens...), "maximum %d elements allowed, got %d", maxElements, total)
+	}
+
+	return nil
+}
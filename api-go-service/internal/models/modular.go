@@ -0,0 +1,283 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Error codes returned by the modular and polynomial ring endpoints
+const (
+	CodeInvalidModulus = "INVALID_MODULUS"
+	CodeInvalidDegree  = "INVALID_DEGREE"
+)
+
+// BigInt is an arbitrary-precision integer carried in JSON as a decimal string
+// Plain JSON integers are also accepted on input so small values need no quoting
+type BigInt struct {
+	value big.Int
+}
+
+// NewBigInt creates a BigInt from an int64
+func NewBigInt(v int64) *BigInt {
+	b := &BigInt{}
+	b.value.SetInt64(v)
+	return b
+}
+
+// Int returns the value as a *big.Int
+func (b *BigInt) Int() *big.Int {
+	return new(big.Int).Set(&b.value)
+}
+
+// String returns the decimal representation of the value
+func (b *BigInt) String() string {
+	return b.value.String()
+}
+
+// MarshalJSON encodes the value as a decimal string
+func (b *BigInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.value.String())
+}
+
+// UnmarshalJSON decodes a decimal string or a JSON integer literal
+func (b *BigInt) UnmarshalJSON(data []byte) error {
+	text := string(bytes.TrimSpace(data))
+	if len(text) > 0 && text[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := ParseBigInt(text)
+	if err != nil {
+		return err
+	}
+	b.value.Set(&parsed.value)
+
+	return nil
+}
+
+// maxQuotedDigits is the number of characters of an invalid integer repeated in its error
+const maxQuotedDigits = 32
+
+// ParseBigInt parses a base-10 integer
+// Texts with more digits than an integer of the integer_max_bits limit are
+// refused before parsing, whose cost grows faster than their length
+func ParseBigInt(text string) (*BigInt, error) {
+	if maxDigits := maxIntegerDigits(Limit(LimitIntegerMaxBits)); len(strings.TrimLeft(text, "+-")) > maxDigits {
+		return nil, fmt.Errorf("integer %s has more than %d digits", quoteDigits(text), maxDigits)
+	}
+
+	b := &BigInt{}
+	if _, ok := b.value.SetString(text, 10); !ok {
+		return nil, fmt.Errorf("invalid integer %s: expected a base-10 integer", quoteDigits(text))
+	}
+	return b, nil
+}
+
+// maxIntegerDigits returns the number of decimal digits of the widest integer of bits bits
+func maxIntegerDigits(bits int) int {
+	return int(math.Ceil(float64(bits)*math.Log10(2))) + 1
+}
+
+// quoteDigits quotes text for an error, cut to its first characters
+func quoteDigits(text string) string {
+	if len(text) <= maxQuotedDigits {
+		return fmt.Sprintf("%q", text)
+	}
+	return fmt.Sprintf("%q...", text[:maxQuotedDigits])
+}
+
+// ModularRequest represents the request payload for arithmetic over Z_q
+type ModularRequest struct {
+	Modulus *BigInt  `json:"modulus" binding:"required"`
+	Values  []BigInt `json:"values" binding:"required"`
+}
+
+// RingRequest represents the request payload for sums and products in Z_q[X]/(X^n+1)
+type RingRequest struct {
+	Modulus     *BigInt    `json:"modulus" binding:"required"`
+	Degree      int        `json:"degree" binding:"required"`
+	Polynomials [][]BigInt `json:"polynomials" binding:"required"`
+}
+
+// RingReduceRequest represents the request payload for reducing a polynomial into Z_q[X]/(X^n+1)
+type RingReduceRequest struct {
+	Modulus    *BigInt  `json:"modulus" binding:"required"`
+	Degree     int      `json:"degree" binding:"required"`
+	Polynomial []BigInt `json:"polynomial" binding:"required"`
+}
+
+// ModularResponse represents the result of an operation over Z_q
+type ModularResponse struct {
+	Operation string    `json:"operation"`
+	Modulus   string    `json:"modulus"`
+	Result    string    `json:"result,omitempty"`
+	Results   []string  `json:"results,omitempty"`
+	Count     int       `json:"count"`
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// RingResponse represents the result of an operation in Z_q[X]/(X^n+1)
+type RingResponse struct {
+	Operation    string    `json:"operation"`
+	Modulus      string    `json:"modulus"`
+	Degree       int       `json:"degree"`
+	Coefficients []string  `json:"coefficients"`
+	Algorithm    string    `json:"algorithm,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+	RequestID    string    `json:"request_id,omitempty"`
+}
+
+// Validate checks the number of values
+// Values may be unreduced, such as the product of two residues, but values
+// wider than twice the modulus are rejected so that their size stays bounded
+func (r *ModularRequest) Validate(maxValues int) error {
+	if len(r.Values) == 0 {
+		return newFieldError(CodeInvalidShape, JSONPointer("values"), "at least 1 value is required")
+	}
+
+	if err := checkElementCount(len(r.Values), maxValues, "values"); err != nil {
+		return err
+	}
+
+	if r.Modulus == nil {
+		return nil
+	}
+	bits := 2 * r.Modulus.value.BitLen()
+	for i := range r.Values {
+		if r.Values[i].value.BitLen() > bits {
+			return newFieldError(CodeValidation, JSONPointer("values", i), "value %d is wider than twice the modulus", i)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that at least two polynomials of exactly degree coefficients were sent
+// Coefficients wider than the modulus are rejected so that they are never reduced
+func (r *RingRequest) Validate(maxValues int) error {
+	if len(r.Polynomials) < 2 {
+		return newFieldError(CodeInvalidShape, JSONPointer("polynomials"), "at least 2 polynomials are required, got %d", len(r.Polynomials))
+	}
+
+	total := 0
+	for i, p := range r.Polynomials {
+		if len(p) != r.Degree {
+			return newFieldError(CodeDimensionMismatch, JSONPointer("polynomials", i), "polynomial %d has %d coefficients, expected %d", i, len(p), r.Degree)
+		}
+		total += len(p)
+	}
+	if err := checkElementCount(total, maxValues, "polynomials"); err != nil {
+		return err
+	}
+
+	if r.Modulus == nil {
+		return nil
+	}
+	bits := r.Modulus.value.BitLen()
+	for i, p := range r.Polynomials {
+		for j := range p {
+			if p[j].value.BitLen() > bits {
+				return newFieldError(CodeValidation, JSONPointer("polynomials", i, j), "coefficient %d of polynomial %d is wider than the modulus", j, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that a non-empty polynomial was sent
+// Like the values of a ModularRequest, coefficients may be at most twice as wide as the modulus
+func (r *RingReduceRequest) Validate(maxValues int) error {
+	if len(r.Polynomial) == 0 {
+		return newFieldError(CodeInvalidShape, JSONPointer("polynomial"), "polynomial is empty")
+	}
+
+	if err := checkElementCount(len(r.Polynomial), maxValues, "polynomial"); err != nil {
+		return err
+	}
+
+	if r.Modulus == nil {
+		return nil
+	}
+	bits := 2 * r.Modulus.value.BitLen()
+	for i := range r.Polynomial {
+		if r.Polynomial[i].value.BitLen() > bits {
+			return newFieldError(CodeValidation, JSONPointer("polynomial", i), "coefficient %d is wider than twice the modulus", i)
+		}
+	}
+
+	return nil
+}
+
+// RingParameters returns the modulus and degree of the ring
+func (r *RingRequest) RingParameters() (*BigInt, int) {
+	return r.Modulus, r.Degree
+}
+
+// RingParameters returns the modulus and degree of the ring
+func (r *RingReduceRequest) RingParameters() (*BigInt, int) {
+	return r.Modulus, r.Degree
+}
+
+// BigInts converts request values to *big.Int
+func BigInts(values []BigInt) []*big.Int {
+	result := make([]*big.Int, len(values))
+	for i := range values {
+		result[i] = values[i].Int()
+	}
+	return result
+}
+
+// NewModularResponse creates a ModularResponse holding a single result
+func NewModularResponse(operation string, modulus, result *big.Int, count int, requestID string) *ModularResponse {
+	return &ModularResponse{
+		Operation: operation,
+		Modulus:   modulus.String(),
+		Result:    result.String(),
+		Count:     count,
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+}
+
+// NewModularVectorResponse creates a ModularResponse holding one result per input value
+func NewModularVectorResponse(operation string, modulus *big.Int, results []*big.Int, requestID string) *ModularResponse {
+	return &ModularResponse{
+		Operation: operation,
+		Modulus:   modulus.String(),
+		Results:   bigIntStrings(results),
+		Count:     len(results),
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+}
+
+// NewRingResponse creates a new RingResponse
+func NewRingResponse(operation string, modulus *big.Int, coefficients []*big.Int, algorithm, requestID string) *RingResponse {
+	return &RingResponse{
+		Operation:    operation,
+		Modulus:      modulus.String(),
+		Degree:       len(coefficients),
+		Coefficients: bigIntStrings(coefficients),
+		Algorithm:    algorithm,
+		Timestamp:    time.Now().UTC(),
+		RequestID:    requestID,
+	}
+}
+
+// bigIntStrings converts integers to their decimal representations
+func bigIntStrings(values []*big.Int) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = v.String()
+	}
+	return result
+}
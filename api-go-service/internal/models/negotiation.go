@@ -0,0 +1,7 @@
+package models
+
+// Error codes returned by content negotiation
+const (
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	CodeNotAcceptable        = "NOT_ACCEPTABLE"
+)
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/paillier"
+)
+
+// Error codes returned by the encrypted sum endpoints
+const (
+	CodeInvalidKeySize    = "INVALID_KEY_SIZE"
+	CodeInvalidPublicKey  = "INVALID_PUBLIC_KEY"
+	CodeInvalidCiphertext = "INVALID_CIPHERTEXT"
+	CodeKeyNotFound       = "KEY_NOT_FOUND"
+	CodeKeyLimitReached   = "KEY_LIMIT_REACHED"
+)
+
+// PaillierGenerateRequest is the payload of a paillier_keygen job
+type PaillierGenerateRequest struct {
+	Bits int `json:"bits"`
+}
+
+// PaillierRegisterRequest represents the request payload for registering a public key
+type PaillierRegisterRequest struct {
+	N *BigInt `json:"n" binding:"required"`
+}
+
+// PaillierSumRequest represents the request payload for an encrypted sum
+type PaillierSumRequest struct {
+	KeyID       string   `json:"key_id" binding:"required"`
+	Ciphertexts []BigInt `json:"ciphertexts" binding:"required"`
+}
+
+// PaillierPrivateKey carries the private part of a generated key pair
+type PaillierPrivateKey struct {
+	Lambda string `json:"lambda"`
+	Mu     string `json:"mu"`
+}
+
+// PaillierKeyResponse represents a registered public key
+type PaillierKeyResponse struct {
+	KeyID     string    `json:"key_id"`
+	N         string    `json:"n"`
+	Bits      int       `json:"bits"`
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// PaillierSumResponse represents the result of an encrypted sum
+type PaillierSumResponse struct {
+	KeyID      string    `json:"key_id"`
+	Ciphertext string    `json:"ciphertext"`
+	Count      int       `json:"count"`
+	Timestamp  time.Time `json:"timestamp"`
+	RequestID  string    `json:"request_id,omitempty"`
+}
+
+// Validate checks that the requested key size is within bounds
+func (r *PaillierGenerateRequest) Validate(minBits, maxBits int) error {
+	return checkKeySize(r.Bits, minBits, maxBits, JSONPointer("bits"))
+}
+
+// Validate checks that the modulus size is within bounds
+func (r *PaillierRegisterRequest) Validate(minBits, maxBits int) error {
+	return checkKeySize(r.N.value.BitLen(), minBits, maxBits, JSONPointer("n"))
+}
+
+// Validate checks the number of ciphertexts
+func (r *PaillierSumRequest) Validate(maxCiphertexts int) error {
+	if len(r.Ciphertexts) < 2 {
+		return newFieldError(CodeInvalidShape, JSONPointer("ciphertexts"), "at least 2 ciphertexts are required, got %d", len(r.Ciphertexts))
+	}
+
+	return checkElementCount(len(r.Ciphertexts), maxCiphertexts, "ciphertexts")
+}
+
+// NewPaillierKeyResponse creates a new PaillierKeyResponse
+func NewPaillierKeyResponse(keyID string, pk *paillier.PublicKey, requestID string) *PaillierKeyResponse {
+	return &PaillierKeyResponse{
+		KeyID:     keyID,
+		N:         pk.N.String(),
+		Bits:      pk.N.BitLen(),
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+}
+
+// NewPaillierSumResponse creates a new PaillierSumResponse
+func NewPaillierSumResponse(keyID string, ciphertext *big.Int, count int, requestID string) *PaillierSumResponse {
+	return &PaillierSumResponse{
+		KeyID:      keyID,
+		Ciphertext: ciphertext.String(),
+		Count:      count,
+		Timestamp:  time.Now().UTC(),
+		RequestID:  requestID,
+	}
+}
+
+// checkKeySize enforces the configured bounds on the key size given at pointer
+func checkKeySize(bits, minBits, maxBits int, pointer string) error {
+	if bits < minBits || bits > maxBits {
+		return newFieldError(CodeInvalidKeySize, pointer, "key size must be between %d and %d bits, got %d", minBits, maxBits, bits)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/privacy"
+)
+
+// Error codes returned by the differentially private sum
+const (
+	CodePrivacyBudgetExhausted = "PRIVACY_BUDGET_EXHAUSTED"
+	CodeInvalidPrivacyParams   = "INVALID_PRIVACY_PARAMETERS"
+)
+
+// PrivacyParams requests a differentially private sum
+// Values are clamped to [lower, upper] before noise calibrated to that range is added
+type PrivacyParams struct {
+	Mechanism string   `json:"mechanism" validate:"oneof=laplace gaussian"`
+	Epsilon   float64  `json:"epsilon" validate:"finite,gt=0,range=0:privacy_max_epsilon"`
+	Delta     float64  `json:"delta,omitempty" validate:"finite"`
+	Lower     *float64 `json:"lower" validate:"required,finite"`
+	Upper     *float64 `json:"upper" validate:"required,finite"`
+}
+
+// PrivacyReport describes the noise added to a differentially private sum
+type PrivacyReport struct {
+	Mechanism       string  `json:"mechanism"`
+	EpsilonSpent    float64 `json:"epsilon_spent"`
+	Delta           float64 `json:"delta,omitempty"`
+	Lower           float64 `json:"lower"`
+	Upper           float64 `json:"upper"`
+	Sensitivity     float64 `json:"sensitivity"`
+	Scale           float64 `json:"scale"`
+	BudgetRemaining float64 `json:"budget_remaining"`
+}
+
+// PrivacyBudgetResponse reports the caller's privacy budget
+type PrivacyBudgetResponse struct {
+	Consumer  string    `json:"consumer"`
+	Budget    float64   `json:"budget"`
+	Remaining float64   `json:"remaining"`
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// Validate checks the privacy parameters
+// The validate tags bound epsilon by the privacy_max_epsilon limit; the rules
+// that depend on the mechanism or on both bounds are checked here
+func (p *PrivacyParams) Validate() error {
+	if err := validate.Struct(p); err != nil {
+		return Nest(tagErrors(p, err, CodeInvalidPrivacyParams), "privacy")
+	}
+
+	if p.Mechanism == privacy.MechanismGaussian {
+		if !(p.Delta > 0 && p.Delta < 1) {
+			return newFieldError(CodeInvalidPrivacyParams, JSONPointer("privacy", "delta"), "the gaussian mechanism requires a delta in (0, 1), got %g", p.Delta)
+		}
+		if p.Epsilon >= 1 {
+			return newFieldError(CodeInvalidPrivacyParams, JSONPointer("privacy", "epsilon"), "the gaussian mechanism requires an epsilon below 1, got %g", p.Epsilon)
+		}
+	} else if p.Delta != 0 {
+		return newFieldError(CodeInvalidPrivacyParams, JSONPointer("privacy", "delta"), "delta is only accepted by the gaussian mechanism")
+	}
+
+	if !(*p.Lower < *p.Upper) {
+		return newFieldError(CodeInvalidPrivacyParams, JSONPointer("privacy"), "bounds must be finite with lower < upper, got [%g, %g]", *p.Lower, *p.Upper)
+	}
+
+	// The sensitivity and the noise scale derived from them must be representable
+	params := p.SumParams()
+	sensitivity := params.Upper - params.Lower
+	if math.IsInf(sensitivity, 0) {
+		return newFieldError(CodeInvalidPrivacyParams, JSONPointer("privacy"), "bounds are too far apart, got [%g, %g]", *p.Lower, *p.Upper)
+	}
+	scale := privacy.LaplaceScale(sensitivity, params.Epsilon)
+	if p.Mechanism == privacy.MechanismGaussian {
+		scale = privacy.GaussianSigma(sensitivity, params.Epsilon, params.Delta)
+	}
+	if math.IsNaN(scale) || math.IsInf(scale, 0) {
+		return newFieldError(CodeInvalidPrivacyParams, JSONPointer("privacy", "epsilon"), "epsilon %g is too small for the bounds", p.Epsilon)
+	}
+
+	return nil
+}
+
+// SumParams converts validated parameters for the privacy package
+func (p *PrivacyParams) SumParams() privacy.SumParams {
+	return privacy.SumParams{
+		Mechanism: p.Mechanism,
+		Epsilon:   p.Epsilon,
+		Delta:     p.Delta,
+		Lower:     *p.Lower,
+		Upper:     *p.Upper,
+	}
+}
+
+// NewPrivateSumResponse creates a SumResponse for a differentially private sum
+// The submitted numbers are not echoed back
+func NewPrivateSumResponse(count int, params privacy.SumParams, result privacy.SumResult, remaining float64, requestID string) *SumResponse {
+	return &SumResponse{
+		Sum:   result.Value,
+		Count: count,
+		Privacy: &PrivacyReport{
+			Mechanism:       params.Mechanism,
+			EpsilonSpent:    params.Epsilon,
+			Delta:           params.Delta,
+			Lower:           params.Lower,
+			Upper:           params.Upper,
+			Sensitivity:     result.Sensitivity,
+			Scale:           result.Scale,
+			BudgetRemaining: remaining,
+		},
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+}
+
+// NewPrivacyBudgetResponse creates a new PrivacyBudgetResponse
+func NewPrivacyBudgetResponse(consumer string, budget, remaining float64, requestID string) *PrivacyBudgetResponse {
+	return &PrivacyBudgetResponse{
+		Consumer:  consumer,
+		Budget:    budget,
+		Remaining: remaining,
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+}
+
+// String returns a string representation of PrivacyParams
+func (p *PrivacyParams) String() string {
+	return fmt.Sprintf("PrivacyParams{Mechanism: %s, Epsilon: %g}", p.Mechanism, p.Epsilon)
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+// Problem is an error response in the RFC 7807 format
+// Code, details, errors, retryable, timestamp and request ID are extension members
+type Problem struct {
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Status    int               `json:"status"`
+	Detail    string            `json:"detail,omitempty"`
+	Instance  string            `json:"instance,omitempty"`
+	Code      string            `json:"code,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	Errors    []FieldError      `json:"errors,omitempty"`
+	Retryable bool              `json:"retryable"`
+	Timestamp time.Time         `json:"timestamp"`
+	RequestID string            `json:"request_id,omitempty"`
+}
+
+// NewProblem converts an error response sent with status to problem details
+// Codes missing from the catalog use about:blank with the status text as title
+func NewProblem(e *ErrorResponse, status int) *Problem {
+	problem := &Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    e.Error,
+		Instance:  e.Path,
+		Code:      e.Code,
+		Details:   e.Details,
+		Errors:    e.Errors,
+		Retryable: e.Retryable,
+		Timestamp: e.Timestamp,
+		RequestID: e.RequestID,
+	}
+
+	if t, ok := LookupErrorType(e.Code); ok {
+		problem.Type = t.Type
+		problem.Title = t.Title
+	}
+	return problem
+}
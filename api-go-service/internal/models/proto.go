@@ -0,0 +1,185 @@
+package models
+
+import (
+	"time"
+
+	apiv1 "github.com/katvio/api-go-service/proto/zama/api/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Protobuf encoding of the messages in proto/zama/api/v1/sum.proto
+// Each model converts to and from its generated message, which does the wire encoding.
+
+// marshalOptions sorts map entries so that equal messages encode to equal bytes
+var marshalOptions = proto.MarshalOptions{Deterministic: true}
+
+// MarshalProto encodes the request as a zama.api.v1.SumRequest
+func (s *SumRequest) MarshalProto() ([]byte, error) {
+	return marshalOptions.Marshal(s.Proto())
+}
+
+// UnmarshalProto decodes a zama.api.v1.SumRequest
+func (s *SumRequest) UnmarshalProto(data []byte) error {
+	var message apiv1.SumRequest
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return err
+	}
+	*s = *SumRequestFromProto(&message)
+	return nil
+}
+
+// Proto converts the request to its proto message
+func (s *SumRequest) Proto() *apiv1.SumRequest {
+	message := &apiv1.SumRequest{Numbers: s.Numbers}
+	if p := s.Privacy; p != nil {
+		message.Privacy = &apiv1.PrivacyParams{
+			Mechanism: p.Mechanism,
+			Epsilon:   p.Epsilon,
+			Delta:     p.Delta,
+			Lower:     p.Lower,
+			Upper:     p.Upper,
+		}
+	}
+	return message
+}
+
+// SumRequestFromProto converts a zama.api.v1.SumRequest to a request
+func SumRequestFromProto(message *apiv1.SumRequest) *SumRequest {
+	request := &SumRequest{Numbers: message.GetNumbers()}
+	if p := message.GetPrivacy(); p != nil {
+		request.Privacy = &PrivacyParams{
+			Mechanism: p.GetMechanism(),
+			Epsilon:   p.GetEpsilon(),
+			Delta:     p.GetDelta(),
+			Lower:     p.Lower,
+			Upper:     p.Upper,
+		}
+	}
+	return request
+}
+
+// MarshalProto encodes the response as a zama.api.v1.SumResponse
+func (s *SumResponse) MarshalProto() ([]byte, error) {
+	return marshalOptions.Marshal(s.Proto())
+}
+
+// UnmarshalProto decodes a zama.api.v1.SumResponse
+func (s *SumResponse) UnmarshalProto(data []byte) error {
+	var message apiv1.SumResponse
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return err
+	}
+
+	*s = SumResponse{
+		Sum:       message.GetSum(),
+		Count:     int(message.GetCount()),
+		Numbers:   message.GetNumbers(),
+		Timestamp: timeFromProto(message.GetTimestamp()),
+		RequestID: message.GetRequestId(),
+	}
+	if p := message.GetPrivacy(); p != nil {
+		s.Privacy = &PrivacyReport{
+			Mechanism:       p.GetMechanism(),
+			EpsilonSpent:    p.GetEpsilonSpent(),
+			Delta:           p.GetDelta(),
+			Lower:           p.GetLower(),
+			Upper:           p.GetUpper(),
+			Sensitivity:     p.GetSensitivity(),
+			Scale:           p.GetScale(),
+			BudgetRemaining: p.GetBudgetRemaining(),
+		}
+	}
+	return nil
+}
+
+// Proto converts the response to its proto message
+func (s *SumResponse) Proto() *apiv1.SumResponse {
+	message := &apiv1.SumResponse{
+		Sum:       s.Sum,
+		Count:     int64(s.Count),
+		Numbers:   s.Numbers,
+		Timestamp: timeProto(s.Timestamp),
+		RequestId: s.RequestID,
+	}
+	if p := s.Privacy; p != nil {
+		message.Privacy = &apiv1.PrivacyReport{
+			Mechanism:       p.Mechanism,
+			EpsilonSpent:    p.EpsilonSpent,
+			Delta:           p.Delta,
+			Lower:           p.Lower,
+			Upper:           p.Upper,
+			Sensitivity:     p.Sensitivity,
+			Scale:           p.Scale,
+			BudgetRemaining: p.BudgetRemaining,
+		}
+	}
+	return message
+}
+
+// MarshalProto encodes the error as a zama.api.v1.ErrorResponse
+func (e *ErrorResponse) MarshalProto() ([]byte, error) {
+	message := &apiv1.ErrorResponse{
+		Error:     e.Error,
+		Code:      e.Code,
+		Details:   e.Details,
+		Timestamp: timeProto(e.Timestamp),
+		RequestId: e.RequestID,
+		Path:      e.Path,
+		Retryable: e.Retryable,
+		Docs:      e.Docs,
+	}
+	for _, fe := range e.Errors {
+		message.Errors = append(message.Errors, &apiv1.FieldError{
+			Pointer:   fe.Pointer,
+			Parameter: fe.Parameter,
+			Rule:      fe.Rule,
+			Message:   fe.Message,
+		})
+	}
+	return marshalOptions.Marshal(message)
+}
+
+// UnmarshalProto decodes a zama.api.v1.ErrorResponse
+func (e *ErrorResponse) UnmarshalProto(data []byte) error {
+	var message apiv1.ErrorResponse
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return err
+	}
+
+	*e = ErrorResponse{
+		Error:     message.GetError(),
+		Code:      message.GetCode(),
+		Details:   message.GetDetails(),
+		Timestamp: timeFromProto(message.GetTimestamp()),
+		RequestID: message.GetRequestId(),
+		Path:      message.GetPath(),
+		Retryable: message.GetRetryable(),
+		Docs:      message.GetDocs(),
+	}
+	for _, fe := range message.GetErrors() {
+		e.Errors = append(e.Errors, FieldError{
+			Pointer:   fe.GetPointer(),
+			Parameter: fe.GetParameter(),
+			Rule:      fe.GetRule(),
+			Message:   fe.GetMessage(),
+		})
+	}
+	return nil
+}
+
+// timeProto converts a time to a google.protobuf.Timestamp, omitting the zero time
+func timeProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeFromProto converts a google.protobuf.Timestamp to a UTC time; nil is the zero time
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
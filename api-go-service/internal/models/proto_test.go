@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	apiv1 "github.com/katvio/api-go-service/proto/zama/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestProto_RoundTrip tests that the models survive their protobuf encoding
+func TestProto_RoundTrip(t *testing.T) {
+	lower := 0.0
+	request := &SumRequest{
+		Numbers: []float64{1, -2.5},
+		Privacy: &PrivacyParams{Mechanism: "laplace", Epsilon: 0.5, Lower: &lower},
+	}
+	data, err := request.MarshalProto()
+	require.NoError(t, err)
+
+	var decodedRequest SumRequest
+	require.NoError(t, decodedRequest.UnmarshalProto(data))
+	assert.Equal(t, request, &decodedRequest)
+
+	response := &SumResponse{
+		Sum:       3,
+		Count:     2,
+		Privacy:   &PrivacyReport{Mechanism: "laplace", EpsilonSpent: 0.5, Scale: 2},
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		RequestID: "req-1",
+	}
+	data, err = response.MarshalProto()
+	require.NoError(t, err)
+
+	var decodedResponse SumResponse
+	require.NoError(t, decodedResponse.UnmarshalProto(data))
+	assert.Equal(t, response, &decodedResponse)
+
+	// The bytes are those of the generated message
+	var message apiv1.SumResponse
+	require.NoError(t, proto.Unmarshal(data, &message))
+	assert.Equal(t, "req-1", message.GetRequestId())
+	assert.Equal(t, int64(2), message.GetCount())
+
+	errorResponse := &ErrorResponse{
+		Error:     "bad",
+		Code:      CodeValidation,
+		Details:   map[string]string{"b": "2", "a": "1"},
+		Errors:    []FieldError{{Pointer: "/numbers/1", Rule: "finite", Message: "numbers[1] must be a finite number"}},
+		Retryable: true,
+		Docs:      ErrorDocsURL(CodeValidation),
+	}
+	data, err = errorResponse.MarshalProto()
+	require.NoError(t, err)
+
+	var decodedError ErrorResponse
+	require.NoError(t, decodedError.UnmarshalProto(data))
+	assert.Equal(t, errorResponse, &decodedError)
+
+	assert.Error(t, decodedError.UnmarshalProto([]byte{0xff}))
+}
@@ -2,35 +2,33 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/katvio/api-go-service/internal/i18n"
 )
 
 // SumRequest represents the request payload for the sum endpoint
 type SumRequest struct {
-	Numbers []float64 `json:"numbers" binding:"required" validate:"required"`
+	Numbers []float64      `json:"numbers" binding:"required" validate:"min_items=sum_min_numbers,max_items=sum_max_numbers,dive,finite"`
+	Privacy *PrivacyParams `json:"privacy,omitempty" validate:"-"` // checked by PrivacyParams.Validate, which reports its own code
 }
 
-// Validate performs custom validation on the SumRequest
+// Validate checks the validate tags of the SumRequest
+// The bounds on the number of values are the sum_min_numbers and sum_max_numbers limits
 func (s *SumRequest) Validate() error {
-	if len(s.Numbers) < 2 {
-		return fmt.Errorf("at least 2 numbers are required, got %d", len(s.Numbers))
-	}
-
-	if len(s.Numbers) > 100 {
-		return fmt.Errorf("maximum 100 numbers allowed, got %d", len(s.Numbers))
-	}
-
-	return nil
+	return ValidateStruct(s)
 }
 
 // SumResponse represents the response payload for the sum endpoint
 type SumResponse struct {
-	Sum       float64   `json:"sum"`
-	Count     int       `json:"count"`
-	Numbers   []float64 `json:"numbers"`
-	Timestamp time.Time `json:"timestamp"`
-	RequestID string    `json:"request_id,omitempty"`
+	Sum       float64        `json:"sum"`
+	Count     int            `json:"count"`
+	Numbers   []float64      `json:"numbers,omitempty"`
+	Privacy   *PrivacyReport `json:"privacy,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+	RequestID string         `json:"request_id,omitempty"`
 }
 
 // HealthResponse represents the response payload for the health endpoint
@@ -48,6 +46,9 @@ type ErrorResponse struct {
 	Error     string            `json:"error"`
 	Code      string            `json:"code,omitempty"`
 	Details   map[string]string `json:"details,omitempty"`
+	Errors    []FieldError      `json:"errors,omitempty"`
+	Retryable bool              `json:"retryable"`
+	Docs      string            `json:"docs,omitempty"`
 	Timestamp time.Time         `json:"timestamp"`
 	RequestID string            `json:"request_id,omitempty"`
 	Path      string            `json:"path,omitempty"`
@@ -99,9 +100,24 @@ func NewHealthResponse(version, uptime string, checks map[string]string, request
 }
 
 // NewErrorResponse creates a new ErrorResponse
+// Validation errors also list the invalid elements of the request
 func NewErrorResponse(err error, code, path, requestID string) *ErrorResponse {
+	return NewLocalizedErrorResponse(err, code, i18n.Default, path, requestID)
+}
+
+// NewLocalizedErrorResponse creates a new ErrorResponse with its messages in locale
+// Messages without a translation are kept in English
+func NewLocalizedErrorResponse(err error, code, locale, path, requestID string) *ErrorResponse {
+	message := Localize(err, locale)
+	if fieldErrs := LocalizedFieldErrors(err, locale); len(fieldErrs) > 0 {
+		response := NewValidationErrorResponse(fieldErrs, path, requestID)
+		response.Error = message
+		response.Code = code
+		return response
+	}
+
 	return &ErrorResponse{
-		Error:     err.Error(),
+		Error:     message,
 		Code:      code,
 		Timestamp: time.Now().UTC(),
 		RequestID: requestID,
@@ -110,17 +126,94 @@ func NewErrorResponse(err error, code, path, requestID string) *ErrorResponse {
 }
 
 // NewValidationErrorResponse creates a new ErrorResponse for validation errors
-func NewValidationErrorResponse(validationErrors map[string]string, path, requestID string) *ErrorResponse {
+// Details maps the JSON pointer of each invalid element, or the name of each
+// invalid parameter, to its messages
+func NewValidationErrorResponse(fieldErrs []FieldError, path, requestID string) *ErrorResponse {
+	var details map[string]string
+	for _, fe := range fieldErrs {
+		key := fe.Pointer
+		if fe.Parameter != "" {
+			key = fe.Parameter + fe.Pointer
+		}
+		if key == "" {
+			continue
+		}
+		if details == nil {
+			details = make(map[string]string)
+		}
+		if previous, ok := details[key]; ok {
+			details[key] = previous + "; " + fe.Message
+		} else {
+			details[key] = fe.Message
+		}
+	}
+
 	return &ErrorResponse{
 		Error:     "validation failed",
-		Code:      "VALIDATION_ERROR",
-		Details:   validationErrors,
+		Code:      CodeValidation,
+		Details:   details,
+		Errors:    fieldErrs,
 		Timestamp: time.Now().UTC(),
 		RequestID: requestID,
 		Path:      path,
 	}
 }
 
+// ErrorCoder is implemented by validation errors that carry their own error code
+type ErrorCoder interface {
+	error
+	ErrorCode() string
+}
+
+// CodedError is a validation error that carries the error code to report
+// Pointer is the JSON pointer of the invalid element, empty for the whole request
+type CodedError struct {
+	Code    string
+	Message string
+	Pointer string
+
+	text *message
+}
+
+// Error implements the error interface
+func (e *CodedError) Error() string {
+	return e.Message
+}
+
+// Localize implements Localizer
+func (e *CodedError) Localize(locale string) string {
+	if e.text == nil {
+		return e.Message
+	}
+	return e.text.Localize(locale)
+}
+
+// ErrorCode returns the error code for the response
+func (e *CodedError) ErrorCode() string {
+	return e.Code
+}
+
+// newCodedError creates a CodedError with a formatted message
+func newCodedError(code, format string, args ...interface{}) *CodedError {
+	text := newMessage(format, args...)
+	return &CodedError{Code: code, Message: text.String(), text: text}
+}
+
+// newFieldError creates a CodedError about the element at pointer
+func newFieldError(code, pointer, format string, args ...interface{}) *CodedError {
+	text := newMessage(format, args...)
+	return &CodedError{Code: code, Message: text.String(), Pointer: pointer, text: text}
+}
+
+// ErrorCode returns the code carried by err, or fallback if it does not carry one
+func ErrorCode(err error, fallback string) string {
+	var coder ErrorCoder
+	if errors.As(err, &coder) {
+		return coder.ErrorCode()
+	}
+	return fallback
+}
+
 // ToJSON converts any response to JSON bytes
 func ToJSON(v interface{}) ([]byte, error) {
 	return json.Marshal(v)
@@ -128,9 +128,11 @@ func TestNewErrorResponse(t *testing.T) {
 }
 
 func TestNewValidationErrorResponse(t *testing.T) {
-	validationErrors := map[string]string{
-		"field1": "is required",
-		"field2": "must be positive",
+	validationErrors := []FieldError{
+		{Pointer: "/field1", Rule: "required", Message: "is required"},
+		{Pointer: "/field2", Rule: "gt", Message: "must be positive"},
+		{Pointer: "/field2", Rule: "finite", Message: "must be finite"},
+		{Rule: "syntax", Message: "applies to the whole body"},
 	}
 	path := "/validation/test"
 	requestID := "test-request-validation"
@@ -139,7 +141,8 @@ func TestNewValidationErrorResponse(t *testing.T) {
 
 	assert.Equal(t, "validation failed", response.Error)
 	assert.Equal(t, "VALIDATION_ERROR", response.Code)
-	assert.Equal(t, validationErrors, response.Details)
+	assert.Equal(t, map[string]string{"/field1": "is required", "/field2": "must be positive; must be finite"}, response.Details)
+	assert.Equal(t, validationErrors, response.Errors)
 	assert.Equal(t, path, response.Path)
 	assert.Equal(t, requestID, response.RequestID)
 }
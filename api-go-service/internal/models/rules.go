@@ -0,0 +1,220 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/katvio/api-go-service/internal/i18n"
+)
+
+// Rules of the validate tags besides the ones built into the validator
+const (
+	RuleFinite    = "finite"
+	RuleRange     = "range"
+	RuleExclusive = "exclusive"
+)
+
+// Names of the configurable limits that validate tags can refer to
+const (
+	LimitSumMinNumbers     = "sum_min_numbers"
+	LimitSumMaxNumbers     = "sum_max_numbers"
+	LimitPrivacyMaxEpsilon = "privacy_max_epsilon"
+	LimitWindowMaxBatch    = "window_max_batch"
+	LimitIntegerMaxBits    = "integer_max_bits"
+	LimitRequestMaxBytes   = "request_max_bytes"
+)
+
+// Limits maps limit names to their values
+type Limits map[string]float64
+
+// DefaultLimits returns the limits used until SetLimits is called
+// They match the defaults of the configuration
+func DefaultLimits() Limits {
+	return Limits{
+		LimitSumMinNumbers:     2,
+		LimitSumMaxNumbers:     100,
+		LimitPrivacyMaxEpsilon: 1,
+		LimitWindowMaxBatch:    1000,
+		LimitIntegerMaxBits:    8192,
+		LimitRequestMaxBytes:   8 << 20,
+	}
+}
+
+// limits holds the values of the named limits; it is set once at startup
+var limits = DefaultLimits()
+
+// SetLimits sets the values of named limits, keeping the defaults of the others
+func SetLimits(l Limits) {
+	merged := DefaultLimits()
+	for name, value := range l {
+		merged[name] = value
+	}
+	limits = merged
+}
+
+// Limit returns the value of a named limit on a count
+func Limit(name string) int {
+	return int(limits[name])
+}
+
+// validate is the engine checking the validate tags of request models
+// It runs next to the binding tags, which only cover what the body must contain
+var validate = newValidate()
+
+// newValidate creates the engine and registers the custom rules
+// The rules also run on nil pointers, which they treat as unset
+func newValidate() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("validate")
+	for tag, fn := range map[string]validator.Func{
+		RuleFinite:    isFinite,
+		RuleMinItems:  hasMinItems,
+		RuleMaxItems:  hasMaxItems,
+		RuleRange:     inRange,
+		RuleExclusive: isExclusive,
+	} {
+		if err := v.RegisterValidation(tag, fn, true); err != nil {
+			panic(err)
+		}
+	}
+	return v
+}
+
+// ValidateStruct checks the validate tags of v
+// Every violation is collected into a single ValidationError
+func ValidateStruct(v interface{}) error {
+	if err := validate.Struct(v); err != nil {
+		return tagErrors(v, err, CodeValidation)
+	}
+	return nil
+}
+
+// ResolveLimit resolves the parameter of a rule: a number or the name of a limit,
+// optionally negated as in -name
+func ResolveLimit(param string) (float64, bool) {
+	if value, err := strconv.ParseFloat(param, 64); err == nil {
+		return value, true
+	}
+
+	sign, name := 1.0, param
+	if strings.HasPrefix(param, "-") {
+		sign, name = -1, param[1:]
+	}
+	value, ok := limits[name]
+	return sign * value, ok
+}
+
+// limitParam resolves the parameter of a rule, which must be valid
+func limitParam(param string) float64 {
+	value, ok := ResolveLimit(param)
+	if !ok {
+		panic(fmt.Sprintf("models: unknown limit %q in validate tag", param))
+	}
+	return value
+}
+
+// rangeParams splits the parameter of the range rule, lo:hi
+func rangeParams(param string) (float64, float64) {
+	lo, hi, ok := strings.Cut(param, ":")
+	if !ok {
+		panic(fmt.Sprintf("models: range %q is not of the form lo:hi", param))
+	}
+	return limitParam(lo), limitParam(hi)
+}
+
+// numberValue returns the value of a numeric field
+func numberValue(field reflect.Value) (float64, bool) {
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(field.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(field.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+// isFinite rejects NaN and infinite numbers, which JSON cannot carry but gRPC and CBOR can
+func isFinite(fl validator.FieldLevel) bool {
+	value, ok := numberValue(fl.Field())
+	return !ok || !(math.IsNaN(value) || math.IsInf(value, 0))
+}
+
+// hasMinItems checks the length of a collection against a lower bound
+func hasMinItems(fl validator.FieldLevel) bool {
+	return float64(fl.Field().Len()) >= limitParam(fl.Param())
+}
+
+// hasMaxItems checks the length of a collection against an upper bound
+func hasMaxItems(fl validator.FieldLevel) bool {
+	return float64(fl.Field().Len()) <= limitParam(fl.Param())
+}
+
+// inRange checks that a number lies between the bounds of range=lo:hi, inclusive
+func inRange(fl validator.FieldLevel) bool {
+	value, ok := numberValue(fl.Field())
+	if !ok {
+		return true
+	}
+	lo, hi := rangeParams(fl.Param())
+	return value >= lo && value <= hi
+}
+
+// isExclusive checks that a field and the sibling named by exclusive=Field are not both set
+func isExclusive(fl validator.FieldLevel) bool {
+	if isUnset(fl.Field()) {
+		return true
+	}
+	parent := fl.Parent()
+	for parent.Kind() == reflect.Ptr {
+		parent = parent.Elem()
+	}
+	return isUnset(parent.FieldByName(fl.Param()))
+}
+
+// isUnset reports whether a field holds its zero value
+func isUnset(field reflect.Value) bool {
+	return !field.IsValid() || field.IsZero()
+}
+
+// tagError describes a failed validate or binding tag
+func tagError(t reflect.Type, pointer string, fe validator.FieldError) FieldError {
+	name := strings.TrimPrefix(pointer, "/")
+	switch fe.Tag() {
+	case RuleFinite:
+		return fieldError(pointer, fe.Tag(), "%s must be a finite number", name)
+	case RuleMinItems:
+		return fieldError(pointer, fe.Tag(), "at least %d %s are required, got %d",
+			int(limitParam(fe.Param())), i18n.Phrase(name), valueLen(fe.Value()))
+	case RuleMaxItems:
+		return fieldError(pointer, fe.Tag(), "maximum %d %s allowed, got %d",
+			int(limitParam(fe.Param())), i18n.Phrase(name), valueLen(fe.Value()))
+	case RuleRange:
+		lo, hi := rangeParams(fe.Param())
+		return fieldError(pointer, fe.Tag(), "%s must be between %g and %g, got %v", name, lo, hi, fe.Value())
+	case RuleExclusive:
+		namespace := fe.StructNamespace()
+		sibling := namespace[:strings.LastIndexByte(namespace, '.')+1] + fe.Param()
+		other := strings.TrimPrefix(namespacePointer(t, sibling), "/")
+		return fieldError(pointer, fe.Tag(), "%s and %s are mutually exclusive", name, other)
+	default:
+		return bindingError(pointer, fe)
+	}
+}
+
+// valueLen returns the length of a collection reported by a failed rule
+func valueLen(value interface{}) int {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+		return v.Len()
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ruleTestRequest exercises the rules that no request model uses yet
+type ruleTestRequest struct {
+	Weights []float64 `json:"weights" validate:"max_items=3,dive,range=-sum_min_numbers:10"`
+	Rate    *float64  `json:"rate" validate:"exclusive=Burst"`
+	Burst   int       `json:"burst"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	t.Run("Valid requests", func(t *testing.T) {
+		assert.NoError(t, ValidateStruct(&SumRequest{Numbers: []float64{1, 2}}))
+		assert.NoError(t, ValidateStruct(&ruleTestRequest{Weights: []float64{-2, 10}, Burst: 5}))
+	})
+
+	t.Run("Numbers must be finite", func(t *testing.T) {
+		err := (&SumRequest{Numbers: []float64{1, math.NaN(), math.Inf(1)}}).Validate()
+		assert.Equal(t, CodeValidation, ErrorCode(err, ""))
+		assert.Equal(t, []FieldError{
+			{Pointer: "/numbers/1", Rule: RuleFinite, Message: "numbers/1 must be a finite number"},
+			{Pointer: "/numbers/2", Rule: RuleFinite, Message: "numbers/2 must be a finite number"},
+		}, FieldErrors(err))
+	})
+
+	t.Run("Missing numbers count as none", func(t *testing.T) {
+		err := (&SumRequest{}).Validate()
+		assert.Equal(t, []FieldError{{Pointer: "/numbers", Rule: RuleMinItems, Message: "at least 2 numbers are required, got 0"}}, FieldErrors(err))
+	})
+
+	t.Run("Ranges and length bounds", func(t *testing.T) {
+		err := ValidateStruct(&ruleTestRequest{Weights: []float64{-3, 1, 11, 4}})
+		assert.Equal(t, []FieldError{
+			{Pointer: "/weights", Rule: RuleMaxItems, Message: "maximum 3 weights allowed, got 4"},
+		}, FieldErrors(err))
+
+		err = ValidateStruct(&ruleTestRequest{Weights: []float64{-3, 11}})
+		assert.Equal(t, []FieldError{
+			{Pointer: "/weights/0", Rule: RuleRange, Message: "weights/0 must be between -2 and 10, got -3"},
+			{Pointer: "/weights/1", Rule: RuleRange, Message: "weights/1 must be between -2 and 10, got 11"},
+		}, FieldErrors(err))
+	})
+
+	t.Run("Mutually exclusive fields", func(t *testing.T) {
+		rate := 0.5
+		assert.NoError(t, ValidateStruct(&ruleTestRequest{Rate: &rate}))
+
+		err := ValidateStruct(&ruleTestRequest{Rate: &rate, Burst: 5})
+		assert.Equal(t, []FieldError{{Pointer: "/rate", Rule: RuleExclusive, Message: "rate and burst are mutually exclusive"}}, FieldErrors(err))
+	})
+
+	t.Run("Messages are localized", func(t *testing.T) {
+		err := (&SumRequest{Numbers: []float64{1}}).Validate()
+		fieldErrs := LocalizedFieldErrors(err, i18n.French)
+		require.Len(t, fieldErrs, 1)
+		assert.Equal(t, "au moins 2 nombres sont requis, 1 reçu(s)", fieldErrs[0].Message)
+	})
+}
+
+func TestSetLimits(t *testing.T) {
+	defer SetLimits(nil)
+
+	SetLimits(Limits{LimitSumMaxNumbers: 3})
+	assert.Equal(t, 2, Limit(LimitSumMinNumbers))
+	assert.Equal(t, 3, Limit(LimitSumMaxNumbers))
+
+	err := (&SumRequest{Numbers: []float64{1, 2, 3, 4}}).Validate()
+	assert.Equal(t, []FieldError{{Pointer: "/numbers", Rule: RuleMaxItems, Message: "maximum 3 numbers allowed, got 4"}}, FieldErrors(err))
+
+	SetLimits(Limits{LimitSumMinNumbers: 1})
+	assert.NoError(t, (&SumRequest{Numbers: []float64{1}}).Validate())
+}
+
+func TestSetLimits_Privacy(t *testing.T) {
+	defer SetLimits(nil)
+	lower, upper := 0.0, 10.0
+	params := PrivacyParams{Mechanism: "laplace", Epsilon: 2, Lower: &lower, Upper: &upper}
+
+	err := params.Validate()
+	assert.Equal(t, CodeInvalidPrivacyParams, ErrorCode(err, ""))
+	assert.Equal(t, []FieldError{{Pointer: "/privacy/epsilon", Rule: RuleRange, Message: "epsilon must be between 0 and 1, got 2"}}, FieldErrors(err))
+
+	SetLimits(Limits{LimitPrivacyMaxEpsilon: 2.5})
+	assert.NoError(t, params.Validate())
+
+	err = (&PrivacyParams{Mechanism: "exponential", Epsilon: 0}).Validate()
+	assert.Equal(t, []string{"/privacy/mechanism", "/privacy/epsilon", "/privacy/lower", "/privacy/upper"}, pointers(FieldErrors(err)))
+}
+
+func TestSetLimits_Window(t *testing.T) {
+	defer SetLimits(nil)
+	now := time.Now()
+	value := 1.0
+	request := WindowAppendRequest{Points: []WindowPoint{{Timestamp: now, Value: &value}, {Timestamp: now, Value: &value}}}
+	assert.NoError(t, request.Validate(now))
+
+	SetLimits(Limits{LimitWindowMaxBatch: 1})
+	err := request.Validate(now)
+	assert.Equal(t, []FieldError{{Pointer: "/points", Rule: RuleMaxItems, Message: "maximum 1 points allowed, got 2"}}, FieldErrors(err))
+
+	nan := math.NaN()
+	err = (&WindowAppendRequest{Points: []WindowPoint{{Timestamp: now, Value: &nan}}}).Validate(now)
+	assert.Equal(t, []string{"/points/0/value"}, pointers(FieldErrors(err)))
+}
+
+func TestSetLimits_Integer(t *testing.T) {
+	defer SetLimits(nil)
+	SetLimits(Limits{LimitIntegerMaxBits: 64})
+
+	// 2^64-1 has 20 digits, so 21 are allowed
+	_, err := ParseBigInt(strings.Repeat("9", 21))
+	assert.NoError(t, err)
+	_, err = ParseBigInt("-" + strings.Repeat("9", 21))
+	assert.NoError(t, err)
+
+	_, err = ParseBigInt(strings.Repeat("9", 1<<20))
+	require.Error(t, err)
+	assert.Equal(t, `integer "99999999999999999999999999999999"... has more than 21 digits`, err.Error())
+
+	var value BigInt
+	assert.Error(t, json.Unmarshal([]byte(`"`+strings.Repeat("1", 22)+`"`), &value))
+}
+
+// pointers returns the pointers of field errors
+func pointers(fieldErrs []FieldError) []string {
+	result := make([]string, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		result[i] = fe.Pointer
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/katvio/api-go-service/internal/stream"
+)
+
+// Error codes returned by the stream endpoints
+const (
+	CodeStreamNotFound     = "STREAM_NOT_FOUND"
+	CodeStreamClosed       = "STREAM_CLOSED"
+	CodeStreamLimitReached = "STREAM_LIMIT_REACHED"
+	CodeStreamMessageLimit = "STREAM_MESSAGE_LIMIT_REACHED"
+)
+
+// Types of the messages sent to stream clients
+const (
+	StreamMessageSession = "session"
+	StreamMessageUpdate  = "update"
+	StreamMessageError   = "error"
+	StreamMessageClose   = "close"
+)
+
+// StreamPushRequest carries numbers pushed into a running-sum stream
+// It is the body of a WebSocket message and of POST /api/v1/stream/sum/{id}
+type StreamPushRequest struct {
+	Numbers []float64 `json:"numbers" binding:"required"`
+}
+
+// StreamSessionMessage announces a new stream to its client
+type StreamSessionMessage struct {
+	Type     string    `json:"type"`
+	StreamID string    `json:"stream_id"`
+	PushURL  string    `json:"push_url,omitempty"` // where SSE clients post numbers
+	Opened   time.Time `json:"opened"`
+}
+
+// StreamUpdate is the running state of a stream after a message
+type StreamUpdate struct {
+	Type      string    `json:"type"`
+	StreamID  string    `json:"stream_id"`
+	Sum       float64   `json:"sum"`
+	Count     int       `json:"count"`
+	Mean      float64   `json:"mean"`
+	Messages  int       `json:"messages"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// StreamErrorMessage reports a message the stream refused; the stream stays open
+type StreamErrorMessage struct {
+	Type  string `json:"type"`
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// StreamCloseMessage tells an SSE client why its stream ended
+type StreamCloseMessage struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// NewStreamSessionMessage creates the first message of a stream
+func NewStreamSessionMessage(s *stream.Session, pushURL string) *StreamSessionMessage {
+	return &StreamSessionMessage{
+		Type:     StreamMessageSession,
+		StreamID: s.ID,
+		PushURL:  pushURL,
+		Opened:   s.CreatedAt.UTC(),
+	}
+}
+
+// NewStreamUpdate creates the message sent after each push
+func NewStreamUpdate(streamID string, u stream.Update) *StreamUpdate {
+	return &StreamUpdate{
+		Type:      StreamMessageUpdate,
+		StreamID:  streamID,
+		Sum:       u.Sum,
+		Count:     u.Count,
+		Mean:      u.Mean,
+		Messages:  u.Messages,
+		Timestamp: u.Timestamp,
+	}
+}
+
+// NewStreamErrorMessage creates the message sent for a refused push
+func NewStreamErrorMessage(err error, code string) *StreamErrorMessage {
+	return &StreamErrorMessage{
+		Type:  StreamMessageError,
+		Error: err.Error(),
+		Code:  code,
+	}
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/katvio/api-go-service/internal/ingest"
+)
+
+// Error codes returned by the upload endpoint
+const (
+	CodeInvalidUpload = "INVALID_UPLOAD"
+	CodeInvalidFile   = "INVALID_FILE"
+)
+
+// UploadParams holds the parsing parameters of POST /api/v1/sum/upload
+// They come from the query string or from form fields sent before the file
+type UploadParams struct {
+	Format    string
+	Columns   string
+	Header    string
+	Delimiter string
+	Decimal   string
+}
+
+// Set assigns a parameter by its form field name, reporting whether the name is known
+func (p *UploadParams) Set(name, value string) bool {
+	switch name {
+	case "format":
+		p.Format = value
+	case "columns":
+		p.Columns = value
+	case "header":
+		p.Header = value
+	case "delimiter":
+		p.Delimiter = value
+	case "decimal":
+		p.Decimal = value
+	default:
+		return false
+	}
+	return true
+}
+
+// Options converts the parameters to parsing options and resolves the file format
+// Without a format parameter, the format follows the file extension and defaults to CSV
+func (p *UploadParams) Options(filename string) (string, ingest.Options, error) {
+	opts := ingest.Options{Header: true, Delimiter: ',', Decimal: '.'}
+
+	format := strings.ToLower(p.Format)
+	if format == "" {
+		switch strings.ToLower(path.Ext(filename)) {
+		case ".jsonl", ".ndjson":
+			format = ingest.FormatJSONL
+		default:
+			format = ingest.FormatCSV
+		}
+	}
+	switch format {
+	case ingest.FormatCSV:
+	case ingest.FormatJSONL, "ndjson":
+		format = ingest.FormatJSONL
+	default:
+		return "", opts, fmt.Errorf("format must be csv or jsonl, got %q", p.Format)
+	}
+
+	if p.Columns != "" {
+		for _, column := range strings.Split(p.Columns, ",") {
+			if column = strings.TrimSpace(column); column == "" {
+				return "", opts, fmt.Errorf("columns must not be empty")
+			}
+			opts.Columns = append(opts.Columns, column)
+		}
+	}
+
+	if p.Header != "" {
+		header, err := strconv.ParseBool(p.Header)
+		if err != nil {
+			return "", opts, fmt.Errorf("header must be true or false, got %q", p.Header)
+		}
+		opts.Header = header
+	}
+
+	switch p.Delimiter {
+	case "":
+	case "tab", `\t`:
+		opts.Delimiter = '\t'
+	default:
+		if utf8.RuneCountInString(p.Delimiter) != 1 {
+			return "", opts, fmt.Errorf("delimiter must be a single character, got %q", p.Delimiter)
+		}
+		opts.Delimiter, _ = utf8.DecodeRuneInString(p.Delimiter)
+	}
+
+	switch p.Decimal {
+	case "", ".":
+	case ",":
+		opts.Decimal = ','
+	default:
+		return "", opts, fmt.Errorf("decimal must be '.' or ',', got %q", p.Decimal)
+	}
+	return format, opts, nil
+}
+
+// UploadColumn holds the statistics of one summed column
+// Mean, min and max are null for columns without values
+type UploadColumn struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Empty int      `json:"empty"`
+	Sum   float64  `json:"sum"`
+	Mean  *float64 `json:"mean"`
+	Min   *float64 `json:"min"`
+	Max   *float64 `json:"max"`
+}
+
+// UploadRowError reports a row that was skipped
+type UploadRowError struct {
+	Line   int    `json:"line"`
+	Column string `json:"column,omitempty"`
+	Error  string `json:"error"`
+}
+
+// UploadSumResponse represents the response payload for the upload endpoint
+// ErrorCount includes the row errors beyond those listed in Errors
+type UploadSumResponse struct {
+	File       string           `json:"file"`
+	Format     string           `json:"format"`
+	Rows       int              `json:"rows"`
+	Skipped    int              `json:"skipped"`
+	Columns    []UploadColumn   `json:"columns"`
+	Errors     []UploadRowError `json:"errors"`
+	ErrorCount int              `json:"error_count"`
+	Timestamp  time.Time        `json:"timestamp"`
+	RequestID  string           `json:"request_id,omitempty"`
+}
+
+// NewUploadSumResponse creates a new UploadSumResponse
+func NewUploadSumResponse(file, format string, summary *ingest.Summary, requestID string) *UploadSumResponse {
+	resp := &UploadSumResponse{
+		File:       file,
+		Format:     format,
+		Rows:       summary.Rows,
+		Skipped:    summary.Skipped,
+		Columns:    make([]UploadColumn, len(summary.Columns)),
+		Errors:     make([]UploadRowError, len(summary.Errors)),
+		ErrorCount: summary.ErrorCount,
+		Timestamp:  time.Now().UTC(),
+		RequestID:  requestID,
+	}
+	for i, c := range summary.Columns {
+		resp.Columns[i] = UploadColumn{Name: c.Name, Count: c.Count, Empty: c.Empty, Sum: c.Sum}
+		if mean, ok := c.Mean(); ok {
+			min, max := c.Min, c.Max
+			resp.Columns[i].Mean, resp.Columns[i].Min, resp.Columns[i].Max = &mean, &min, &max
+		}
+	}
+	for i, e := range summary.Errors {
+		resp.Errors[i] = UploadRowError{Line: e.Line, Column: e.Column, Error: e.Err.Error()}
+	}
+	return resp
+}
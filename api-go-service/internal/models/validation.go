@@ -0,0 +1,366 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/katvio/api-go-service/internal/i18n"
+)
+
+// Rules reported in field errors besides the binding tags
+const (
+	RuleType     = "type"
+	RuleSyntax   = "syntax"
+	RuleMinItems = "min_items"
+	RuleMaxItems = "max_items"
+)
+
+// FieldError describes one invalid element of a request
+// Pointer is the JSON pointer (RFC 6901) of the element; the empty pointer is the whole body.
+// Parameter names the path, query or header parameter holding the element, if any
+type FieldError struct {
+	Pointer   string `json:"pointer"`
+	Parameter string `json:"parameter,omitempty"`
+	Rule      string `json:"rule,omitempty"`
+	Message   string `json:"message"`
+
+	text *message
+}
+
+// fieldError creates a FieldError whose message can be localized
+func fieldError(pointer, rule, format string, args ...interface{}) FieldError {
+	text := newMessage(format, args...)
+	return FieldError{Pointer: pointer, Rule: rule, Message: text.String(), text: text}
+}
+
+// FieldViolation creates a FieldError whose message is format filled with args
+// and localized like the messages of the validation rules
+func FieldViolation(pointer, rule, format string, args ...interface{}) FieldError {
+	return fieldError(pointer, rule, format, args...)
+}
+
+// Localize returns the field error with its message in locale
+// Messages built without a format are kept as they are
+func (fe FieldError) Localize(locale string) FieldError {
+	if fe.text != nil {
+		fe.Message = fe.text.Localize(locale)
+	}
+	fe.text = nil
+	return fe
+}
+
+// ValidationError is a request validation failure listing every invalid element
+type ValidationError struct {
+	Code   string
+	Errors []FieldError
+}
+
+// NewValidationError creates a ValidationError reported with code
+func NewValidationError(code string, errs ...FieldError) *ValidationError {
+	return &ValidationError{Code: code, Errors: errs}
+}
+
+// Error implements the error interface
+// The message of the first field error is kept so that single failures read as before
+func (e *ValidationError) Error() string {
+	return e.Localize(i18n.Default)
+}
+
+// Localize implements Localizer
+func (e *ValidationError) Localize(locale string) string {
+	switch len(e.Errors) {
+	case 0:
+		return i18n.Sprintf(locale, "validation failed")
+	case 1:
+		return e.Errors[0].Localize(locale).Message
+	default:
+		return i18n.Sprintf(locale, "%s (and %d more)", e.Errors[0].Localize(locale).Message, len(e.Errors)-1)
+	}
+}
+
+// ErrorCode returns the error code for the response
+func (e *ValidationError) ErrorCode() string {
+	if e.Code == "" {
+		return CodeValidation
+	}
+	return e.Code
+}
+
+// violation creates a ValidationError about the element at pointer
+func violation(pointer, rule, format string, args ...interface{}) *ValidationError {
+	return NewValidationError(CodeValidation, fieldError(pointer, rule, format, args...))
+}
+
+// Nest prefixes the JSON pointers of the field errors of err with tokens
+// It is used when a request is decoded from a value nested in another request
+func Nest(err error, tokens ...interface{}) error {
+	return Rebase(err, "", JSONPointer(tokens...))
+}
+
+// Rebase moves the field errors of err found under the JSON pointer from to the pointer to
+// It is used when a field of a request is decoded from another place, such as positional params
+func Rebase(err error, from, to string) error {
+	rebase := func(pointer string) string {
+		if pointer == from || strings.HasPrefix(pointer, from+"/") {
+			return to + pointer[len(from):]
+		}
+		return pointer
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		nested := NewValidationError(validationErr.Code)
+		for _, fe := range validationErr.Errors {
+			fe.Pointer = rebase(fe.Pointer)
+			nested.Errors = append(nested.Errors, fe)
+		}
+		return nested
+	}
+
+	var coded *CodedError
+	if errors.As(err, &coded) && coded.Pointer != "" {
+		nested := *coded
+		nested.Pointer = rebase(coded.Pointer)
+		return &nested
+	}
+	return err
+}
+
+// ErrorAt reports err as the error of the element at the JSON pointer of tokens
+// It is used for errors found outside the models, whose message is kept as it is
+func ErrorAt(err error, code string, tokens ...interface{}) *CodedError {
+	return &CodedError{Code: code, Message: err.Error(), Pointer: JSONPointer(tokens...)}
+}
+
+// FieldErrors returns the field errors described by err
+// Coded errors only have one when they name the invalid element
+func FieldErrors(err error) []FieldError {
+	return LocalizedFieldErrors(err, i18n.Default)
+}
+
+// LocalizedFieldErrors returns the field errors described by err with their messages in locale
+func LocalizedFieldErrors(err error, locale string) []FieldError {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		fieldErrs := make([]FieldError, len(validationErr.Errors))
+		for i, fe := range validationErr.Errors {
+			fieldErrs[i] = fe.Localize(locale)
+		}
+		return fieldErrs
+	}
+
+	var coded *CodedError
+	if errors.As(err, &coded) && coded.Pointer != "" {
+		return []FieldError{{Pointer: coded.Pointer, Rule: strings.ToLower(coded.Code), Message: coded.Localize(locale)}}
+	}
+	return nil
+}
+
+// JSONPointer builds the JSON pointer of an element from its object keys and array indexes
+func JSONPointer(tokens ...interface{}) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		switch t := token.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(t))
+		default:
+			b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(fmt.Sprint(t)))
+		}
+	}
+	return b.String()
+}
+
+// DecodeError converts an error from decoding body as JSON into a ValidationError
+// Type mismatches are located in the body, so that a string in an array of
+// numbers is reported as /numbers/3 rather than as the numbers field
+func DecodeError(body []byte, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+
+	switch {
+	case errors.As(err, &typeErr):
+		pointer, ok := locate(body, typeErr.Offset)
+		if !ok && typeErr.Field != "" {
+			pointer = "/" + strings.ReplaceAll(typeErr.Field, ".", "/")
+		}
+		return NewValidationError(CodeInvalidRequestBody,
+			fieldError(pointer, RuleType, "expected %s, got %s", jsonTypeName(typeErr.Type), i18n.Phrase(typeErr.Value)))
+	case errors.As(err, &syntaxErr):
+		return NewValidationError(CodeInvalidRequestBody,
+			fieldError("", RuleSyntax, "invalid JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error()))
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return NewValidationError(CodeInvalidRequestBody, fieldError("", RuleSyntax, "request body is empty or truncated"))
+	default:
+		return err
+	}
+}
+
+// BindingError converts the errors of the binding tags of v into a ValidationError
+// Field names are reported as JSON pointers built from the json tags
+func BindingError(v interface{}, err error) error {
+	return tagErrors(v, err, CodeInvalidRequestBody)
+}
+
+// tagErrors converts the errors of the struct tags of v into a ValidationError reported with code
+func tagErrors(v interface{}, err error, code string) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	t := reflect.TypeOf(v)
+	fieldErrs := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		fieldErrs = append(fieldErrs, tagError(t, namespacePointer(t, fe.StructNamespace()), fe))
+	}
+	return NewValidationError(code, fieldErrs...)
+}
+
+// bindingError describes a failed binding tag
+func bindingError(pointer string, fe validator.FieldError) FieldError {
+	name := strings.TrimPrefix(pointer, "/")
+	switch fe.Tag() {
+	case "required":
+		return fieldError(pointer, fe.Tag(), "%s is required", name)
+	case "min", "gte":
+		return fieldError(pointer, fe.Tag(), "%s must be at least %s", name, fe.Param())
+	case "max", "lte":
+		return fieldError(pointer, fe.Tag(), "%s must be at most %s", name, fe.Param())
+	case "gt":
+		return fieldError(pointer, fe.Tag(), "%s must be greater than %s", name, fe.Param())
+	case "oneof":
+		return fieldError(pointer, fe.Tag(), "%s must be one of %s", name, fe.Param())
+	default:
+		return fieldError(pointer, fe.Tag(), "%s failed the %s rule", name, fe.Tag())
+	}
+}
+
+// namespacePointer maps a validator namespace such as SumRequest.Items[2].Name
+// to the JSON pointer /items/2/name using the json tags of t
+func namespacePointer(t reflect.Type, namespace string) string {
+	parts := strings.Split(namespace, ".")
+	var tokens []interface{}
+	for _, part := range parts[1:] {
+		name, index := part, ""
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			name, index = part[:i], strings.Trim(part[i:], "[]")
+		}
+
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if field, ok := t.FieldByName(name); ok {
+			t = field.Type
+			if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+				name = tag
+			}
+		}
+		tokens = append(tokens, name)
+
+		if index != "" {
+			if i, err := strconv.Atoi(index); err == nil {
+				tokens = append(tokens, i)
+			} else {
+				tokens = append(tokens, index)
+			}
+		}
+	}
+	return JSONPointer(tokens...)
+}
+
+// jsonTypeName names a Go type the way a JSON client would
+func jsonTypeName(t reflect.Type) i18n.Phrase {
+	if t == nil {
+		return "a value"
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// jsonSpan is the extent of one value of a JSON document
+// Start is the offset after the preceding token, End the offset after the value
+type jsonSpan struct {
+	pointer    string
+	start, end int64
+}
+
+// locate finds the JSON pointer of the innermost value of body that contains offset
+func locate(body []byte, offset int64) (string, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	var spans []jsonSpan
+	if err := walkJSON(dec, nil, &spans); err != nil && len(spans) == 0 {
+		return "", false
+	}
+
+	best := -1
+	for i, span := range spans {
+		if span.start < offset && offset <= span.end && (best < 0 || span.start > spans[best].start) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+	return spans[best].pointer, true
+}
+
+// walkJSON reads one value from dec and records the spans of it and its elements
+func walkJSON(dec *json.Decoder, path []interface{}, spans *[]jsonSpan) error {
+	start := dec.InputOffset()
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token {
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := walkJSON(dec, append(path, i), spans); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if err := walkJSON(dec, append(path, fmt.Sprint(key)), spans); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	}
+
+	*spans = append(*spans, jsonSpan{pointer: JSONPointer(path...), start: start, end: dec.InputOffset()})
+	return nil
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPointer(t *testing.T) {
+	assert.Equal(t, "", JSONPointer())
+	assert.Equal(t, "/numbers/3", JSONPointer("numbers", 3))
+	assert.Equal(t, "/a~1b/c~0d", JSONPointer("a/b", "c~d"))
+}
+
+func TestDecodeError(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		target  interface{}
+		pointer string
+		rule    string
+	}{
+		{"String in an array of numbers", `{"numbers": [1, 2, 3, "four"]}`, &SumRequest{}, "/numbers/3", RuleType},
+		{"Object in an array of numbers", `{"numbers": [1, {"x": 2}]}`, &SumRequest{}, "/numbers/1", RuleType},
+		{"Nested field", `{"numbers": [1, 2], "privacy": {"epsilon": "high"}}`, &SumRequest{}, "/privacy/epsilon", RuleType},
+		{"Matrix element", `{"matrix": [[1, 2], [3, true]]}`, &MatrixRequest{}, "/matrix/1/1", RuleType},
+		{"Wrong root type", `[1, 2]`, &SumRequest{}, "", RuleType},
+		{"Syntax error", `{"numbers": [1, 2,]}`, &SumRequest{}, "", RuleSyntax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DecodeError([]byte(tt.body), json.Unmarshal([]byte(tt.body), tt.target))
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr), "got %v", err)
+			assert.Equal(t, "INVALID_REQUEST_BODY", validationErr.ErrorCode())
+			require.Len(t, validationErr.Errors, 1)
+			assert.Equal(t, tt.pointer, validationErr.Errors[0].Pointer)
+			assert.Equal(t, tt.rule, validationErr.Errors[0].Rule)
+		})
+	}
+
+	t.Run("Other errors are kept", func(t *testing.T) {
+		other := errors.New("other")
+		assert.Equal(t, other, DecodeError(nil, other))
+	})
+}
+
+func TestBindingError(t *testing.T) {
+	var request SumRequest
+	require.NoError(t, json.Unmarshal([]byte(`{}`), &request))
+
+	err := BindingError(&request, binding.Validator.ValidateStruct(&request))
+	assert.Equal(t, []FieldError{{Pointer: "/numbers", Rule: "required", Message: "numbers is required"}}, FieldErrors(err))
+}
+
+func TestFieldErrors(t *testing.T) {
+	t.Run("Validation errors", func(t *testing.T) {
+		err := (&SumRequest{Numbers: []float64{1}}).Validate()
+		assert.Equal(t, "VALIDATION_ERROR", ErrorCode(err, ""))
+		assert.Equal(t, []FieldError{{Pointer: "/numbers", Rule: RuleMinItems, Message: "at least 2 numbers are required, got 1"}}, FieldErrors(err))
+	})
+
+	t.Run("Coded errors naming an element", func(t *testing.T) {
+		err := (&VectorsRequest{Vectors: [][]float64{{1, 2}, {3}}}).Validate(100)
+		assert.Equal(t, []FieldError{{Pointer: "/vectors/1", Rule: "dimension_mismatch", Message: "vector 1 has length 1, expected 2"}}, FieldErrors(err))
+	})
+
+	t.Run("Other errors", func(t *testing.T) {
+		assert.Empty(t, FieldErrors(errors.New("other")))
+		assert.Empty(t, FieldErrors(checkElementCount(10, 5)))
+	})
+
+	t.Run("Nested requests", func(t *testing.T) {
+		err := fmt.Errorf("invalid payload: %w", Nest((&SumJobPayload{}).Validate(10), "payload"))
+		assert.Equal(t, "/payload/numbers", FieldErrors(err)[0].Pointer)
+	})
+
+	t.Run("Rebased requests", func(t *testing.T) {
+		err := Rebase(checkElementCount(10, 5, "numbers"), "/numbers", "/params")
+		assert.Equal(t, "/params", FieldErrors(err)[0].Pointer)
+
+		err = Rebase(checkElementCount(10, 5, "values"), "/numbers", "/params")
+		assert.Equal(t, "/values", FieldErrors(err)[0].Pointer)
+	})
+
+	t.Run("Error responses list the invalid elements", func(t *testing.T) {
+		err := NewValidationError("INVALID_REQUEST_BODY",
+			FieldError{Pointer: "/numbers/3", Rule: RuleType, Message: "expected a number, got string"},
+			FieldError{Pointer: "/privacy", Rule: RuleType, Message: "expected an object, got array"},
+		)
+		response := NewErrorResponse(err, "INVALID_REQUEST_BODY", "/api/v1/sum", "req")
+		assert.Equal(t, "expected a number, got string (and 1 more)", response.Error)
+		assert.Equal(t, "INVALID_REQUEST_BODY", response.Code)
+		assert.Len(t, response.Errors, 2)
+		assert.Equal(t, "expected a number, got string", response.Details["/numbers/3"])
+	})
+}
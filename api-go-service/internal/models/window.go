@@ -0,0 +1,161 @@
+package models
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/window"
+)
+
+// Error codes returned by the window endpoints
+const (
+	CodeWindowStreamNotFound = "WINDOW_STREAM_NOT_FOUND"
+	CodeWindowStreamLimit    = "WINDOW_STREAM_LIMIT_REACHED"
+	CodeInvalidStreamName    = "INVALID_STREAM_NAME"
+	CodeInvalidWindowQuery   = "INVALID_WINDOW_QUERY"
+)
+
+// streamName matches the names accepted for window streams
+var streamName = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// ValidateStreamName checks the name of a window stream
+func ValidateStreamName(name string) error {
+	if !streamName.MatchString(name) {
+		return newCodedError(CodeInvalidStreamName, "stream names are 1 to 128 letters, digits, '.', '_' or '-'")
+	}
+	return nil
+}
+
+// WindowPoint is one timestamped value
+type WindowPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     *float64  `json:"value" validate:"required,finite"`
+}
+
+// WindowAppendRequest represents the request payload for appending values to a stream
+type WindowAppendRequest struct {
+	Points []WindowPoint `json:"points" binding:"required" validate:"min_items=1,max_items=window_max_batch,dive"`
+}
+
+// Validate checks the points of the request
+// The validate tags bound the batch by the window_max_batch limit. Timestamps
+// after latest are refused so that clock errors cannot push a stream into the future
+func (r *WindowAppendRequest) Validate(latest time.Time) error {
+	if err := ValidateStruct(r); err != nil {
+		return err
+	}
+
+	var errs []FieldError
+	for i, p := range r.Points {
+		switch {
+		case p.Timestamp.IsZero():
+			errs = append(errs, fieldError(JSONPointer("points", i, "timestamp"), "required", "points[%d]: timestamp is required", i))
+		case p.Timestamp.After(latest):
+			errs = append(errs, fieldError(JSONPointer("points", i, "timestamp"), "not_future", "points[%d]: timestamp %s is in the future", i, p.Timestamp.Format(time.RFC3339Nano)))
+		}
+	}
+	if len(errs) > 0 {
+		return NewValidationError(CodeValidation, errs...)
+	}
+	return nil
+}
+
+// WindowPoints converts the request to store points
+func (r *WindowAppendRequest) WindowPoints() []window.Point {
+	points := make([]window.Point, len(r.Points))
+	for i, p := range r.Points {
+		points[i] = window.Point{Timestamp: p.Timestamp, Value: *p.Value}
+	}
+	return points
+}
+
+// WindowAppendResponse reports the outcome of an append
+// Late lists the indexes of points that arrived past the lateness bound and were not stored
+type WindowAppendResponse struct {
+	Stream    string     `json:"stream"`
+	Accepted  int        `json:"accepted"`
+	Late      []int      `json:"late"`
+	Points    int        `json:"points"`
+	Watermark *time.Time `json:"watermark,omitempty"` // newest timestamp held
+	Timestamp time.Time  `json:"timestamp"`
+	RequestID string     `json:"request_id,omitempty"`
+}
+
+// WindowResult is the aggregate of one window, covering [start, end)
+// Mean is null for windows without values
+type WindowResult struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Sum   float64   `json:"sum"`
+	Count int       `json:"count"`
+	Mean  *float64  `json:"mean"`
+}
+
+// WindowQueryResponse represents the response payload for a window query
+type WindowQueryResponse struct {
+	Stream    string         `json:"stream"`
+	Type      string         `json:"type"`
+	Size      string         `json:"size"`
+	Step      string         `json:"step,omitempty"`
+	Windows   []WindowResult `json:"windows"`
+	Timestamp time.Time      `json:"timestamp"`
+	RequestID string         `json:"request_id,omitempty"`
+}
+
+// WindowStreamsResponse lists the window streams of a consumer
+type WindowStreamsResponse struct {
+	Streams   []string  `json:"streams"`
+	Count     int       `json:"count"`
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// NewWindowAppendResponse creates a new WindowAppendResponse
+func NewWindowAppendResponse(stream string, result *window.AppendResult, requestID string) *WindowAppendResponse {
+	late := result.Late
+	if late == nil {
+		late = []int{}
+	}
+	resp := &WindowAppendResponse{
+		Stream:    stream,
+		Accepted:  result.Accepted,
+		Late:      late,
+		Points:    result.Points,
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+	if !result.Watermark.IsZero() {
+		watermark := result.Watermark.UTC()
+		resp.Watermark = &watermark
+	}
+	return resp
+}
+
+// NewWindowQueryResponse creates a new WindowQueryResponse
+func NewWindowQueryResponse(stream string, q window.Query, results []window.Result, requestID string) *WindowQueryResponse {
+	windows := make([]WindowResult, len(results))
+	for i, r := range results {
+		windows[i] = WindowResult{
+			Start: r.Start.UTC(),
+			End:   r.End.UTC(),
+			Sum:   r.Sum,
+			Count: r.Count,
+		}
+		if mean, ok := r.Mean(); ok {
+			windows[i].Mean = &mean
+		}
+	}
+
+	resp := &WindowQueryResponse{
+		Stream:    stream,
+		Type:      q.Type,
+		Size:      q.Size.String(),
+		Windows:   windows,
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+	}
+	if q.Step > 0 {
+		resp.Step = q.Step.String()
+	}
+	return resp
+}
@@ -0,0 +1,75 @@
+package modring
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Errors returned when validating ring parameters
+var (
+	ErrInvalidModulus = errors.New("modulus must be an integer greater than 1")
+	ErrInvalidDegree  = errors.New("degree must be a power of two")
+)
+
+// Modulus is a validated modulus q for arithmetic over Z_q
+type Modulus struct {
+	q *big.Int
+}
+
+// NewModulus validates q and returns a Modulus
+// maxBits bounds the bit length of q; zero disables the bound
+func NewModulus(q *big.Int, maxBits int) (*Modulus, error) {
+	if q == nil || q.Cmp(big.NewInt(2)) < 0 {
+		return nil, ErrInvalidModulus
+	}
+
+	if maxBits > 0 && q.BitLen() > maxBits {
+		return nil, fmt.Errorf("modulus is %d bits, maximum is %d bits", q.BitLen(), maxBits)
+	}
+
+	return &Modulus{q: new(big.Int).Set(q)}, nil
+}
+
+// Value returns a copy of q
+func (m *Modulus) Value() *big.Int {
+	return new(big.Int).Set(m.q)
+}
+
+// Reduce returns x mod q in the range [0, q)
+func (m *Modulus) Reduce(x *big.Int) *big.Int {
+	return new(big.Int).Mod(x, m.q)
+}
+
+// ReduceAll returns every value reduced into [0, q)
+func (m *Modulus) ReduceAll(values []*big.Int) []*big.Int {
+	result := make([]*big.Int, len(values))
+	for i, v := range values {
+		result[i] = m.Reduce(v)
+	}
+	return result
+}
+
+// Sum returns the sum of all values mod q
+func (m *Modulus) Sum(values []*big.Int) *big.Int {
+	sum := new(big.Int)
+	for _, v := range values {
+		sum.Add(sum, v)
+	}
+	return sum.Mod(sum, m.q)
+}
+
+// Product returns the product of all values mod q
+func (m *Modulus) Product(values []*big.Int) *big.Int {
+	product := big.NewInt(1)
+	for _, v := range values {
+		product.Mul(product, v)
+		product.Mod(product, m.q)
+	}
+	return product.Mod(product, m.q)
+}
+
+// IsPowerOfTwo reports whether n is a positive power of two
+func IsPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
@@ -0,0 +1,99 @@
+package modring
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ints(values ...int64) []*big.Int {
+	result := make([]*big.Int, len(values))
+	for i, v := range values {
+		result[i] = big.NewInt(v)
+	}
+	return result
+}
+
+func TestModulus(t *testing.T) {
+	_, err := NewModulus(big.NewInt(1), 0)
+	assert.ErrorIs(t, err, ErrInvalidModulus)
+
+	_, err = NewModulus(new(big.Int).Lsh(big.NewInt(1), 70), 64)
+	assert.Error(t, err)
+
+	m, err := NewModulus(big.NewInt(17), 64)
+	require.NoError(t, err)
+
+	assert.Equal(t, big.NewInt(4), m.Sum(ints(10, 11)))
+	assert.Equal(t, big.NewInt(8), m.Product(ints(5, 5)))
+	assert.Equal(t, ints(16, 0, 3), m.ReduceAll(ints(-1, 17, 20)))
+}
+
+func TestRing_Multiply(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       int64
+		n       int
+		wantNTT bool
+	}{
+		{name: "NTT-friendly prime", q: 7681, n: 256, wantNTT: true},
+		{name: "Large NTT-friendly prime", q: 998244353, n: 1024, wantNTT: true},
+		{name: "Prime without 2n-th roots", q: 7681, n: 1024, wantNTT: false},
+		{name: "Composite modulus", q: 1 << 16, n: 64, wantNTT: false},
+	}
+
+	rng := rand.New(rand.NewSource(42))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modulus, err := NewModulus(big.NewInt(tt.q), 64)
+			require.NoError(t, err)
+
+			ring, err := NewRing(modulus, tt.n, 4096, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNTT, ring.NTTEnabled())
+
+			a := make([]*big.Int, tt.n)
+			b := make([]*big.Int, tt.n)
+			for i := range a {
+				a[i] = big.NewInt(rng.Int63n(tt.q))
+				b[i] = big.NewInt(rng.Int63n(tt.q))
+			}
+
+			assert.Equal(t, ring.schoolbook(a, b), ring.Multiply(a, b))
+		})
+	}
+}
+
+func TestRing_NegacyclicWraparound(t *testing.T) {
+	modulus, _ := NewModulus(big.NewInt(17), 0)
+	ring, err := NewRing(modulus, 4, 0, 0)
+	require.NoError(t, err)
+
+	// X^3 · X = X^4 ≡ -1
+	product := ring.Multiply(ints(0, 0, 0, 1), ints(0, 1, 0, 0))
+	assert.Equal(t, ints(16, 0, 0, 0), product)
+
+	// 1 + 2X^4 + 3X^5 ≡ (1 - 2) - 3X
+	assert.Equal(t, ints(16, 14, 0, 0), ring.Reduce(ints(1, 0, 0, 0, 2, 3)))
+
+	assert.Equal(t, ints(2, 4, 6, 8), ring.Add([][]*big.Int{ints(1, 2, 3, 4), ints(1, 2, 3, 4)}))
+}
+
+func TestNewRing_InvalidDegree(t *testing.T) {
+	modulus, _ := NewModulus(big.NewInt(17), 0)
+
+	_, err := NewRing(modulus, 6, 0, 0)
+	assert.ErrorIs(t, err, ErrInvalidDegree)
+
+	_, err = NewRing(modulus, 8192, 4096, 0)
+	assert.Error(t, err)
+
+	// 17 is 5 bits wide, so degree 16 needs a ring size of 80 bits
+	_, err = NewRing(modulus, 16, 0, 80)
+	assert.NoError(t, err)
+	_, err = NewRing(modulus, 32, 0, 80)
+	assert.Error(t, err)
+}
@@ -0,0 +1,171 @@
+package modring
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// maxRootCandidates bounds the search for a primitive 2n-th root of unity
+const maxRootCandidates = 1 << 16
+
+// nttContext holds the precomputed values for negacyclic NTT multiplication
+// in Z_q[X]/(X^n+1), where q is a prime below 2^63 with q ≡ 1 (mod 2n)
+type nttContext struct {
+	q        uint64
+	n        int
+	omega    uint64   // primitive n-th root of unity (ψ²)
+	omegaInv uint64   // ω⁻¹
+	psi      []uint64 // ψ^i
+	psiInv   []uint64 // ψ^-i · n⁻¹, folding the final scaling into the untwist
+}
+
+// newNTTContext returns the NTT tables for q and n, or nil if q is not NTT-friendly
+func newNTTContext(q *big.Int, n int) *nttContext {
+	if q.BitLen() > 62 || !q.ProbablyPrime(20) {
+		return nil
+	}
+
+	qu := q.Uint64()
+	twoN := uint64(2 * n)
+	if (qu-1)%twoN != 0 {
+		return nil
+	}
+
+	psi, ok := findPrimitiveRoot(qu, twoN)
+	if !ok {
+		return nil
+	}
+
+	ctx := &nttContext{
+		q:      qu,
+		n:      n,
+		psi:    make([]uint64, n),
+		psiInv: make([]uint64, n),
+	}
+	ctx.omega = ctx.mul(psi, psi)
+	ctx.omegaInv = ctx.inverse(ctx.omega)
+
+	psiInv := ctx.inverse(psi)
+	nInv := ctx.inverse(uint64(n) % qu)
+	ctx.psi[0], ctx.psiInv[0] = 1, nInv
+	for i := 1; i < n; i++ {
+		ctx.psi[i] = ctx.mul(ctx.psi[i-1], psi)
+		ctx.psiInv[i] = ctx.mul(ctx.psiInv[i-1], psiInv)
+	}
+
+	return ctx
+}
+
+// findPrimitiveRoot finds ψ with ψ^order = 1 and ψ^(order/2) = -1 (mod q)
+// For a power-of-two order this makes ψ a primitive order-th root of unity
+func findPrimitiveRoot(q, order uint64) (uint64, bool) {
+	exponent := (q - 1) / order
+	for g := uint64(2); g < q && g < maxRootCandidates; g++ {
+		psi := powMod(g, exponent, q)
+		if powMod(psi, order/2, q) == q-1 {
+			return psi, true
+		}
+	}
+	return 0, false
+}
+
+// multiply returns the negacyclic product of two reduced polynomials
+func (t *nttContext) multiply(a, b []*big.Int) []*big.Int {
+	fa := make([]uint64, t.n)
+	fb := make([]uint64, t.n)
+	for i := 0; i < t.n; i++ {
+		fa[i] = t.mul(a[i].Uint64(), t.psi[i])
+		fb[i] = t.mul(b[i].Uint64(), t.psi[i])
+	}
+
+	t.transform(fa, t.omega)
+	t.transform(fb, t.omega)
+	for i := range fa {
+		fa[i] = t.mul(fa[i], fb[i])
+	}
+	t.transform(fa, t.omegaInv)
+
+	result := make([]*big.Int, t.n)
+	for i := range fa {
+		result[i] = new(big.Int).SetUint64(t.mul(fa[i], t.psiInv[i]))
+	}
+
+	return result
+}
+
+// transform performs an in-place iterative Cooley-Tukey NTT using the given root
+func (t *nttContext) transform(a []uint64, root uint64) {
+	n := len(a)
+
+	// Bit-reversal permutation
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+
+	for length := 2; length <= n; length <<= 1 {
+		step := powMod(root, uint64(n/length), t.q)
+		half := length / 2
+		for start := 0; start < n; start += length {
+			w := uint64(1)
+			for j := 0; j < half; j++ {
+				u := a[start+j]
+				v := t.mul(a[start+j+half], w)
+				a[start+j] = t.add(u, v)
+				a[start+j+half] = t.sub(u, v)
+				w = t.mul(w, step)
+			}
+		}
+	}
+}
+
+func (t *nttContext) add(a, b uint64) uint64 {
+	sum := a + b
+	if sum >= t.q {
+		sum -= t.q
+	}
+	return sum
+}
+
+func (t *nttContext) sub(a, b uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return a + t.q - b
+}
+
+func (t *nttContext) mul(a, b uint64) uint64 {
+	return mulMod(a, b, t.q)
+}
+
+// inverse returns a⁻¹ mod q using Fermat's little theorem (q is prime)
+func (t *nttContext) inverse(a uint64) uint64 {
+	return powMod(a, t.q-2, t.q)
+}
+
+// mulMod returns a·b mod q for a, b < q
+func mulMod(a, b, q uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi, lo, q)
+	return rem
+}
+
+// powMod returns base^exp mod q
+func powMod(base, exp, q uint64) uint64 {
+	result := uint64(1) % q
+	base %= q
+	for exp > 0 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, q)
+		}
+		base = mulMod(base, base, q)
+		exp >>= 1
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package modring
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Ring is the polynomial ring Z_q[X]/(X^n+1)
+// Polynomials are represented by their n coefficients, lowest degree first
+type Ring struct {
+	modulus *Modulus
+	n       int
+	ntt     *nttContext // nil when q is not NTT-friendly for n
+}
+
+// NewRing creates the ring Z_q[X]/(X^n+1)
+// n must be a power of two not larger than maxDegree, and n times the bit length
+// of q not larger than maxSize, which bounds the cost of schoolbook products
+// Zero disables either bound
+func NewRing(modulus *Modulus, n, maxDegree, maxSize int) (*Ring, error) {
+	if !IsPowerOfTwo(n) {
+		return nil, ErrInvalidDegree
+	}
+
+	if maxDegree > 0 && n > maxDegree {
+		return nil, fmt.Errorf("degree %d exceeds maximum of %d", n, maxDegree)
+	}
+
+	if bits := modulus.q.BitLen(); maxSize > 0 && n*bits > maxSize {
+		return nil, fmt.Errorf("degree %d over a %d-bit modulus exceeds the maximum ring size of %d bits", n, bits, maxSize)
+	}
+
+	return &Ring{
+		modulus: modulus,
+		n:       n,
+		ntt:     newNTTContext(modulus.q, n),
+	}, nil
+}
+
+// Degree returns n
+func (r *Ring) Degree() int {
+	return r.n
+}
+
+// NTTEnabled reports whether products are computed with the number theoretic transform
+func (r *Ring) NTTEnabled() bool {
+	return r.ntt != nil
+}
+
+// Add returns the sum of polynomials that each have exactly n coefficients
+func (r *Ring) Add(polys [][]*big.Int) []*big.Int {
+	sum := make([]*big.Int, r.n)
+	for i := range sum {
+		sum[i] = new(big.Int)
+	}
+
+	for _, p := range polys {
+		for i, coeff := range p {
+			sum[i].Add(sum[i], coeff)
+		}
+	}
+
+	return r.modulus.ReduceAll(sum)
+}
+
+// Multiply returns the product of two polynomials that each have exactly n coefficients
+// It uses the negacyclic NTT when available and schoolbook multiplication otherwise
+func (r *Ring) Multiply(a, b []*big.Int) []*big.Int {
+	a = r.modulus.ReduceAll(a)
+	b = r.modulus.ReduceAll(b)
+
+	if r.ntt != nil {
+		return r.ntt.multiply(a, b)
+	}
+
+	return r.schoolbook(a, b)
+}
+
+// Reduce reduces a polynomial of any length modulo X^n+1 and q
+// Since X^n ≡ -1, the coefficient of X^(kn+i) is added to position i with sign (-1)^k
+func (r *Ring) Reduce(p []*big.Int) []*big.Int {
+	result := make([]*big.Int, r.n)
+	for i := range result {
+		result[i] = new(big.Int)
+	}
+
+	for i, coeff := range p {
+		if (i/r.n)%2 == 0 {
+			result[i%r.n].Add(result[i%r.n], coeff)
+		} else {
+			result[i%r.n].Sub(result[i%r.n], coeff)
+		}
+	}
+
+	return r.modulus.ReduceAll(result)
+}
+
+// schoolbook computes the negacyclic product of a and b in O(n²)
+func (r *Ring) schoolbook(a, b []*big.Int) []*big.Int {
+	result := make([]*big.Int, r.n)
+	for i := range result {
+		result[i] = new(big.Int)
+	}
+
+	term := new(big.Int)
+	for i := 0; i < r.n; i++ {
+		if a[i].Sign() == 0 {
+			continue
+		}
+		for j := 0; j < r.n; j++ {
+			term.Mul(a[i], b[j])
+			if k := i + j; k < r.n {
+				result[k].Add(result[k], term)
+			} else {
+				result[k-r.n].Sub(result[k-r.n], term)
+			}
+		}
+	}
+
+	return r.modulus.ReduceAll(result)
+}
@@ -0,0 +1,303 @@
+package negotiation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+)
+
+// Media types of the supported formats
+const (
+	MediaTypeJSON     = "application/json"
+	MediaTypeCBOR     = "application/cbor"
+	MediaTypeMsgPack  = "application/msgpack"
+	MediaTypeProtobuf = "application/x-protobuf"
+	MediaTypeCSV      = "text/csv"
+	MediaTypeProblem  = "application/problem+json"
+)
+
+var (
+	// ErrUnsupportedMediaType is returned when a request body is in an unknown format
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+	// ErrNotAcceptable is returned when none of the accepted formats is supported
+	ErrNotAcceptable = errors.New("none of the accepted media types is supported")
+
+	// ErrUnsupportedMessage is returned when a value has no representation in a format
+	ErrUnsupportedMessage = errors.New("message cannot be represented in this format")
+)
+
+// ProtoMarshaler is implemented by messages with a Protobuf wire representation
+type ProtoMarshaler interface {
+	MarshalProto() ([]byte, error)
+}
+
+// ProtoUnmarshaler is implemented by messages that can be decoded from Protobuf
+type ProtoUnmarshaler interface {
+	UnmarshalProto(data []byte) error
+}
+
+// CSVMarshaler is implemented by messages with a CSV representation
+type CSVMarshaler interface {
+	MarshalCSV() ([][]string, error)
+}
+
+// CSVUnmarshaler is implemented by messages that can be decoded from CSV records
+type CSVUnmarshaler interface {
+	UnmarshalCSV(records [][]string) error
+}
+
+// Format encodes and decodes message bodies in one media type
+type Format struct {
+	// MediaType is the canonical media type, used in Content-Type
+	MediaType string
+	// Aliases are other media types accepted for the format
+	Aliases []string
+
+	contentType string
+	decode      func(data []byte, v interface{}) error
+	encode      func(v interface{}) ([]byte, error)
+}
+
+var (
+	cborHandle    = &codec.CborHandle{}
+	msgpackHandle = &codec.MsgpackHandle{WriteExt: true}
+)
+
+// JSON is the default format
+// Clients asking for problem details get JSON bodies on success
+var JSON = &Format{
+	MediaType:   MediaTypeJSON,
+	Aliases:     []string{MediaTypeProblem},
+	contentType: "application/json; charset=utf-8",
+	decode:      json.Unmarshal,
+	encode:      json.Marshal,
+}
+
+// Formats lists the supported formats in order of preference
+var Formats = []*Format{
+	JSON,
+	{
+		MediaType:   MediaTypeCBOR,
+		contentType: MediaTypeCBOR,
+		decode:      ugorjiDecoder(cborHandle),
+		encode:      ugorjiEncoder(cborHandle),
+	},
+	{
+		MediaType:   MediaTypeMsgPack,
+		Aliases:     []string{"application/x-msgpack", "application/vnd.msgpack"},
+		contentType: MediaTypeMsgPack,
+		decode:      ugorjiDecoder(msgpackHandle),
+		encode:      ugorjiEncoder(msgpackHandle),
+	},
+	{
+		MediaType:   MediaTypeProtobuf,
+		Aliases:     []string{"application/protobuf", "application/vnd.google.protobuf"},
+		contentType: MediaTypeProtobuf,
+		decode:      decodeProto,
+		encode:      encodeProto,
+	},
+	{
+		MediaType:   MediaTypeCSV,
+		contentType: "text/csv; charset=utf-8",
+		decode:      decodeCSV,
+		encode:      encodeCSV,
+	},
+}
+
+// ContentType returns the Content-Type header of responses in this format
+func (f *Format) ContentType() string {
+	return f.contentType
+}
+
+// Decode decodes a request body into v
+func (f *Format) Decode(data []byte, v interface{}) error {
+	return f.decode(data, v)
+}
+
+// Encode encodes v as a response body
+func (f *Format) Encode(v interface{}) ([]byte, error) {
+	return f.encode(v)
+}
+
+// matches reports whether the format is known under the media type
+func (f *Format) matches(mediaType string) bool {
+	if mediaType == f.MediaType {
+		return true
+	}
+	for _, alias := range f.Aliases {
+		if mediaType == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestFormat selects the format of a request body from its Content-Type
+// Bodies without a Content-Type are decoded as JSON
+func RequestFormat(contentType string) (*Format, error) {
+	if strings.TrimSpace(contentType) == "" {
+		return JSON, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, contentType)
+	}
+	for _, format := range Formats {
+		if format.matches(mediaType) {
+			return format, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType)
+}
+
+// ResponseFormat selects the response format from an Accept header
+// The highest-quality supported media range wins; ties keep the client's order.
+// A missing header selects JSON.
+func ResponseFormat(accept string) (*Format, error) {
+	if strings.TrimSpace(accept) == "" {
+		return JSON, nil
+	}
+
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	var ranges []mediaRange
+	excluded := make(map[*Format]bool)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality <= 0 {
+			// Refusing problem details does not refuse JSON
+			for _, format := range Formats {
+				if format.matches(mediaType) && mediaType != MediaTypeProblem {
+					excluded[format] = true
+				}
+			}
+			continue
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	for _, r := range ranges {
+		for _, format := range Formats {
+			if excluded[format] {
+				continue
+			}
+			if r.mediaType == "*/*" || format.matches(r.mediaType) || strings.HasSuffix(r.mediaType, "/*") && strings.HasPrefix(format.MediaType, strings.TrimSuffix(r.mediaType, "*")) {
+				return format, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotAcceptable, accept)
+}
+
+// AcceptsProblem reports whether an Accept header asks for RFC 7807 problem details
+func AcceptsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != MediaTypeProblem {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if quality, err := strconv.ParseFloat(q, 64); err != nil || quality <= 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// ugorjiDecoder returns a decoder for a ugorji codec handle
+func ugorjiDecoder(handle codec.Handle) func([]byte, interface{}) error {
+	return func(data []byte, v interface{}) error {
+		return codec.NewDecoderBytes(data, handle).Decode(v)
+	}
+}
+
+// ugorjiEncoder returns an encoder for a ugorji codec handle
+func ugorjiEncoder(handle codec.Handle) func(interface{}) ([]byte, error) {
+	return func(v interface{}) ([]byte, error) {
+		var out []byte
+		err := codec.NewEncoderBytes(&out, handle).Encode(v)
+		return out, err
+	}
+}
+
+// decodeProto decodes messages that implement ProtoUnmarshaler
+func decodeProto(data []byte, v interface{}) error {
+	message, ok := v.(ProtoUnmarshaler)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnsupportedMessage, v)
+	}
+	return message.UnmarshalProto(data)
+}
+
+// encodeProto encodes messages that implement ProtoMarshaler
+func encodeProto(v interface{}) ([]byte, error) {
+	message, ok := v.(ProtoMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedMessage, v)
+	}
+	return message.MarshalProto()
+}
+
+// decodeCSV parses the body and hands the records to a CSVUnmarshaler
+// Rows may have different numbers of fields
+func decodeCSV(data []byte, v interface{}) error {
+	message, ok := v.(CSVUnmarshaler)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnsupportedMessage, v)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	return message.UnmarshalCSV(records)
+}
+
+// encodeCSV writes the records of a CSVMarshaler
+func encodeCSV(v interface{}) ([]byte, error) {
+	message, ok := v.(CSVMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedMessage, v)
+	}
+
+	records, err := message.MarshalCSV()
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	writer := csv.NewWriter(&out)
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
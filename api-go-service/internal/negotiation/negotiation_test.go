@@ -0,0 +1,103 @@
+package negotiation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestFormat(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        string
+		wantError   bool
+	}{
+		{"", MediaTypeJSON, false},
+		{"application/json; charset=utf-8", MediaTypeJSON, false},
+		{"application/cbor", MediaTypeCBOR, false},
+		{"application/x-msgpack", MediaTypeMsgPack, false},
+		{"application/protobuf", MediaTypeProtobuf, false},
+		{"text/csv; header=present", MediaTypeCSV, false},
+		{"application/xml", "", true},
+		{"not a media type", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			format, err := RequestFormat(tt.contentType)
+			if tt.wantError {
+				assert.True(t, errors.Is(err, ErrUnsupportedMediaType))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, format.MediaType)
+		})
+	}
+}
+
+func TestResponseFormat(t *testing.T) {
+	tests := []struct {
+		accept    string
+		want      string
+		wantError bool
+	}{
+		{"", MediaTypeJSON, false},
+		{"*/*", MediaTypeJSON, false},
+		{"application/cbor", MediaTypeCBOR, false},
+		{"text/html, text/*", MediaTypeCSV, false},
+		{"application/json;q=0.5, application/msgpack", MediaTypeMsgPack, false},
+		{"application/xml, application/x-protobuf;q=0.1", MediaTypeProtobuf, false},
+		{"application/json;q=0, application/*", MediaTypeCBOR, false},
+		{"application/problem+json", MediaTypeJSON, false},
+		{"application/json, application/problem+json;q=0", MediaTypeJSON, false},
+		{"application/xml", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			format, err := ResponseFormat(tt.accept)
+			if tt.wantError {
+				assert.True(t, errors.Is(err, ErrNotAcceptable))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, format.MediaType)
+		})
+	}
+}
+
+func TestAcceptsProblem(t *testing.T) {
+	assert.True(t, AcceptsProblem("application/problem+json"))
+	assert.True(t, AcceptsProblem("application/json;q=0.9, application/problem+json;q=0.5"))
+	assert.False(t, AcceptsProblem(""))
+	assert.False(t, AcceptsProblem("*/*"))
+	assert.False(t, AcceptsProblem("application/json, application/problem+json;q=0"))
+}
+
+// plain has no Protobuf or CSV representation
+type plain struct {
+	Name string `json:"name"`
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	for _, format := range Formats[:3] {
+		t.Run(format.MediaType, func(t *testing.T) {
+			body, err := format.Encode(&plain{Name: "x"})
+			require.NoError(t, err)
+
+			var decoded plain
+			require.NoError(t, format.Decode(body, &decoded))
+			assert.Equal(t, "x", decoded.Name)
+		})
+	}
+
+	for _, format := range Formats[3:] {
+		t.Run(format.MediaType+" unsupported", func(t *testing.T) {
+			_, err := format.Encode(&plain{})
+			assert.True(t, errors.Is(err, ErrUnsupportedMessage))
+			assert.True(t, errors.Is(format.Decode([]byte("x"), &plain{}), ErrUnsupportedMessage))
+		})
+	}
+}
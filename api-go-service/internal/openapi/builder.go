@@ -0,0 +1,250 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Media types of the bodies described by generated documents
+const (
+	MediaTypeJSON    = "application/json"
+	MediaTypeProblem = "application/problem+json"
+)
+
+// Route describes the operation served by a route
+// Request and Response are values of the body models; Query is a value of a
+// struct whose form tags name query parameters
+type Route struct {
+	Summary     string
+	Description string
+	Tag         string
+	Request     interface{}
+	Response    interface{}
+	Status      int // status of successful responses, 200 by default
+	Query       interface{}
+	Parameters  []*Parameter
+	Public      bool // served without credentials
+}
+
+// Builder generates an OpenAPI document from the routes of a gin engine and
+// the descriptions of their operations
+// Routes without a description are still listed with their path parameters
+// and error responses, so that the document covers every registered route
+type Builder struct {
+	info      Info
+	routes    map[string]Route
+	overrides map[reflect.Type]*Schema
+	schemes   map[string]*SecurityScheme
+	tags      []Tag
+	errorBody interface{}
+	problem   interface{}
+	limit     func(string) (float64, bool)
+}
+
+// NewBuilder creates a Builder for the API described by info
+func NewBuilder(info Info) *Builder {
+	return &Builder{
+		info:      info,
+		routes:    make(map[string]Route),
+		overrides: make(map[reflect.Type]*Schema),
+		schemes:   make(map[string]*SecurityScheme),
+	}
+}
+
+// Describe sets the description of the operation of method on path, written as gin writes it
+func (b *Builder) Describe(method, path string, route Route) {
+	b.routes[method+" "+path] = route
+}
+
+// DefineSchema sets the schema of the type of v, for types with a custom JSON encoding
+func (b *Builder) DefineSchema(v interface{}, schema *Schema) {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	b.overrides[t] = schema
+}
+
+// SetErrorModels sets the bodies of error responses in JSON and as RFC 7807 problem details
+func (b *Builder) SetErrorModels(errorBody, problem interface{}) {
+	b.errorBody = errorBody
+	b.problem = problem
+}
+
+// SetLimits resolves the named limits of validate tags into schema bounds
+func (b *Builder) SetLimits(limit func(param string) (float64, bool)) {
+	b.limit = limit
+}
+
+// AddSecurityScheme adds a way for clients to authenticate
+// Operations that are not public accept any of the schemes
+func (b *Builder) AddSecurityScheme(name string, scheme *SecurityScheme) {
+	b.schemes[name] = scheme
+}
+
+// AddTag describes a group of operations
+func (b *Builder) AddTag(name, description string) {
+	b.tags = append(b.tags, Tag{Name: name, Description: description})
+}
+
+// Build generates the document of routes
+func (b *Builder) Build(routes gin.RoutesInfo) *Document {
+	gen := newGenerator(b.overrides, b.limit)
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    b.info,
+		Paths:   make(map[string]PathItem),
+		Tags:    b.tags,
+	}
+
+	names := make([]string, 0, len(b.schemes))
+	for name := range b.schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		doc.Security = append(doc.Security, SecurityRequirement{name: {}})
+	}
+
+	for _, info := range routes {
+		path, params := convertPath(info.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(info.Method)] = b.operation(gen, info, params)
+	}
+
+	doc.Components.Schemas = gen.schemas
+	if len(b.schemes) > 0 {
+		doc.Components.SecuritySchemes = b.schemes
+	}
+	return doc
+}
+
+// operation generates the operation of one route
+func (b *Builder) operation(gen *generator, info gin.RouteInfo, params []*Parameter) *Operation {
+	route := b.routes[info.Method+" "+info.Path]
+	op := &Operation{
+		Summary:     route.Summary,
+		Description: route.Description,
+		OperationID: operationID(info.Method, info.Path),
+		Parameters:  params,
+		Responses:   make(map[string]*Response),
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+	if route.Public {
+		op.Security = &[]SecurityRequirement{}
+	}
+
+	if route.Query != nil {
+		op.Parameters = append(op.Parameters, queryParameters(gen, reflect.TypeOf(route.Query))...)
+	}
+	op.Parameters = append(op.Parameters, route.Parameters...)
+
+	if route.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{MediaTypeJSON: {Schema: gen.schemaOf(reflect.TypeOf(route.Request), false)}},
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status)}
+	if route.Response != nil {
+		success.Content = map[string]MediaType{MediaTypeJSON: {Schema: gen.schemaOf(reflect.TypeOf(route.Response), true)}}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+
+	if b.errorBody != nil {
+		failure := &Response{
+			Description: "Error",
+			Content:     map[string]MediaType{MediaTypeJSON: {Schema: gen.schemaOf(reflect.TypeOf(b.errorBody), true)}},
+		}
+		if b.problem != nil {
+			failure.Content[MediaTypeProblem] = MediaType{Schema: gen.schemaOf(reflect.TypeOf(b.problem), true)}
+		}
+		op.Responses["default"] = failure
+	}
+	return op
+}
+
+// PathOf returns the OpenAPI form of a path written as gin writes it, such as /keys/{id} for /keys/:id
+func PathOf(ginPath string) string {
+	path, _ := convertPath(ginPath)
+	return path
+}
+
+// convertPath rewrites a gin path such as /keys/:id into /keys/{id} and lists its parameters
+func convertPath(path string) (string, []*Parameter) {
+	segments := strings.Split(path, "/")
+	var params []*Parameter
+	for i, segment := range segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			name := segment[1:]
+			segments[i] = "{" + name + "}"
+			params = append(params, &Parameter{Name: name, In: InPath, Required: true, Schema: &Schema{Type: Types{"string"}}})
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// queryParameters lists the query parameters named by the form tags of t
+func queryParameters(gen *generator, t reflect.Type) []*Parameter {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var params []*Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		schema := gen.schemaOf(field.Type, false)
+		gen.applyTags(schema, field)
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       InQuery,
+			Required: hasRule(field.Tag.Get("binding"), "required"),
+			Schema:   schema,
+		})
+	}
+	return params
+}
+
+// operationID derives an identifier such as postApiV1LinalgVectorAdd from a route
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	upper := true
+	for _, r := range path {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	if strings.Trim(path, "/") == "" {
+		b.WriteString("Root")
+	}
+	return b.String()
+}
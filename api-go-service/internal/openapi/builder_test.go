@@ -0,0 +1,163 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type testRequest struct {
+	Numbers []float64   `json:"numbers" binding:"required" validate:"min_items=min_numbers,max_items=100,dive,range=-1:1"`
+	Mode    string      `json:"mode,omitempty" binding:"omitempty,oneof=fast exact"`
+	Nested  *testNested `json:"nested,omitempty"`
+}
+
+type testNested struct {
+	Key testKey `json:"key"`
+}
+
+type testKey struct{}
+
+type testResponse struct {
+	Sum       float64           `json:"sum"`
+	Values    []float64         `json:"values"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	RequestID string            `json:"request_id,omitempty"`
+}
+
+type testError struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+type testQuery struct {
+	Top   int    `form:"top"`
+	Order string `form:"order" binding:"required"`
+}
+
+func buildTestDocument() *Document {
+	b := NewBuilder(Info{Title: "test", Version: "1.0.0"})
+	b.SetErrorModels(testError{}, nil)
+	b.SetLimits(func(param string) (float64, bool) {
+		switch param {
+		case "min_numbers":
+			return 2, true
+		case "100":
+			return 100, true
+		case "-1":
+			return -1, true
+		case "1":
+			return 1, true
+		}
+		return 0, false
+	})
+	b.DefineSchema(testKey{}, &Schema{Type: Types{"string"}, Pattern: "^[0-9]+$"})
+	b.AddSecurityScheme("apiKey", &SecurityScheme{Type: "apiKey", In: InHeader, Name: "X-API-Key"})
+	b.AddSecurityScheme("bearerAuth", &SecurityScheme{Type: "http", Scheme: "bearer"})
+
+	b.Describe(http.MethodPost, "/api/sum", Route{Summary: "Sum", Request: testRequest{}, Response: testResponse{}})
+	b.Describe(http.MethodGet, "/health", Route{Summary: "Health", Public: true})
+	b.Describe(http.MethodGet, "/api/keys/:id", Route{Query: testQuery{}, Status: http.StatusAccepted})
+
+	return b.Build(gin.RoutesInfo{
+		{Method: http.MethodPost, Path: "/api/sum"},
+		{Method: http.MethodGet, Path: "/health"},
+		{Method: http.MethodGet, Path: "/api/keys/:id"},
+		{Method: http.MethodDelete, Path: "/api/keys/:id"},
+	})
+}
+
+func TestBuild(t *testing.T) {
+	doc := buildTestDocument()
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Equal(t, []SecurityRequirement{{"apiKey": {}}, {"bearerAuth": {}}}, doc.Security)
+	assert.Len(t, doc.Components.SecuritySchemes, 2)
+
+	t.Run("Request schemas", func(t *testing.T) {
+		op, ok := doc.Operation(http.MethodPost, "/api/sum")
+		require.True(t, ok)
+		assert.Equal(t, "postApiSum", op.OperationID)
+		assert.Nil(t, op.Security)
+		assert.Equal(t, Ref("testRequest"), op.RequestBody.Content[MediaTypeJSON].Schema)
+
+		request := doc.Components.Schemas["testRequest"]
+		require.NotNil(t, request)
+		assert.Equal(t, []string{"numbers"}, request.Required)
+
+		numbers := request.Properties["numbers"]
+		assert.Equal(t, Types{"array"}, numbers.Type)
+		assert.Equal(t, 2, *numbers.MinItems)
+		assert.Equal(t, 100, *numbers.MaxItems)
+		assert.Equal(t, -1.0, *numbers.Items.Minimum)
+		assert.Equal(t, 1.0, *numbers.Items.Maximum)
+		assert.Equal(t, []interface{}{"fast", "exact"}, request.Properties["mode"].Enum)
+		assert.Equal(t, Ref("testNested"), request.Properties["nested"])
+		assert.Equal(t, "^[0-9]+$", doc.Components.Schemas["testNested"].Properties["key"].Pattern)
+	})
+
+	t.Run("Response schemas", func(t *testing.T) {
+		op, _ := doc.Operation(http.MethodPost, "/api/sum")
+		assert.Equal(t, Ref("testResponse"), op.Responses["200"].Content[MediaTypeJSON].Schema)
+		assert.Equal(t, Ref("testError"), op.Responses["default"].Content[MediaTypeJSON].Schema)
+
+		response := doc.Components.Schemas["testResponse"]
+		assert.Equal(t, []string{"sum", "values", "timestamp"}, response.Required)
+		assert.Equal(t, Types{"array", "null"}, response.Properties["values"].Type)
+		assert.Equal(t, "date-time", response.Properties["timestamp"].Format)
+
+		assert.Len(t, doc.Referenced(op), 4)
+	})
+
+	t.Run("Parameters and statuses", func(t *testing.T) {
+		op, ok := doc.Operation(http.MethodGet, "/api/keys/{id}")
+		require.True(t, ok)
+		require.Len(t, op.Parameters, 3)
+		assert.Equal(t, &Parameter{Name: "id", In: InPath, Required: true, Schema: &Schema{Type: Types{"string"}}}, op.Parameters[0])
+		assert.Equal(t, "top", op.Parameters[1].Name)
+		assert.Equal(t, Types{"integer"}, op.Parameters[1].Schema.Type)
+		assert.True(t, op.Parameters[2].Required)
+		assert.Contains(t, op.Responses, "202")
+
+		// Routes without a description are listed too
+		op, ok = doc.Operation(http.MethodDelete, "/api/keys/{id}")
+		require.True(t, ok)
+		assert.Contains(t, op.Responses, "200")
+		assert.Contains(t, op.Responses, "default")
+	})
+
+	t.Run("Public operations", func(t *testing.T) {
+		op, _ := doc.Operation(http.MethodGet, "/health")
+		require.NotNil(t, op.Security)
+		assert.Empty(t, *op.Security)
+
+		body, err := json.Marshal(op)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"security":[]`)
+	})
+}
+
+func TestEncoding(t *testing.T) {
+	doc := buildTestDocument()
+
+	jsonBody, err := doc.JSON()
+	require.NoError(t, err)
+	yamlBody, err := doc.YAML()
+	require.NoError(t, err)
+
+	var fromJSON, fromYAML map[string]interface{}
+	require.NoError(t, json.Unmarshal(jsonBody, &fromJSON))
+	require.NoError(t, yaml.Unmarshal(yamlBody, &fromYAML))
+	assert.Equal(t, "3.1.0", fromYAML["openapi"])
+
+	// Response codes stay strings in YAML
+	assert.Contains(t, string(yamlBody), `"200":`)
+	paths := fromYAML["paths"].(map[string]interface{})
+	assert.Len(t, paths, len(fromJSON["paths"].(map[string]interface{})))
+}
@@ -0,0 +1,15 @@
+package openapi
+
+import (
+	_ "embed"
+)
+
+// docsPage is the interactive documentation page, which renders /openapi.json
+//
+//go:embed docs.html
+var docsPage []byte
+
+// DocsPage returns the HTML of the interactive documentation page
+func DocsPage() []byte {
+	return docsPage
+}
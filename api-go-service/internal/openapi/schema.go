@@ -0,0 +1,242 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// generator builds schemas from Go types, registering named structs as component schemas
+// Request properties are required when their binding tag says so; response
+// properties are required unless they are omitempty, since they are always written
+type generator struct {
+	schemas   map[string]*Schema
+	names     map[reflect.Type]string
+	overrides map[reflect.Type]*Schema
+	limit     func(param string) (float64, bool)
+}
+
+// newGenerator creates a generator resolving named limits of validate tags with limit
+func newGenerator(overrides map[reflect.Type]*Schema, limit func(string) (float64, bool)) *generator {
+	if limit == nil {
+		limit = func(string) (float64, bool) { return 0, false }
+	}
+	return &generator{
+		schemas:   make(map[string]*Schema),
+		names:     make(map[reflect.Type]string),
+		overrides: overrides,
+		limit:     limit,
+	}
+}
+
+// schemaOf returns the schema of values of t
+func (g *generator) schemaOf(t reflect.Type, response bool) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if override, ok := g.overrides[t]; ok {
+		copied := *override
+		return &copied
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		// Custom encodings are unknown unless an override describes them
+		return &Schema{}
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return &Schema{Type: Types{"string"}}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}, Minimum: float(0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}, Format: "byte"}
+		}
+		return &Schema{Type: Types{"array"}, Items: g.schemaOf(t.Elem(), response)}
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: g.schemaOf(t.Elem(), response)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t, response)
+		}
+		return g.component(t, response)
+	default:
+		return &Schema{}
+	}
+}
+
+// component registers the schema of a named struct and returns a reference to it
+// A type keeps the schema of its first use, as a request or a response
+func (g *generator) component(t reflect.Type, response bool) *Schema {
+	if name, ok := g.names[t]; ok {
+		return Ref(name)
+	}
+
+	name := t.Name()
+	for i := 2; g.schemas[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", t.Name(), i)
+	}
+	g.names[t] = name
+	g.schemas[name] = &Schema{} // placeholder for recursive types
+	*g.schemas[name] = *g.structSchema(t, response)
+	return Ref(name)
+}
+
+// structSchema returns the object schema of the exported fields of t
+func (g *generator) structSchema(t reflect.Type, response bool) *Schema {
+	schema := &Schema{Type: Types{"object"}, Properties: make(map[string]*Schema)}
+	g.addFields(schema, t, response)
+	return schema
+}
+
+// addFields adds the fields of t to schema, flattening embedded structs as encoding/json does
+func (g *generator) addFields(schema *Schema, t reflect.Type, response bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.addFields(schema, embedded, response)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schemaOf(field.Type, response)
+		g.applyTags(property, field)
+		omitempty := strings.Contains(options, "omitempty")
+		if response && !omitempty && nullable(field.Type) {
+			property = withNull(property)
+		}
+		schema.Properties[name] = property
+
+		required := hasRule(field.Tag.Get("binding"), "required")
+		if response {
+			required = !omitempty
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// applyTags adds the constraints of the binding and validate tags of field to its schema
+// Rules after dive apply to the elements of a collection
+func (g *generator) applyTags(schema *Schema, field reflect.StructField) {
+	for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+		if name, param, _ := strings.Cut(rule, "="); name == "oneof" {
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, value)
+			}
+		}
+	}
+
+	target := schema
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			if target.Items != nil {
+				target = target.Items
+			} else if target.AdditionalProperties != nil {
+				target = target.AdditionalProperties
+			}
+		case "min_items":
+			if value, ok := g.limit(param); ok {
+				target.MinItems = count(value)
+			}
+		case "max_items":
+			if value, ok := g.limit(param); ok {
+				target.MaxItems = count(value)
+			}
+		case "range":
+			lo, hi, _ := strings.Cut(param, ":")
+			if value, ok := g.limit(lo); ok {
+				target.Minimum = float(value)
+			}
+			if value, ok := g.limit(hi); ok {
+				target.Maximum = float(value)
+			}
+		}
+	}
+}
+
+// hasRule reports whether a comma-separated tag holds rule
+func hasRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// nullable reports whether encoding/json writes null for the zero value of t
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+// withNull returns a schema that also accepts null
+func withNull(s *Schema) *Schema {
+	switch {
+	case s.Ref != "":
+		return &Schema{AnyOf: []*Schema{s, {Type: Types{"null"}}}}
+	case len(s.Type) > 0:
+		s.Type = append(s.Type, "null")
+	}
+	return s
+}
+
+// float returns a pointer to v
+func float(v float64) *float64 {
+	return &v
+}
+
+// count returns a pointer to v as an integer
+func count(v float64) *int {
+	n := int(v)
+	return &n
+}
@@ -0,0 +1,469 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/katvio/api-go-service/internal/i18n"
+)
+
+// Rules reported in violations, named like the binding tags where one exists
+const (
+	RuleType     = "type"
+	RuleSyntax   = "syntax"
+	RuleRequired = "required"
+	RuleEnum     = "oneof"
+	RulePattern  = "pattern"
+	RuleFormat   = "format"
+	RuleMinimum  = "min"
+	RuleMaximum  = "max"
+	RuleMinItems = "min_items"
+	RuleMaxItems = "max_items"
+	RuleAnyOf    = "any_of"
+	RuleStatus   = "status"
+)
+
+// Violation is a part of a request or response that its operation does not allow
+// Pointer is the JSON pointer of the element of the body, or of the element of
+// the parameter named by Parameter; Format and Args make the message
+type Violation struct {
+	Pointer   string
+	Parameter string
+	In        string
+	Rule      string
+	Format    string
+	Args      []interface{}
+}
+
+// Message returns the message of the violation in English
+func (v Violation) Message() string {
+	return fmt.Sprintf(v.Format, v.Args...)
+}
+
+// Request holds the parts of a request checked against its operation
+// Path maps path parameter names to their values
+type Request struct {
+	Path   map[string]string
+	Query  url.Values
+	Header http.Header
+}
+
+// Validator checks requests and responses against the operations of a document
+// Only JSON bodies are checked; the document does not describe other media types
+type Validator struct {
+	doc *Document
+
+	mu       sync.Mutex
+	patterns map[string]*regexp.Regexp
+}
+
+// NewValidator creates a Validator for the operations of doc
+func NewValidator(doc *Document) *Validator {
+	return &Validator{doc: doc, patterns: make(map[string]*regexp.Regexp)}
+}
+
+// ValidateParameters checks the path, query and header parameters of a request
+func (v *Validator) ValidateParameters(op *Operation, req Request) []Violation {
+	var violations []Violation
+	for _, param := range op.Parameters {
+		var values []string
+		switch param.In {
+		case InPath:
+			if value, ok := req.Path[param.Name]; ok {
+				values = []string{value}
+			}
+		case InQuery:
+			values = req.Query[param.Name]
+		case InHeader:
+			values = req.Header.Values(param.Name)
+		default:
+			continue
+		}
+
+		c := &checker{v: v, param: param.Name, in: param.In}
+		switch {
+		case len(values) == 0:
+			if param.Required {
+				c.report("", RuleRequired, "%s is required", param.Name)
+			}
+		case v.resolve(param.Schema).Type.Has("array"):
+			items := make([]interface{}, len(values))
+			for i, value := range values {
+				items[i] = v.coerce(v.resolve(param.Schema).Items, value)
+			}
+			c.check(param.Schema, items, "")
+		default:
+			c.check(param.Schema, v.coerce(param.Schema, values[0]), "")
+		}
+		violations = append(violations, c.violations...)
+	}
+	return violations
+}
+
+// ValidateRequestBody checks the body of a request sent with contentType
+func (v *Validator) ValidateRequestBody(op *Operation, contentType string, body []byte) []Violation {
+	if op.RequestBody == nil || !isJSON(contentType) {
+		return nil
+	}
+	c := &checker{v: v}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			c.report("", RuleRequired, "%s is required", i18n.Phrase("the body"))
+		}
+		return c.violations
+	}
+	media, ok := op.RequestBody.Content[MediaTypeJSON]
+	if !ok {
+		return nil
+	}
+	// Bodies that are not JSON are left to the handler, which reports where decoding failed
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil
+	}
+	c.check(media.Schema, value, "")
+	return c.violations
+}
+
+// ValidateResponse checks the status and the body of a response sent with contentType
+// Responses are looked up by status, then by status class such as 4XX, then as default
+func (v *Validator) ValidateResponse(op *Operation, status int, contentType string, body []byte) []Violation {
+	c := &checker{v: v}
+	response := op.Responses[strconv.Itoa(status)]
+	if response == nil {
+		response = op.Responses[strconv.Itoa(status/100)+"XX"]
+	}
+	if response == nil {
+		response = op.Responses["default"]
+	}
+	if response == nil {
+		c.report("", RuleStatus, "status %d is not documented", status)
+		return c.violations
+	}
+
+	if !isJSON(contentType) || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	media, ok := response.Content[mediaType]
+	if !ok {
+		media, ok = response.Content[MediaTypeJSON]
+	}
+	if !ok {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		c.report("", RuleSyntax, "response body is not valid JSON: %s", err.Error())
+		return c.violations
+	}
+	c.check(media.Schema, value, "")
+	return c.violations
+}
+
+// Declares reports whether some response of op other than the default one has a JSON body
+func (v *Validator) Declares(op *Operation) bool {
+	for status, response := range op.Responses {
+		if status == "default" {
+			continue
+		}
+		for mediaType := range response.Content {
+			if isJSON(mediaType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolve returns the schema that s refers to, or an empty schema
+func (v *Validator) resolve(s *Schema) *Schema {
+	if s = v.doc.Resolve(s); s == nil {
+		return &Schema{}
+	}
+	return s
+}
+
+// coerce converts a parameter value to the type its schema expects
+// Values that cannot be converted are kept as strings and fail the type check
+func (v *Validator) coerce(s *Schema, value string) interface{} {
+	types := v.resolve(s).Type
+	switch {
+	case types.Has("integer") || types.Has("number"):
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case types.Has("boolean"):
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// pattern returns the compiled regular expression of a schema pattern
+func (v *Validator) pattern(expr string) (*regexp.Regexp, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	re, ok := v.patterns[expr]
+	if !ok {
+		re, _ = regexp.Compile(expr)
+		v.patterns[expr] = re
+	}
+	return re, re != nil
+}
+
+// checker collects the violations of one parameter or body
+type checker struct {
+	v          *Validator
+	param      string
+	in         string
+	violations []Violation
+}
+
+// report records a violation at pointer
+func (c *checker) report(pointer, rule, format string, args ...interface{}) {
+	c.violations = append(c.violations, Violation{Pointer: pointer, Parameter: c.param, In: c.in, Rule: rule, Format: format, Args: args})
+}
+
+// name names the element at pointer in messages
+func (c *checker) name(pointer string) interface{} {
+	switch {
+	case c.param != "":
+		return c.param + pointer
+	case pointer == "":
+		return i18n.Phrase("the body")
+	default:
+		return strings.TrimPrefix(pointer, "/")
+	}
+}
+
+// check checks value, found at pointer, against s
+func (c *checker) check(s *Schema, value interface{}, pointer string) {
+	if s = c.v.doc.Resolve(s); s == nil {
+		return
+	}
+
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, alternative := range s.AnyOf {
+			sub := &checker{v: c.v, param: c.param, in: c.in}
+			if sub.check(alternative, value, pointer); len(sub.violations) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			c.report(pointer, RuleAnyOf, "%s does not match any allowed schema", c.name(pointer))
+			return
+		}
+	}
+
+	if len(s.Type) > 0 && !hasType(s.Type, value) {
+		c.report(pointer, RuleType, "expected %s, got %s", typePhrase(s.Type), valuePhrase(value))
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		c.report(pointer, RuleEnum, "%s must be one of %s", c.name(pointer), enumList(s.Enum))
+	}
+
+	switch value := value.(type) {
+	case string:
+		c.checkString(s, value, pointer)
+	case float64:
+		if s.Minimum != nil && value < *s.Minimum {
+			c.report(pointer, RuleMinimum, "%s must be at least %s", c.name(pointer), formatFloat(*s.Minimum))
+		}
+		if s.Maximum != nil && value > *s.Maximum {
+			c.report(pointer, RuleMaximum, "%s must be at most %s", c.name(pointer), formatFloat(*s.Maximum))
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(value) < *s.MinItems {
+			c.report(pointer, RuleMinItems, "at least %d %s are required, got %d", *s.MinItems, i18n.Phrase(fmt.Sprint(c.name(pointer))), len(value))
+		}
+		if s.MaxItems != nil && len(value) > *s.MaxItems {
+			c.report(pointer, RuleMaxItems, "maximum %d %s allowed, got %d", *s.MaxItems, i18n.Phrase(fmt.Sprint(c.name(pointer))), len(value))
+		}
+		if s.Items != nil {
+			for i, item := range value {
+				c.check(s.Items, item, pointer+"/"+strconv.Itoa(i))
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := value[name]; !ok {
+				c.report(childPointer(pointer, name), RuleRequired, "%s is required", c.name(childPointer(pointer, name)))
+			}
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, ok := s.Properties[key]; ok {
+				c.check(property, value[key], childPointer(pointer, key))
+			} else if s.AdditionalProperties != nil {
+				c.check(s.AdditionalProperties, value[key], childPointer(pointer, key))
+			}
+		}
+	}
+}
+
+// checkString checks the pattern and the format of a string
+func (c *checker) checkString(s *Schema, value, pointer string) {
+	if s.Pattern != "" {
+		if re, ok := c.v.pattern(s.Pattern); ok && !re.MatchString(value) {
+			c.report(pointer, RulePattern, "%s must match the pattern %s", c.name(pointer), s.Pattern)
+		}
+	}
+
+	var err error
+	switch s.Format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	case "byte":
+		_, err = base64.StdEncoding.DecodeString(value)
+	}
+	if err != nil {
+		c.report(pointer, RuleFormat, "%s is not a valid %s value", c.name(pointer), s.Format)
+	}
+}
+
+// isJSON reports whether a media type is JSON, such as application/json or
+// application/problem+json; requests without a content type are decoded as JSON
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == MediaTypeJSON || strings.HasSuffix(mediaType, "+json")
+}
+
+// hasType reports whether value is of one of types
+func hasType(types Types, value interface{}) bool {
+	for _, typ := range types {
+		switch v := value.(type) {
+		case nil:
+			if typ == "null" {
+				return true
+			}
+		case bool:
+			if typ == "boolean" {
+				return true
+			}
+		case string:
+			if typ == "string" {
+				return true
+			}
+		case float64:
+			if typ == "number" || typ == "integer" && v == math.Trunc(v) && !math.IsInf(v, 0) {
+				return true
+			}
+		case []interface{}:
+			if typ == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if typ == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// typePhrases name JSON Schema types in messages
+var typePhrases = map[string]i18n.Phrase{
+	"boolean": "a boolean",
+	"integer": "an integer",
+	"number":  "a number",
+	"string":  "a string",
+	"array":   "an array",
+	"object":  "an object",
+}
+
+// typePhrase names the type expected by a type keyword, leaving out null
+func typePhrase(types Types) i18n.Phrase {
+	var phrase i18n.Phrase
+	for _, typ := range types {
+		if typ == "null" {
+			continue
+		}
+		if phrase != "" {
+			return "a value"
+		}
+		phrase = typePhrases[typ]
+	}
+	if phrase == "" {
+		return "a value"
+	}
+	return phrase
+}
+
+// valuePhrase names the JSON type of a decoded value
+func valuePhrase(value interface{}) i18n.Phrase {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case []interface{}:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// inEnum reports whether value is one of the values of an enum keyword
+// Values are compared by their JSON encoding
+func inEnum(enum []interface{}, value interface{}) bool {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range enum {
+		if candidate, err := json.Marshal(allowed); err == nil && bytes.Equal(candidate, encoded) {
+			return true
+		}
+	}
+	return false
+}
+
+// enumList lists the values of an enum keyword the way oneof tags do
+func enumList(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, value := range enum {
+		values[i] = fmt.Sprint(value)
+	}
+	return strings.Join(values, " ")
+}
+
+// formatFloat writes a bound without a needless fraction
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// childPointer appends a token to a JSON pointer, escaping it as RFC 6901 requires
+func childPointer(pointer, token string) string {
+	return pointer + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator(t *testing.T) {
+	doc := buildTestDocument()
+	v := NewValidator(doc)
+
+	rules := func(violations []Violation) map[string]string {
+		m := make(map[string]string, len(violations))
+		for _, violation := range violations {
+			m[violation.Parameter+violation.Pointer] = violation.Rule
+		}
+		return m
+	}
+
+	t.Run("Request bodies", func(t *testing.T) {
+		op, ok := doc.Operation(http.MethodPost, "/api/sum")
+		require.True(t, ok)
+
+		assert.Empty(t, v.ValidateRequestBody(op, "application/json", []byte(`{"numbers": [0.5, -1], "mode": "fast", "nested": {"key": "42"}}`)))
+
+		violations := v.ValidateRequestBody(op, "application/json; charset=utf-8", []byte(`{"numbers": [0.5, 2, "x"], "mode": "slow", "nested": {"key": "abc"}}`))
+		assert.Equal(t, map[string]string{
+			"/numbers/1":  RuleMaximum,
+			"/numbers/2":  RuleType,
+			"/mode":       RuleEnum,
+			"/nested/key": RulePattern,
+		}, rules(violations))
+		assert.Equal(t, "mode must be one of fast exact", violations[0].Message())
+		assert.Equal(t, "numbers/1 must be at most 1", violations[2].Message())
+
+		violations = v.ValidateRequestBody(op, "", []byte(`{"mode": "fast"}`))
+		assert.Equal(t, map[string]string{"/numbers": RuleRequired}, rules(violations))
+		violations = v.ValidateRequestBody(op, "application/json", []byte(`{"numbers": [1]}`))
+		assert.Equal(t, "at least 2 numbers are required, got 1", violations[0].Message())
+
+		violations = v.ValidateRequestBody(op, "application/json", nil)
+		assert.Equal(t, map[string]string{"": RuleRequired}, rules(violations))
+
+		// Other media types and undecodable bodies are left to the handler
+		assert.Empty(t, v.ValidateRequestBody(op, "application/x-msgpack", []byte{0x81}))
+		assert.Empty(t, v.ValidateRequestBody(op, "application/json", []byte(`{"numbers": [`)))
+	})
+
+	t.Run("Parameters", func(t *testing.T) {
+		op, ok := doc.Operation(http.MethodGet, "/api/keys/{id}")
+		require.True(t, ok)
+
+		req := Request{Path: map[string]string{"id": "k1"}, Query: url.Values{"order": {"asc"}, "top": {"3"}}}
+		assert.Empty(t, v.ValidateParameters(op, req))
+
+		req.Query = url.Values{"top": {"three"}}
+		violations := v.ValidateParameters(op, req)
+		assert.Equal(t, map[string]string{"top": RuleType, "order": RuleRequired}, rules(violations))
+		assert.Equal(t, InQuery, violations[0].In)
+		assert.Equal(t, "expected an integer, got a string", violations[0].Message())
+	})
+
+	t.Run("Responses", func(t *testing.T) {
+		op, _ := doc.Operation(http.MethodPost, "/api/sum")
+		assert.True(t, v.Declares(op))
+
+		ok := `{"sum": 1.5, "values": null, "timestamp": "2026-01-02T03:04:05Z"}`
+		assert.Empty(t, v.ValidateResponse(op, http.StatusOK, "application/json; charset=utf-8", []byte(ok)))
+
+		drift := `{"sum": "1.5", "values": [1], "labels": {"a": 1}, "timestamp": "yesterday"}`
+		assert.Equal(t, map[string]string{
+			"/sum":       RuleType,
+			"/labels/a":  RuleType,
+			"/timestamp": RuleFormat,
+		}, rules(v.ValidateResponse(op, http.StatusOK, "application/json", []byte(drift))))
+
+		// Errors follow the default response
+		assert.Empty(t, v.ValidateResponse(op, http.StatusBadRequest, "application/json", []byte(`{"error": "bad", "code": "BAD"}`)))
+		assert.Equal(t, map[string]string{"/code": RuleRequired},
+			rules(v.ValidateResponse(op, http.StatusBadRequest, "application/json", []byte(`{"error": "bad"}`))))
+
+		health, _ := doc.Operation(http.MethodGet, "/health")
+		assert.False(t, v.Declares(health))
+		health.Responses = map[string]*Response{"200": {Description: "OK"}}
+		assert.Equal(t, map[string]string{"": RuleStatus}, rules(v.ValidateResponse(health, http.StatusInternalServerError, "", nil)))
+	})
+
+	t.Run("Alternatives", func(t *testing.T) {
+		schema := &Schema{AnyOf: []*Schema{{Type: Types{"string"}}, {Type: Types{"array"}, Items: &Schema{Type: Types{"string"}}}}}
+		for value, valid := range map[interface{}]bool{"a": true, 1.0: false} {
+			c := &checker{v: v}
+			c.check(schema, value, "")
+			assert.Equal(t, valid, len(c.violations) == 0, value)
+		}
+		c := &checker{v: v}
+		c.check(schema, []interface{}{"a", true}, "")
+		assert.Equal(t, map[string]string{"": RuleAnyOf}, rules(c.violations))
+	})
+}
+
+func TestParse(t *testing.T) {
+	doc := buildTestDocument()
+	jsonBody, err := doc.JSON()
+	require.NoError(t, err)
+	yamlBody, err := doc.YAML()
+	require.NoError(t, err)
+
+	for name, body := range map[string][]byte{"JSON": jsonBody, "YAML": yamlBody} {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := Parse(body)
+			require.NoError(t, err)
+			assert.Equal(t, doc.Components.Schemas["testRequest"], parsed.Components.Schemas["testRequest"])
+			op, ok := parsed.Operation(http.MethodGet, PathOf("/api/keys/:id"))
+			require.True(t, ok)
+			assert.Contains(t, op.Responses, "202")
+		})
+	}
+
+	t.Run("Unquoted response codes", func(t *testing.T) {
+		parsed, err := Parse([]byte("openapi: 3.0.3\ninfo: {title: t, version: '1'}\npaths:\n  /a:\n    get:\n      responses:\n        200: {description: OK}\n"))
+		require.NoError(t, err)
+		op, _ := parsed.Operation(http.MethodGet, "/a")
+		assert.Contains(t, op.Responses, "200")
+	})
+
+	_, err = Parse([]byte(`{"swagger": "2.0"}`))
+	assert.Error(t, err)
+	_, err = Parse([]byte("{"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,248 @@
+package privacy
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/katvio/api-go-service/internal/storage"
+)
+
+// Mechanism names accepted by the API
+const (
+	MechanismLaplace  = "laplace"
+	MechanismGaussian = "gaussian"
+)
+
+// ErrBudgetExhausted is returned when a consumer cannot afford the requested epsilon
+var ErrBudgetExhausted = errors.New("privacy budget exhausted")
+
+// ErrNotFinite is returned when the noisy sum cannot be represented
+var ErrNotFinite = errors.New("noisy sum is not finite")
+
+// Accountant tracks the cumulative epsilon spent by each consumer
+// Budgets compose sequentially: every answered query consumes its epsilon
+type Accountant struct {
+	budget float64
+	db     storage.Store // nil unless spending is persisted
+
+	mu    sync.Mutex
+	spent map[string]float64
+}
+
+// NewAccountant creates an accountant granting each consumer the given total epsilon
+func NewAccountant(budget float64) *Accountant {
+	return &Accountant{
+		budget: budget,
+		spent:  make(map[string]float64),
+	}
+}
+
+// NewPersistentAccountant creates an accountant whose spending is kept in db
+// Budgets spent before a restart remain spent
+func NewPersistentAccountant(budget float64, db storage.Store) (*Accountant, error) {
+	a := NewAccountant(budget)
+	a.db = db
+
+	err := db.View(func(tx storage.Tx) error {
+		return tx.ForEach(storage.BucketPrivacy, func(consumer string, value []byte) error {
+			spent, err := strconv.ParseFloat(string(value), 64)
+			if err != nil {
+				return fmt.Errorf("invalid privacy budget of %q: %w", consumer, err)
+			}
+			a.spent[consumer] = spent
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Spend charges epsilon to the consumer's budget and returns the remaining budget
+// Nothing is charged when the budget cannot cover epsilon
+func (a *Accountant) Spend(consumer string, epsilon float64) (float64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	remaining := a.budget - a.spent[consumer]
+	if epsilon > remaining+1e-12 {
+		return remaining, fmt.Errorf("%w: requested epsilon %g, remaining %g", ErrBudgetExhausted, epsilon, math.Max(remaining, 0))
+	}
+
+	spent := a.spent[consumer] + epsilon
+	if a.db != nil {
+		err := a.db.Update(func(tx storage.Tx) error {
+			return tx.Put(storage.BucketPrivacy, consumer, []byte(strconv.FormatFloat(spent, 'g', -1, 64)))
+		})
+		if err != nil {
+			return remaining, fmt.Errorf("failed to record privacy budget: %w", err)
+		}
+	}
+
+	a.spent[consumer] = spent
+	return math.Max(a.budget-spent, 0), nil
+}
+
+// Refund gives back epsilon charged by Spend for a query that was not answered
+// Nothing was released, so the budget is as if the query had never been made
+func (a *Accountant) Refund(consumer string, epsilon float64) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	spent := math.Max(a.spent[consumer]-epsilon, 0)
+	if a.db != nil {
+		err := a.db.Update(func(tx storage.Tx) error {
+			return tx.Put(storage.BucketPrivacy, consumer, []byte(strconv.FormatFloat(spent, 'g', -1, 64)))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record privacy budget: %w", err)
+		}
+	}
+
+	a.spent[consumer] = spent
+	return nil
+}
+
+// Remaining returns the consumer's remaining budget
+func (a *Accountant) Remaining(consumer string) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return math.Max(a.budget-a.spent[consumer], 0)
+}
+
+// Budget returns the total budget granted to each consumer
+func (a *Accountant) Budget() float64 {
+	return a.budget
+}
+
+// Spent returns the budget consumed by each consumer
+func (a *Accountant) Spent() map[string]float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	spent := make(map[string]float64, len(a.spent))
+	for consumer, epsilon := range a.spent {
+		spent[consumer] = epsilon
+	}
+	return spent
+}
+
+// LaplaceScale returns the scale b = Δ/ε of the Laplace mechanism
+func LaplaceScale(sensitivity, epsilon float64) float64 {
+	return sensitivity / epsilon
+}
+
+// GaussianSigma returns σ = Δ·√(2·ln(1.25/δ))/ε of the classic Gaussian mechanism
+// The analysis holds for ε < 1
+func GaussianSigma(sensitivity, epsilon, delta float64) float64 {
+	return sensitivity * math.Sqrt(2*math.Log(1.25/delta)) / epsilon
+}
+
+// Laplace draws a sample from Laplace(0, scale) using the system CSPRNG
+func Laplace(scale float64) (float64, error) {
+	u, err := uniform()
+	if err != nil {
+		return 0, err
+	}
+
+	// Inverse CDF on u ∈ (0, 1) shifted to (-1/2, 1/2)
+	u -= 0.5
+	return -scale * sign(u) * math.Log(1-2*math.Abs(u)), nil
+}
+
+// Gaussian draws a sample from N(0, sigma²) using the Box-Muller transform
+func Gaussian(sigma float64) (float64, error) {
+	u1, err := uniform()
+	if err != nil {
+		return 0, err
+	}
+	u2, err := uniform()
+	if err != nil {
+		return 0, err
+	}
+
+	return sigma * math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2), nil
+}
+
+// Clamp limits v to [lower, upper]
+func Clamp(v, lower, upper float64) float64 {
+	return math.Min(math.Max(v, lower), upper)
+}
+
+// uniform returns a uniformly distributed float64 in the open interval (0, 1)
+func uniform() (float64, error) {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, err
+		}
+		// 53 random bits give every representable multiple of 2^-53
+		u := float64(binary.BigEndian.Uint64(b[:])>>11) / (1 << 53)
+		if u > 0 {
+			return u, nil
+		}
+	}
+}
+
+func sign(v float64) float64 {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
+
+// SumParams describes a differentially private sum
+type SumParams struct {
+	Mechanism string
+	Epsilon   float64
+	Delta     float64 // only used by the Gaussian mechanism
+	Lower     float64
+	Upper     float64
+}
+
+// SumResult is the outcome of a differentially private sum
+type SumResult struct {
+	Value       float64
+	Sensitivity float64
+	Scale       float64 // Laplace scale b or Gaussian standard deviation σ
+}
+
+// Sum clamps every value to the declared bounds and adds calibrated noise to their sum
+// Neighbouring datasets differ by replacing one value, so the count is treated as
+// public and the L1/L2 sensitivity of the clamped sum is upper - lower
+func Sum(values []float64, params SumParams) (SumResult, error) {
+	result := SumResult{Sensitivity: params.Upper - params.Lower}
+
+	sum := 0.0
+	for _, v := range values {
+		sum += Clamp(v, params.Lower, params.Upper)
+	}
+
+	var noise float64
+	var err error
+	switch params.Mechanism {
+	case MechanismLaplace:
+		result.Scale = LaplaceScale(result.Sensitivity, params.Epsilon)
+		noise, err = Laplace(result.Scale)
+	case MechanismGaussian:
+		result.Scale = GaussianSigma(result.Sensitivity, params.Epsilon, params.Delta)
+		noise, err = Gaussian(result.Scale)
+	default:
+		return SumResult{}, fmt.Errorf("unknown mechanism %q", params.Mechanism)
+	}
+	if err != nil {
+		return SumResult{}, fmt.Errorf("failed to sample noise: %w", err)
+	}
+
+	result.Value = sum + noise
+	if math.IsNaN(result.Value) || math.IsInf(result.Value, 0) {
+		return SumResult{}, ErrNotFinite
+	}
+	return result, nil
+}
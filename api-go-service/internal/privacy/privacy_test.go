@@ -0,0 +1,118 @@
+package privacy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/katvio/api-go-service/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountant_Spend(t *testing.T) {
+	a := NewAccountant(1.0)
+
+	remaining, err := a.Spend("alice", 0.4)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.6, remaining, 1e-9)
+
+	remaining, err = a.Spend("alice", 0.6)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.0, remaining, 1e-9)
+
+	_, err = a.Spend("alice", 0.1)
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+
+	// Budgets are tracked per consumer
+	assert.InDelta(t, 1.0, a.Remaining("bob"), 1e-9)
+	assert.InDelta(t, 1.0, a.Spent()["alice"], 1e-9)
+}
+
+func TestAccountant_Refund(t *testing.T) {
+	a := NewAccountant(1.0)
+
+	_, err := a.Spend("alice", 0.4)
+	require.NoError(t, err)
+	require.NoError(t, a.Refund("alice", 0.4))
+	assert.InDelta(t, 1.0, a.Remaining("alice"), 1e-9)
+}
+
+func TestPersistentAccountant(t *testing.T) {
+	db := storage.NewMemoryStore()
+	_, _, err := storage.Migrate(db, storage.Migrations)
+	require.NoError(t, err)
+
+	a, err := NewPersistentAccountant(1.0, db)
+	require.NoError(t, err)
+	_, err = a.Spend("alice", 0.25)
+	require.NoError(t, err)
+
+	// Spending survives a new accountant over the same storage
+	reloaded, err := NewPersistentAccountant(1.0, db)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.75, reloaded.Remaining("alice"), 1e-9)
+
+	// Nothing is charged when the spending cannot be recorded
+	require.NoError(t, db.Close())
+	_, err = reloaded.Spend("alice", 0.25)
+	assert.ErrorIs(t, err, storage.ErrClosed)
+	assert.InDelta(t, 0.75, reloaded.Remaining("alice"), 1e-9)
+}
+
+func TestMechanisms(t *testing.T) {
+	const samples = 20000
+
+	t.Run("Laplace noise has the expected spread", func(t *testing.T) {
+		scale := 2.0
+		sumAbs := 0.0
+		for i := 0; i < samples; i++ {
+			v, err := Laplace(scale)
+			require.NoError(t, err)
+			sumAbs += math.Abs(v)
+		}
+		// E|X| = b for Laplace(0, b)
+		assert.InDelta(t, scale, sumAbs/samples, 0.1)
+	})
+
+	t.Run("Gaussian noise has the expected spread", func(t *testing.T) {
+		sigma := 3.0
+		sumSq := 0.0
+		for i := 0; i < samples; i++ {
+			v, err := Gaussian(sigma)
+			require.NoError(t, err)
+			sumSq += v * v
+		}
+		assert.InDelta(t, sigma*sigma, sumSq/samples, 0.5)
+	})
+
+	assert.Equal(t, 5.0, LaplaceScale(10, 2))
+	assert.InDelta(t, 10*math.Sqrt(2*math.Log(1.25/1e-5))/0.5, GaussianSigma(10, 0.5, 1e-5), 1e-9)
+	assert.Equal(t, 3.0, Clamp(7, -3, 3))
+}
+
+func TestSum(t *testing.T) {
+	t.Run("Values are clamped to the declared bounds", func(t *testing.T) {
+		// A huge epsilon makes the noise negligible
+		result, err := Sum([]float64{-5, 2, 3, 50}, SumParams{Mechanism: MechanismLaplace, Epsilon: 1e9, Lower: 0, Upper: 10})
+		require.NoError(t, err)
+		assert.InDelta(t, 15.0, result.Value, 1e-3)
+		assert.Equal(t, 10.0, result.Sensitivity)
+	})
+
+	t.Run("Gaussian scale follows the declared delta", func(t *testing.T) {
+		params := SumParams{Mechanism: MechanismGaussian, Epsilon: 0.5, Delta: 1e-5, Lower: -1, Upper: 1}
+		result, err := Sum([]float64{1, 1}, params)
+		require.NoError(t, err)
+		assert.InDelta(t, GaussianSigma(2, 0.5, 1e-5), result.Scale, 1e-9)
+	})
+
+	t.Run("Sums that overflow are refused", func(t *testing.T) {
+		_, err := Sum([]float64{1e308, 1e308}, SumParams{Mechanism: MechanismLaplace, Epsilon: 1e9, Lower: 0, Upper: 1e308})
+		assert.ErrorIs(t, err, ErrNotFinite)
+	})
+
+	t.Run("Unknown mechanism", func(t *testing.T) {
+		_, err := Sum([]float64{1, 2}, SumParams{Mechanism: "exponential", Epsilon: 1, Upper: 1})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,422 @@
+package secagg
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// DropoutPolicy decides what happens when a session times out before every share arrived
+type DropoutPolicy string
+
+// Supported dropout policies
+const (
+	// PolicyAbort fails the session; nothing is revealed
+	PolicyAbort DropoutPolicy = "abort"
+	// PolicyPartial reveals the aggregate of the shares received, provided at
+	// least MinParticipants submitted. Participants must mask their inputs so
+	// that partial sums are safe to reveal.
+	PolicyPartial DropoutPolicy = "partial"
+)
+
+// State is the lifecycle state of a session
+type State string
+
+// Session states
+const (
+	StateCollecting State = "collecting"
+	StateComplete   State = "complete"
+	StateFailed     State = "failed"
+	StateExpired    State = "expired"
+)
+
+// Errors returned by the manager
+var (
+	ErrSessionNotFound    = errors.New("aggregation session not found")
+	ErrSessionClosed      = errors.New("aggregation session is no longer accepting shares")
+	ErrDuplicateShare     = errors.New("participant has already submitted shares")
+	ErrUnknownParticipant = errors.New("participant is not part of this aggregation session")
+	ErrTooManySessions    = errors.New("maximum number of aggregation sessions reached")
+	ErrConsumerLimit      = errors.New("maximum number of aggregation sessions reached for this consumer")
+	ErrInvalidShare       = errors.New("shares must be non-negative and no wider than the session modulus")
+)
+
+// Options configures a new session
+type Options struct {
+	Participants    int      // number of expected participants
+	ParticipantIDs  []string // optional allow-list of participant identities
+	Modulus         *big.Int
+	Timeout         time.Duration
+	Policy          DropoutPolicy
+	MinParticipants int // only used by PolicyPartial
+}
+
+// Snapshot is a point-in-time copy of a session, safe to hand to callers
+type Snapshot struct {
+	ID              string
+	Owner           string
+	State           State
+	Modulus         *big.Int
+	Expected        int
+	Submitted       []string
+	Dropped         []string
+	Policy          DropoutPolicy
+	MinParticipants int
+	Aggregate       *big.Int // nil unless State is StateComplete
+	Partial         bool
+	Reason          string
+	CreatedAt       time.Time
+	Deadline        time.Time
+	ClosedAt        time.Time
+}
+
+// session is the mutable state of an aggregation session, guarded by Manager.mu
+type session struct {
+	id        string
+	owner     string
+	opts      Options
+	allowed   map[string]bool
+	submitted map[string]bool
+	sum       *big.Int
+	state     State
+	partial   bool
+	reason    string
+	createdAt time.Time
+	deadline  time.Time
+	closedAt  time.Time
+}
+
+// Manager keeps aggregation sessions in memory and enforces their deadlines and expiry
+type Manager struct {
+	logger         *logger.Logger
+	retention      time.Duration
+	maxSessions    int
+	maxPerConsumer int
+	now            func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*session
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewManager creates a session manager
+// Sessions are removed retention after they complete or fail; until then they
+// count towards both maxSessions and the maxPerConsumer sessions of their owner
+func NewManager(log *logger.Logger, retention time.Duration, maxSessions, maxPerConsumer int) *Manager {
+	return &Manager{
+		logger:         log,
+		retention:      retention,
+		maxSessions:    maxSessions,
+		maxPerConsumer: maxPerConsumer,
+		now:            time.Now,
+		sessions:       make(map[string]*session),
+	}
+}
+
+// Start runs the background sweep that applies timeouts and removes expired sessions
+func (m *Manager) Start(interval time.Duration) {
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.sweep()
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the background sweep
+func (m *Manager) Stop() {
+	if m.stop == nil {
+		return
+	}
+	close(m.stop)
+	<-m.done
+	m.stop = nil
+}
+
+// Create opens a new session owned by owner
+func (m *Manager) Create(owner string, opts Options) (*Snapshot, error) {
+	if opts.Participants < 2 {
+		return nil, fmt.Errorf("at least 2 participants are required, got %d", opts.Participants)
+	}
+	if len(opts.ParticipantIDs) > 0 && len(opts.ParticipantIDs) != opts.Participants {
+		return nil, fmt.Errorf("%d participant IDs given for %d participants", len(opts.ParticipantIDs), opts.Participants)
+	}
+	if opts.Policy == PolicyPartial && (opts.MinParticipants < 2 || opts.MinParticipants > opts.Participants) {
+		return nil, fmt.Errorf("min_participants must be between 2 and %d", opts.Participants)
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.now()
+	s := &session{
+		id:        id,
+		owner:     owner,
+		opts:      opts,
+		submitted: make(map[string]bool),
+		sum:       new(big.Int),
+		state:     StateCollecting,
+		createdAt: now,
+		deadline:  now.Add(opts.Timeout),
+	}
+	if len(opts.ParticipantIDs) > 0 {
+		s.allowed = make(map[string]bool, len(opts.ParticipantIDs))
+		for _, p := range opts.ParticipantIDs {
+			s.allowed[p] = true
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.maxPerConsumer > 0 && m.owned(owner) >= m.maxPerConsumer {
+		return nil, ErrConsumerLimit
+	}
+	if m.maxSessions > 0 && len(m.sessions) >= m.maxSessions {
+		return nil, ErrTooManySessions
+	}
+	m.sessions[id] = s
+
+	m.audit(s, "", StateCollecting, "session_created", map[string]interface{}{
+		"owner":        owner,
+		"participants": opts.Participants,
+		"policy":       string(opts.Policy),
+		"deadline":     s.deadline,
+	})
+
+	return s.snapshot(), nil
+}
+
+// Submit adds a participant's shares to the session
+// The aggregate becomes available once every expected participant has submitted
+func (m *Manager) Submit(id, participant string, shares []*big.Int) (*Snapshot, error) {
+	for _, share := range shares {
+		if share.Sign() < 0 {
+			return nil, ErrInvalidShare
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	bits := s.opts.Modulus.BitLen()
+	for _, share := range shares {
+		if share.BitLen() > bits {
+			return nil, ErrInvalidShare
+		}
+	}
+
+	m.advance(s, m.now())
+	if s.state != StateCollecting {
+		return s.snapshot(), ErrSessionClosed
+	}
+	if s.allowed != nil && !s.allowed[participant] {
+		return s.snapshot(), ErrUnknownParticipant
+	}
+	if s.submitted[participant] {
+		return s.snapshot(), ErrDuplicateShare
+	}
+	if s.allowed == nil && len(s.submitted) >= s.opts.Participants {
+		return s.snapshot(), ErrSessionClosed
+	}
+
+	for _, share := range shares {
+		s.sum.Add(s.sum, share)
+	}
+	s.sum.Mod(s.sum, s.opts.Modulus)
+	s.submitted[participant] = true
+
+	m.audit(s, StateCollecting, StateCollecting, "share_received", map[string]interface{}{
+		"participant": participant,
+		"received":    len(s.submitted),
+		"expected":    s.opts.Participants,
+	})
+
+	if len(s.submitted) == s.opts.Participants {
+		m.transition(s, StateComplete, "all_shares_received", m.now())
+	}
+
+	return s.snapshot(), nil
+}
+
+// Get returns a snapshot of the session if it belongs to owner
+// Sessions of other owners are reported as not found
+func (m *Manager) Get(id, owner string) (*Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[id]
+	if !ok || s.owner != owner {
+		return nil, ErrSessionNotFound
+	}
+
+	m.advance(s, m.now())
+	return s.snapshot(), nil
+}
+
+// owned returns the number of sessions held for owner; m.mu must be held
+func (m *Manager) owned(owner string) int {
+	count := 0
+	for _, s := range m.sessions {
+		if s.owner == owner {
+			count++
+		}
+	}
+	return count
+}
+
+// Len returns the number of sessions held in memory
+func (m *Manager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sessions)
+}
+
+// sweep applies deadlines and removes expired sessions
+func (m *Manager) sweep() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for id, s := range m.sessions {
+		m.advance(s, now)
+		if s.state != StateCollecting && now.Sub(s.closedAt) >= m.retention {
+			m.audit(s, s.state, StateExpired, "session_expired", nil)
+			delete(m.sessions, id)
+		}
+	}
+}
+
+// advance applies the dropout policy once the deadline has passed
+func (m *Manager) advance(s *session, now time.Time) {
+	if s.state != StateCollecting || now.Before(s.deadline) {
+		return
+	}
+
+	if s.opts.Policy == PolicyPartial && len(s.submitted) >= s.opts.MinParticipants {
+		s.partial = true
+		m.transition(s, StateComplete, "timeout_partial", now)
+		return
+	}
+
+	s.reason = fmt.Sprintf("timed out with %d of %d shares", len(s.submitted), s.opts.Participants)
+	m.transition(s, StateFailed, "timeout", now)
+}
+
+// transition moves a session into a terminal state
+func (m *Manager) transition(s *session, to State, event string, now time.Time) {
+	from := s.state
+	s.state = to
+	s.closedAt = now
+
+	m.audit(s, from, to, event, map[string]interface{}{
+		"received": len(s.submitted),
+		"expected": s.opts.Participants,
+		"dropped":  s.dropped(),
+	})
+}
+
+// audit logs a session event for the audit trail
+// Shares and aggregates are never logged
+func (m *Manager) audit(s *session, from, to State, event string, fields map[string]interface{}) {
+	entry := m.logger.WithFields(map[string]interface{}{
+		"component":  "secure_aggregation",
+		"type":       "audit",
+		"event":      event,
+		"session_id": s.id,
+		"from_state": string(from),
+		"to_state":   string(to),
+	})
+	if fields != nil {
+		entry = entry.WithFields(fields)
+	}
+	entry.Info("Aggregation session event")
+}
+
+// snapshot copies the session state
+func (s *session) snapshot() *Snapshot {
+	snap := &Snapshot{
+		ID:              s.id,
+		Owner:           s.owner,
+		State:           s.state,
+		Modulus:         new(big.Int).Set(s.opts.Modulus),
+		Expected:        s.opts.Participants,
+		Submitted:       sortedKeys(s.submitted),
+		Dropped:         s.dropped(),
+		Policy:          s.opts.Policy,
+		MinParticipants: s.opts.MinParticipants,
+		Partial:         s.partial,
+		Reason:          s.reason,
+		CreatedAt:       s.createdAt,
+		Deadline:        s.deadline,
+		ClosedAt:        s.closedAt,
+	}
+
+	if s.state == StateComplete {
+		snap.Aggregate = new(big.Int).Set(s.sum)
+	}
+
+	return snap
+}
+
+// dropped lists the allowed participants that did not submit
+// Without an allow-list only the number of missing participants is known
+func (s *session) dropped() []string {
+	if s.allowed == nil || s.state == StateCollecting {
+		return nil
+	}
+
+	var dropped []string
+	for p := range s.allowed {
+		if !s.submitted[p] {
+			dropped = append(dropped, p)
+		}
+	}
+	sort.Strings(dropped)
+	return dropped
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// newSessionID returns a random, unguessable session ID
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "agg_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,142 @@
+package secagg
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestManager(now *time.Time) *Manager {
+	m := NewManager(logger.New("error", "json"), time.Minute, 10, 10)
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+func TestManager_AllSharesReceived(t *testing.T) {
+	now := time.Now()
+	m := newTestManager(&now)
+
+	snap, err := m.Create("owner", Options{
+		Participants: 3,
+		Modulus:      big.NewInt(101),
+		Timeout:      time.Minute,
+		Policy:       PolicyAbort,
+	})
+	require.NoError(t, err)
+
+	// Shares wider than the modulus or negative are refused without being counted
+	_, err = m.Submit(snap.ID, "alice", []*big.Int{big.NewInt(1 << 7)})
+	assert.ErrorIs(t, err, ErrInvalidShare)
+	_, err = m.Submit(snap.ID, "alice", []*big.Int{big.NewInt(-1)})
+	assert.ErrorIs(t, err, ErrInvalidShare)
+
+	// Each party's shares are masked values that only sum to the secret total
+	_, err = m.Submit(snap.ID, "alice", []*big.Int{big.NewInt(90), big.NewInt(40)})
+	require.NoError(t, err)
+	snap, err = m.Submit(snap.ID, "bob", []*big.Int{big.NewInt(77)})
+	require.NoError(t, err)
+	assert.Equal(t, StateCollecting, snap.State)
+	assert.Nil(t, snap.Aggregate, "aggregate must not be revealed before every share arrived")
+
+	_, err = m.Submit(snap.ID, "bob", []*big.Int{big.NewInt(1)})
+	assert.ErrorIs(t, err, ErrDuplicateShare)
+
+	snap, err = m.Submit(snap.ID, "carol", []*big.Int{big.NewInt(5)})
+	require.NoError(t, err)
+	assert.Equal(t, StateComplete, snap.State)
+	assert.Equal(t, big.NewInt((90+40+77+5)%101), snap.Aggregate)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, snap.Submitted)
+
+	_, err = m.Submit(snap.ID, "dave", []*big.Int{big.NewInt(1)})
+	assert.ErrorIs(t, err, ErrSessionClosed)
+}
+
+func TestManager_Timeout(t *testing.T) {
+	now := time.Now()
+	m := newTestManager(&now)
+
+	opts := Options{
+		Participants:    3,
+		ParticipantIDs:  []string{"alice", "bob", "carol"},
+		Modulus:         big.NewInt(101),
+		Timeout:         time.Minute,
+		MinParticipants: 2,
+	}
+
+	t.Run("Abort policy fails the session", func(t *testing.T) {
+		opts.Policy = PolicyAbort
+		snap, err := m.Create("owner", opts)
+		require.NoError(t, err)
+
+		_, err = m.Submit(snap.ID, "mallory", []*big.Int{big.NewInt(1)})
+		assert.ErrorIs(t, err, ErrUnknownParticipant)
+
+		_, err = m.Submit(snap.ID, "alice", []*big.Int{big.NewInt(1)})
+		require.NoError(t, err)
+
+		now = now.Add(2 * time.Minute)
+		snap, err = m.Get(snap.ID, "owner")
+		require.NoError(t, err)
+		assert.Equal(t, StateFailed, snap.State)
+		assert.Nil(t, snap.Aggregate)
+		assert.Equal(t, []string{"bob", "carol"}, snap.Dropped)
+	})
+
+	t.Run("Partial policy reveals the received aggregate", func(t *testing.T) {
+		opts.Policy = PolicyPartial
+		snap, err := m.Create("owner", opts)
+		require.NoError(t, err)
+
+		_, err = m.Submit(snap.ID, "alice", []*big.Int{big.NewInt(10)})
+		require.NoError(t, err)
+		_, err = m.Submit(snap.ID, "bob", []*big.Int{big.NewInt(20)})
+		require.NoError(t, err)
+
+		now = now.Add(2 * time.Minute)
+		snap, err = m.Get(snap.ID, "owner")
+		require.NoError(t, err)
+		assert.Equal(t, StateComplete, snap.State)
+		assert.True(t, snap.Partial)
+		assert.Equal(t, big.NewInt(30), snap.Aggregate)
+		assert.Equal(t, []string{"carol"}, snap.Dropped)
+	})
+
+	t.Run("Closed sessions expire after the retention period", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		m.sweep()
+		assert.Equal(t, 0, m.Len())
+	})
+}
+
+func TestManager_Limits(t *testing.T) {
+	now := time.Now()
+	m := NewManager(logger.New("error", "json"), time.Minute, 2, 1)
+	m.now = func() time.Time { return now }
+
+	opts := Options{Participants: 2, Modulus: big.NewInt(7), Timeout: time.Minute, Policy: PolicyAbort}
+	snap, err := m.Create("owner", opts)
+	require.NoError(t, err)
+
+	// One owner cannot take every session
+	_, err = m.Create("owner", opts)
+	assert.ErrorIs(t, err, ErrConsumerLimit)
+
+	_, err = m.Create("other", opts)
+	require.NoError(t, err)
+	_, err = m.Create("third", opts)
+	assert.ErrorIs(t, err, ErrTooManySessions)
+
+	_, err = m.Create("owner", Options{Participants: 1, Modulus: big.NewInt(7)})
+	assert.Error(t, err)
+
+	_, err = m.Get("agg_missing", "owner")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+
+	// Sessions of other owners are not found
+	_, err = m.Get(snap.ID, "intruder")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
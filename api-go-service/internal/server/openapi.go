@@ -0,0 +1,170 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/handlers"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/openapi"
+)
+
+// buildOpenAPI generates the OpenAPI document of routes
+// Schemas come from the model types; this table only adds what types cannot
+// tell: summaries, success statuses, query parameters and public routes
+func buildOpenAPI(cfg *config.Config, routes gin.RoutesInfo) *openapi.Document {
+	b := openapi.NewBuilder(openapi.Info{
+		Title:       "zama-api-service",
+		Version:     getVersion(),
+		Description: "Sums, linear algebra, modular arithmetic and privacy-preserving aggregation.",
+	})
+	b.SetErrorModels(models.ErrorResponse{}, models.Problem{})
+	b.SetLimits(models.ResolveLimit)
+	b.DefineSchema(models.BigInt{}, &openapi.Schema{
+		Type:        openapi.Types{"string", "integer"},
+		Pattern:     "^-?[0-9]+$",
+		Description: "Arbitrary-precision integer written in decimal; JSON integers are accepted in requests",
+	})
+
+	b.AddSecurityScheme("apiKey", &openapi.SecurityScheme{
+		Type:        "apiKey",
+		In:          openapi.InHeader,
+		Name:        cfg.Security.APIKeyHeader,
+		Description: "API key checked by the gateway in front of the service",
+	})
+	b.AddSecurityScheme("bearerAuth", &openapi.SecurityScheme{
+		Type:        "http",
+		Scheme:      "bearer",
+		Description: "Bearer token checked by the gateway in front of the service",
+	})
+
+	b.AddTag("sum", "Sums of numbers, exact or differentially private")
+	b.AddTag("linalg", "Vector and matrix operations")
+	b.AddTag("modular", "Arithmetic over Z_q and in Z_q[X]/(X^n+1)")
+	b.AddTag("paillier", "Additively homomorphic encrypted sums")
+	b.AddTag("aggregation", "Secure aggregation over additive secret shares")
+	b.AddTag("jobs", "Asynchronous computations")
+	b.AddTag("streams", "Running sums and time windows")
+	b.AddTag("service", "Health, errors and API description")
+
+	service := func(summary string, response interface{}) openapi.Route {
+		return openapi.Route{Summary: summary, Tag: "service", Response: response, Public: true}
+	}
+	b.Describe(http.MethodGet, cfg.Health.Path, service("Health of the service", models.HealthResponse{}))
+	b.Describe(http.MethodGet, "/healthz/live", service("Liveness probe", models.HealthResponse{}))
+	b.Describe(http.MethodGet, "/healthz/ready", service("Readiness probe", models.HealthResponse{}))
+	b.Describe(http.MethodGet, cfg.Metrics.Path, service("Prometheus metrics", nil))
+	b.Describe(http.MethodGet, models.ErrorCatalogPath, service("Error catalog", models.ErrorCatalogResponse{}))
+	b.Describe(http.MethodGet, models.ErrorCatalogPath+"/:code", service("Description of an error code", models.ErrorType{}))
+	b.Describe(http.MethodGet, handlers.OpenAPIJSONPath, service("This document in JSON", nil))
+	b.Describe(http.MethodGet, handlers.OpenAPIYAMLPath, service("This document in YAML", nil))
+	b.Describe(http.MethodGet, handlers.DocsPath, service("Interactive documentation", nil))
+	b.Describe(http.MethodGet, "/", service("Service information and endpoints", nil))
+	b.Describe(http.MethodPost, "/rpc", openapi.Route{Summary: "JSON-RPC 2.0 calls and batches", Tag: "service", Request: models.RPCRequest{}, Response: models.RPCResponse{}})
+
+	b.Describe(http.MethodPost, "/api/v1/sum", openapi.Route{
+		Summary: "Sum of numbers",
+		Description: "Adds the numbers, or returns a differentially private sum when privacy is set. " +
+			"Bodies may also be CBOR, MessagePack, Protobuf or CSV, selected by Content-Type and Accept.",
+		Tag:      "sum",
+		Request:  models.SumRequest{},
+		Response: models.SumResponse{},
+	})
+	b.Describe(http.MethodGet, "/api/v1/sum", openapi.Route{Summary: "Description of POST /api/v1/sum taken from this document", Tag: "sum"})
+	b.Describe(http.MethodGet, "/api/v1/privacy/budget", openapi.Route{Summary: "Privacy budget left to the consumer", Tag: "sum", Response: models.PrivacyBudgetResponse{}})
+	b.Describe(http.MethodPost, "/api/v1/sum/upload", openapi.Route{Summary: "Column totals of a CSV or JSON Lines file", Tag: "sum", Response: models.UploadSumResponse{}})
+	b.Describe(http.MethodPost, "/api/v1/aggregate", openapi.Route{Summary: "Per-group aggregates of keyed records", Tag: "sum", Query: models.GroupByParams{}, Response: models.AggregateResponse{}})
+
+	linalg := func(summary string, request, response interface{}) openapi.Route {
+		return openapi.Route{Summary: summary, Tag: "linalg", Request: request, Response: response}
+	}
+	b.Describe(http.MethodPost, "/api/v1/linalg/vector/add", linalg("Element-wise sum of vectors", models.VectorsRequest{}, models.VectorResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/linalg/vector/scale", linalg("Vector multiplied by a scalar", models.ScaleRequest{}, models.VectorResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/linalg/vector/dot", linalg("Dot product of two vectors", models.DotRequest{}, models.ScalarResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/linalg/matrix/add", linalg("Element-wise sum of matrices", models.MatricesRequest{}, models.MatrixResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/linalg/matrix/multiply", linalg("Product of two matrices", models.MatrixMultiplyRequest{}, models.MatrixResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/linalg/matrix/transpose", linalg("Transpose of a matrix", models.MatrixRequest{}, models.MatrixResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/linalg/matrix/row-sums", linalg("Sums of the rows of a matrix", models.MatrixRequest{}, models.VectorResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/linalg/matrix/column-sums", linalg("Sums of the columns of a matrix", models.MatrixRequest{}, models.VectorResponse{}))
+
+	modular := func(summary string, request, response interface{}) openapi.Route {
+		return openapi.Route{Summary: summary, Tag: "modular", Request: request, Response: response}
+	}
+	b.Describe(http.MethodPost, "/api/v1/modular/sum", modular("Sum modulo q", models.ModularRequest{}, models.ModularResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/modular/product", modular("Product modulo q", models.ModularRequest{}, models.ModularResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/modular/reduce", modular("Values reduced modulo q", models.ModularRequest{}, models.ModularResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/ring/add", modular("Sum of polynomials", models.RingRequest{}, models.RingResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/ring/multiply", modular("Product of polynomials", models.RingRequest{}, models.RingResponse{}))
+	b.Describe(http.MethodPost, "/api/v1/ring/reduce", modular("Polynomial reduced into the ring", models.RingReduceRequest{}, models.RingResponse{}))
+
+	b.Describe(http.MethodPost, "/api/v1/paillier/keys", openapi.Route{Summary: "Register a public key", Tag: "paillier", Request: models.PaillierRegisterRequest{}, Response: models.PaillierKeyResponse{}, Status: http.StatusCreated})
+	b.Describe(http.MethodGet, "/api/v1/paillier/keys/:id", openapi.Route{Summary: "Public key", Tag: "paillier", Response: models.PaillierKeyResponse{}})
+	b.Describe(http.MethodPost, "/api/v1/paillier/sum", openapi.Route{Summary: "Sum of ciphertexts", Tag: "paillier", Request: models.PaillierSumRequest{}, Response: models.PaillierSumResponse{}})
+
+	b.Describe(http.MethodPost, "/api/v1/aggregation/sessions", openapi.Route{Summary: "Open an aggregation session", Tag: "aggregation", Request: models.AggregationSessionRequest{}, Response: models.AggregationSessionResponse{}, Status: http.StatusCreated})
+	b.Describe(http.MethodGet, "/api/v1/aggregation/sessions/:id", openapi.Route{Summary: "State of a session", Tag: "aggregation", Response: models.AggregationSessionResponse{}})
+	b.Describe(http.MethodPost, "/api/v1/aggregation/sessions/:id/shares", openapi.Route{Summary: "Submit the shares of a participant", Tag: "aggregation", Request: models.AggregationSharesRequest{}, Response: models.AggregationSessionResponse{}, Status: http.StatusAccepted})
+
+	b.Describe(http.MethodPost, "/api/v1/jobs", openapi.Route{Summary: "Submit a job", Tag: "jobs", Request: models.JobRequest{}, Response: models.JobResponse{}, Status: http.StatusAccepted})
+	b.Describe(http.MethodGet, "/api/v1/jobs/:id", openapi.Route{Summary: "State and result of a job", Tag: "jobs", Response: models.JobResponse{}})
+	b.Describe(http.MethodDelete, "/api/v1/jobs/:id", openapi.Route{Summary: "Cancel a job", Tag: "jobs", Response: models.JobResponse{}})
+	b.Describe(http.MethodGet, "/api/v1/webhooks/secret", openapi.Route{Summary: "Key the caller's callbacks are signed with", Tag: "jobs", Response: models.WebhookSecretResponse{}})
+	b.Describe(http.MethodGet, "/api/v1/webhooks/dead-letters", openapi.Route{Summary: "Undelivered job callbacks", Tag: "jobs", Response: models.DeadLetterListResponse{}})
+	b.Describe(http.MethodGet, "/api/v1/webhooks/dead-letters/:id", openapi.Route{Summary: "Undelivered job callback", Tag: "jobs", Response: models.DeadLetterDetailResponse{}})
+
+	b.Describe(http.MethodGet, "/api/v1/stream/sum/ws", openapi.Route{Summary: "Running sum over WebSocket", Tag: "streams"})
+	b.Describe(http.MethodGet, "/api/v1/stream/sum/events", openapi.Route{Summary: "Running sum as Server-Sent Events", Tag: "streams"})
+	b.Describe(http.MethodPost, "/api/v1/stream/sum/:id", openapi.Route{Summary: "Push numbers into a Server-Sent Events stream", Tag: "streams", Request: models.StreamPushRequest{}})
+	b.Describe(http.MethodGet, "/api/v1/windows", openapi.Route{Summary: "Named streams", Tag: "streams", Response: models.WindowStreamsResponse{}})
+	b.Describe(http.MethodGet, "/api/v1/windows/:name", openapi.Route{
+		Summary:  "Sums, counts and means over time windows of a stream",
+		Tag:      "streams",
+		Response: models.WindowQueryResponse{},
+		Parameters: []*openapi.Parameter{
+			queryParameter("type", "sliding or tumbling", false, &openapi.Schema{Type: openapi.Types{"string"}, Enum: []interface{}{"sliding", "tumbling"}}),
+			queryParameter("size", "Window length, such as 5m", true, &openapi.Schema{Type: openapi.Types{"string"}}),
+			queryParameter("step", "Distance between sliding windows", false, &openapi.Schema{Type: openapi.Types{"string"}}),
+			queryParameter("from", "Start of the queried range", false, &openapi.Schema{Type: openapi.Types{"string"}, Format: "date-time"}),
+			queryParameter("to", "End of the queried range", false, &openapi.Schema{Type: openapi.Types{"string"}, Format: "date-time"}),
+		},
+	})
+	b.Describe(http.MethodDelete, "/api/v1/windows/:name", openapi.Route{Summary: "Delete a stream", Tag: "streams", Status: http.StatusNoContent})
+	b.Describe(http.MethodPost, "/api/v1/windows/:name/points", openapi.Route{Summary: "Append points to a stream", Tag: "streams", Request: models.WindowAppendRequest{}, Response: models.WindowAppendResponse{}})
+
+	minLimit := 1.0
+	b.Describe(http.MethodGet, "/api/v1/history", openapi.Route{
+		Summary:  "Requests recorded for the consumer",
+		Tag:      "service",
+		Response: models.HistoryListResponse{},
+		Parameters: []*openapi.Parameter{
+			queryParameter("from", "Earliest recording time", false, &openapi.Schema{Type: openapi.Types{"string"}, Format: "date-time"}),
+			queryParameter("to", "Latest recording time", false, &openapi.Schema{Type: openapi.Types{"string"}, Format: "date-time"}),
+			queryParameter("cursor", "Cursor of the next page", false, &openapi.Schema{Type: openapi.Types{"string"}}),
+			queryParameter("limit", "Entries per page", false, &openapi.Schema{Type: openapi.Types{"integer"}, Minimum: &minLimit}),
+		},
+	})
+	b.Describe(http.MethodGet, "/api/v1/history/:request_id", openapi.Route{Summary: "Recorded request and result", Tag: "service", Response: models.HistoryEntryResponse{}})
+
+	doc := b.Build(routes)
+
+	// JSON-RPC batches are arrays of calls, answered by arrays of responses
+	if op, ok := doc.Operation(http.MethodPost, "/rpc"); ok {
+		batchOf(op.RequestBody.Content)
+		batchOf(op.Responses["200"].Content)
+	}
+	return doc
+}
+
+// batchOf lets the bodies described by content be a value or an array of values
+func batchOf(content map[string]openapi.MediaType) {
+	for mediaType, media := range content {
+		media.Schema = &openapi.Schema{AnyOf: []*openapi.Schema{media.Schema, {Type: openapi.Types{"array"}, Items: media.Schema}}}
+		content[mediaType] = media
+	}
+}
+
+// queryParameter describes a query parameter read by a handler
+func queryParameter(name, description string, required bool, schema *openapi.Schema) *openapi.Parameter {
+	return &openapi.Parameter{Name: name, In: openapi.InQuery, Description: description, Required: required, Schema: schema}
+}
@@ -1,16 +1,20 @@
 package server
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/katvio/api-go-service/internal/config"
 	"github.com/katvio/api-go-service/internal/handlers"
 	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/openapi"
 	"github.com/katvio/api-go-service/pkg/logger"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // SetupRoutes configures all routes for the application
-func SetupRoutes(cfg *config.Config, log *logger.Logger) *gin.Engine {
+func SetupRoutes(cfg *config.Config, log *logger.Logger, svc *Services) *gin.Engine {
 	// Set Gin mode based on environment
 	if cfg.IsProduction() {
 		gin.SetMode(gin.ReleaseMode)
@@ -27,14 +31,63 @@ func SetupRoutes(cfg *config.Config, log *logger.Logger) *gin.Engine {
 	// Global middleware
 	router.Use(middleware.RecoveryMiddleware(log))
 	router.Use(middleware.LoggingMiddleware(log))
+	router.Use(middleware.ConsumerMiddleware())
+	router.Use(middleware.LocaleMiddleware())
 
 	if cfg.Metrics.Enabled {
 		router.Use(middleware.MetricsMiddleware())
 	}
 
+	// Recorded before idempotency so that replayed responses appear in the history too
+	if cfg.History.Enabled {
+		router.Use(middleware.HistoryMiddleware(svc.History, middleware.HistoryOptions{
+			Consumers:    cfg.History.Consumers,
+			RedactInputs: cfg.History.RedactInputs,
+			MaxBodyBytes: cfg.History.MaxBodyBytes,
+		}, log))
+	}
+
+	if cfg.Idempotency.Enabled {
+		router.Use(middleware.IdempotencyMiddleware(svc.Idempotency, cfg.Idempotency.TTL, cfg.Idempotency.MaxBodyBytes, log))
+	}
+
+	// Requests are checked against the API contract before any handler runs;
+	// outside production the responses are checked too
+	if cfg.OpenAPI.Validate {
+		router.Use(middleware.OpenAPIMiddleware(func() (*openapi.Document, error) {
+			if svc.Contract != nil {
+				return svc.Contract, nil
+			}
+			return buildOpenAPI(cfg, router.Routes()), nil
+		}, middleware.OpenAPIOptions{
+			ValidateResponses: !cfg.IsProduction(),
+			FailOnDrift:       cfg.OpenAPI.FailOnDrift,
+			MaxBodyBytes:      cfg.OpenAPI.MaxBodyBytes,
+		}, log))
+	}
+
+	// Innermost global middleware, so that the middlewares above record rendered errors
+	router.Use(middleware.ErrorMiddleware(log))
+	models.SetErrorDocsBase(cfg.Errors.DocsBaseURL)
+
 	// Initialize handlers
-	healthHandler := handlers.NewHealthHandler(log, getVersion())
-	sumHandler := handlers.NewSumHandler(log)
+	healthHandler := svc.Health
+	sumHandler := handlers.NewSumHandler(log, svc.Privacy)
+	linalgHandler := handlers.NewLinalgHandler(log, cfg.Linalg.MaxElements)
+	modularHandler := handlers.NewModularHandler(log, cfg.Modular)
+	paillierHandler := handlers.NewPaillierHandler(log, cfg.Paillier)
+	aggregationHandler := handlers.NewAggregationHandler(log, cfg.Aggregation, cfg.Modular.MaxModulusBits, svc.Aggregations)
+	jobsHandler := handlers.NewJobsHandler(log, cfg.Jobs, cfg.Webhook, svc.Jobs, paillierHandler)
+	webhookHandler := handlers.NewWebhookHandler(log, svc.Webhooks)
+	streamHandler := handlers.NewStreamHandler(log, cfg.Stream, svc.Streams)
+	windowHandler := handlers.NewWindowHandler(log, cfg.Window, svc.Windows)
+	groupByHandler := handlers.NewGroupByHandler(log, cfg.GroupBy)
+	uploadHandler := handlers.NewUploadHandler(log, cfg.Upload)
+	rpcHandler := handlers.NewRPCHandler(log, cfg.RPC.MaxBatch, sumHandler, healthHandler)
+	errorCatalogHandler := handlers.NewErrorCatalogHandler(log, router.Routes)
+	openapiHandler := handlers.NewOpenAPIHandler(log, func() *openapi.Document {
+		return buildOpenAPI(cfg, router.Routes())
+	})
 
 	// Health check routes (no API key required)
 	router.GET(cfg.Health.Path, healthHandler.HandleHealth)
@@ -46,13 +99,146 @@ func SetupRoutes(cfg *config.Config, log *logger.Logger) *gin.Engine {
 		router.GET(cfg.Metrics.Path, gin.WrapH(promhttp.Handler()))
 	}
 
+	// Error catalog, the target of problem type URIs (no API key required)
+	router.GET(models.ErrorCatalogPath, errorCatalogHandler.HandleList)
+	router.GET(models.ErrorCatalogPath+"/:code", errorCatalogHandler.HandleGet)
+
+	// API description generated from the routes and the model types (no API key required)
+	router.GET(handlers.OpenAPIJSONPath, openapiHandler.HandleJSON)
+	router.GET(handlers.OpenAPIYAMLPath, openapiHandler.HandleYAML)
+	router.GET(handlers.DocsPath, openapiHandler.HandleDocs)
+
+	// Deterministic endpoints get ETags and may be served from the response cache;
+	// identical requests that miss the cache share one computation
+	deterministic := []gin.HandlerFunc{middleware.ResponseCacheMiddleware(svc.Responses, cfg.Cache.MaxAge)}
+	if cfg.Coalesce.Enabled {
+		deterministic = append(deterministic, middleware.CoalesceMiddleware())
+	}
+
 	// API routes (versioned)
 	v1 := router.Group("/api/v1")
 	{
 		// Sum endpoint
-		v1.POST("/sum", sumHandler.HandleSum)
-		v1.GET("/sum", sumHandler.HandleSumGet) // Info endpoint
+		v1.POST("/sum", append(deterministic, sumHandler.HandleSum)...)
+		v1.GET("/sum", openapiHandler.HandleOperation(http.MethodPost, "/api/v1/sum")) // Info endpoint
+		v1.GET("/privacy/budget", sumHandler.HandlePrivacyBudget)
+		v1.POST("/sum/upload", uploadHandler.HandleSumUpload) // Column totals of CSV and JSON Lines files
+
+		// Per-group sums, counts, minimums and maximums of keyed records
+		v1.POST("/aggregate", groupByHandler.HandleAggregate)
+
+		// Linear algebra endpoints
+		linalg := v1.Group("/linalg", deterministic...)
+		{
+			linalg.POST("/vector/add", linalgHandler.HandleVectorAdd)
+			linalg.POST("/vector/scale", linalgHandler.HandleVectorScale)
+			linalg.POST("/vector/dot", linalgHandler.HandleVectorDot)
+			linalg.POST("/matrix/add", linalgHandler.HandleMatrixAdd)
+			linalg.POST("/matrix/multiply", linalgHandler.HandleMatrixMultiply)
+			linalg.POST("/matrix/transpose", linalgHandler.HandleMatrixTranspose)
+			linalg.POST("/matrix/row-sums", linalgHandler.HandleRowSums)
+			linalg.POST("/matrix/column-sums", linalgHandler.HandleColumnSums)
+		}
+
+		// Modular arithmetic over Z_q
+		modular := v1.Group("/modular", deterministic...)
+		{
+			modular.POST("/sum", modularHandler.HandleModularSum)
+			modular.POST("/product", modularHandler.HandleModularProduct)
+			modular.POST("/reduce", modularHandler.HandleModularReduce)
+		}
+
+		// Polynomial ring arithmetic in Z_q[X]/(X^n+1)
+		ring := v1.Group("/ring", deterministic...)
+		{
+			ring.POST("/add", modularHandler.HandleRingAdd)
+			ring.POST("/multiply", modularHandler.HandleRingMultiply)
+			ring.POST("/reduce", modularHandler.HandleRingReduce)
+		}
+
+		// Additively homomorphic encrypted sums
+		encrypted := v1.Group("/paillier")
+		{
+			encrypted.POST("/keys", paillierHandler.HandleRegisterKey)
+			encrypted.GET("/keys/:id", paillierHandler.HandleGetKey)
+			encrypted.POST("/sum", paillierHandler.HandleEncryptedSum)
+		}
+
+		// Secure aggregation over additive secret shares
+		aggregation := v1.Group("/aggregation")
+		{
+			aggregation.POST("/sessions", aggregationHandler.HandleCreateSession)
+			aggregation.GET("/sessions/:id", aggregationHandler.HandleGetSession)
+			aggregation.POST("/sessions/:id/shares", aggregationHandler.HandleSubmitShares)
+		}
+
+		// Asynchronous jobs for long-running computations
+		jobs := v1.Group("/jobs")
+		{
+			jobs.POST("", jobsHandler.HandleSubmitJob)
+			jobs.GET("/:id", jobsHandler.HandleGetJob)
+			jobs.DELETE("/:id", jobsHandler.HandleCancelJob)
+		}
+
+		// Live running sums over WebSocket and Server-Sent Events
+		streams := v1.Group("/stream/sum")
+		{
+			streams.GET("/ws", streamHandler.HandleWebSocket)
+			streams.GET("/events", streamHandler.HandleEvents)
+			streams.POST("/:id", streamHandler.HandlePush)
+		}
+
+		// Sums, counts and means over time windows of named streams
+		windows := v1.Group("/windows")
+		{
+			windows.GET("", windowHandler.HandleList)
+			windows.GET("/:name", windowHandler.HandleQuery)
+			windows.DELETE("/:name", windowHandler.HandleDelete)
+			windows.POST("/:name/points", windowHandler.HandleAppend)
+		}
+
+		// Callback signing secrets and job completion callbacks that could not be delivered
+		webhooks := v1.Group("/webhooks")
+		{
+			webhooks.GET("/secret", webhookHandler.HandleGetSecret)
+			webhooks.GET("/dead-letters", webhookHandler.HandleListDeadLetters)
+			webhooks.GET("/dead-letters/:id", webhookHandler.HandleGetDeadLetter)
+		}
+
+		// Requests and results recorded for the calling consumer
+		if cfg.History.Enabled {
+			historyHandler := handlers.NewHistoryHandler(log, cfg.History, svc.History)
+			v1.GET("/history", historyHandler.HandleList)
+			v1.GET("/history/:request_id", historyHandler.HandleGet)
+		}
+	}
+
+	v1Endpoints := gin.H{
+		"sum":         "/api/v1/sum",
+		"linalg":      "/api/v1/linalg",
+		"modular":     "/api/v1/modular",
+		"ring":        "/api/v1/ring",
+		"paillier":    "/api/v1/paillier",
+		"aggregation": "/api/v1/aggregation",
+		"privacy":     "/api/v1/privacy/budget",
+		"jobs":        "/api/v1/jobs",
+		"webhooks":    "/api/v1/webhooks/dead-letters",
+		"stream":      "/api/v1/stream/sum",
+		"windows":     "/api/v1/windows",
+		"aggregate":   "/api/v1/aggregate",
+		"upload":      "/api/v1/sum/upload",
 	}
+	if cfg.History.Enabled {
+		v1Endpoints["history"] = "/api/v1/history"
+	}
+
+	// Unknown paths and methods get JSON errors; 405 responses list the allowed methods
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(errorCatalogHandler.HandleNoRoute)
+	router.NoMethod(errorCatalogHandler.HandleNoMethod)
+
+	// JSON-RPC 2.0 endpoint dispatching to the same logic as the REST routes
+	router.POST("/rpc", rpcHandler.HandleRPC)
 
 	// Root endpoint - API information
 	router.GET("/", func(c *gin.Context) {
@@ -63,10 +249,12 @@ func SetupRoutes(cfg *config.Config, log *logger.Logger) *gin.Engine {
 			"endpoints": gin.H{
 				"health":  cfg.Health.Path,
 				"metrics": cfg.Metrics.Path,
+				"rpc":     "/rpc",
+				"errors":  models.ErrorCatalogPath,
+				"openapi": handlers.OpenAPIJSONPath,
+				"docs":    handlers.DocsPath,
 				"api": gin.H{
-					"v1": gin.H{
-						"sum": "/api/v1/sum",
-					},
+					"v1": v1Endpoints,
 				},
 			},
 		})
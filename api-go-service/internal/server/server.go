@@ -2,34 +2,56 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/grpcapi"
 	"github.com/katvio/api-go-service/internal/middleware"
+	"github.com/katvio/api-go-service/internal/models"
 	"github.com/katvio/api-go-service/pkg/logger"
 )
 
-// Server represents the HTTP server
+// Server represents the HTTP server and the gRPC server next to it
 type Server struct {
 	httpServer *http.Server
+	grpcServer *grpcapi.Server // nil unless gRPC is enabled
+	services   *Services
 	config     *config.Config
 	logger     *logger.Logger
 }
 
 // New creates a new server instance
-func New(cfg *config.Config, log *logger.Logger) *Server {
+func New(cfg *config.Config, log *logger.Logger) (*Server, error) {
 	// Initialize metrics if enabled
 	if cfg.Metrics.Enabled {
 		middleware.InitMetrics(getVersion(), cfg.Server.Environment)
 	}
 
-	// Setup routes
-	router := SetupRoutes(cfg, log)
+	// Request models of both transports check their validate tags against these limits
+	models.SetLimits(models.Limits{
+		models.LimitSumMinNumbers:     float64(cfg.Validation.SumMinNumbers),
+		models.LimitSumMaxNumbers:     float64(cfg.Validation.SumMaxNumbers),
+		models.LimitPrivacyMaxEpsilon: cfg.Privacy.MaxEpsilon,
+		models.LimitWindowMaxBatch:    float64(cfg.Window.MaxBatch),
+		// Values may be twice as wide as a modulus, and ciphertexts as n squared
+		models.LimitIntegerMaxBits: float64(2 * max(cfg.Modular.MaxModulusBits, cfg.Paillier.MaxKeyBits)),
+		// HTTP bodies are read up to this size before they are decoded
+		models.LimitRequestMaxBytes: float64(cfg.Server.MaxBodyBytes),
+	})
+
+	// Create long-lived components and setup routes
+	services, err := NewServices(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	router := SetupRoutes(cfg, log, services)
 
 	// Create HTTP server
 	httpServer := &http.Server{
@@ -39,18 +61,46 @@ func New(cfg *config.Config, log *logger.Logger) *Server {
 		WriteTimeout: cfg.Server.WriteTimeout,
 	}
 
+	// Streaming connections never go idle on their own; end them as soon as shutdown begins
+	httpServer.RegisterOnShutdown(services.Streams.Close)
+
+	// Create gRPC server, sharing the services and readiness checks of the HTTP API
+	var grpcServer *grpcapi.Server
+	if cfg.GRPC.Enabled {
+		grpcServer, err = grpcapi.NewServer(cfg, log, services.Privacy, services.Health.Ready)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Server{
 		httpServer: httpServer,
+		grpcServer: grpcServer,
+		services:   services,
 		config:     cfg,
 		logger:     log,
-	}
+	}, nil
 }
 
-// Start starts the HTTP server
+// Start starts the HTTP and gRPC servers
 func (s *Server) Start() error {
 	// Log service startup
 	s.logger.LogServiceStart("zama-api-service", getVersion(), s.config.Server.Port)
 
+	// Start gRPC server first, so a port conflict fails startup cleanly
+	if s.grpcServer != nil {
+		if err := s.grpcServer.Start(); err != nil {
+			s.logger.LogError(err, "server", "start_grpc_server", map[string]interface{}{
+				"port": s.config.GRPC.Port,
+				"host": s.config.Server.Host,
+			})
+			return err
+		}
+	}
+
+	// Start background work
+	s.services.Start(s.config)
+
 	// Start server in a goroutine
 	go func() {
 		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -71,7 +121,7 @@ func (s *Server) Start() error {
 	return nil
 }
 
-// Stop gracefully stops the HTTP server
+// Stop gracefully stops the HTTP and gRPC servers
 func (s *Server) Stop() error {
 	s.logger.LogServiceStop("zama-api-service")
 
@@ -79,9 +129,29 @@ func (s *Server) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.config.Server.ShutdownTimeout)
 	defer cancel()
 
-	// Shutdown server
-	if err := s.httpServer.Shutdown(ctx); err != nil {
+	// Shutdown both servers concurrently under the same deadline
+	var wg sync.WaitGroup
+	if s.grpcServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.grpcServer.Stop(ctx)
+		}()
+	}
+
+	err := s.httpServer.Shutdown(ctx)
+	wg.Wait()
+	if err != nil {
 		s.logger.LogError(err, "server", "shutdown", nil)
+	}
+
+	// Stop background work once no more requests are in flight, or once the
+	// deadline has passed; stores are closed even if shutdown was cut short
+	if stopErr := s.services.Stop(ctx); stopErr != nil {
+		s.logger.LogError(stopErr, "server", "stop_services", nil)
+		err = errors.Join(err, stopErr)
+	}
+	if err != nil {
 		return err
 	}
 
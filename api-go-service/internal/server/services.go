@@ -0,0 +1,237 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/katvio/api-go-service/internal/cache"
+	"github.com/katvio/api-go-service/internal/config"
+	"github.com/katvio/api-go-service/internal/handlers"
+	"github.com/katvio/api-go-service/internal/history"
+	"github.com/katvio/api-go-service/internal/idempotency"
+	"github.com/katvio/api-go-service/internal/jobs"
+	"github.com/katvio/api-go-service/internal/models"
+	"github.com/katvio/api-go-service/internal/openapi"
+	"github.com/katvio/api-go-service/internal/privacy"
+	"github.com/katvio/api-go-service/internal/secagg"
+	"github.com/katvio/api-go-service/internal/storage"
+	"github.com/katvio/api-go-service/internal/stream"
+	"github.com/katvio/api-go-service/internal/webhook"
+	"github.com/katvio/api-go-service/internal/window"
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// Services holds the long-lived components shared by the routes
+// Their background work is started and stopped with the server
+type Services struct {
+	Health       *handlers.HealthHandler // readiness checks of both transports
+	Storage      storage.Store
+	Aggregations *secagg.Manager
+	Privacy      *privacy.Accountant
+	Jobs         *jobs.Manager
+	Webhooks     *webhook.Dispatcher
+	Idempotency  idempotency.Store
+	Responses    *cache.LRU // nil unless the response cache is enabled
+	Streams      *stream.Manager
+	Windows      *window.Store
+	History      history.Store     // nil unless the history is enabled
+	Contract     *openapi.Document // nil unless OPENAPI_SPEC_PATH is set
+
+	historySweeper *history.Sweeper
+}
+
+// NewServices creates the long-lived components
+// It fails when a persistent store cannot be opened
+func NewServices(cfg *config.Config, log *logger.Logger) (*Services, error) {
+	contract, err := loadContract(cfg.OpenAPI)
+	if err != nil {
+		return nil, err
+	}
+	db, err := OpenStorage(cfg.Storage, log)
+	if err != nil {
+		return nil, err
+	}
+	accountant, err := privacy.NewPersistentAccountant(cfg.Privacy.Budget, db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to load privacy budgets: %w", err)
+	}
+	idempotencyStore, err := idempotency.NewPersistentStore(db, cfg.Idempotency.MaxKeys, cfg.Idempotency.MaxBytes)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to load idempotency records: %w", err)
+	}
+
+	webhooks := webhook.NewDispatcher(log, webhook.Options{
+		Secret:         cfg.Webhook.Secret,
+		MaxAttempts:    cfg.Webhook.MaxAttempts,
+		InitialBackoff: cfg.Webhook.InitialBackoff,
+		MaxBackoff:     cfg.Webhook.MaxBackoff,
+		Timeout:        cfg.Webhook.Timeout,
+		MaxDeadLetters: cfg.Webhook.MaxDeadLetters,
+	})
+
+	var responses *cache.LRU
+	if cfg.Cache.Enabled {
+		responses = cache.NewLRU(cfg.Cache.MaxEntries, cfg.Cache.MaxBytes)
+	}
+
+	var historyStore history.Store
+	var historySweeper *history.Sweeper
+	if cfg.History.Enabled {
+		if historyStore, err = openHistoryStore(cfg.History); err != nil {
+			db.Close()
+			return nil, err
+		}
+		historySweeper = history.NewSweeper(log, historyStore, cfg.History.Retention)
+	}
+
+	health := handlers.NewHealthHandler(log, getVersion())
+	health.AddReadinessCheck("storage", db.Ping)
+
+	return &Services{
+		Health:       health,
+		Storage:      db,
+		Aggregations: secagg.NewManager(log, cfg.Aggregation.Retention, cfg.Aggregation.MaxSessions, cfg.Aggregation.MaxPerConsumer),
+		Privacy:      accountant,
+		Jobs: jobs.NewManager(log, jobs.Options{
+			Workers:        cfg.Jobs.Workers,
+			QueueSize:      cfg.Jobs.QueueSize,
+			MaxPerConsumer: cfg.Jobs.MaxPerConsumer,
+			Retention:      cfg.Jobs.Retention,
+			OnFinish:       notifyJobFinished(webhooks),
+			Archive:        jobs.NewStorageArchive(db),
+		}),
+		Webhooks:    webhooks,
+		Idempotency: idempotencyStore,
+		Responses:   responses,
+		Streams: stream.NewManager(log, stream.Options{
+			MaxConnections: cfg.Stream.MaxConnections,
+			MaxPerConsumer: cfg.Stream.MaxPerConsumer,
+			MaxMessages:    cfg.Stream.MaxMessages,
+			MaxNumbers:     cfg.Stream.MaxNumbers,
+			IdleTimeout:    cfg.Stream.IdleTimeout,
+		}),
+		Windows: window.NewStore(log, window.Options{
+			Retention:  cfg.Window.Retention,
+			Lateness:   cfg.Window.Lateness,
+			MaxPoints:  cfg.Window.MaxPoints,
+			MaxStreams: cfg.Window.MaxStreams,
+			MaxWindows: cfg.Window.MaxWindows,
+		}),
+		History:        historyStore,
+		Contract:       contract,
+		historySweeper: historySweeper,
+	}, nil
+}
+
+// loadContract reads the OpenAPI document that requests are validated against
+// It returns nil when validation is off or uses the generated document
+func loadContract(cfg config.OpenAPIConfig) (*openapi.Document, error) {
+	if !cfg.Validate || cfg.SpecPath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(cfg.SpecPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+	doc, err := openapi.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.SpecPath, err)
+	}
+	return doc, nil
+}
+
+// Start starts background work
+func (s *Services) Start(cfg *config.Config) {
+	s.Aggregations.Start(cfg.Aggregation.SweepInterval)
+	s.Jobs.Start(cfg.Jobs.SweepInterval)
+	s.Streams.Start(cfg.Stream.SweepInterval)
+	s.Windows.Start(cfg.Window.SweepInterval)
+	if s.historySweeper != nil {
+		s.historySweeper.Start(cfg.History.SweepInterval)
+	}
+}
+
+// Stop stops background work and closes the stores
+// Pending jobs and callbacks are drained until ctx is done and abandoned afterwards
+func (s *Services) Stop(ctx context.Context) error {
+	s.Streams.Stop(ctx)
+	s.Jobs.Stop(ctx)
+	s.Webhooks.Stop(ctx)
+	s.Aggregations.Stop()
+	s.Windows.Stop()
+
+	var err error
+	if s.History != nil {
+		s.historySweeper.Stop()
+		if closeErr := s.History.Close(); closeErr != nil {
+			err = fmt.Errorf("failed to close history store: %w", closeErr)
+		}
+	}
+	if closeErr := s.Storage.Close(); closeErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close storage: %w", closeErr))
+	}
+	return err
+}
+
+// OpenStorage opens the configured storage backend and applies pending migrations
+func OpenStorage(cfg config.StorageConfig, log *logger.Logger) (storage.Store, error) {
+	db, err := storage.Open(cfg.Backend, cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := storage.Migrate(db, storage.Migrations)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if from != to {
+		log.WithFields(map[string]interface{}{
+			"component": "storage",
+			"operation": "migrate",
+			"backend":   cfg.Backend,
+			"from":      from,
+			"to":        to,
+		}).Info("Storage schema migrated")
+	}
+	return db, nil
+}
+
+// openHistoryStore opens the configured history backend
+func openHistoryStore(cfg config.HistoryConfig) (history.Store, error) {
+	switch cfg.Backend {
+	case history.BackendMemory:
+		return history.NewMemoryStore(cfg.MaxEntries), nil
+	case history.BackendBolt:
+		return history.OpenBoltStore(cfg.Path, cfg.MaxEntries)
+	default:
+		return nil, fmt.Errorf("unknown history backend %q", cfg.Backend)
+	}
+}
+
+// notifyJobFinished posts the final job state to the job's callback URL
+// The body is the same document GET /api/v1/jobs/{id} returns; a state that
+// cannot be encoded is dead-lettered with the encoding error
+func notifyJobFinished(webhooks *webhook.Dispatcher) func(*jobs.Snapshot) {
+	return func(snap *jobs.Snapshot) {
+		delivery := webhook.Delivery{
+			URL:       snap.Callback,
+			Owner:     snap.Owner,
+			RequestID: snap.RequestID,
+			Subject:   snap.ID,
+		}
+
+		body, err := json.Marshal(models.NewJobResponse(snap, snap.RequestID))
+		if err != nil {
+			webhooks.Reject(delivery, fmt.Errorf("failed to encode job state: %w", err))
+			return
+		}
+		delivery.Body = body
+		webhooks.Enqueue(delivery)
+	}
+}
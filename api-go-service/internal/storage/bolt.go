@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltStore is a Store kept in a bbolt database file
+// The file is locked while open, so only one process can use it at a time
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens or creates the database at path
+func OpenBoltStore(path string) (*BoltStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open storage %s: %w", path, err)
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// View implements Store
+func (s *BoltStore) View(fn func(Tx) error) error {
+	return boltError(s.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	}))
+}
+
+// Update implements Store
+func (s *BoltStore) Update(fn func(Tx) error) error {
+	return boltError(s.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	}))
+}
+
+// Ping implements Store
+func (s *BoltStore) Ping() error {
+	return s.View(func(Tx) error { return nil })
+}
+
+// Close implements Store
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// boltTx adapts a bbolt transaction to Tx
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+// Get implements Tx
+func (t *boltTx) Get(bucket, key string) []byte {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+	return b.Get([]byte(key))
+}
+
+// Put implements Tx
+func (t *boltTx) Put(bucket, key string, value []byte) error {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return ErrBucketNotFound
+	}
+	return boltError(b.Put([]byte(key), value))
+}
+
+// Delete implements Tx
+func (t *boltTx) Delete(bucket, key string) error {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return ErrBucketNotFound
+	}
+	return boltError(b.Delete([]byte(key)))
+}
+
+// ForEach implements Tx
+// Keys are collected first so that fn may modify the bucket
+func (t *boltTx) ForEach(bucket string, fn func(key string, value []byte) error) error {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return ErrBucketNotFound
+	}
+
+	var keys []string
+	err := b.ForEach(func(k, _ []byte) error {
+		keys = append(keys, string(k))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if value := b.Get([]byte(key)); value != nil {
+			if err := fn(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CreateBucket implements Tx
+func (t *boltTx) CreateBucket(bucket string) error {
+	_, err := t.tx.CreateBucketIfNotExists([]byte(bucket))
+	return boltError(err)
+}
+
+// DeleteBucket implements Tx
+func (t *boltTx) DeleteBucket(bucket string) error {
+	err := t.tx.DeleteBucket([]byte(bucket))
+	if errors.Is(err, bolt.ErrBucketNotFound) {
+		return nil
+	}
+	return boltError(err)
+}
+
+// Buckets implements Tx
+func (t *boltTx) Buckets() []string {
+	var names []string
+	_ = t.tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+		names = append(names, string(name))
+		return nil
+	})
+	return names
+}
+
+// boltError maps bbolt errors to the errors of this package
+func boltError(err error) error {
+	switch {
+	case errors.Is(err, bolt.ErrDatabaseNotOpen):
+		return ErrClosed
+	case errors.Is(err, bolt.ErrTxNotWritable):
+		return ErrReadOnly
+	default:
+		return err
+	}
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+)
+
+// MemoryStore is an in-memory Store
+// Data is lost on restart; it suits tests and single-replica deployments without a volume
+type MemoryStore struct {
+	mu      sync.RWMutex
+	buckets map[string]map[string][]byte
+	closed  bool
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]map[string][]byte)}
+}
+
+// View implements Store
+func (s *MemoryStore) View(fn func(Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+	return fn(&memoryTx{buckets: s.buckets})
+}
+
+// Update implements Store
+// Buckets are copied on their first write so that a failed update leaves the store untouched
+func (s *MemoryStore) Update(fn func(Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+
+	buckets := make(map[string]map[string][]byte, len(s.buckets))
+	for name, bucket := range s.buckets {
+		buckets[name] = bucket
+	}
+	tx := &memoryTx{buckets: buckets, writable: true, copied: make(map[string]bool)}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	s.buckets = tx.buckets
+	return nil
+}
+
+// Ping implements Store
+func (s *MemoryStore) Ping() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+	return nil
+}
+
+// Close implements Store
+func (s *MemoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	return nil
+}
+
+// memoryTx is a transaction of a MemoryStore
+// Writable transactions work on copies of the buckets they modify
+type memoryTx struct {
+	buckets  map[string]map[string][]byte
+	writable bool
+	copied   map[string]bool // buckets already copied by this transaction
+}
+
+// Get implements Tx
+func (tx *memoryTx) Get(bucket, key string) []byte {
+	return tx.buckets[bucket][key]
+}
+
+// Put implements Tx
+func (tx *memoryTx) Put(bucket, key string, value []byte) error {
+	b, err := tx.writableBucket(bucket)
+	if err != nil {
+		return err
+	}
+	b[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete implements Tx
+func (tx *memoryTx) Delete(bucket, key string) error {
+	b, err := tx.writableBucket(bucket)
+	if err != nil {
+		return err
+	}
+	delete(b, key)
+	return nil
+}
+
+// ForEach implements Tx
+func (tx *memoryTx) ForEach(bucket string, fn func(key string, value []byte) error) error {
+	b, ok := tx.buckets[bucket]
+	if !ok {
+		return ErrBucketNotFound
+	}
+
+	keys := make([]string, 0, len(b))
+	for key := range b {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := fn(key, b[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateBucket implements Tx
+func (tx *memoryTx) CreateBucket(bucket string) error {
+	if !tx.writable {
+		return ErrReadOnly
+	}
+	if _, ok := tx.buckets[bucket]; !ok {
+		tx.buckets[bucket] = make(map[string][]byte)
+		tx.copied[bucket] = true
+	}
+	return nil
+}
+
+// DeleteBucket implements Tx
+func (tx *memoryTx) DeleteBucket(bucket string) error {
+	if !tx.writable {
+		return ErrReadOnly
+	}
+	delete(tx.buckets, bucket)
+	delete(tx.copied, bucket)
+	return nil
+}
+
+// Buckets implements Tx
+func (tx *memoryTx) Buckets() []string {
+	names := make([]string, 0, len(tx.buckets))
+	for name := range tx.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writableBucket returns bucket, copying it on the first write of the transaction
+func (tx *memoryTx) writableBucket(bucket string) (map[string][]byte, error) {
+	if !tx.writable {
+		return nil, ErrReadOnly
+	}
+	b, ok := tx.buckets[bucket]
+	if !ok {
+		return nil, ErrBucketNotFound
+	}
+	if !tx.copied[bucket] {
+		copied := make(map[string][]byte, len(b)+1)
+		for key, value := range b {
+			copied[key] = value
+		}
+		tx.buckets[bucket] = copied
+		tx.copied[bucket] = true
+		b = copied
+	}
+	return b, nil
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// schemaVersionKey holds the version of the last applied migration in BucketMeta
+const schemaVersionKey = "schema_version"
+
+// ErrSchemaTooNew is returned when the data was written by a newer release
+var ErrSchemaTooNew = errors.New("storage schema is newer than this release supports")
+
+// Migration upgrades the stored data by one schema version
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(Tx) error
+}
+
+// Migrations lists the schema migrations in version order
+// Released migrations must never change; add a new one instead
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "create idempotency, jobs and privacy budget buckets",
+		Up: func(tx Tx) error {
+			for _, bucket := range []string{BucketIdempotency, BucketJobs, BucketPrivacy} {
+				if err := tx.CreateBucket(bucket); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+}
+
+// SchemaVersion returns the version of the last migration applied to the store
+func SchemaVersion(store Store) (int, error) {
+	version := 0
+	err := store.View(func(tx Tx) error {
+		var err error
+		version, err = schemaVersion(tx)
+		return err
+	})
+	return version, err
+}
+
+// Migrate applies the migrations newer than the schema version of the store
+// Each migration runs in its own transaction together with the version update,
+// so an interrupted upgrade resumes from the last completed migration
+// It returns the schema versions before and after the upgrade
+func Migrate(store Store, migrations []Migration) (from, to int, err error) {
+	if from, err = SchemaVersion(store); err != nil {
+		return 0, 0, err
+	}
+	latest := 0
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].Version
+	}
+	if from > latest {
+		return from, from, fmt.Errorf("%w: schema version %d, latest known %d", ErrSchemaTooNew, from, latest)
+	}
+
+	to = from
+	for _, m := range migrations {
+		if m.Version <= to {
+			continue
+		}
+		err := store.Update(func(tx Tx) error {
+			if err := tx.CreateBucket(BucketMeta); err != nil {
+				return err
+			}
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Put(BucketMeta, schemaVersionKey, []byte(strconv.Itoa(m.Version)))
+		})
+		if err != nil {
+			return from, to, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+		to = m.Version
+	}
+	return from, to, nil
+}
+
+// schemaVersion reads the schema version within a transaction
+func schemaVersion(tx Tx) (int, error) {
+	value := tx.Get(BucketMeta, schemaVersionKey)
+	if value == nil {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(string(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid storage schema version %q", value)
+	}
+	return version, nil
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// snapshotFormat identifies snapshot files
+const snapshotFormat = "api-go-service-snapshot/v1"
+
+// ErrInvalidSnapshot is returned when restoring from something that is not a readable snapshot
+var ErrInvalidSnapshot = errors.New("invalid storage snapshot")
+
+// SnapshotHeader is the first line of a snapshot
+type SnapshotHeader struct {
+	Format        string    `json:"format"`
+	SchemaVersion int       `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// snapshotRecord is one line of a snapshot after the header
+// A line without a key declares a bucket, so that empty buckets are kept
+type snapshotRecord struct {
+	Bucket string  `json:"bucket"`
+	Key    *string `json:"key,omitempty"`
+	Value  []byte  `json:"value,omitempty"`
+}
+
+// Snapshot writes a consistent copy of every bucket of store to w
+// Snapshots are JSON Lines and portable between backends
+func Snapshot(store Store, w io.Writer) (*SnapshotHeader, error) {
+	header := &SnapshotHeader{Format: snapshotFormat, CreatedAt: time.Now().UTC()}
+	buffered := bufio.NewWriter(w)
+	enc := json.NewEncoder(buffered)
+
+	err := store.View(func(tx Tx) error {
+		var err error
+		if header.SchemaVersion, err = schemaVersion(tx); err != nil {
+			return err
+		}
+		if err := enc.Encode(header); err != nil {
+			return err
+		}
+
+		for _, bucket := range tx.Buckets() {
+			if err := enc.Encode(snapshotRecord{Bucket: bucket}); err != nil {
+				return err
+			}
+			err := tx.ForEach(bucket, func(key string, value []byte) error {
+				return enc.Encode(snapshotRecord{Bucket: bucket, Key: &key, Value: value})
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return header, buffered.Flush()
+}
+
+// Restore replaces the whole content of store with the snapshot read from r
+// Nothing is changed unless the snapshot is read completely; snapshots of a
+// newer schema than this release supports are refused
+func Restore(store Store, r io.Reader) (*SnapshotHeader, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+
+	var header SnapshotHeader
+	if err := dec.Decode(&header); err != nil || header.Format != snapshotFormat {
+		return nil, fmt.Errorf("%w: missing %s header", ErrInvalidSnapshot, snapshotFormat)
+	}
+	if latest := LatestVersion(); header.SchemaVersion > latest {
+		return nil, fmt.Errorf("%w: snapshot schema version %d, latest known %d", ErrSchemaTooNew, header.SchemaVersion, latest)
+	}
+
+	err := store.Update(func(tx Tx) error {
+		for _, bucket := range tx.Buckets() {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return err
+			}
+		}
+
+		for line := 2; ; line++ {
+			var record snapshotRecord
+			err := dec.Decode(&record)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil || record.Bucket == "" {
+				return fmt.Errorf("%w: line %d is not a snapshot record", ErrInvalidSnapshot, line)
+			}
+
+			if record.Key == nil {
+				if err := tx.CreateBucket(record.Bucket); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Put(record.Bucket, *record.Key, record.Value); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &header, nil
+}
+
+// LatestVersion returns the schema version reached by Migrations
+func LatestVersion() int {
+	if len(Migrations) == 0 {
+		return 0
+	}
+	return Migrations[len(Migrations)-1].Version
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Supported storage backends
+const (
+	BackendMemory = "memory"
+	BackendBolt   = "bolt"
+)
+
+// Buckets used by the service
+// They are created by the migrations, which must be updated along with this list
+const (
+	BucketMeta        = "meta"
+	BucketIdempotency = "idempotency"
+	BucketJobs        = "jobs"
+	BucketPrivacy     = "privacy_spent"
+)
+
+// Errors returned by stores
+var (
+	ErrBucketNotFound = errors.New("storage bucket not found")
+	ErrClosed         = errors.New("storage is closed")
+	ErrReadOnly       = errors.New("storage transaction is read-only")
+)
+
+// Tx is a transaction over the buckets of a store
+// Values returned by Get and ForEach are only valid until the transaction ends
+// and must not be modified
+type Tx interface {
+	// Get returns the value of key in bucket, or nil if either does not exist
+	Get(bucket, key string) []byte
+
+	// Put sets the value of key in an existing bucket
+	Put(bucket, key string, value []byte) error
+
+	// Delete removes key from an existing bucket
+	Delete(bucket, key string) error
+
+	// ForEach calls fn for every key of bucket in ascending order, stopping at the first error
+	ForEach(bucket string, fn func(key string, value []byte) error) error
+
+	// CreateBucket creates bucket unless it already exists
+	CreateBucket(bucket string) error
+
+	// DeleteBucket removes bucket and its keys unless it does not exist
+	DeleteBucket(bucket string) error
+
+	// Buckets returns the names of all buckets in ascending order
+	Buckets() []string
+}
+
+// Store is a transactional key-value store organised in buckets
+// Transactions see a consistent view; an update is applied only if fn returns nil
+type Store interface {
+	// View runs fn in a read-only transaction
+	View(fn func(Tx) error) error
+
+	// Update runs fn in a read-write transaction
+	Update(fn func(Tx) error) error
+
+	// Ping reports whether the store is usable
+	Ping() error
+
+	// Close releases the resources held by the store
+	Close() error
+}
+
+// Open opens the store of the given backend
+// Path is the database file of the bolt backend and is ignored by the memory backend
+func Open(backend, path string) (Store, error) {
+	switch backend {
+	case BackendMemory:
+		return NewMemoryStore(), nil
+	case BackendBolt:
+		return OpenBoltStore(path)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
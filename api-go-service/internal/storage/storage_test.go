@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backends opens an empty store of each kind
+func backends(t *testing.T) map[string]Store {
+	bolt, err := OpenBoltStore(filepath.Join(t.TempDir(), "service.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { bolt.Close() })
+
+	return map[string]Store{
+		BackendMemory: NewMemoryStore(),
+		BackendBolt:   bolt,
+	}
+}
+
+// keys returns the keys of bucket in iteration order
+func keys(t *testing.T, store Store, bucket string) []string {
+	var out []string
+	require.NoError(t, store.View(func(tx Tx) error {
+		return tx.ForEach(bucket, func(key string, _ []byte) error {
+			out = append(out, key)
+			return nil
+		})
+	}))
+	return out
+}
+
+func TestStore(t *testing.T) {
+	for name, store := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, store.Ping())
+
+			err := store.Update(func(tx Tx) error {
+				return tx.Put("missing", "k", []byte("v"))
+			})
+			assert.ErrorIs(t, err, ErrBucketNotFound)
+
+			require.NoError(t, store.Update(func(tx Tx) error {
+				require.NoError(t, tx.CreateBucket("b"))
+				for _, key := range []string{"c", "a", "b"} {
+					require.NoError(t, tx.Put("b", key, []byte("value-"+key)))
+				}
+				return nil
+			}))
+			assert.Equal(t, []string{"a", "b", "c"}, keys(t, store, "b"))
+
+			t.Run("Failed updates are rolled back", func(t *testing.T) {
+				failure := errors.New("failure")
+				err := store.Update(func(tx Tx) error {
+					require.NoError(t, tx.Delete("b", "a"))
+					require.NoError(t, tx.Put("b", "d", []byte("value-d")))
+					require.NoError(t, tx.CreateBucket("other"))
+					return failure
+				})
+				assert.ErrorIs(t, err, failure)
+				assert.Equal(t, []string{"a", "b", "c"}, keys(t, store, "b"))
+
+				require.NoError(t, store.View(func(tx Tx) error {
+					assert.Equal(t, []string{"b"}, tx.Buckets())
+					return nil
+				}))
+			})
+
+			t.Run("Deleting while iterating", func(t *testing.T) {
+				require.NoError(t, store.Update(func(tx Tx) error {
+					return tx.ForEach("b", func(key string, _ []byte) error {
+						if key != "b" {
+							return tx.Delete("b", key)
+						}
+						return nil
+					})
+				}))
+				assert.Equal(t, []string{"b"}, keys(t, store, "b"))
+			})
+
+			t.Run("Views are read-only", func(t *testing.T) {
+				err := store.View(func(tx Tx) error {
+					assert.Equal(t, "value-b", string(tx.Get("b", "b")))
+					assert.Nil(t, tx.Get("b", "a"))
+					assert.Nil(t, tx.Get("missing", "a"))
+					return tx.Put("b", "x", []byte("y"))
+				})
+				assert.ErrorIs(t, err, ErrReadOnly)
+			})
+
+			require.NoError(t, store.Close())
+			assert.ErrorIs(t, store.Ping(), ErrClosed)
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Description: "one", Up: func(tx Tx) error { return tx.CreateBucket("one") }},
+		{Version: 2, Description: "two", Up: func(tx Tx) error { return tx.Put("one", "k", []byte("v")) }},
+	}
+
+	for name, store := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			from, to, err := Migrate(store, migrations[:1])
+			require.NoError(t, err)
+			assert.Equal(t, 0, from)
+			assert.Equal(t, 1, to)
+
+			from, to, err = Migrate(store, migrations)
+			require.NoError(t, err)
+			assert.Equal(t, 1, from)
+			assert.Equal(t, 2, to)
+
+			// Already up to date
+			from, to, err = Migrate(store, migrations)
+			require.NoError(t, err)
+			assert.Equal(t, 2, from)
+			assert.Equal(t, 2, to)
+
+			// Data written by a newer release is refused
+			_, _, err = Migrate(store, migrations[:1])
+			assert.ErrorIs(t, err, ErrSchemaTooNew)
+		})
+	}
+
+	t.Run("Failed migrations are not recorded", func(t *testing.T) {
+		store := NewMemoryStore()
+		failing := append(migrations[:1:1], Migration{Version: 2, Description: "fails", Up: func(tx Tx) error {
+			return tx.Put("missing", "k", nil)
+		}})
+		_, to, err := Migrate(store, failing)
+		assert.ErrorIs(t, err, ErrBucketNotFound)
+		assert.Equal(t, 1, to)
+
+		version, err := SchemaVersion(store)
+		require.NoError(t, err)
+		assert.Equal(t, 1, version)
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+	source := NewMemoryStore()
+	_, _, err := Migrate(source, Migrations)
+	require.NoError(t, err)
+	require.NoError(t, source.Update(func(tx Tx) error {
+		return tx.Put(BucketJobs, "job_1", []byte(`{"state": "succeeded"}`))
+	}))
+
+	var snapshot bytes.Buffer
+	header, err := Snapshot(source, &snapshot)
+	require.NoError(t, err)
+	assert.Equal(t, LatestVersion(), header.SchemaVersion)
+
+	// Snapshots move between backends and replace the previous content
+	for name, target := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, target.Update(func(tx Tx) error { return tx.CreateBucket("stale") }))
+
+			restored, err := Restore(target, bytes.NewReader(snapshot.Bytes()))
+			require.NoError(t, err)
+			assert.Equal(t, header.SchemaVersion, restored.SchemaVersion)
+
+			require.NoError(t, target.View(func(tx Tx) error {
+				assert.Equal(t, []string{BucketIdempotency, BucketJobs, BucketMeta, BucketPrivacy}, tx.Buckets())
+				assert.JSONEq(t, `{"state": "succeeded"}`, string(tx.Get(BucketJobs, "job_1")))
+				return nil
+			}))
+			version, err := SchemaVersion(target)
+			require.NoError(t, err)
+			assert.Equal(t, LatestVersion(), version)
+		})
+	}
+
+	t.Run("Invalid snapshots change nothing", func(t *testing.T) {
+		target := NewMemoryStore()
+		require.NoError(t, target.Update(func(tx Tx) error { return tx.CreateBucket("kept") }))
+
+		_, err := Restore(target, strings.NewReader(`{"bucket": "b"}`))
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+
+		truncated := snapshot.String() + "{not json"
+		_, err = Restore(target, strings.NewReader(truncated))
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+
+		newer := strings.Replace(snapshot.String(), `"schema_version":1`, `"schema_version":99`, 1)
+		_, err = Restore(target, strings.NewReader(newer))
+		assert.ErrorIs(t, err, ErrSchemaTooNew)
+
+		require.NoError(t, target.View(func(tx Tx) error {
+			assert.Equal(t, []string{"kept"}, tx.Buckets())
+			return nil
+		}))
+	})
+}
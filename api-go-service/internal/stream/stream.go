@@ -0,0 +1,439 @@
+package stream
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Transports a session can be served over
+const (
+	TransportWebSocket = "websocket"
+	TransportSSE       = "sse"
+)
+
+// Reasons a session is closed for
+const (
+	ReasonClientClosed = "client_closed"
+	ReasonIdleTimeout  = "idle_timeout"
+	ReasonMessageLimit = "message_limit"
+	ReasonShutdown     = "shutdown"
+)
+
+// Errors returned by the manager and sessions
+var (
+	ErrSessionNotFound = errors.New("stream not found")
+	ErrSessionClosed   = errors.New("stream is closed")
+	ErrConnectionLimit = errors.New("maximum number of open streams reached")
+	ErrConsumerLimit   = errors.New("maximum number of open streams reached for this consumer")
+	ErrMessageLimit    = errors.New("maximum number of messages reached for this stream")
+	ErrInvalidMessage  = errors.New("invalid message")
+	ErrShuttingDown    = errors.New("stream manager is shutting down")
+)
+
+var (
+	// Open stream connections
+	openConnections = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "stream_connections",
+			Help: "Number of open streaming connections",
+		},
+		[]string{"transport"},
+	)
+
+	// Stream connections accepted
+	openedConnections = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "stream_connections_opened_total",
+			Help: "Total number of streaming connections opened",
+		},
+		[]string{"transport"},
+	)
+
+	// Stream connections closed, by reason
+	closedConnections = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "stream_connections_closed_total",
+			Help: "Total number of streaming connections closed",
+		},
+		[]string{"transport", "reason"},
+	)
+
+	// Messages pushed into streams
+	streamMessages = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "stream_messages_total",
+			Help: "Total number of messages pushed into streams",
+		},
+		[]string{"transport"},
+	)
+)
+
+// Options configures the manager
+type Options struct {
+	MaxConnections int           // open sessions across all consumers, 0 for no limit
+	MaxPerConsumer int           // open sessions per consumer, 0 for no limit
+	MaxMessages    int           // messages accepted per session, 0 for no limit
+	MaxNumbers     int           // numbers accepted per message
+	IdleTimeout    time.Duration // sessions without messages for this long are closed
+}
+
+// Update is the running state of a session after a message
+type Update struct {
+	Sum       float64
+	Count     int
+	Mean      float64
+	Messages  int
+	Timestamp time.Time
+}
+
+// Session is the running sum of one streaming connection
+// The transport pushes numbers into it and forwards its updates to the client
+type Session struct {
+	ID        string
+	Owner     string
+	Transport string
+	CreatedAt time.Time
+
+	manager *Manager
+
+	mu         sync.Mutex
+	sum        float64
+	count      int
+	messages   int
+	lastActive time.Time
+	reason     string
+
+	updates   chan Update // holds the latest update not yet forwarded
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Push adds numbers to the running sum and returns the new state
+// Updates are cumulative, so a client that falls behind only receives the latest one
+func (s *Session) Push(numbers []float64) (Update, error) {
+	if len(numbers) == 0 {
+		return Update{}, fmt.Errorf("%w: at least 1 number is required", ErrInvalidMessage)
+	}
+	if max := s.manager.opts.MaxNumbers; max > 0 && len(numbers) > max {
+		return Update{}, fmt.Errorf("%w: maximum %d numbers allowed per message, got %d", ErrInvalidMessage, max, len(numbers))
+	}
+	for i, n := range numbers {
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return Update{}, fmt.Errorf("%w: number %d is not finite", ErrInvalidMessage, i)
+		}
+	}
+
+	s.mu.Lock()
+	select {
+	case <-s.done:
+		s.mu.Unlock()
+		return Update{}, ErrSessionClosed
+	default:
+	}
+
+	// A stream that used up its messages is closed, so the client reconnects with a fresh sum
+	if max := s.manager.opts.MaxMessages; max > 0 && s.messages >= max {
+		s.mu.Unlock()
+		s.Close(ReasonMessageLimit)
+		return Update{}, ErrMessageLimit
+	}
+	defer s.mu.Unlock()
+
+	// A message that would overflow the sum is refused and leaves the stream unchanged
+	sum := s.sum
+	for _, n := range numbers {
+		sum += n
+	}
+	if math.IsInf(sum, 0) {
+		return Update{}, fmt.Errorf("%w: the numbers overflow the sum of the stream", ErrInvalidMessage)
+	}
+
+	s.sum = sum
+	s.count += len(numbers)
+	s.messages++
+	s.lastActive = s.manager.now()
+	streamMessages.WithLabelValues(s.Transport).Inc()
+
+	update := Update{
+		Sum:       s.sum,
+		Count:     s.count,
+		Mean:      s.sum / float64(s.count),
+		Messages:  s.messages,
+		Timestamp: s.lastActive.UTC(),
+	}
+
+	// Replace an update the transport has not forwarded yet
+	select {
+	case <-s.updates:
+	default:
+	}
+	s.updates <- update
+
+	return update, nil
+}
+
+// Updates delivers the state after each message
+func (s *Session) Updates() <-chan Update {
+	return s.updates
+}
+
+// Done is closed when the session is closed
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Reason returns why the session was closed
+func (s *Session) Reason() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reason
+}
+
+// Close closes the session; the first reason given is kept
+func (s *Session) Close(reason string) {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		s.reason = reason
+		s.mu.Unlock()
+		close(s.done)
+	})
+}
+
+// idleSince reports whether the session has had no messages since cutoff
+func (s *Session) idleSince(cutoff time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastActive.Before(cutoff)
+}
+
+// Manager tracks open sessions and enforces connection limits and idle timeouts
+type Manager struct {
+	logger *logger.Logger
+	opts   Options
+	now    func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+	perOwner map[string]int
+	closed   bool
+	released sync.WaitGroup
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewManager creates a stream session manager
+func NewManager(log *logger.Logger, opts Options) *Manager {
+	return &Manager{
+		logger:   log,
+		opts:     opts,
+		now:      time.Now,
+		sessions: make(map[string]*Session),
+		perOwner: make(map[string]int),
+	}
+}
+
+// Start launches the background sweep that closes idle sessions
+func (m *Manager) Start(sweepInterval time.Duration) {
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(sweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.sweep()
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Close refuses new sessions and closes the open ones
+// Transports see their session closed and end their connections
+func (m *Manager) Close() {
+	m.mu.Lock()
+	m.closed = true
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		sessions = append(sessions, s)
+	}
+	m.mu.Unlock()
+
+	for _, s := range sessions {
+		s.Close(ReasonShutdown)
+	}
+}
+
+// Stop closes all sessions and waits until their transports have released them
+// Connections still open when ctx is done are abandoned
+func (m *Manager) Stop(ctx context.Context) {
+	m.Close()
+
+	if m.stop != nil {
+		close(m.stop)
+		<-m.done
+		m.stop = nil
+	}
+
+	released := make(chan struct{})
+	go func() {
+		m.released.Wait()
+		close(released)
+	}()
+
+	select {
+	case <-released:
+	case <-ctx.Done():
+		m.logger.WithFields(map[string]interface{}{
+			"component": "stream",
+			"operation": "stop",
+		}).Warn("Shutdown deadline reached with streams still open")
+	}
+}
+
+// Open creates a session for owner
+// The transport must call Release once its connection has ended
+func (m *Manager) Open(owner, transport string) (*Session, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrShuttingDown
+	}
+	if m.opts.MaxConnections > 0 && len(m.sessions) >= m.opts.MaxConnections {
+		return nil, ErrConnectionLimit
+	}
+	if m.opts.MaxPerConsumer > 0 && m.perOwner[owner] >= m.opts.MaxPerConsumer {
+		return nil, ErrConsumerLimit
+	}
+
+	now := m.now()
+	s := &Session{
+		ID:         id,
+		Owner:      owner,
+		Transport:  transport,
+		CreatedAt:  now,
+		manager:    m,
+		lastActive: now,
+		updates:    make(chan Update, 1),
+		done:       make(chan struct{}),
+	}
+	m.sessions[id] = s
+	m.perOwner[owner]++
+	m.released.Add(1)
+
+	openConnections.WithLabelValues(transport).Inc()
+	openedConnections.WithLabelValues(transport).Inc()
+	m.event(s, "opened", nil)
+
+	return s, nil
+}
+
+// Get returns an open session of owner
+// Sessions of other consumers are reported as not found
+func (m *Manager) Get(id, owner string) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[id]
+	if !ok || s.Owner != owner {
+		return nil, ErrSessionNotFound
+	}
+	return s, nil
+}
+
+// Release removes a session once its connection has ended
+func (m *Manager) Release(s *Session, reason string) {
+	s.Close(reason)
+
+	m.mu.Lock()
+	if _, ok := m.sessions[s.ID]; !ok {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.sessions, s.ID)
+	if m.perOwner[s.Owner]--; m.perOwner[s.Owner] <= 0 {
+		delete(m.perOwner, s.Owner)
+	}
+	m.mu.Unlock()
+
+	reason = s.Reason()
+	openConnections.WithLabelValues(s.Transport).Dec()
+	closedConnections.WithLabelValues(s.Transport, reason).Inc()
+	m.event(s, "closed", map[string]interface{}{"reason": reason})
+
+	m.released.Done()
+}
+
+// Len returns the number of open sessions
+func (m *Manager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sessions)
+}
+
+// sweep closes sessions that have been idle for longer than the idle timeout
+func (m *Manager) sweep() {
+	if m.opts.IdleTimeout <= 0 {
+		return
+	}
+	cutoff := m.now().Add(-m.opts.IdleTimeout)
+
+	m.mu.Lock()
+	var idle []*Session
+	for _, s := range m.sessions {
+		if s.idleSince(cutoff) {
+			idle = append(idle, s)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, s := range idle {
+		s.Close(ReasonIdleTimeout)
+	}
+}
+
+// event logs a session lifecycle event
+func (m *Manager) event(s *Session, event string, fields map[string]interface{}) {
+	entry := m.logger.WithFields(map[string]interface{}{
+		"component": "stream",
+		"event":     event,
+		"stream_id": s.ID,
+		"owner":     s.Owner,
+		"transport": s.Transport,
+	})
+	if fields != nil {
+		entry = entry.WithFields(fields)
+	}
+	entry.Info("Stream event")
+}
+
+// newSessionID returns a random session identifier
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "stream_" + hex.EncodeToString(b), nil
+}
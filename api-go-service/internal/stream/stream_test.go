@@ -0,0 +1,146 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestManager(opts Options) *Manager {
+	return NewManager(logger.New("error", "json"), opts)
+}
+
+func TestSession_Push(t *testing.T) {
+	m := setupTestManager(Options{MaxNumbers: 3, MaxMessages: 2})
+	s, err := m.Open("alice", TransportWebSocket)
+	require.NoError(t, err)
+
+	update, err := s.Push([]float64{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, 6.0, update.Sum)
+	assert.Equal(t, 3, update.Count)
+	assert.Equal(t, 2.0, update.Mean)
+	assert.Equal(t, 1, update.Messages)
+
+	_, err = s.Push([]float64{1, 2, 3, 4})
+	assert.True(t, errors.Is(err, ErrInvalidMessage))
+	_, err = s.Push(nil)
+	assert.True(t, errors.Is(err, ErrInvalidMessage))
+	_, err = s.Push([]float64{math.Inf(1)})
+	assert.True(t, errors.Is(err, ErrInvalidMessage))
+
+	// An overflowing message leaves the sum unchanged
+	_, err = s.Push([]float64{math.MaxFloat64, math.MaxFloat64})
+	assert.True(t, errors.Is(err, ErrInvalidMessage))
+
+	update, err = s.Push([]float64{4})
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, update.Sum)
+	assert.Equal(t, 2.5, update.Mean)
+
+	// Only the latest update is pending for the transport
+	assert.Equal(t, update, <-s.Updates())
+
+	_, err = s.Push([]float64{1})
+	assert.True(t, errors.Is(err, ErrMessageLimit))
+	<-s.Done()
+	assert.Equal(t, ReasonMessageLimit, s.Reason())
+
+	_, err = s.Push([]float64{1})
+	assert.True(t, errors.Is(err, ErrSessionClosed))
+}
+
+func TestManager_Limits(t *testing.T) {
+	m := setupTestManager(Options{MaxConnections: 3, MaxPerConsumer: 2})
+
+	a1, err := m.Open("alice", TransportSSE)
+	require.NoError(t, err)
+	_, err = m.Open("alice", TransportSSE)
+	require.NoError(t, err)
+	_, err = m.Open("alice", TransportSSE)
+	assert.True(t, errors.Is(err, ErrConsumerLimit))
+
+	_, err = m.Open("bob", TransportSSE)
+	require.NoError(t, err)
+	_, err = m.Open("carol", TransportSSE)
+	assert.True(t, errors.Is(err, ErrConnectionLimit))
+
+	m.Release(a1, ReasonClientClosed)
+	assert.Equal(t, 2, m.Len())
+	_, err = m.Open("alice", TransportSSE)
+	require.NoError(t, err)
+}
+
+func TestManager_Get(t *testing.T) {
+	m := setupTestManager(Options{})
+	s, err := m.Open("alice", TransportSSE)
+	require.NoError(t, err)
+
+	got, err := m.Get(s.ID, "alice")
+	require.NoError(t, err)
+	assert.Same(t, s, got)
+
+	// Streams of other consumers are not visible
+	_, err = m.Get(s.ID, "bob")
+	assert.True(t, errors.Is(err, ErrSessionNotFound))
+
+	m.Release(s, ReasonClientClosed)
+	_, err = m.Get(s.ID, "alice")
+	assert.True(t, errors.Is(err, ErrSessionNotFound))
+}
+
+func TestManager_IdleTimeout(t *testing.T) {
+	m := setupTestManager(Options{IdleTimeout: time.Minute})
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	idle, err := m.Open("alice", TransportWebSocket)
+	require.NoError(t, err)
+	active, err := m.Open("alice", TransportWebSocket)
+	require.NoError(t, err)
+
+	now = now.Add(45 * time.Second)
+	_, err = active.Push([]float64{1})
+	require.NoError(t, err)
+
+	now = now.Add(30 * time.Second)
+	m.sweep()
+
+	<-idle.Done()
+	assert.Equal(t, ReasonIdleTimeout, idle.Reason())
+	select {
+	case <-active.Done():
+		t.Fatal("active session was closed")
+	default:
+	}
+}
+
+func TestManager_Stop(t *testing.T) {
+	m := setupTestManager(Options{})
+	m.Start(time.Hour)
+
+	s, err := m.Open("alice", TransportWebSocket)
+	require.NoError(t, err)
+
+	// A transport releases its session once it sees it closed
+	go func() {
+		<-s.Done()
+		m.Release(s, ReasonClientClosed)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	m.Stop(ctx)
+
+	assert.Equal(t, ReasonShutdown, s.Reason())
+	assert.Equal(t, 0, m.Len())
+
+	_, err = m.Open("alice", TransportWebSocket)
+	assert.True(t, errors.Is(err, ErrShuttingDown))
+}
@@ -0,0 +1,431 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/logger"
+)
+
+// Headers set on every delivery
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderID        = "X-Webhook-ID"
+	HeaderRequestID = "X-Request-ID"
+)
+
+// Errors returned by the dispatcher
+var (
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	ErrForbiddenAddress   = errors.New("callback address is not publicly routable")
+)
+
+// reservedNetworks are the non-public IPv4 ranges net.IP has no predicate for:
+// "this network" and the carrier-grade NAT shared address space
+var reservedNetworks = []*net.IPNet{
+	{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)},
+	{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)},
+}
+
+// Options configures the dispatcher
+type Options struct {
+	Secret         string // service secret the signing secret of every consumer is derived from
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Timeout        time.Duration // per attempt
+	MaxDeadLetters int
+
+	// AllowPrivateNetworks lets callbacks reach loopback, private and link-local
+	// addresses; it is meant for tests only
+	AllowPrivateNetworks bool
+}
+
+// Delivery is a callback to POST
+type Delivery struct {
+	URL       string
+	Owner     string // consumer the callback belongs to
+	RequestID string // request that caused the callback
+	Subject   string // e.g. the job ID
+	Body      []byte
+}
+
+// DeadLetter records a delivery that exhausted its attempts
+type DeadLetter struct {
+	ID         string
+	URL        string
+	Owner      string
+	RequestID  string
+	Subject    string
+	Attempts   int
+	LastStatus int // 0 if no response was received
+	LastError  string
+	CreatedAt  time.Time
+	FailedAt   time.Time
+}
+
+// Dispatcher delivers signed callbacks in the background, retrying failures
+// with jittered exponential backoff
+type Dispatcher struct {
+	logger *logger.Logger
+	opts   Options
+	client *http.Client
+	now    func() time.Time
+
+	// ctx is cancelled when Stop gives up on in-flight deliveries
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	inflight sync.WaitGroup
+
+	mu          sync.Mutex
+	closed      bool
+	deadLetters []*DeadLetter
+}
+
+// NewDispatcher creates a webhook dispatcher
+func NewDispatcher(log *logger.Logger, opts Options) *Dispatcher {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		logger: log,
+		opts:   opts,
+		client: newClient(opts),
+		now:    time.Now,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Enabled reports whether a signing secret is configured
+func (d *Dispatcher) Enabled() bool {
+	return d.opts.Secret != ""
+}
+
+// Secret returns the key the callbacks of owner are signed with
+func (d *Dispatcher) Secret(owner string) string {
+	return ConsumerSecret(d.opts.Secret, owner)
+}
+
+// Enqueue starts delivering a callback in the background
+// It never blocks; deliveries enqueued after Stop are dead-lettered immediately
+func (d *Dispatcher) Enqueue(delivery Delivery) {
+	created := d.now()
+	id, err := newDeliveryID()
+	if err != nil {
+		d.deadLetter(id, delivery, created, 0, 0, err)
+		return
+	}
+
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		d.deadLetter(id, delivery, created, 0, 0, errors.New("dispatcher is shutting down"))
+		return
+	}
+	d.inflight.Add(1)
+	d.mu.Unlock()
+
+	go func() {
+		defer d.inflight.Done()
+		d.deliver(id, delivery, created)
+	}()
+}
+
+// Reject records a delivery whose body could not be built as a dead letter
+// Nothing is sent, so the receiver never gets an unsigned or empty callback
+func (d *Dispatcher) Reject(delivery Delivery, err error) {
+	id, idErr := newDeliveryID()
+	if idErr != nil {
+		err = errors.Join(err, idErr)
+	}
+	d.deadLetter(id, delivery, d.now(), 0, 0, err)
+}
+
+// Stop waits for in-flight deliveries until ctx is done and abandons the rest
+// Abandoned deliveries are recorded as dead letters
+func (d *Dispatcher) Stop(ctx context.Context) {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		d.cancel()
+		<-done
+	}
+	d.cancel()
+}
+
+// DeadLetters returns the dead letters belonging to owner, oldest first
+func (d *Dispatcher) DeadLetters(owner string) []DeadLetter {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var letters []DeadLetter
+	for _, letter := range d.deadLetters {
+		if letter.Owner == owner {
+			letters = append(letters, *letter)
+		}
+	}
+	return letters
+}
+
+// DeadLetter returns a single dead letter belonging to owner
+func (d *Dispatcher) DeadLetter(owner, id string) (DeadLetter, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, letter := range d.deadLetters {
+		if letter.ID == id && letter.Owner == owner {
+			return *letter, nil
+		}
+	}
+	return DeadLetter{}, ErrDeadLetterNotFound
+}
+
+// ConsumerSecret derives the signing secret of a consumer from the service secret
+// Each consumer only learns its own secret, so it cannot forge callbacks to others
+func ConsumerSecret(secret, owner string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("webhook-consumer:"))
+	mac.Write([]byte(owner))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sign returns the signature of a delivery: hex HMAC-SHA256 over "timestamp.body"
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign in constant time
+// Receivers should also reject timestamps too far from their own clock
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// deliver runs the attempts of a single delivery
+func (d *Dispatcher) deliver(id string, delivery Delivery, created time.Time) {
+	var status int
+	var err error
+
+	for attempt := 1; attempt <= d.opts.MaxAttempts; attempt++ {
+		start := d.now()
+		status, err = d.attempt(id, delivery)
+
+		entry := d.logger.WithFields(map[string]interface{}{
+			"component":   "webhook",
+			"operation":   "deliver",
+			"request_id":  delivery.RequestID,
+			"delivery_id": id,
+			"subject":     delivery.Subject,
+			"url":         delivery.URL,
+			"attempt":     attempt,
+			"status_code": status,
+			"duration_ms": d.now().Sub(start).Milliseconds(),
+		})
+		if err == nil {
+			entry.Info("Webhook delivered")
+			return
+		}
+		entry.WithError(err).Warn("Webhook delivery attempt failed")
+
+		if !retryable(status) || errors.Is(err, ErrForbiddenAddress) || attempt == d.opts.MaxAttempts {
+			d.deadLetter(id, delivery, created, attempt, status, err)
+			return
+		}
+
+		timer := time.NewTimer(d.backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-d.ctx.Done():
+			timer.Stop()
+			d.deadLetter(id, delivery, created, attempt, status, fmt.Errorf("abandoned during shutdown: %w", err))
+			return
+		}
+	}
+}
+
+// attempt POSTs the delivery once and returns the response status
+func (d *Dispatcher) attempt(id string, delivery Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "zama-api-service-webhook")
+	req.Header.Set(HeaderID, id)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(d.Secret(delivery.Owner), timestamp, delivery.Body))
+	if delivery.RequestID != "" {
+		req.Header.Set(HeaderRequestID, delivery.RequestID)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// newClient creates the HTTP client deliveries are sent with
+// Addresses are checked when each connection is dialled, after name resolution,
+// so a host that resolves to an internal address cannot be reached even when its
+// DNS record changes after the callback URL was validated. Proxies from the
+// environment are ignored and redirects are not followed for the same reason.
+func newClient(opts Options) *http.Client {
+	dialer := &net.Dialer{Timeout: opts.Timeout}
+	if !opts.AllowPrivateNetworks {
+		dialer.Control = checkAddress
+	}
+
+	return &http.Client{
+		Timeout: opts.Timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkAddress refuses connections to addresses that are not publicly routable
+// It runs once the address is resolved, just before the socket connects
+func checkAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// publicIP reports whether ip is a publicly routable unicast address
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// backoff returns the wait before the next attempt: a random duration in
+// [b/2, b] where b doubles with every attempt up to MaxBackoff
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	b := d.opts.InitialBackoff
+	for i := 1; i < attempt && b < d.opts.MaxBackoff; i++ {
+		b *= 2
+	}
+	if d.opts.MaxBackoff > 0 && b > d.opts.MaxBackoff {
+		b = d.opts.MaxBackoff
+	}
+	if b <= 0 {
+		return 0
+	}
+
+	half := b / 2
+	jitter, err := rand.Int(rand.Reader, big.NewInt(int64(half)+1))
+	if err != nil {
+		return b
+	}
+	return half + time.Duration(jitter.Int64())
+}
+
+// deadLetter records a delivery that will not be retried
+func (d *Dispatcher) deadLetter(id string, delivery Delivery, created time.Time, attempts, status int, err error) {
+	letter := &DeadLetter{
+		ID:         id,
+		URL:        delivery.URL,
+		Owner:      delivery.Owner,
+		RequestID:  delivery.RequestID,
+		Subject:    delivery.Subject,
+		Attempts:   attempts,
+		LastStatus: status,
+		LastError:  err.Error(),
+		CreatedAt:  created,
+		FailedAt:   d.now(),
+	}
+
+	d.mu.Lock()
+	d.deadLetters = append(d.deadLetters, letter)
+	if d.opts.MaxDeadLetters > 0 && len(d.deadLetters) > d.opts.MaxDeadLetters {
+		d.deadLetters = d.deadLetters[len(d.deadLetters)-d.opts.MaxDeadLetters:]
+	}
+	d.mu.Unlock()
+
+	d.logger.WithError(err).WithFields(map[string]interface{}{
+		"component":   "webhook",
+		"operation":   "dead_letter",
+		"request_id":  delivery.RequestID,
+		"delivery_id": id,
+		"subject":     delivery.Subject,
+		"url":         delivery.URL,
+		"attempts":    attempts,
+	}).Error("Webhook delivery moved to dead letters")
+}
+
+// retryable reports whether a failed attempt should be retried
+// Network errors, timeouts, rate limiting and server errors are retried;
+// other client errors will not succeed on retry
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+}
+
+// newDeliveryID returns a random delivery ID
+func newDeliveryID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whd_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,192 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDispatcher(maxAttempts int) *Dispatcher {
+	return NewDispatcher(logger.New("error", "json"), Options{
+		Secret:         "s3cret",
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Timeout:        time.Second,
+		MaxDeadLetters: 10,
+
+		AllowPrivateNetworks: true,
+	})
+}
+
+func TestDispatcher_RetriesUntilDelivered(t *testing.T) {
+	var calls int32
+	delivered := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		assert.NoError(t, err)
+		assert.True(t, Verify(ConsumerSecret("s3cret", "alice"), timestamp, body, r.Header.Get(HeaderSignature)))
+		assert.False(t, Verify(ConsumerSecret("s3cret", "bob"), timestamp, body, r.Header.Get(HeaderSignature)))
+		assert.False(t, Verify("s3cret", timestamp, body, r.Header.Get(HeaderSignature)))
+		assert.Equal(t, "req-1", r.Header.Get(HeaderRequestID))
+		assert.Equal(t, `{"ok":true}`, string(body))
+		close(delivered)
+	}))
+	defer server.Close()
+
+	d := newTestDispatcher(5)
+	d.Enqueue(Delivery{URL: server.URL, Owner: "alice", RequestID: "req-1", Subject: "job_1", Body: []byte(`{"ok":true}`)})
+
+	select {
+	case <-delivered:
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback was not delivered")
+	}
+	d.Stop(context.Background())
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Empty(t, d.DeadLetters("alice"))
+}
+
+func TestDispatcher_DeadLetters(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer rejecting.Close()
+
+	d := newTestDispatcher(3)
+	d.Enqueue(Delivery{URL: server.URL, Owner: "alice", RequestID: "req-1", Subject: "job_1"})
+	d.Enqueue(Delivery{URL: rejecting.URL, Owner: "alice", RequestID: "req-2", Subject: "job_2"})
+	d.Stop(context.Background())
+
+	letters := d.DeadLetters("alice")
+	require.Len(t, letters, 2)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Empty(t, d.DeadLetters("bob"))
+
+	byRequest := map[string]DeadLetter{}
+	for _, letter := range letters {
+		byRequest[letter.RequestID] = letter
+	}
+	assert.Equal(t, 3, byRequest["req-1"].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, byRequest["req-1"].LastStatus)
+
+	// Client errors other than 408 and 429 are not retried
+	assert.Equal(t, 1, byRequest["req-2"].Attempts)
+
+	letter, err := d.DeadLetter("alice", letters[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, letters[0], letter)
+	_, err = d.DeadLetter("bob", letters[0].ID)
+	assert.ErrorIs(t, err, ErrDeadLetterNotFound)
+
+	// Deliveries after Stop are dead-lettered immediately
+	d.Enqueue(Delivery{URL: server.URL, Owner: "bob"})
+	assert.Len(t, d.DeadLetters("bob"), 1)
+}
+
+func TestDispatcher_Reject(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	d := newTestDispatcher(3)
+	d.Reject(Delivery{URL: server.URL, Owner: "alice", RequestID: "req-1", Subject: "job_1"}, errors.New("failed to encode job state"))
+	d.Stop(context.Background())
+
+	letters := d.DeadLetters("alice")
+	require.Len(t, letters, 1)
+	assert.Equal(t, "job_1", letters[0].Subject)
+	assert.Equal(t, 0, letters[0].Attempts)
+	assert.Equal(t, "failed to encode job state", letters[0].LastError)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
+
+func TestDispatcher_RefusesInternalAddresses(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(logger.New("error", "json"), Options{Secret: "s3cret", MaxAttempts: 3, Timeout: time.Second})
+	d.Enqueue(Delivery{URL: server.URL, Owner: "alice", RequestID: "req-1"})
+	d.Enqueue(Delivery{URL: "http://localhost:1/hook", Owner: "alice", RequestID: "req-2"})
+	d.Stop(context.Background())
+
+	letters := d.DeadLetters("alice")
+	require.Len(t, letters, 2)
+	for _, letter := range letters {
+		// Refused addresses are not retried
+		assert.Equal(t, 1, letter.Attempts, letter.RequestID)
+		assert.Contains(t, letter.LastError, ErrForbiddenAddress.Error(), letter.RequestID)
+	}
+	assert.Zero(t, atomic.LoadInt32(&calls))
+
+	for ip, public := range map[string]bool{
+		"93.184.216.34": true, "2606:2800:220:1::": true,
+		"127.0.0.1": false, "10.1.2.3": false, "172.16.0.1": false, "192.168.1.1": false,
+		"169.254.169.254": false, "100.64.0.1": false, "0.0.0.0": false, "::1": false,
+		"fe80::1": false, "fd00::1": false, "::ffff:127.0.0.1": false,
+	} {
+		assert.Equal(t, public, publicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestDispatcher_DoesNotFollowRedirects(t *testing.T) {
+	var followed int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&followed, 1)
+	}))
+	defer target.Close()
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirecting.Close()
+
+	d := newTestDispatcher(3)
+	d.Enqueue(Delivery{URL: redirecting.URL, Owner: "alice"})
+	d.Stop(context.Background())
+
+	letters := d.DeadLetters("alice")
+	require.Len(t, letters, 1)
+	assert.Equal(t, http.StatusTemporaryRedirect, letters[0].LastStatus)
+	assert.Equal(t, 1, letters[0].Attempts)
+	assert.Zero(t, atomic.LoadInt32(&followed))
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	d := NewDispatcher(logger.New("error", "json"), Options{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	for attempt, ceiling := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 8: 10 * time.Second} {
+		wait := d.backoff(attempt)
+		assert.GreaterOrEqual(t, wait, ceiling/2)
+		assert.LessOrEqual(t, wait, ceiling)
+	}
+}
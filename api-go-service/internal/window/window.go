@@ -0,0 +1,411 @@
+package window
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Window types
+const (
+	TypeSliding  = "sliding"
+	TypeTumbling = "tumbling"
+)
+
+// Errors returned by the store
+var (
+	ErrStreamNotFound = errors.New("stream not found")
+	ErrStreamLimit    = errors.New("maximum number of streams reached for this consumer")
+	ErrInvalidQuery   = errors.New("invalid window query")
+	ErrOverflow       = errors.New("the values of the stream would overflow its sums")
+)
+
+var (
+	// Points held across all streams
+	storedPoints = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "window_points",
+			Help: "Number of timestamped values held by the window store",
+		},
+	)
+
+	// Points dropped, by reason
+	droppedPoints = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "window_points_dropped_total",
+			Help: "Total number of timestamped values dropped by the window store",
+		},
+		[]string{"reason"},
+	)
+)
+
+// Options configures the store
+type Options struct {
+	Retention  time.Duration // points older than this are evicted
+	Lateness   time.Duration // how far behind the newest point a value may arrive
+	MaxPoints  int           // points kept per stream; the oldest are evicted first
+	MaxStreams int           // streams per consumer, 0 for no limit
+	MaxWindows int           // windows returned by one query
+}
+
+// Point is a timestamped value
+type Point struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// AppendResult reports what happened to the points of an append
+type AppendResult struct {
+	Accepted  int
+	Late      []int // indexes of points older than the lateness bound allows
+	Points    int   // points held by the stream afterwards
+	Watermark time.Time
+}
+
+// Query selects the windows to aggregate
+type Query struct {
+	Type string
+	Size time.Duration
+	Step time.Duration // sliding windows only; zero for the single window ending at To
+	From time.Time     // zero for the oldest point held
+	To   time.Time     // zero for now
+}
+
+// Result is the aggregate of one window, covering [Start, End)
+type Result struct {
+	Start time.Time
+	End   time.Time
+	Sum   float64
+	Count int
+}
+
+// Mean returns the mean of the window, or false when it is empty
+func (r Result) Mean() (float64, bool) {
+	if r.Count == 0 {
+		return 0, false
+	}
+	return r.Sum / float64(r.Count), true
+}
+
+// stream is a named series of points ordered by timestamp
+type stream struct {
+	points    []Point
+	watermark time.Time // newest timestamp seen
+}
+
+// Store keeps named streams of timestamped values in memory, scoped per consumer
+type Store struct {
+	logger *logger.Logger
+	opts   Options
+	now    func() time.Time
+
+	mu      sync.Mutex
+	streams map[string]map[string]*stream // owner, then stream name
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewStore creates a window store
+func NewStore(log *logger.Logger, opts Options) *Store {
+	return &Store{
+		logger:  log,
+		opts:    opts,
+		now:     time.Now,
+		streams: make(map[string]map[string]*stream),
+	}
+}
+
+// Start launches the background sweep that evicts expired points
+func (s *Store) Start(interval time.Duration) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.sweep()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the background sweep
+func (s *Store) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+		s.stop = nil
+	}
+}
+
+// Append adds points to a stream of owner, creating the stream on first use
+// Points older than the lateness bound, or than the retention, are not stored
+// and are reported by index. A batch that could make a window sum overflow is
+// refused as a whole
+func (s *Store) Append(owner, name string, points []Point) (*AppendResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	owned := s.streams[owner]
+	st, ok := owned[name]
+
+	// Every window sum and prefix sum is bounded by the sum of the magnitudes
+	magnitude := 0.0
+	if ok {
+		for _, p := range st.points {
+			magnitude += math.Abs(p.Value)
+		}
+	}
+	for _, p := range points {
+		magnitude += math.Abs(p.Value)
+	}
+	if math.IsInf(magnitude, 0) {
+		return nil, ErrOverflow
+	}
+
+	if !ok {
+		if s.opts.MaxStreams > 0 && len(owned) >= s.opts.MaxStreams {
+			return nil, ErrStreamLimit
+		}
+		if owned == nil {
+			owned = make(map[string]*stream)
+			s.streams[owner] = owned
+		}
+		st = &stream{}
+		owned[name] = st
+	}
+
+	horizon := s.now().Add(-s.opts.Retention)
+	result := &AppendResult{}
+	for i, p := range points {
+		if p.Timestamp.Before(horizon) || p.Timestamp.Before(st.watermark.Add(-s.opts.Lateness)) {
+			result.Late = append(result.Late, i)
+			continue
+		}
+		st.insert(p)
+		result.Accepted++
+	}
+	storedPoints.Add(float64(result.Accepted))
+	if len(result.Late) > 0 {
+		droppedPoints.WithLabelValues("late").Add(float64(len(result.Late)))
+	}
+
+	if s.opts.MaxPoints > 0 && len(st.points) > s.opts.MaxPoints {
+		evicted := len(st.points) - s.opts.MaxPoints
+		st.points = append(st.points[:0:0], st.points[evicted:]...)
+		storedPoints.Sub(float64(evicted))
+		droppedPoints.WithLabelValues("capacity").Add(float64(evicted))
+	}
+
+	// An append made only of late points must not leave an empty stream behind
+	if len(st.points) == 0 {
+		delete(owned, name)
+		if len(owned) == 0 {
+			delete(s.streams, owner)
+		}
+	}
+
+	result.Points = len(st.points)
+	result.Watermark = st.watermark
+	return result, nil
+}
+
+// Aggregate computes sum and count over the windows selected by q
+func (s *Store) Aggregate(owner, name string, q Query) ([]Result, error) {
+	if q.Size <= 0 {
+		return nil, fmt.Errorf("%w: size must be positive", ErrInvalidQuery)
+	}
+	if q.Step < 0 {
+		return nil, fmt.Errorf("%w: step must be positive", ErrInvalidQuery)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.streams[owner][name]
+	if !ok {
+		return nil, ErrStreamNotFound
+	}
+
+	to := q.To
+	if to.IsZero() {
+		to = s.now()
+	}
+	from := q.From
+	if from.IsZero() {
+		from = st.points[0].Timestamp
+	}
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidQuery)
+	}
+
+	var bounds [][2]time.Time
+	switch q.Type {
+	case TypeSliding, "":
+		if q.Step == 0 {
+			bounds = append(bounds, [2]time.Time{to.Add(-q.Size), to})
+			break
+		}
+		// Windows end every step from the first one that reaches from
+		for end := from.Add(q.Step); ; end = end.Add(q.Step) {
+			bounds = append(bounds, [2]time.Time{end.Add(-q.Size), end})
+			if !end.Before(to) || s.tooMany(bounds) {
+				break
+			}
+		}
+	case TypeTumbling:
+		if q.Step != 0 {
+			return nil, fmt.Errorf("%w: tumbling windows take no step", ErrInvalidQuery)
+		}
+		// Windows are aligned to multiples of size since the Unix epoch
+		for start := alignToEpoch(from, q.Size); start.Before(to); start = start.Add(q.Size) {
+			bounds = append(bounds, [2]time.Time{start, start.Add(q.Size)})
+			if s.tooMany(bounds) {
+				break
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: type must be %q or %q", ErrInvalidQuery, TypeSliding, TypeTumbling)
+	}
+	if s.tooMany(bounds) {
+		return nil, fmt.Errorf("%w: the query selects more than %d windows", ErrInvalidQuery, s.opts.MaxWindows)
+	}
+
+	// Prefix sums make every window a difference of two binary searches
+	prefix := make([]float64, len(st.points)+1)
+	for i, p := range st.points {
+		prefix[i+1] = prefix[i] + p.Value
+	}
+
+	results := make([]Result, len(bounds))
+	for i, b := range bounds {
+		lo, hi := st.search(b[0]), st.search(b[1])
+		results[i] = Result{
+			Start: b[0],
+			End:   b[1],
+			Sum:   prefix[hi] - prefix[lo],
+			Count: hi - lo,
+		}
+	}
+	return results, nil
+}
+
+// Names returns the names of the streams of owner, sorted
+func (s *Store) Names(owner string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.streams[owner]))
+	for name := range s.streams[owner] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Delete removes a stream of owner
+func (s *Store) Delete(owner, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.streams[owner][name]
+	if !ok {
+		return ErrStreamNotFound
+	}
+	storedPoints.Sub(float64(len(st.points)))
+	delete(s.streams[owner], name)
+	if len(s.streams[owner]) == 0 {
+		delete(s.streams, owner)
+	}
+	return nil
+}
+
+// sweep evicts points older than the retention and removes empty streams
+func (s *Store) sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	horizon := s.now().Add(-s.opts.Retention)
+	evicted, removed := 0, 0
+	for owner, owned := range s.streams {
+		for name, st := range owned {
+			if n := st.search(horizon); n > 0 {
+				st.points = append(st.points[:0:0], st.points[n:]...)
+				evicted += n
+			}
+			if len(st.points) == 0 {
+				delete(owned, name)
+				removed++
+			}
+		}
+		if len(owned) == 0 {
+			delete(s.streams, owner)
+		}
+	}
+
+	if evicted > 0 {
+		storedPoints.Sub(float64(evicted))
+		droppedPoints.WithLabelValues("expired").Add(float64(evicted))
+	}
+	if evicted > 0 || removed > 0 {
+		s.logger.WithFields(map[string]interface{}{
+			"component":       "window",
+			"operation":       "sweep",
+			"points_evicted":  evicted,
+			"streams_removed": removed,
+		}).Debug("Expired window points evicted")
+	}
+}
+
+// tooMany reports whether a query has selected more windows than allowed
+func (s *Store) tooMany(bounds [][2]time.Time) bool {
+	return s.opts.MaxWindows > 0 && len(bounds) > s.opts.MaxWindows
+}
+
+// insert adds a point, keeping the stream ordered by timestamp
+// Points with equal timestamps keep their arrival order
+func (st *stream) insert(p Point) {
+	i := sort.Search(len(st.points), func(i int) bool {
+		return st.points[i].Timestamp.After(p.Timestamp)
+	})
+	st.points = append(st.points, Point{})
+	copy(st.points[i+1:], st.points[i:])
+	st.points[i] = p
+
+	if p.Timestamp.After(st.watermark) {
+		st.watermark = p.Timestamp
+	}
+}
+
+// search returns the index of the first point at or after t
+func (st *stream) search(t time.Time) int {
+	return sort.Search(len(st.points), func(i int) bool {
+		return !st.points[i].Timestamp.Before(t)
+	})
+}
+
+// alignToEpoch returns the last multiple of size since the Unix epoch at or before t
+// time.Truncate would align to the zero time instead, which only agrees with the
+// epoch for sizes dividing the 719162 days between them
+func alignToEpoch(t time.Time, size time.Duration) time.Time {
+	offset := time.Duration(t.UnixNano() % int64(size))
+	if offset < 0 {
+		offset += size
+	}
+	return t.Add(-offset)
+}
@@ -0,0 +1,193 @@
+package window
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/katvio/api-go-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var epoch = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func setupTestStore(opts Options) *Store {
+	s := NewStore(logger.New("error", "json"), opts)
+	s.now = func() time.Time { return epoch.Add(10 * time.Minute) }
+	return s
+}
+
+// at returns a point seconds after epoch
+func at(seconds int, value float64) Point {
+	return Point{Timestamp: epoch.Add(time.Duration(seconds) * time.Second), Value: value}
+}
+
+func TestStore_Aggregate(t *testing.T) {
+	s := setupTestStore(Options{Retention: time.Hour, Lateness: time.Minute})
+	_, err := s.Append("alice", "sensor", []Point{at(0, 1), at(30, 2), at(60, 3), at(90, 4), at(150, 5)})
+	require.NoError(t, err)
+
+	t.Run("Tumbling windows are aligned to their size", func(t *testing.T) {
+		results, err := s.Aggregate("alice", "sensor", Query{Type: TypeTumbling, Size: time.Minute, To: epoch.Add(3 * time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, Result{Start: epoch, End: epoch.Add(time.Minute), Sum: 3, Count: 2}, results[0])
+		assert.Equal(t, 7.0, results[1].Sum)
+		assert.Equal(t, 5.0, results[2].Sum)
+
+		mean, ok := results[1].Mean()
+		assert.True(t, ok)
+		assert.Equal(t, 3.5, mean)
+	})
+
+	t.Run("Tumbling windows are aligned to the Unix epoch", func(t *testing.T) {
+		unix := time.Unix(0, 0).UTC()
+		s := NewStore(logger.New("error", "json"), Options{Retention: 30 * 24 * time.Hour, Lateness: time.Minute})
+		s.now = func() time.Time { return unix.Add(5 * 24 * time.Hour) }
+		_, err := s.Append("alice", "epoch", []Point{
+			{Timestamp: unix.Add(-3 * time.Second), Value: 1},
+			{Timestamp: unix.Add(2 * time.Second), Value: 2},
+			{Timestamp: unix.Add(8 * time.Second), Value: 4},
+		})
+		require.NoError(t, err)
+
+		results, err := s.Aggregate("alice", "epoch", Query{Type: TypeTumbling, Size: 7 * time.Second, To: unix.Add(14 * time.Second)})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, Result{Start: unix.Add(-7 * time.Second), End: unix, Sum: 1, Count: 1}, results[0])
+		assert.Equal(t, Result{Start: unix, End: unix.Add(7 * time.Second), Sum: 2, Count: 1}, results[1])
+		assert.Equal(t, Result{Start: unix.Add(7 * time.Second), End: unix.Add(14 * time.Second), Sum: 4, Count: 1}, results[2])
+
+		// Weeks start on the epoch's Thursday, not on the Monday of the zero time
+		week := 168 * time.Hour
+		results, err = s.Aggregate("alice", "epoch", Query{Type: TypeTumbling, Size: week, From: unix.Add(time.Hour), To: unix.Add(2 * time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, Result{Start: unix, End: unix.Add(week), Sum: 6, Count: 2}, results[0])
+	})
+
+	t.Run("Sliding windows overlap", func(t *testing.T) {
+		results, err := s.Aggregate("alice", "sensor", Query{Size: 2 * time.Minute, Step: time.Minute, From: epoch, To: epoch.Add(3 * time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, 3.0, results[0].Sum)
+		assert.Equal(t, 10.0, results[1].Sum)
+		assert.Equal(t, 12.0, results[2].Sum)
+	})
+
+	t.Run("A sliding window without step ends at to", func(t *testing.T) {
+		results, err := s.Aggregate("alice", "sensor", Query{Size: time.Minute, To: epoch.Add(2 * time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 7.0, results[0].Sum)
+		assert.Equal(t, 2, results[0].Count)
+
+		// Empty windows have no mean
+		results, err = s.Aggregate("alice", "sensor", Query{Size: time.Minute})
+		require.NoError(t, err)
+		_, ok := results[0].Mean()
+		assert.False(t, ok)
+	})
+
+	t.Run("Invalid queries", func(t *testing.T) {
+		queries := []Query{
+			{Size: 0},
+			{Size: time.Minute, Type: "hopping"},
+			{Size: time.Minute, Type: TypeTumbling, Step: time.Second},
+			{Size: time.Minute, From: epoch.Add(time.Hour), To: epoch},
+		}
+		for _, q := range queries {
+			_, err := s.Aggregate("alice", "sensor", q)
+			assert.True(t, errors.Is(err, ErrInvalidQuery), "%+v", q)
+		}
+	})
+
+	t.Run("Streams are scoped per consumer", func(t *testing.T) {
+		_, err := s.Aggregate("bob", "sensor", Query{Size: time.Minute})
+		assert.True(t, errors.Is(err, ErrStreamNotFound))
+	})
+}
+
+func TestStore_Lateness(t *testing.T) {
+	s := setupTestStore(Options{Retention: 8 * time.Minute, Lateness: 30 * time.Second})
+
+	result, err := s.Append("alice", "sensor", []Point{at(300, 1), at(280, 2), at(200, 3), at(100, 4)})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Accepted)
+	assert.Equal(t, []int{2, 3}, result.Late) // one too late, one older than the retention
+	assert.Equal(t, epoch.Add(300*time.Second), result.Watermark)
+
+	// Accepted late points are ordered by timestamp
+	results, err := s.Aggregate("alice", "sensor", Query{Type: TypeTumbling, Size: 10 * time.Second, From: epoch.Add(280 * time.Second), To: epoch.Add(290 * time.Second)})
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, results[0].Sum)
+
+	// A batch of late points only does not create a stream
+	result, err = s.Append("alice", "empty", []Point{at(0, 1)})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Points)
+	assert.Equal(t, []string{"sensor"}, s.Names("alice"))
+}
+
+func TestStore_Limits(t *testing.T) {
+	s := setupTestStore(Options{Retention: time.Hour, Lateness: time.Hour, MaxPoints: 3, MaxStreams: 2, MaxWindows: 5})
+
+	result, err := s.Append("alice", "a", []Point{at(0, 1), at(1, 2), at(2, 3), at(3, 4)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Points)
+
+	// The oldest points are evicted first
+	results, err := s.Aggregate("alice", "a", Query{Size: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, 9.0, results[0].Sum)
+
+	_, err = s.Append("alice", "b", []Point{at(0, 1)})
+	require.NoError(t, err)
+	_, err = s.Append("alice", "c", []Point{at(0, 1)})
+	assert.True(t, errors.Is(err, ErrStreamLimit))
+	_, err = s.Append("bob", "c", []Point{at(0, 1)})
+	require.NoError(t, err)
+
+	_, err = s.Aggregate("alice", "a", Query{Type: TypeTumbling, Size: time.Second, From: epoch, To: epoch.Add(time.Minute)})
+	assert.True(t, errors.Is(err, ErrInvalidQuery))
+}
+
+func TestStore_Overflow(t *testing.T) {
+	s := setupTestStore(Options{Retention: time.Hour, Lateness: time.Hour})
+
+	_, err := s.Append("alice", "a", []Point{at(0, 1e308), at(1, 1e308)})
+	assert.True(t, errors.Is(err, ErrOverflow))
+	assert.Empty(t, s.Names("alice"))
+
+	_, err = s.Append("alice", "a", []Point{at(0, 1e308)})
+	require.NoError(t, err)
+	_, err = s.Append("alice", "a", []Point{at(1, -1e308)})
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	// The refused batch left the stream readable
+	results, err := s.Aggregate("alice", "a", Query{Size: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, 1e308, results[0].Sum)
+	assert.Equal(t, 1, results[0].Count)
+}
+
+func TestStore_Sweep(t *testing.T) {
+	s := setupTestStore(Options{Retention: 5 * time.Minute, Lateness: time.Hour})
+	_, err := s.Append("alice", "old", []Point{at(300, 1)})
+	require.NoError(t, err)
+	_, err = s.Append("alice", "mixed", []Point{at(300, 1), at(500, 2)})
+	require.NoError(t, err)
+
+	s.now = func() time.Time { return epoch.Add(12 * time.Minute) }
+	s.sweep()
+
+	assert.Equal(t, []string{"mixed"}, s.Names("alice"))
+	results, err := s.Aggregate("alice", "mixed", Query{Size: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, 1, results[0].Count)
+
+	require.NoError(t, s.Delete("alice", "mixed"))
+	assert.Empty(t, s.Names("alice"))
+	assert.True(t, errors.Is(s.Delete("alice", "mixed"), ErrStreamNotFound))
+}
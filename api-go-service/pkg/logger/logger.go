@@ -93,6 +93,18 @@ func (l *Logger) LogHTTPRequest(method, path, userAgent, clientIP string, status
 	}).Info("HTTP request processed")
 }
 
+// LogGRPCRequest logs gRPC request details
+func (l *Logger) LogGRPCRequest(method, peer, code string, latency time.Duration, requestID string) {
+	l.WithFields(map[string]interface{}{
+		"method":     method,
+		"code":       code,
+		"latency_ms": latency.Milliseconds(),
+		"peer":       peer,
+		"request_id": requestID,
+		"type":       "grpc_request",
+	}).Info("gRPC request processed")
+}
+
 // LogError logs error with additional context
 func (l *Logger) LogError(err error, component, operation string, fields map[string]interface{}) {
 	entry := l.WithError(err).WithFields(map[string]interface{}{
@@ -0,0 +1,216 @@
+// Package paillier implements the Paillier cryptosystem with g = n+1.
+//
+// Ciphertexts can be added without decrypting them: the product of two
+// ciphertexts mod n² decrypts to the sum of their plaintexts mod n. The API
+// service uses this to compute encrypted sums; clients use this package to
+// encrypt their inputs and decrypt the result.
+package paillier
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// Errors returned by the cryptosystem
+var (
+	ErrInvalidPublicKey  = errors.New("paillier: invalid public key")
+	ErrInvalidCiphertext = errors.New("paillier: ciphertext is not an element of Z*_n²")
+	ErrKeySize           = errors.New("paillier: key size must be an even number of bits, at least 64")
+)
+
+var one = big.NewInt(1)
+
+// CiphertextError reports which of the ciphertexts passed to Add is invalid
+type CiphertextError struct {
+	Index int
+	Err   error
+}
+
+// Error implements the error interface
+func (e *CiphertextError) Error() string {
+	return fmt.Sprintf("ciphertext %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the cause of the error
+func (e *CiphertextError) Unwrap() error {
+	return e.Err
+}
+
+// PublicKey is a Paillier public key
+type PublicKey struct {
+	N        *big.Int
+	NSquared *big.Int
+}
+
+// PrivateKey is a Paillier private key
+type PrivateKey struct {
+	PublicKey
+	Lambda *big.Int // lcm(p-1, q-1)
+	Mu     *big.Int // λ⁻¹ mod n
+}
+
+// NewPublicKey creates a public key from the modulus n
+func NewPublicKey(n *big.Int) (*PublicKey, error) {
+	// n is a product of two odd primes, so it is odd and at least 15
+	if n == nil || n.Cmp(big.NewInt(15)) < 0 || n.Bit(0) == 0 {
+		return nil, ErrInvalidPublicKey
+	}
+
+	return &PublicKey{
+		N:        new(big.Int).Set(n),
+		NSquared: new(big.Int).Mul(n, n),
+	}, nil
+}
+
+// NewPrivateKey recreates a private key from n, λ and μ
+func NewPrivateKey(n, lambda, mu *big.Int) (*PrivateKey, error) {
+	pk, err := NewPublicKey(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if lambda == nil || mu == nil || lambda.Sign() <= 0 || mu.Sign() <= 0 {
+		return nil, errors.New("paillier: invalid private key")
+	}
+
+	return &PrivateKey{
+		PublicKey: *pk,
+		Lambda:    new(big.Int).Set(lambda),
+		Mu:        new(big.Int).Set(mu),
+	}, nil
+}
+
+// GenerateKey generates a key pair whose modulus n has exactly bits bits
+func GenerateKey(random io.Reader, bits int) (*PrivateKey, error) {
+	if bits < 64 || bits%2 != 0 {
+		return nil, ErrKeySize
+	}
+
+	for {
+		p, err := rand.Prime(random, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := rand.Prime(random, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+
+		n := new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			continue
+		}
+
+		pMinus := new(big.Int).Sub(p, one)
+		qMinus := new(big.Int).Sub(q, one)
+		gcd := new(big.Int).GCD(nil, nil, pMinus, qMinus)
+		lambda := new(big.Int).Mul(pMinus, qMinus)
+		lambda.Div(lambda, gcd)
+
+		mu := new(big.Int).ModInverse(lambda, n)
+		if mu == nil {
+			continue
+		}
+
+		return &PrivateKey{
+			PublicKey: PublicKey{N: n, NSquared: new(big.Int).Mul(n, n)},
+			Lambda:    lambda,
+			Mu:        mu,
+		}, nil
+	}
+}
+
+// Encrypt encrypts m, which is first reduced mod n so negative values wrap around
+func (pk *PublicKey) Encrypt(random io.Reader, m *big.Int) (*big.Int, error) {
+	r, err := pk.randomUnit(random)
+	if err != nil {
+		return nil, err
+	}
+
+	// c = (1 + m·n) · r^n mod n²
+	gm := new(big.Int).Mod(m, pk.N)
+	gm.Mul(gm, pk.N)
+	gm.Add(gm, one)
+
+	rn := new(big.Int).Exp(r, pk.N, pk.NSquared)
+
+	c := gm.Mul(gm, rn)
+	return c.Mod(c, pk.NSquared), nil
+}
+
+// Add returns a ciphertext of the sum of the plaintexts of all ciphertexts
+func (pk *PublicKey) Add(ciphertexts ...*big.Int) (*big.Int, error) {
+	sum := big.NewInt(1)
+	for i, c := range ciphertexts {
+		if err := pk.ValidateCiphertext(c); err != nil {
+			return nil, &CiphertextError{Index: i, Err: err}
+		}
+		sum.Mul(sum, c)
+		sum.Mod(sum, pk.NSquared)
+	}
+
+	return sum, nil
+}
+
+// ValidateCiphertext checks that c is an element of Z*_n²
+func (pk *PublicKey) ValidateCiphertext(c *big.Int) error {
+	if c == nil || c.Sign() <= 0 || c.Cmp(pk.NSquared) >= 0 {
+		return ErrInvalidCiphertext
+	}
+
+	if new(big.Int).GCD(nil, nil, c, pk.N).Cmp(one) != 0 {
+		return ErrInvalidCiphertext
+	}
+
+	return nil
+}
+
+// Decrypt returns the plaintext of c in the range [0, n)
+func (sk *PrivateKey) Decrypt(c *big.Int) (*big.Int, error) {
+	if err := sk.ValidateCiphertext(c); err != nil {
+		return nil, err
+	}
+
+	// m = L(c^λ mod n²) · μ mod n, where L(x) = (x-1)/n
+	x := new(big.Int).Exp(c, sk.Lambda, sk.NSquared)
+	x.Sub(x, one)
+	x.Div(x, sk.N)
+	x.Mul(x, sk.Mu)
+
+	return x.Mod(x, sk.N), nil
+}
+
+// DecryptSigned returns the plaintext of c in the range [-n/2, n/2)
+// Use it when the encrypted values may be negative
+func (sk *PrivateKey) DecryptSigned(c *big.Int) (*big.Int, error) {
+	m, err := sk.Decrypt(c)
+	if err != nil {
+		return nil, err
+	}
+
+	half := new(big.Int).Rsh(sk.N, 1)
+	if m.Cmp(half) >= 0 {
+		m.Sub(m, sk.N)
+	}
+
+	return m, nil
+}
+
+// randomUnit returns a uniformly random element of Z*_n
+func (pk *PublicKey) randomUnit(random io.Reader) (*big.Int, error) {
+	for {
+		r, err := rand.Int(random, pk.N)
+		if err != nil {
+			return nil, err
+		}
+		if r.Sign() > 0 && new(big.Int).GCD(nil, nil, r, pk.N).Cmp(one) == 0 {
+			return r, nil
+		}
+	}
+}
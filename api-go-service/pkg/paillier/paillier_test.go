@@ -0,0 +1,64 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	sk, err := GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+	assert.Equal(t, 512, sk.N.BitLen())
+
+	c, err := sk.Encrypt(rand.Reader, big.NewInt(42))
+	require.NoError(t, err)
+
+	m, err := sk.Decrypt(c)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), m)
+}
+
+func TestHomomorphicAdd(t *testing.T) {
+	sk, err := GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	pk, err := NewPublicKey(sk.N)
+	require.NoError(t, err)
+
+	var ciphertexts []*big.Int
+	for _, v := range []int64{10, -3, 250, 0} {
+		c, err := pk.Encrypt(rand.Reader, big.NewInt(v))
+		require.NoError(t, err)
+		ciphertexts = append(ciphertexts, c)
+	}
+
+	sum, err := pk.Add(ciphertexts...)
+	require.NoError(t, err)
+
+	m, err := sk.DecryptSigned(sum)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(257), m)
+}
+
+func TestValidation(t *testing.T) {
+	_, err := NewPublicKey(big.NewInt(16))
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+
+	_, err = GenerateKey(rand.Reader, 63)
+	assert.ErrorIs(t, err, ErrKeySize)
+
+	pk, err := NewPublicKey(big.NewInt(15))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, pk.ValidateCiphertext(big.NewInt(0)), ErrInvalidCiphertext)
+	assert.ErrorIs(t, pk.ValidateCiphertext(big.NewInt(225)), ErrInvalidCiphertext)
+	assert.ErrorIs(t, pk.ValidateCiphertext(big.NewInt(5)), ErrInvalidCiphertext)
+	assert.NoError(t, pk.ValidateCiphertext(big.NewInt(2)))
+
+	_, err = pk.Add(big.NewInt(2), big.NewInt(3))
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+}